@@ -0,0 +1,153 @@
+// Package admit provides target admission control: rejecting new PUTs and xactions
+// when the target is overloaded.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package admit
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// The target is considered overloaded when any of the following exceeds
+// its configured limit (see cmn.AdmissionConf):
+// - memory pressure (memsys);
+// - max disk utilization across available mountpaths (ios);
+// - number of goroutines.
+// The state is refreshed by the housekeeper and checked (cheaply) upon
+// each new PUT and before starting massive and background xactions.
+// Work that is not admitted is rejected with cmn.ErrOverloaded (and
+// Retry-After) - it is never queued.
+
+const (
+	refreshIval  = 2 * time.Second
+	dfltRetry    = 5 * time.Second
+	dfltPressure = memsys.PressureHigh
+)
+
+type (
+	Status struct {
+		Reason      string `json:"reason,omitempty"` // empty when not overloaded
+		MemPressure int    `json:"mem_pressure"`
+		DiskUtil    int64  `json:"disk_util"`
+		Goroutines  int    `json:"goroutines"`
+	}
+	admission struct {
+		status atomic.Pointer // *Status
+		nput   atomic.Int64   // rejected PUTs
+		nxact  atomic.Int64   // rejected xactions
+	}
+)
+
+var (
+	g admission
+
+	memPressureEnum = map[string]int{
+		"moderate": memsys.PressureModerate,
+		"high":     memsys.PressureHigh,
+		"extreme":  memsys.PressureExtreme,
+	}
+)
+
+func memPressureText(p int) string {
+	for s, v := range memPressureEnum {
+		if v == p {
+			return s
+		}
+	}
+	if p >= memsys.OOM {
+		return "OOM"
+	}
+	return "low"
+}
+
+func RegWithHK() {
+	g.status.Store(unsafe.Pointer(&Status{}))
+	hk.Reg("admission"+hk.NameSuffix, g.refresh, refreshIval)
+}
+
+// returns nil unless overloaded; `xkind` is empty for PUTs
+func Check(xkind string) error {
+	config := cmn.GCO.Get()
+	if !config.Admission.Enabled {
+		return nil
+	}
+	st := g.get()
+	if st.Reason == "" {
+		return nil
+	}
+	retryAfter := config.Admission.RetryAfter.D()
+	if retryAfter == 0 {
+		retryAfter = dfltRetry
+	}
+	if xkind == "" {
+		g.nput.Inc()
+		return cmn.NewErrOverloaded("target", st.Reason, retryAfter)
+	}
+	g.nxact.Inc()
+	return cmn.NewErrOverloaded("target", st.Reason+", not starting "+xkind, retryAfter)
+}
+
+func GetStatus() Status { return *g.get() }
+
+// cumulative numbers of rejected PUTs and rejected xactions (stats)
+func Stats() (nput, nxact int64) { return g.nput.Load(), g.nxact.Load() }
+
+func (a *admission) get() *Status {
+	if p := a.status.Load(); p != nil {
+		return (*Status)(p)
+	}
+	return &Status{}
+}
+
+func (a *admission) refresh() time.Duration {
+	config := cmn.GCO.Get()
+	if !config.Admission.Enabled {
+		a.status.Store(unsafe.Pointer(&Status{}))
+		return refreshIval
+	}
+	st := &Status{Goroutines: runtime.NumGoroutine(), MemPressure: memsys.PageMM().Pressure()}
+	for mpath := range fs.GetAvail() {
+		if util := fs.GetMpathUtil(mpath); util > st.DiskUtil {
+			st.DiskUtil = util
+		}
+	}
+	st.eval(&config.Admission)
+	if prev := a.get(); prev.Reason != st.Reason {
+		if st.Reason != "" {
+			glog.Errorf("admission: overloaded (%s)", st.Reason)
+		} else {
+			glog.Infof("admission: no longer overloaded (%s)", prev.Reason)
+		}
+	}
+	a.status.Store(unsafe.Pointer(st))
+	return refreshIval
+}
+
+// given current readings, sets (or clears) the reason to reject
+func (st *Status) eval(conf *cmn.AdmissionConf) {
+	maxPress := dfltPressure
+	if p, ok := memPressureEnum[conf.MemPressure]; ok {
+		maxPress = p
+	}
+	switch {
+	case st.MemPressure >= maxPress:
+		st.Reason = "memory pressure " + memPressureText(st.MemPressure)
+	case conf.DiskUtil > 0 && st.DiskUtil > conf.DiskUtil:
+		st.Reason = fmt.Sprintf("disk utilization %d%%", st.DiskUtil)
+	case conf.MaxGoroutines > 0 && st.Goroutines > conf.MaxGoroutines:
+		st.Reason = fmt.Sprintf("number of goroutines %d", st.Goroutines)
+	default:
+		st.Reason = ""
+	}
+}
@@ -0,0 +1,139 @@
+// Package admit provides target admission control: rejecting new PUTs and xactions
+// when the target is overloaded.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package admit
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// (cluster/mock imports stats that, in turn, imports admit)
+type utilStater struct {
+	utils ios.MpathUtil
+}
+
+func (m *utilStater) GetAllMpathUtils() *ios.MpathUtil           { return &m.utils }
+func (m *utilStater) GetMpathUtil(mpath string) int64            { return m.utils.Get(mpath) }
+func (*utilStater) AddMpath(string, string) (ios.FsDisks, error) { return nil, nil }
+func (*utilStater) RemoveMpath(string)                           {}
+func (*utilStater) LogAppend(l []string) []string                { return l }
+func (*utilStater) FillDiskStats(ios.AllDiskStats)               {}
+
+func admitConfig(t *testing.T, conf cmn.AdmissionConf) {
+	config := cmn.GCO.BeginUpdate()
+	config.Admission = conf
+	cmn.GCO.CommitUpdate(config)
+	t.Cleanup(func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Admission = cmn.AdmissionConf{}
+		cmn.GCO.CommitUpdate(config)
+		g.status.Store(unsafe.Pointer(&Status{}))
+	})
+}
+
+func TestCheck(t *testing.T) {
+	admitConfig(t, cmn.AdmissionConf{Enabled: true, RetryAfter: cos.Duration(1500 * time.Millisecond)})
+
+	tassert.CheckFatal(t, Check(""))
+
+	g.status.Store(unsafe.Pointer(&Status{Reason: "memory pressure high"}))
+	nput, nxact := Stats()
+
+	err := Check("")
+	erro, ok := err.(*cmn.ErrOverloaded)
+	tassert.Fatalf(t, ok, "expected ErrOverloaded, got %v", err)
+	tassert.Errorf(t, erro.RetryAfter() == "2", "expected Retry-After rounded up to 2s, got %q", erro.RetryAfter())
+
+	err = Check("store-cleanup")
+	tassert.Errorf(t, cmn.IsErrOverloaded(err), "expected ErrOverloaded, got %v", err)
+
+	np, nx := Stats()
+	tassert.Errorf(t, np == nput+1 && nx == nxact+1, "unexpected counters: (%d, %d) vs (%d, %d)", np, nx, nput, nxact)
+
+	// disabled
+	config := cmn.GCO.BeginUpdate()
+	config.Admission.Enabled = false
+	cmn.GCO.CommitUpdate(config)
+	tassert.CheckFatal(t, Check(""))
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		st     Status
+		conf   cmn.AdmissionConf
+		reason string // prefix; empty - not overloaded
+	}{
+		{Status{MemPressure: memsys.PressureModerate}, cmn.AdmissionConf{}, ""},
+		{Status{MemPressure: memsys.PressureHigh}, cmn.AdmissionConf{}, "memory pressure high"},
+		{Status{MemPressure: memsys.PressureHigh}, cmn.AdmissionConf{MemPressure: "extreme"}, ""},
+		{Status{MemPressure: memsys.PressureModerate}, cmn.AdmissionConf{MemPressure: "moderate"}, "memory pressure moderate"},
+		{Status{MemPressure: memsys.OOM}, cmn.AdmissionConf{MemPressure: "extreme"}, "memory pressure OOM"},
+		{Status{DiskUtil: 95}, cmn.AdmissionConf{}, ""},
+		{Status{DiskUtil: 95}, cmn.AdmissionConf{DiskUtil: 95}, ""},
+		{Status{DiskUtil: 96}, cmn.AdmissionConf{DiskUtil: 95}, "disk utilization 96%"},
+		{Status{Goroutines: 1000}, cmn.AdmissionConf{}, ""},
+		{Status{Goroutines: 1001}, cmn.AdmissionConf{MaxGoroutines: 1000}, "number of goroutines 1001"},
+		// memory first
+		{Status{MemPressure: memsys.PressureExtreme, DiskUtil: 99}, cmn.AdmissionConf{DiskUtil: 90}, "memory pressure"},
+		// clears the previous reason
+		{Status{Reason: "disk utilization 99%", DiskUtil: 10}, cmn.AdmissionConf{DiskUtil: 90}, ""},
+	}
+	for _, test := range tests {
+		st := test.st
+		st.eval(&test.conf)
+		if test.reason == "" {
+			tassert.Errorf(t, st.Reason == "", "%+v, %+v: expected not overloaded, got %q", test.st, test.conf, st.Reason)
+		} else {
+			tassert.Errorf(t, strings.HasPrefix(st.Reason, test.reason), "%+v, %+v: expected %q, got %q",
+				test.st, test.conf, test.reason, st.Reason)
+		}
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	var (
+		mpath  = t.TempDir()
+		iostat = &utilStater{}
+	)
+	fs.TestNew(iostat)
+	fs.TestDisableValidation()
+	_, err := fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+	iostat.utils.Set(mpath, 97)
+
+	// disabled: never overloaded
+	admitConfig(t, cmn.AdmissionConf{MemPressure: "extreme", DiskUtil: 90})
+	g.refresh()
+	tassert.Errorf(t, GetStatus() == Status{}, "expected empty status when disabled, got %+v", GetStatus())
+
+	// disk utilization reported by ios
+	admitConfig(t, cmn.AdmissionConf{Enabled: true, MemPressure: "extreme", DiskUtil: 90})
+	g.refresh()
+	st := GetStatus()
+	tassert.Errorf(t, st.DiskUtil == 97 && st.Goroutines > 0, "unexpected readings %+v", st)
+	tassert.Errorf(t, st.Reason == "disk utilization 97%", "unexpected reason %q", st.Reason)
+	tassert.Errorf(t, cmn.IsErrOverloaded(Check("")), "expected PUT rejected")
+
+	// no longer overloaded
+	iostat.utils.Set(mpath, 10)
+	g.refresh()
+	tassert.Errorf(t, GetStatus().Reason == "", "expected not overloaded, got %q", GetStatus().Reason)
+	tassert.CheckError(t, Check(""))
+
+	// goroutines
+	admitConfig(t, cmn.AdmissionConf{Enabled: true, MemPressure: "extreme", MaxGoroutines: 1})
+	g.refresh()
+	tassert.Errorf(t, strings.HasPrefix(GetStatus().Reason, "number of goroutines"), "unexpected reason %q", GetStatus().Reason)
+}
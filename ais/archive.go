@@ -13,6 +13,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -83,6 +84,16 @@ func (goi *getObjInfo) freadArch(file *os.File) (cos.ReadCloseSizer, error) {
 	}
 	archname := filepath.Join(goi.lom.Bck().Name, goi.lom.ObjName)
 	filename := goi.archive.filename
+	// random access via archive index, if available and up to date (see cmn/archidx)
+	if finfo, err := file.Stat(); err == nil {
+		if idx := goi.lom.LoadArchIndex(finfo); idx != nil && idx.Matches(mime) {
+			e := idx.Find(filename)
+			if e == nil {
+				return nil, notFoundInArch(filename, archname)
+			}
+			return idx.Open(file, e)
+		}
+	}
 	switch mime {
 	case cos.ExtTar:
 		return freadTar(file, filename, archname)
@@ -98,14 +109,18 @@ func (goi *getObjInfo) freadArch(file *os.File) (cos.ReadCloseSizer, error) {
 	}
 }
 
-func (goi *getObjInfo) mime(file *os.File) (m string, err error) {
+func (goi *getObjInfo) mime(file *os.File) (string, error) {
+	return archMime(goi.t, goi.archive.mime, goi.lom.ObjName, file)
+}
+
+func archMime(t *target, mime, objName string, file *os.File) (m string, err error) {
 	// either ok or non-empty user-defined mime type (that must work)
-	if m, err = cos.Mime(goi.archive.mime, goi.lom.ObjName); err == nil || goi.archive.mime != "" {
+	if m, err = cos.Mime(mime, objName); err == nil || mime != "" {
 		return
 	}
 	// otherwise, by magic
 	var (
-		buf, slab = goi.t.smm.AllocSize(sizeDetectMime)
+		buf, slab = t.smm.AllocSize(sizeDetectMime)
 		n         int
 	)
 	n, err = file.Read(buf)
@@ -117,7 +132,7 @@ func (goi *getObjInfo) mime(file *os.File) (m string, err error) {
 	}
 	if m == "" {
 		if err == nil {
-			err = cos.NewUnknownMimeError(goi.lom.ObjName)
+			err = cos.NewUnknownMimeError(objName)
 		} else {
 			err = cos.NewUnknownMimeError(err.Error())
 		}
@@ -221,3 +236,105 @@ func archNamesEq(n1, n2 string) bool {
 	}
 	return n1 == n2
 }
+
+/////////////////////////////
+// ITERATE: archived files //
+/////////////////////////////
+
+// archCB is called for each archived (regular) file, in the order of appearance
+// (msgpack: sorted by name); `reader` is only valid for the duration of the call
+type archCB func(filename string, oah cmn.ObjAttrsHolder, reader io.Reader) error
+
+func iterArch(file *os.File, mime string, size int64, cb archCB) error {
+	switch mime {
+	case cos.ExtTar:
+		return iterTar(file, cb)
+	case cos.ExtTarTgz, cos.ExtTgz:
+		gzr, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		err = iterTar(gzr, cb)
+		gzr.Close()
+		return err
+	case cos.ExtZip:
+		return iterZip(file, size, cb)
+	case cos.ExtMsgpack:
+		return iterMsgpack(file, cb)
+	default:
+		return cos.NewUnknownMimeError(mime)
+	}
+}
+
+func iterTar(reader io.Reader, cb archCB) error {
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if !hdr.FileInfo().Mode().IsRegular() {
+			continue
+		}
+		oa := &cmn.ObjAttrs{Size: hdr.Size, Atime: hdr.ModTime.UnixNano()}
+		if err := cb(hdr.Name, oa, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func iterZip(readerAt io.ReaderAt, size int64, cb archCB) error {
+	zr, err := zip.NewReader(readerAt, size)
+	if err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		finfo := f.FileInfo()
+		if finfo.IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		oa := &cmn.ObjAttrs{Size: finfo.Size(), Atime: f.Modified.UnixNano()}
+		err = cb(f.Name, oa, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func iterMsgpack(reader io.Reader, cb archCB) error {
+	var (
+		dst interface{}
+		dec = msgpack.NewDecoder(reader)
+	)
+	if err := dec.Decode(&dst); err != nil {
+		return err
+	}
+	out, ok := dst.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected type (%T)", dst)
+	}
+	names := make([]string, 0, len(out))
+	for name := range out {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b, ok := out[name].([]byte)
+		if !ok {
+			return fmt.Errorf("%s: unexpected type (%T)", name, out[name])
+		}
+		if err := cb(name, &cmn.ObjAttrs{Size: int64(len(b))}, bytes.NewReader(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@
 package backend
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -363,7 +364,7 @@ func (m *AISBackendProvider) ListObjects(remoteBck *cluster.Bck, msg *apc.ListOb
 	return
 }
 
-func (m *AISBackendProvider) listBucketsCluster(uuid string, qbck cmn.QueryBcks) (bcks cmn.Bcks, err error) {
+func (m *AISBackendProvider) listBucketsCluster(ctx context.Context, uuid string, qbck cmn.QueryBcks) (bcks cmn.Bcks, err error) {
 	var (
 		aisCluster  *remAISCluster
 		remoteQuery = cmn.QueryBcks{Provider: apc.ProviderAIS, Ns: cmn.Ns{Name: qbck.Ns.Name}}
@@ -371,7 +372,16 @@ func (m *AISBackendProvider) listBucketsCluster(uuid string, qbck cmn.QueryBcks)
 	if aisCluster, err = m.remoteCluster(uuid); err != nil {
 		return
 	}
-	bcks, err = api.ListBuckets(aisCluster.bp, remoteQuery)
+	bp := aisCluster.bp
+	if deadline, ok := ctx.Deadline(); ok {
+		// api.BaseParams carries no context - enforce the deadline via client timeout
+		client := *bp.Client
+		if client.Timeout = time.Until(deadline); client.Timeout <= 0 {
+			return nil, context.DeadlineExceeded
+		}
+		bp.Client = &client
+	}
+	bcks, err = api.ListBuckets(bp, remoteQuery)
 	if err != nil {
 		_, err = extractErrCode(err)
 		return nil, err
@@ -383,12 +393,12 @@ func (m *AISBackendProvider) listBucketsCluster(uuid string, qbck cmn.QueryBcks)
 	return bcks, nil
 }
 
-func (m *AISBackendProvider) ListBuckets(qbck cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
+func (m *AISBackendProvider) ListBuckets(ctx context.Context, qbck cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
 	if !qbck.Ns.IsAnyRemote() {
-		bcks, err = m.listBucketsCluster(qbck.Ns.UUID, qbck)
+		bcks, err = m.listBucketsCluster(ctx, qbck.Ns.UUID, qbck)
 	} else {
 		for uuid := range m.remote {
-			remoteBcks, tryErr := m.listBucketsCluster(uuid, qbck)
+			remoteBcks, tryErr := m.listBucketsCluster(ctx, uuid, qbck)
 			bcks = append(bcks, remoteBcks...)
 			if tryErr != nil {
 				err = tryErr
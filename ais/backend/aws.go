@@ -244,13 +244,13 @@ func (awsp *awsProvider) ListObjects(bck *cluster.Bck, msg *apc.ListObjsMsg) (bc
 // LIST BUCKETS //
 //////////////////
 
-func (*awsProvider) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
+func (*awsProvider) ListBuckets(ctx context.Context, _ cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
 	svc, _, err := newClient(sessConf{}, "")
 	if err != nil {
 		errCode, err = awsErrorToAISError(err, &cmn.Bck{Provider: apc.ProviderAmazon})
 		return
 	}
-	result, err := svc.ListBuckets(&s3.ListBucketsInput{})
+	result, err := svc.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		errCode, err = awsErrorToAISError(err, &cmn.Bck{Provider: apc.ProviderAmazon})
 		return
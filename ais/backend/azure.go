@@ -281,14 +281,14 @@ func (ap *azureProvider) ListObjects(bck *cluster.Bck, msg *apc.ListObjsMsg) (bc
 // LIST BUCKETS //
 //////////////////
 
-func (ap *azureProvider) ListBuckets(_ cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
+func (ap *azureProvider) ListBuckets(ctx context.Context, _ cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
 	var (
 		o          azblob.ListContainersSegmentOptions
 		marker     azblob.Marker
 		containers *azblob.ListContainersSegmentResponse
 	)
 	for marker.NotDone() {
-		containers, err = ap.s.ListContainersSegment(azctx, marker, o)
+		containers, err = ap.s.ListContainersSegment(ctx, marker, o)
 		if err != nil {
 			errCode, err = azureErrorToAISError(err, &cmn.Bck{Provider: apc.ProviderAzure}, "")
 			return
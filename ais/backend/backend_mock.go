@@ -45,7 +45,7 @@ func (*dummyBackendProvider) ListObjects(bck *cluster.Bck, _ *apc.ListObjsMsg) (
 }
 
 // The function must not fail - it should return empty list.
-func (*dummyBackendProvider) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
+func (*dummyBackendProvider) ListBuckets(context.Context, cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
 	return
 }
 
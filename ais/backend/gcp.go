@@ -205,14 +205,14 @@ func (gcpp *gcpProvider) ListObjects(bck *cluster.Bck, msg *apc.ListObjsMsg) (bc
 // LIST BUCKETS //
 //////////////////
 
-func (gcpp *gcpProvider) ListBuckets(_ cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
+func (gcpp *gcpProvider) ListBuckets(ctx context.Context, _ cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
 	if gcpp.projectID == "" {
 		// NOTE: empty `projectID` results in obscure: "googleapi: Error 400: Invalid argument"
 		return nil, http.StatusBadRequest,
 			errors.New("empty project ID: cannot list GCP buckets with no authentication")
 	}
 	bcks = make(cmn.Bcks, 0, 16)
-	it := gcpClient.Buckets(gctx, gcpp.projectID)
+	it := gcpClient.Buckets(ctx, gcpp.projectID)
 	for {
 		var battrs *storage.BucketAttrs
 
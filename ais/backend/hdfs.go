@@ -196,7 +196,7 @@ func skipDir(fi os.FileInfo) error {
 // LIST BUCKETS //
 //////////////////
 
-func (*hdfsProvider) ListBuckets(cmn.QueryBcks) (buckets cmn.Bcks, errCode int, err error) {
+func (*hdfsProvider) ListBuckets(context.Context, cmn.QueryBcks) (buckets cmn.Bcks, errCode int, err error) {
 	debug.Assert(false)
 	return
 }
@@ -105,7 +105,7 @@ func (*httpProvider) ListObjects(*cluster.Bck, *apc.ListObjsMsg) (bckList *cmn.B
 	return
 }
 
-func (*httpProvider) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
+func (*httpProvider) ListBuckets(context.Context, cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
 	debug.Assert(false)
 	return
 }
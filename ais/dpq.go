@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/NVIDIA/aistore/ais/s3compat"
 	"github.com/NVIDIA/aistore/api/apc"
 )
 
@@ -24,6 +25,9 @@ type dpq struct {
 	origURL             string // ht://url->
 	appendTy, appendHdl string // APPEND { apc.AppendOp, ... }
 	owt                 string // object write transaction { OwtPut, ... }
+	versionID           string // previous version (apc.QparamVersionID or S3 "versionId")
+	snapshot            string // bucket snapshot (apc.QparamSnapshot)
+	archmode            string // PUT into existing archive (apc.QparamArchmode)
 }
 
 var (
@@ -84,6 +88,8 @@ func (dpq *dpq) fromRawQ(rawQuery string) (err error) {
 			if dpq.archmime, err = url.QueryUnescape(value); err != nil {
 				return
 			}
+		case apc.QparamArchmode:
+			dpq.archmode = value
 		case apc.QparamIsGFNRequest:
 			dpq.isGFN = value
 		case apc.QparamOrigURL:
@@ -98,6 +104,12 @@ func (dpq *dpq) fromRawQ(rawQuery string) (err error) {
 			}
 		case apc.QparamOWT:
 			dpq.owt = value
+		case apc.QparamVersionID, s3compat.QparamVersionID:
+			if dpq.versionID, err = url.QueryUnescape(value); err != nil {
+				return
+			}
+		case apc.QparamSnapshot:
+			dpq.snapshot = value
 		default:
 			err = errors.New("failed to fast-parse [" + rawQuery + "]")
 			return
@@ -365,6 +365,9 @@ func (p *proxy) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntargets i
 	if etlMD.Version > 0 {
 		_ = p.metasyncer.sync(revsPair{etlMD, aisMsg})
 	}
+	if schMD := p.sched.owner.get(); schMD != nil && schMD.Version > 0 {
+		_ = p.metasyncer.sync(revsPair{schMD, aisMsg})
+	}
 
 	// Clear regpool
 	p.reg.mtx.Lock()
@@ -0,0 +1,386 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Cluster event log:
+// - any proxy can originate an event; non-primary forwards it to the primary;
+// - primary assigns sequence IDs, persists, and replicates new events to all other proxies;
+// - each proxy keeps (up to `config.Log.EventsMax`) events in memory and in a JSONL file
+//   in its config directory, so that the history survives restarts and primary failover;
+// - optionally, primary POSTs each new event to `config.Log.EventsWebhook` - asynchronously,
+//   via its own bounded queue, so that a slow webhook does not stall the log;
+// - AuthN login is recorded once per token (and not once per proxy that sees it).
+
+const (
+	evlogChanCap  = 512
+	evlogMaxBatch = 64
+	evlogTimeout  = 10 * time.Second // webhook
+)
+
+type evlog struct {
+	p      *proxy
+	fh     *os.File
+	fpath  string
+	events []cmn.Event         // in memory, in the increasing order of IDs
+	logins map[string]struct{} // tokens (fingerprints) of the logins in `events`
+	seq    int64               // last assigned or received ID
+	nfile  int                 // number of lines in the file (to trigger compaction)
+	workCh chan cmn.Event
+	hookCh chan cmn.Event // webhook queue
+	client *http.Client   // webhook
+	mu     sync.RWMutex
+}
+
+func (e *evlog) init(p *proxy, config *cmn.Config) {
+	e.p = p
+	e.fpath = filepath.Join(config.ConfigDir, fname.Events)
+	e.logins = make(map[string]struct{}, 16)
+	e.load()
+	fh, err := os.OpenFile(e.fpath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+	if err != nil {
+		glog.Errorf("%s: failed to open %q (events won't persist): %v", p, e.fpath, err)
+	}
+	e.fh = fh
+	e.client = cmn.NewClient(cmn.TransportArgs{Timeout: evlogTimeout})
+	e.workCh = make(chan cmn.Event, evlogChanCap)
+	e.hookCh = make(chan cmn.Event, evlogChanCap)
+	go e.run()
+	go e.runHook()
+}
+
+func evlogMax() int {
+	if n := cmn.GCO.Get().Log.EventsMax; n > 0 {
+		return n
+	}
+	return cmn.DfltEventsMax
+}
+
+func (e *evlog) load() {
+	fh, err := os.Open(e.fpath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to load events: %v", e.p, err)
+		}
+		return
+	}
+	scanner := bufio.NewScanner(fh)
+	for scanner.Scan() {
+		var ev cmn.Event
+		if err := jsoniter.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			glog.Errorf("%s: failed to parse event %q: %v", e.p, scanner.Text(), err)
+			continue
+		}
+		e.nfile++
+		if ev.ID <= e.seq {
+			continue
+		}
+		e.seq = ev.ID
+		e.events = append(e.events, ev)
+	}
+	fh.Close()
+	if max := evlogMax(); len(e.events) > max {
+		e.events = append([]cmn.Event(nil), e.events[len(e.events)-max:]...)
+	}
+	e.indexLogins()
+	glog.Infof("%s: loaded %d events (last ID %d)", e.p, len(e.events), e.seq)
+}
+
+// record a new event; never blocks
+func (e *evlog) add(ev *cmn.Event) {
+	if e.workCh == nil {
+		return // not initialized (unit tests)
+	}
+	if ev.Time == 0 {
+		ev.Time = time.Now().UnixNano()
+	}
+	if ev.Node == "" {
+		ev.Node = e.p.si.ID()
+	}
+	select {
+	case e.workCh <- *ev:
+	default:
+		glog.Errorf("%s: event log is busy, dropping %s", e.p, ev)
+	}
+}
+
+// received from another proxy
+func (e *evlog) recv(events []cmn.Event, fromPrimary bool) {
+	if !fromPrimary {
+		for i := range events {
+			e.add(&events[i]) // (on primary) assign IDs and replicate
+		}
+		return
+	}
+	e.append(events, false /*assign IDs*/)
+}
+
+func (e *evlog) run() {
+	batch := make([]cmn.Event, 0, evlogMaxBatch)
+	for ev := range e.workCh {
+		batch = append(batch[:0], ev)
+	drain:
+		for len(batch) < evlogMaxBatch {
+			select {
+			case ev := <-e.workCh:
+				batch = append(batch, ev)
+			default:
+				break drain
+			}
+		}
+		smap := e.p.owner.smap.get()
+		if !smap.isPrimary(e.p.si) {
+			e.forward(smap, batch)
+			continue
+		}
+		if batch = e.append(batch, true /*assign IDs*/); len(batch) == 0 {
+			continue
+		}
+		// NOTE: replicating in order (see recv)
+		e.replicate(smap, batch)
+		if cmn.GCO.Get().Log.EventsWebhook != "" {
+			for i := range batch {
+				select {
+				case e.hookCh <- batch[i]:
+				default:
+					glog.Errorf("%s: events webhook is busy, dropping %s", e.p, &batch[i])
+				}
+			}
+		}
+	}
+}
+
+// returns the events that were actually added (in place, reusing the batch)
+func (e *evlog) append(batch []cmn.Event, assign bool) []cmn.Event {
+	var (
+		buf   bytes.Buffer
+		added = batch[:0]
+	)
+	e.mu.Lock()
+	for i := range batch {
+		ev := &batch[i]
+		if assign {
+			if e.isRelogin(ev) {
+				continue
+			}
+			e.seq++
+			ev.ID = e.seq
+		} else if ev.ID <= e.seq {
+			continue // duplicate
+		} else {
+			e.seq = ev.ID
+		}
+		e.addLogin(ev)
+		e.events = append(e.events, *ev)
+		buf.Write(cos.MustMarshal(ev))
+		buf.WriteByte('\n')
+		e.nfile++
+		added = append(added, *ev)
+	}
+	max := evlogMax()
+	if len(e.events) > max {
+		e.events = append(e.events[:0:0], e.events[len(e.events)-max:]...)
+		e.indexLogins()
+	}
+	if e.fh != nil {
+		if e.nfile > 2*max {
+			e.compact()
+		} else if _, err := e.fh.Write(buf.Bytes()); err != nil {
+			glog.Errorf("%s: failed to persist events: %v", e.p, err)
+		}
+	}
+	e.mu.Unlock()
+	return added
+}
+
+// login events carry the token's fingerprint (see proxy.validateToken) - the primary
+// records only the first one; under lock
+func (e *evlog) isRelogin(ev *cmn.Event) bool {
+	if ev.Kind != cmn.EvKindAuth || ev.Action != cmn.EvActLogin || ev.TokenFP == "" {
+		return false
+	}
+	_, ok := e.logins[ev.TokenFP]
+	return ok
+}
+
+func (e *evlog) addLogin(ev *cmn.Event) {
+	if ev.Kind == cmn.EvKindAuth && ev.Action == cmn.EvActLogin && ev.TokenFP != "" {
+		e.logins[ev.TokenFP] = struct{}{}
+	}
+}
+
+// (re)build the index from the events that are currently kept in memory
+func (e *evlog) indexLogins() {
+	e.logins = make(map[string]struct{}, len(e.logins))
+	for i := range e.events {
+		e.addLogin(&e.events[i])
+	}
+}
+
+// rewrite the file to contain only the events that are currently kept in memory
+// (under lock)
+func (e *evlog) compact() {
+	var (
+		buf bytes.Buffer
+		tmp = e.fpath + ".tmp"
+	)
+	for i := range e.events {
+		buf.Write(cos.MustMarshal(&e.events[i]))
+		buf.WriteByte('\n')
+	}
+	if err := os.WriteFile(tmp, buf.Bytes(), cos.PermRWR); err != nil {
+		glog.Errorf("%s: failed to compact events: %v", e.p, err)
+		return
+	}
+	e.fh.Close()
+	if err := os.Rename(tmp, e.fpath); err != nil {
+		glog.Errorf("%s: failed to compact events: %v", e.p, err)
+	}
+	fh, err := os.OpenFile(e.fpath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+	if err != nil {
+		glog.Errorf("%s: failed to reopen %q: %v", e.p, e.fpath, err)
+	}
+	e.fh = fh
+	e.nfile = len(e.events)
+}
+
+func (e *evlog) query(q *cmn.EventQuery) (events []cmn.Event) {
+	e.mu.RLock()
+	for i := len(e.events) - 1; i >= 0; i-- {
+		if q.Limit > 0 && len(events) >= q.Limit {
+			break
+		}
+		if ev := &e.events[i]; q.Match(ev) {
+			events = append(events, *ev)
+		}
+	}
+	e.mu.RUnlock()
+	// chronological order
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return
+}
+
+func (e *evlog) replicate(smap *smapX, batch []cmn.Event) {
+	if smap.CountActiveProxies() < 2 {
+		return
+	}
+	msg := apc.ActionMsg{Action: apc.ActAddEvents, Value: batch}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathDae.S, Body: cos.MustMarshal(msg)}
+	args.to = cluster.Proxies
+	args.smap = smap
+	args.timeout = cmn.Timeout.MaxKeepalive()
+	args.async = true
+	_ = e.p.bcastGroup(args) // best effort
+	freeBcArgs(args)
+}
+
+func (e *evlog) forward(smap *smapX, batch []cmn.Event) {
+	if smap.Primary == nil {
+		glog.Errorf("%s: primary unknown, dropping %d event(s)", e.p, len(batch))
+		return
+	}
+	msg := apc.ActionMsg{Action: apc.ActAddEvents, Value: batch}
+	cargs := allocCargs()
+	{
+		cargs.si = smap.Primary
+		cargs.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathDae.S, Body: cos.MustMarshal(msg)}
+		cargs.timeout = cmn.Timeout.MaxKeepalive()
+	}
+	res := e.p.call(cargs)
+	if res.err != nil {
+		glog.Errorf("%s: failed to forward %d event(s) to primary %s: %v", e.p, len(batch), smap.Primary, res.err)
+	}
+	freeCargs(cargs)
+	freeCR(res)
+}
+
+func (e *evlog) runHook() {
+	for ev := range e.hookCh {
+		url := cmn.GCO.Get().Log.EventsWebhook
+		if url == "" {
+			continue // disabled in the meantime
+		}
+		ev.TokenFP = "" // (internal)
+		e.webhook(url, &ev)
+	}
+}
+
+func (e *evlog) webhook(url string, ev *cmn.Event) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(cos.MustMarshal(ev)))
+	if err != nil {
+		glog.Errorf("%s: events webhook: %v", e.p, err)
+		return
+	}
+	req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	resp, err := e.client.Do(req)
+	if err != nil {
+		glog.Errorf("%s: events webhook %q: %v", e.p, url, err)
+		return
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		glog.Errorf("%s: events webhook %q: status %d", e.p, url, resp.StatusCode)
+	}
+}
+
+//
+// proxy cont-ed
+//
+
+// best-effort identification of the API caller (see cmn.Event.Actor)
+func (p *proxy) actor(r *http.Request) string {
+	if name := r.Header.Get(apc.HdrCallerName); name != "" {
+		return name // intra-cluster
+	}
+	if cmn.GCO.Get().Auth.Enabled {
+		if token, err := tok.ExtractToken(r.Header); err == nil {
+			if tk, _, err := p.authn.validateToken(token); err == nil {
+				return tk.UserID
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// GET /v1/cluster?what=events
+func (p *proxy) queryClusterEvents(w http.ResponseWriter, r *http.Request, what string) {
+	var q cmn.EventQuery
+	if err := q.FromQuery(r.URL.Query()); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	events := p.evlog.query(&q)
+	if events == nil {
+		events = []cmn.Event{}
+	}
+	p.writeJSON(w, r, events, what)
+}
@@ -0,0 +1,208 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+// temporarily override event log configuration
+func evlogConfig(t *testing.T, max int, webhook string) {
+	config := cmn.GCO.BeginUpdate()
+	prevMax, prevHook := config.Log.EventsMax, config.Log.EventsWebhook
+	config.Log.EventsMax, config.Log.EventsWebhook = max, webhook
+	cmn.GCO.CommitUpdate(config)
+	t.Cleanup(func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Log.EventsMax, config.Log.EventsWebhook = prevMax, prevHook
+		cmn.GCO.CommitUpdate(config)
+	})
+}
+
+func newTestEvlog(t *testing.T, p *proxy, fpath string) *evlog {
+	e := &evlog{p: p, fpath: fpath, logins: make(map[string]struct{})}
+	e.load()
+	fh, err := os.OpenFile(fpath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+	tassert.CheckFatal(t, err)
+	e.fh = fh
+	t.Cleanup(func() { e.fh.Close() })
+	return e
+}
+
+func countLines(t *testing.T, fpath string) (n int) {
+	fh, err := os.Open(fpath)
+	tassert.CheckFatal(t, err)
+	defer fh.Close()
+	for scanner := bufio.NewScanner(fh); scanner.Scan(); {
+		n++
+	}
+	return
+}
+
+// events sent to a given (fake) proxy via ActAddEvents
+type evlogSink struct {
+	mu     sync.Mutex
+	events []cmn.Event
+}
+
+func (s *evlogSink) server(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			msg    apc.ActionMsg
+			events []cmn.Event
+		)
+		tassert.CheckError(t, cmn.ReadJSON(w, r, &msg))
+		tassert.Errorf(t, msg.Action == apc.ActAddEvents, "unexpected action %q", msg.Action)
+		tassert.CheckError(t, cos.MorphMarshal(msg.Value, &events))
+		s.mu.Lock()
+		s.events = append(s.events, events...)
+		s.mu.Unlock()
+	}))
+}
+
+func (s *evlogSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.events)
+}
+
+func TestEvlogAppendCompactLoad(t *testing.T) {
+	const max = 4
+	evlogConfig(t, max, "")
+	var (
+		p     = newPrimary()
+		fpath = filepath.Join(t.TempDir(), "events")
+		e     = newTestEvlog(t, p, fpath)
+	)
+	for i := 0; i < 3; i++ {
+		added := e.append([]cmn.Event{{Kind: cmn.EvKindBucket}, {Kind: cmn.EvKindConfig}}, true /*assign IDs*/)
+		tassert.Errorf(t, len(added) == 2, "expected 2 events added, got %d", len(added))
+	}
+	tassert.Errorf(t, e.seq == 6, "expected last ID 6, got %d", e.seq)
+	tassert.Errorf(t, len(e.events) == max, "expected %d events in memory, got %d", max, len(e.events))
+	tassert.Errorf(t, e.events[0].ID == 3, "expected the oldest kept ID 3, got %d", e.events[0].ID)
+
+	// 6 lines > 2*max not yet - one more batch to trigger compaction
+	e.append([]cmn.Event{{Kind: cmn.EvKindSmap}, {Kind: cmn.EvKindSmap}, {Kind: cmn.EvKindSmap}}, true)
+	tassert.Errorf(t, countLines(t, fpath) == max, "expected %d lines after compaction, got %d", max, countLines(t, fpath))
+
+	// replicated (IDs assigned by primary): duplicates and older events are skipped
+	added := e.append([]cmn.Event{{ID: 8, Kind: cmn.EvKindNode}, {ID: 10, Kind: cmn.EvKindNode}}, false)
+	tassert.Errorf(t, len(added) == 1 && added[0].ID == 10, "expected only ID 10 added, got %+v", added)
+
+	// restart
+	e2 := newTestEvlog(t, p, fpath)
+	tassert.Errorf(t, e2.seq == 10, "expected last ID 10 after load, got %d", e2.seq)
+	tassert.Errorf(t, len(e2.events) == max, "expected %d events after load, got %d", max, len(e2.events))
+	events := e2.query(&cmn.EventQuery{Kinds: []string{cmn.EvKindNode}})
+	tassert.Errorf(t, len(events) == 1 && events[0].ID == 10, "unexpected query result %+v", events)
+	events = e2.query(&cmn.EventQuery{Limit: 2})
+	tassert.Errorf(t, len(events) == 2 && events[0].ID < events[1].ID, "expected 2 most recent in order, got %+v", events)
+}
+
+func TestEvlogLoginOnce(t *testing.T) {
+	evlogConfig(t, 100, "")
+	var (
+		p     = newPrimary()
+		fpath = filepath.Join(t.TempDir(), "events")
+		e     = newTestEvlog(t, p, fpath)
+		login = func(fp string) cmn.Event {
+			return cmn.Event{Kind: cmn.EvKindAuth, Action: cmn.EvActLogin, Actor: "alice", TokenFP: fp}
+		}
+	)
+	// the same token seen by two proxies, and a new one
+	added := e.append([]cmn.Event{login("aaa"), login("aaa")}, true)
+	tassert.Errorf(t, len(added) == 1, "expected a single login, got %d", len(added))
+	added = e.append([]cmn.Event{login("aaa"), login("bbb")}, true)
+	tassert.Errorf(t, len(added) == 1 && added[0].TokenFP == "bbb", "expected only the new token, got %+v", added)
+
+	// (new primary) remembers the logins upon restart
+	e2 := newTestEvlog(t, p, fpath)
+	added = e2.append([]cmn.Event{login("bbb")}, true)
+	tassert.Errorf(t, len(added) == 0, "expected no repeated login after load, got %+v", added)
+}
+
+func TestEvlogForwardReplicate(t *testing.T) {
+	var (
+		primary = newPrimary()
+		sinks   = [2]evlogSink{}
+		smap    = primary.owner.smap.get().clone()
+		batch   = []cmn.Event{{ID: 1, Kind: cmn.EvKindBucket}, {ID: 2, Kind: cmn.EvKindBMD}}
+	)
+	for i := range sinks {
+		ts := sinks[i].server(t)
+		defer ts.Close()
+		id := "proxy" + string(rune('1'+i))
+		addr := serverTCPAddr(ts.URL)
+		smap.Pmap[id] = cluster.NewSnode(id, apc.Proxy, addr, addr, addr)
+	}
+	smap.Version++
+	primary.owner.smap.put(smap)
+
+	e := &evlog{p: primary}
+	e.replicate(smap, batch)
+	for i := range sinks {
+		tassert.Errorf(t, sinks[i].count() == len(batch), "proxy %d: expected %d events, got %d",
+			i+1, len(batch), sinks[i].count())
+	}
+
+	// non-primary forwards to the primary
+	nonPrimary := newPrimary()
+	nonPrimary.si = cluster.NewSnode("proxy3", apc.Proxy, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+	smap = smap.clone()
+	smap.Primary = smap.Pmap["proxy1"]
+	e = &evlog{p: nonPrimary}
+	e.forward(smap, batch[:1])
+	tassert.Errorf(t, sinks[0].count() == len(batch)+1, "primary: expected %d events, got %d",
+		len(batch)+1, sinks[0].count())
+}
+
+func TestEvlogSlowWebhook(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		hooked  = make(chan struct{}, 1)
+		hook    = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case hooked <- struct{}{}:
+			default:
+			}
+			<-release
+		}))
+	)
+	defer hook.Close()
+	defer close(release)
+	evlogConfig(t, 100, hook.URL)
+
+	config := &cmn.Config{}
+	config.ConfigDir = t.TempDir()
+	e := &evlog{}
+	e.init(newPrimary(), config)
+	e.add(&cmn.Event{Kind: cmn.EvKindBucket})
+	<-hooked // the webhook is now stuck
+
+	const num = 10
+	for i := 0; i < num; i++ {
+		e.add(&cmn.Event{Kind: cmn.EvKindConfig})
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(e.query(&cmn.EventQuery{})) < num+1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	n := len(e.query(&cmn.EventQuery{}))
+	tassert.Errorf(t, n == num+1, "expected %d events logged regardless of the webhook, got %d", num+1, n)
+}
@@ -0,0 +1,297 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/admit"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Detailed health and readiness (compare with the plain "alive" GET /v1/health):
+// - GET /v1/health?details=true returns cmn.NodeHealth with per-component status;
+// - GET /v1/health?ready=true fails with 503 unless all components listed in
+//   `config.Keepalive.Readiness` are "ok" (no criteria - same as ?readiness=true);
+// - GET /v1/cluster?what=health (primary) collects all nodes and computes metasync lag;
+// - remote backends are checked on demand and at most once per `config.Periodic.StatsTime`
+//   (the result is cached) - the (unauthenticated) health endpoint must not translate
+//   into a stream of cloud API calls.
+
+// components specific to the node type (proxy or target)
+type healthCompsFunc func(nh *cmn.NodeHealth, query url.Values, criteria []string)
+
+// returns true if responded (that is, details were requested or readiness criteria are configured
+// and evaluated via ?ready=true)
+func (h *htrun) healthDetailed(w http.ResponseWriter, r *http.Request, query url.Values, comps healthCompsFunc) bool {
+	var (
+		details   = cos.IsParseBool(query.Get(apc.QparamHealthDetails))
+		readiness = cos.IsParseBool(query.Get(apc.QparamHealthReady))
+		criteria  = cmn.ParseReadiness(cmn.GCO.Get().Keepalive.Readiness)
+	)
+	if !details && (!readiness || len(criteria) == 0) {
+		return false
+	}
+	nh := h.nodeHealth(r.Header)
+	if comps != nil {
+		comps(nh, query, criteria)
+	}
+	nh.Eval(criteria)
+	if readiness && len(criteria) > 0 && !nh.Ready {
+		if !details {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return true
+		}
+		w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if details {
+		h.writeJSON(w, r, nh, "node-health")
+	}
+	return true
+}
+
+// components common for proxies and targets: Smap membership and metasync
+func (h *htrun) nodeHealth(hdr http.Header) *cmn.NodeHealth {
+	var (
+		smap = h.owner.smap.get()
+		nh   = &cmn.NodeHealth{Node: h.si.ID(), Role: h.si.Type(), SmapVer: smap.version()}
+	)
+	if bmd := h.owner.bmd.get(); bmd != nil {
+		nh.BMDVer = bmd.version()
+	}
+	switch {
+	case !h.NodeStarted():
+		nh.Add(cmn.HealthSmap, cmn.HealthStarting, "node is starting up")
+	case !smap.isValid():
+		nh.Add(cmn.HealthSmap, cmn.HealthStarting, "no valid cluster map yet")
+	case !smap.isPresent(h.si):
+		nh.Add(cmn.HealthSmap, cmn.HealthFailed, "not present in "+smap.StringEx())
+	case smap.PresentInMaint(h.si):
+		nh.Add(cmn.HealthSmap, cmn.HealthDegraded, "in maintenance, "+smap.StringEx())
+	case !h.ClusterStarted():
+		nh.Add(cmn.HealthSmap, cmn.HealthStarting, "cluster is starting up, "+smap.StringEx())
+	default:
+		nh.Add(cmn.HealthSmap, cmn.HealthOK, smap.StringEx())
+	}
+	// lag relative to the caller's Smap, if known (see also healthMetasync)
+	callerVer, _ := strconv.ParseInt(hdr.Get(apc.HdrCallerSmapVersion), 10, 64)
+	if callerVer > nh.SmapVer {
+		nh.Add(cmn.HealthMetasync, cmn.HealthDegraded,
+			fmt.Sprintf("Smap v%d behind the caller's v%d", nh.SmapVer, callerVer))
+	} else {
+		nh.Add(cmn.HealthMetasync, cmn.HealthOK, fmt.Sprintf("Smap v%d, BMD v%d", nh.SmapVer, nh.BMDVer))
+	}
+	return nh
+}
+
+// (re)evaluate metasync lag relative to the given (primary's) versions
+func healthMetasync(nh *cmn.NodeHealth, smapVer, bmdVer int64) {
+	c := nh.Get(cmn.HealthMetasync)
+	if c == nil {
+		return
+	}
+	var lag []string
+	if nh.SmapVer < smapVer {
+		lag = append(lag, fmt.Sprintf("Smap v%d (primary v%d)", nh.SmapVer, smapVer))
+	}
+	if nh.BMDVer < bmdVer {
+		lag = append(lag, fmt.Sprintf("BMD v%d (primary v%d)", nh.BMDVer, bmdVer))
+	}
+	if len(lag) > 0 {
+		c.Status, c.Msg = cmn.HealthDegraded, "lagging behind: "+strings.Join(lag, ", ")
+	} else {
+		c.Status, c.Msg = cmn.HealthOK, fmt.Sprintf("Smap v%d, BMD v%d", nh.SmapVer, nh.BMDVer)
+	}
+}
+
+//
+// target
+//
+
+func (t *target) healthComps(nh *cmn.NodeHealth, query url.Values, criteria []string) {
+	// mountpaths (FSHC disables faulty ones)
+	avail, disabled := fs.Get()
+	switch {
+	case len(avail) == 0:
+		nh.Add(cmn.HealthMountpaths, cmn.HealthFailed, cmn.ErrNoMountpaths.Error())
+	case len(disabled) > 0:
+		nh.Add(cmn.HealthMountpaths, cmn.HealthDegraded, "disabled: "+disabled.String())
+	default:
+		nh.Add(cmn.HealthMountpaths, cmn.HealthOK, strconv.Itoa(len(avail))+" available")
+	}
+	// capacity vs space watermarks
+	switch cs := fs.GetCapStatus(); {
+	case cs.IsNil():
+		nh.Add(cmn.HealthCapacity, cmn.HealthStarting, "capacity not yet known")
+	case cs.OOS:
+		nh.Add(cmn.HealthCapacity, cmn.HealthFailed, cs.String())
+	case cs.Err != nil:
+		nh.Add(cmn.HealthCapacity, cmn.HealthDegraded, cs.String())
+	default:
+		nh.Add(cmn.HealthCapacity, cmn.HealthOK, cs.String())
+	}
+	// admission control
+	if st := admit.GetStatus(); st.Reason != "" {
+		nh.Add(cmn.HealthAdmission, cmn.HealthDegraded, "overloaded: "+st.Reason)
+	} else {
+		nh.Add(cmn.HealthAdmission, cmn.HealthOK, "")
+	}
+	// rebalance and resilver
+	healthMarked(nh, cmn.HealthRebalance, xreg.GetRebMarked())
+	healthMarked(nh, cmn.HealthResilver, xreg.GetResilverMarked())
+
+	// remote backends: on demand (network round-trips)
+	if cos.IsParseBool(query.Get(apc.QparamHealthBackends)) || cos.StringInSlice(cmn.HealthBackend, criteria) {
+		t.healthBackends(nh)
+	}
+}
+
+func healthMarked(nh *cmn.NodeHealth, name string, marked xact.Marked) {
+	switch {
+	case marked.Xact != nil:
+		nh.Add(name, cmn.HealthDegraded, "running "+marked.Xact.String())
+	case marked.Interrupted:
+		nh.Add(name, cmn.HealthDegraded, "interrupted")
+	default:
+		nh.Add(name, cmn.HealthOK, "")
+	}
+}
+
+// (see healthBackends)
+type backendHealth struct {
+	mu     sync.Mutex
+	comp   cmn.HealthComponent
+	tcheck int64 // mono.NanoTime() of the last check
+}
+
+// list buckets of each configured remote backend (including remote AIS clusters, if any);
+// reuse the most recent result if it's fresh enough - one check at a time
+func (t *target) healthBackends(nh *cmn.NodeHealth) {
+	bh := &t.backendHealth
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+	config := cmn.GCO.Get()
+	if bh.tcheck == 0 || mono.Since(bh.tcheck) >= config.Periodic.StatsTime.D() {
+		bh.comp = checkBackends(t, config)
+		bh.tcheck = mono.NanoTime()
+	}
+	nh.Add(bh.comp.Name, bh.comp.Status, bh.comp.Msg)
+}
+
+func checkBackends(t *target, config *cmn.Config) cmn.HealthComponent {
+	var (
+		providers = make([]string, 0, len(config.Backend.Providers)+1)
+		errs      []string
+	)
+	for provider := range config.Backend.Providers {
+		if provider == apc.ProviderHDFS {
+			continue // cannot list buckets - nothing to ping
+		}
+		providers = append(providers, provider)
+	}
+	if _, ok := config.Backend.ProviderConf(apc.ProviderAIS); ok {
+		providers = append(providers, apc.ProviderAIS)
+	}
+	if len(providers) == 0 {
+		return cmn.HealthComponent{Name: cmn.HealthBackend, Status: cmn.HealthOK, Msg: "no remote backends"}
+	}
+	timeout := config.Timeout.MaxKeepalive.D()
+	for _, provider := range providers {
+		if err := t.pingBackend(provider, timeout); err != nil {
+			errs = append(errs, provider+": "+err.Error())
+		}
+	}
+	switch {
+	case len(errs) == len(providers):
+		return cmn.HealthComponent{Name: cmn.HealthBackend, Status: cmn.HealthFailed, Msg: strings.Join(errs, "; ")}
+	case len(errs) > 0:
+		return cmn.HealthComponent{Name: cmn.HealthBackend, Status: cmn.HealthDegraded, Msg: strings.Join(errs, "; ")}
+	default:
+		return cmn.HealthComponent{Name: cmn.HealthBackend, Status: cmn.HealthOK, Msg: strings.Join(providers, ", ")}
+	}
+}
+
+func (t *target) pingBackend(provider string, timeout time.Duration) error {
+	bp := t.backend[provider]
+	if bp == nil {
+		return fmt.Errorf("backend %q is not initialized", provider)
+	}
+	qbck := cmn.QueryBcks{Provider: provider}
+	if provider == apc.ProviderAIS {
+		qbck.Ns = cmn.NsAnyRemote
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	_, _, err := bp.ListBuckets(ctx, qbck)
+	cancel()
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+	return err
+}
+
+//
+// proxy
+//
+
+// GET /v1/cluster?what=health
+func (p *proxy) queryClusterHealth(w http.ResponseWriter, r *http.Request, what string) {
+	if p.forwardCP(w, r, nil, what) {
+		return
+	}
+	var (
+		query    = r.URL.Query()
+		criteria = cmn.ParseReadiness(cmn.GCO.Get().Keepalive.Readiness)
+		smap     = p.owner.smap.get()
+		bmdVer   = p.owner.bmd.get().version()
+		out      = make(cmn.ClusterHealth, smap.Count())
+	)
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathHealth.S,
+		Query:  url.Values{apc.QparamHealthDetails: []string{"true"}, apc.QparamHealthBackends: query[apc.QparamHealthBackends]},
+	}
+	args.to = cluster.AllNodes
+	args.smap = smap
+	args.ignoreMaintenance = true
+	args.cresv = cresNH{}
+	args.timeout = cmn.Timeout.MaxKeepalive()
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			nh := &cmn.NodeHealth{Node: res.si.ID(), Role: res.si.Type()}
+			nh.Add(cmn.HealthSmap, cmn.HealthFailed, res.err.Error())
+			nh.Eval(criteria)
+			out[res.si.ID()] = nh
+			continue
+		}
+		nh := res.v.(*cmn.NodeHealth)
+		healthMetasync(nh, smap.version(), bmdVer)
+		nh.Eval(criteria)
+		out[res.si.ID()] = nh
+	}
+	freeBcastRes(results)
+
+	self := p.nodeHealth(r.Header)
+	self.Eval(criteria)
+	out[p.si.ID()] = self
+	p.writeJSON(w, r, out, what)
+}
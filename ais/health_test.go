@@ -0,0 +1,103 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+// counts ListBuckets calls; optionally, blocks until canceled
+type pingBackend struct {
+	cluster.BackendProvider
+	calls atomic.Int32
+	hang  bool
+}
+
+func (b *pingBackend) ListBuckets(ctx context.Context, _ cmn.QueryBcks) (cmn.Bcks, int, error) {
+	b.calls.Inc()
+	if b.hang {
+		<-ctx.Done()
+		return nil, 0, ctx.Err()
+	}
+	return nil, 0, nil
+}
+
+func healthBackendsConfig(t *testing.T, statsTime time.Duration) {
+	config := cmn.GCO.BeginUpdate()
+	prevProviders, prevStatsTime, prevKalive := config.Backend.Providers, config.Periodic.StatsTime, config.Timeout.MaxKeepalive
+	config.Backend.Providers = map[string]cmn.Ns{apc.ProviderAmazon: cmn.NsGlobal}
+	config.Periodic.StatsTime = cos.Duration(statsTime)
+	config.Timeout.MaxKeepalive = cos.Duration(100 * time.Millisecond)
+	cmn.GCO.CommitUpdate(config)
+	t.Cleanup(func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Backend.Providers, config.Periodic.StatsTime, config.Timeout.MaxKeepalive = prevProviders, prevStatsTime, prevKalive
+		cmn.GCO.CommitUpdate(config)
+	})
+}
+
+func TestHealthBackendsCached(t *testing.T) {
+	healthBackendsConfig(t, time.Hour)
+	var (
+		bp  = &pingBackend{}
+		tgt = &target{backend: backends{apc.ProviderAmazon: bp}}
+	)
+	for i := 0; i < 3; i++ {
+		nh := &cmn.NodeHealth{}
+		tgt.healthBackends(nh)
+		c := nh.Get(cmn.HealthBackend)
+		tassert.Fatalf(t, c != nil && c.Status == cmn.HealthOK, "expected backend ok, got %+v", c)
+	}
+	tassert.Errorf(t, bp.calls.Load() == 1, "expected a single (cached) check, got %d", bp.calls.Load())
+
+	// stale
+	tgt.backendHealth.tcheck -= int64(2 * time.Hour)
+	tgt.healthBackends(&cmn.NodeHealth{})
+	tassert.Errorf(t, bp.calls.Load() == 2, "expected another check, got %d", bp.calls.Load())
+}
+
+func TestHealthBackendsTimeout(t *testing.T) {
+	healthBackendsConfig(t, 0)
+	var (
+		bp  = &pingBackend{hang: true}
+		tgt = &target{backend: backends{apc.ProviderAmazon: bp}}
+		nh  = &cmn.NodeHealth{}
+	)
+	started := time.Now()
+	tgt.healthBackends(nh)
+	c := nh.Get(cmn.HealthBackend)
+	tassert.Fatalf(t, c != nil && c.Status == cmn.HealthFailed, "expected backend failed, got %+v", c)
+	tassert.Errorf(t, strings.Contains(c.Msg, "timed out"), "unexpected %q", c.Msg)
+	tassert.Errorf(t, time.Since(started) < 5*time.Second, "took %v", time.Since(started))
+}
+
+// HDFS cannot list buckets - not pinged
+func TestHealthBackendsSkipHDFS(t *testing.T) {
+	healthBackendsConfig(t, 0)
+	config := cmn.GCO.BeginUpdate()
+	config.Backend.Providers = map[string]cmn.Ns{apc.ProviderAmazon: cmn.NsGlobal, apc.ProviderHDFS: cmn.NsGlobal}
+	cmn.GCO.CommitUpdate(config)
+	var (
+		bp   = &pingBackend{}
+		hdfs = &pingBackend{}
+		tgt  = &target{backend: backends{apc.ProviderAmazon: bp, apc.ProviderHDFS: hdfs}}
+		nh   = &cmn.NodeHealth{}
+	)
+	tgt.healthBackends(nh)
+	c := nh.Get(cmn.HealthBackend)
+	tassert.Fatalf(t, c != nil && c.Status == cmn.HealthOK, "expected backend ok, got %+v", c)
+	tassert.Errorf(t, bp.calls.Load() == 1 && hdfs.calls.Load() == 0, "expected only %s pinged, got %d and %d",
+		apc.ProviderAmazon, bp.calls.Load(), hdfs.calls.Load())
+}
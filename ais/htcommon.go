@@ -424,6 +424,7 @@ type (
 	cresEH struct{} // -> etl.PodHealthMsg
 	cresIC struct{} // -> icBundle
 	cresBM struct{} // -> bucketMD
+	cresNH struct{} // -> cmn.NodeHealth
 
 	cresBsumm struct{} // -> cmn.BckSummaries
 )
@@ -439,6 +440,7 @@ var (
 	_ cresv = cresEH{}
 	_ cresv = cresIC{}
 	_ cresv = cresBM{}
+	_ cresv = cresNH{}
 	_ cresv = cresBsumm{}
 )
 
@@ -483,6 +485,9 @@ func (c cresIC) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresBM) newV() interface{}                      { return &bucketMD{} }
 func (c cresBM) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresNH) newV() interface{}                      { return &cmn.NodeHealth{} }
+func (c cresNH) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 func (cresBsumm) newV() interface{}                      { return &cmn.BckSummaries{} }
 func (c cresBsumm) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
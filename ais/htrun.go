@@ -1768,7 +1768,11 @@ func (h *htrun) healthByExternalWD(w http.ResponseWriter, r *http.Request) (resp
 	caller := r.Header.Get(apc.HdrCallerName)
 	// external call
 	if callerID == "" && caller == "" {
-		readiness := cos.IsParseBool(r.URL.Query().Get(apc.QparamHealthReadiness))
+		var (
+			query     = r.URL.Query()
+			readiness = cos.IsParseBool(query.Get(apc.QparamHealthReadiness)) ||
+				cos.IsParseBool(query.Get(apc.QparamHealthReady)) // (no criteria configured)
+		)
 		if glog.FastV(4, glog.SmoduleAIS) {
 			glog.Infof("%s: external health-ping from %s (readiness=%t)", h.si, r.RemoteAddr, readiness)
 		}
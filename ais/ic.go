@@ -218,7 +218,25 @@ func (ic *ic) writeStatus(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	w.Write(cos.MustMarshal(status)) // TODO: include stats, e.g., progress when ready
+	// xactions: include cluster-wide progress
+	if _, ok := nl.(*xact.NotifXactListener); !ok {
+		w.Write(cos.MustMarshal(status))
+		return
+	}
+	var (
+		snaps = make([]*xact.SnapExt, 0, nl.NodeStats().Len())
+		ext   = &xact.StatusExt{NotifStatus: *status}
+	)
+	nl.NodeStats().Range(func(_ string, stats interface{}) bool {
+		if snap, ok := stats.(*xact.SnapExt); ok {
+			snaps = append(snaps, snap)
+		}
+		return true
+	})
+	if len(snaps) > 0 {
+		ext.Progress = xact.AggrProgress(snaps)
+	}
+	w.Write(cos.MustMarshal(ext))
 }
 
 // verb /v1/ic
@@ -320,6 +338,9 @@ func (ic *ic) handlePost(w http.ResponseWriter, r *http.Request) {
 }
 
 func (ic *ic) registerEqual(a regIC) {
+	if kind := a.nl.Kind(); kind != apc.ActList {
+		ic.p.evlog.add(&cmn.Event{Kind: cmn.EvKindXaction, Action: kind, XactID: a.nl.UUID()})
+	}
 	if a.query != nil {
 		a.query.Set(apc.QparamNotifyMe, equalIC)
 	}
@@ -81,8 +81,9 @@ const (
 	revsConfTag  = "Conf"
 	revsTokenTag = "token"
 	revsEtlMDTag = "EtlMD"
+	revsSchMDTag = "SchMD"
 
-	revsMaxTags   = 6         // NOTE
+	revsMaxTags   = 7         // NOTE
 	revsActionTag = "-action" // prefix revs tag
 )
 
@@ -247,6 +248,17 @@ func (y *metasyncer) sync(pairs ...revsPair) *sync.WaitGroup {
 	}
 	req.wg.Add(1)
 	req.reqType = revsReqSync
+	for _, pair := range pairs {
+		switch tag := pair.revs.tag(); tag {
+		case revsSmapTag, revsBMDTag:
+			kind := cmn.EvKindSmap
+			if tag == revsBMDTag {
+				kind = cmn.EvKindBMD
+			}
+			y.p.evlog.add(&cmn.Event{Kind: kind, Action: pair.msg.Action, Ver: pair.revs.version(),
+				Msg: pair.msg.Name})
+		}
+	}
 	y.workCh <- req
 	return req.wg
 }
@@ -194,6 +194,8 @@ func (n *notifs) add(nl nl.NotifListener) (err error) {
 	if exists := n.nls.add(nl, false /*locked*/); exists {
 		return
 	}
+	// re-registered (e.g., downloader's "retry failed tasks") - replaces the finished one
+	n.fin.del(nl, false /*locked*/)
 	nl.SetAddedTime()
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infoln("add " + nl.String())
@@ -386,6 +388,11 @@ func (n *notifs) done(nl nl.NotifListener) {
 				!smap.IsIC(smap.Primary) // never happens but ok
 		}
 		if doSend {
+			ev := &cmn.Event{Kind: cmn.EvKindXaction, Action: cmn.EvActAbort, XactID: nl.UUID(), Msg: nl.Kind()}
+			if err := nl.Err(); err != nil {
+				ev.Err = err.Error()
+			}
+			n.p.evlog.add(ev)
 			// NOTE: we accept finished notifications even after
 			// `nl` is aborted. Handle locks carefully.
 			args := allocBcArgs()
@@ -400,6 +407,7 @@ func (n *notifs) done(nl nl.NotifListener) {
 		}
 	}
 	nl.Callback(nl, time.Now().UnixNano())
+	n.p.pipes.jobDone(nl.UUID())
 }
 
 //
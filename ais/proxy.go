@@ -82,7 +82,10 @@ type (
 			mtx  sync.RWMutex
 			pool nodeRegPool
 		}
-		qm lsobjMem
+		qm    lsobjMem
+		evlog evlog
+		sched scheduler
+		pipes pipelines
 	}
 )
 
@@ -170,6 +173,8 @@ func (p *proxy) Run() error {
 
 	p.owner.bmd.init() // initialize owner and load BMD
 	p.owner.etl.init() // initialize owner and load EtlMD
+	p.sched.init(p, config)
+	p.pipes.init(p)
 
 	cluster.Init(nil /*cluster.Target*/)
 
@@ -179,6 +184,7 @@ func (p *proxy) Run() error {
 	p.bootstrap()
 
 	p.authn = newAuthManager()
+	p.evlog.init(p, config)
 
 	p.rproxy.init()
 
@@ -528,6 +534,8 @@ func (p *proxy) httpbckget(w http.ResponseWriter, r *http.Request) {
 		}
 	case apc.ActSummaryBck:
 		p.bucketSummary(w, r, qbck, msg, dpq)
+	case apc.ActGetBatch:
+		p.getBatch(w, r, qbck, msg, dpq)
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
@@ -674,6 +682,9 @@ func (p *proxy) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		bckArgs.createAIS = false
 		bckArgs.headRemB = true
 	}
+	if r.URL.Query().Get(apc.QparamArchpath) != "" {
+		bckArgs.perms = apc.AcePUT // (deleting archived file modifies the archive)
+	}
 	bck, objName, err := p._parseReqTry(w, r, bckArgs)
 	if err != nil {
 		return
@@ -824,6 +835,7 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 		newBMD, msgBMD, errBMD       = p.extractBMD(payload, caller)
 		newRMD, msgRMD, errRMD       = p.extractRMD(payload, caller)
 		newEtlMD, msgEtlMD, errEtlMD = p.extractEtlMD(payload, caller)
+		newSchMD, _, errSchMD        = p.extractSchMD(payload, caller)
 		revokedTokens, errTokens     = p.extractRevokedTokenList(payload, caller)
 	)
 	// 2. apply
@@ -842,15 +854,19 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 	if errEtlMD == nil && newEtlMD != nil {
 		errEtlMD = p.receiveEtlMD(newEtlMD, msgEtlMD, payload, caller, nil)
 	}
+	if errSchMD == nil && newSchMD != nil {
+		errSchMD = p.receiveSchMD(newSchMD, payload)
+	}
 	if errTokens == nil && revokedTokens != nil {
 		_ = p.authn.updateRevokedList(revokedTokens)
 	}
 	// 3. respond
-	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil {
+	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil &&
+		errSchMD == nil {
 		return
 	}
 	cii.fill(&p.htrun)
-	err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errTokens)
+	err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errTokens, errSchMD)
 	p.writeErr(w, r, errors.New(cos.MustMarshalToString(err)), http.StatusConflict)
 }
 
@@ -872,11 +888,14 @@ func (p *proxy) syncNewICOwners(smap, newSmap *smapX) {
 
 // GET /v1/health
 func (p *proxy) healthHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if responded := p.healthDetailed(w, r, query, nil); responded {
+		return
+	}
 	if !p.NodeStarted() {
 		w.WriteHeader(http.StatusServiceUnavailable)
 		return
 	}
-	query := r.URL.Query()
 	prr := cos.IsParseBool(query.Get(apc.QparamPrimaryReadyReb))
 	if !prr {
 		if responded := p.healthByExternalWD(w, r); responded {
@@ -979,7 +998,7 @@ func (p *proxy) httpbckput(w http.ResponseWriter, r *http.Request) {
 			p.writeErr(w, r, err)
 			return
 		}
-		xactID, err := p.createArchMultiObj(bckFrom, bckTo, msg)
+		xactID, _, err := p.createArchMultiObj(bckFrom, bckTo, msg)
 		if err == nil {
 			w.Write([]byte(xactID))
 		} else {
@@ -1027,7 +1046,7 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 
 	// only the primary can do metasync
 	xactRecord := xact.Table[msg.Action]
-	if xactRecord.Metasync {
+	if xactRecord.Metasync || msg.Action == apc.ActDeleteSnap {
 		if p.forwardCP(w, r, msg, bucket) {
 			return
 		}
@@ -1036,6 +1055,9 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 	// Initialize bucket; if doesn't exist try creating it on the fly
 	// but only if it's a remote bucket (and user did not explicitly disallowed).
 	bckArgs := bckInitArgs{p: p, w: w, r: r, bck: bck, msg: msg, query: query}
+	if msg.Action == apc.ActDeleteSnap {
+		bckArgs.perms = apc.AceObjDELETE
+	}
 	bckArgs.createAIS = false
 	bckArgs.headRemB = shouldHeadRemB()
 	if bck, err = bckArgs.initAndTry(bck.Name); err != nil {
@@ -1065,6 +1087,10 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 			p.writeErrf(w, r, "cannot rename bucket %q as %q", bckFrom, bckTo)
 			return
 		}
+		if len(bckFrom.Props.Snapshots) > 0 {
+			p.writeErrf(w, r, "cannot rename bucket %q that has snapshots (delete the snapshots first)", bckFrom)
+			return
+		}
 
 		bckFrom.Provider = apc.ProviderAIS
 		bckTo.Provider = apc.ProviderAIS
@@ -1098,11 +1124,19 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 				p.writeErr(w, r, err)
 				return
 			}
+			if tcbMsg.Sync {
+				p.writeErrf(w, r, "%s: sync (incremental copy) is not supported", msg.Action)
+				return
+			}
 		case apc.ActCopyBck:
 			if err = cos.MorphMarshal(msg.Value, &tcbMsg.CopyBckMsg); err != nil {
 				p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
 				return
 			}
+			if err = tcbMsg.ValidateSync(); err != nil {
+				p.writeErr(w, r, err)
+				return
+			}
 		}
 		bckTo, err = newBckFromQuname(query, true /*required*/)
 		if err != nil {
@@ -1131,6 +1165,11 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 			p.writeErrf(w, r, "cannot %s to HTTP bucket %q", msg.Action, bckTo)
 			return
 		}
+		// (prune walks in-cluster objects - can't do remote)
+		if tcbMsg.Prune && !bckTo.IsAIS() {
+			p.writeErrf(w, r, "%s: cannot prune remote bucket %q (only %q buckets)", msg.Action, bckTo, apc.ProviderAIS)
+			return
+		}
 		glog.Infof("%s bucket %s => %s", msg.Action, bck, bckTo)
 		if xactID, err = p.tcb(bck, bckTo, msg, tcbMsg.DryRun); err != nil {
 			p.writeErr(w, r, err)
@@ -1206,6 +1245,13 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 			return
 		}
 		w.Write([]byte(xactID))
+	case apc.ActSnapshotBck, apc.ActRestoreSnap, apc.ActDeleteSnap:
+		var xactID string
+		if xactID, err = p.snapshotBck(bck, msg); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		w.Write([]byte(xactID))
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
@@ -1294,6 +1340,26 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *cluster
 		lsmsg.AddProps(apc.GetPropsDefault...)
 	}
 
+	// Version history is maintained only for ais:// buckets (see cmn.VersionConf.History).
+	if lsmsg.IsFlagSet(apc.LsVersions) && !bck.IsAIS() {
+		p.writeErrf(w, r, "cannot list object versions in %s: version history is supported only for ais:// buckets", bck)
+		return
+	}
+
+	// Snapshots are read-only point-in-time copies of ais:// buckets (see cmn.BckSnap).
+	if lsmsg.Snapshot != "" {
+		if _, ok := bck.Props.Snap(lsmsg.Snapshot); !ok || !bck.IsAIS() {
+			p.writeErr(w, r, cmn.NewErrNotFound("%s: snapshot %q of %s", p.si, lsmsg.Snapshot, bck), http.StatusNotFound)
+			return
+		}
+		if lsmsg.IsFlagSet(apc.LsVersions) || lsmsg.IsFlagSet(apc.LsArchDir) || lsmsg.IsFlagSet(apc.UseListObjsCache) {
+			p.writeErrf(w, r, "cannot list snapshot %q of %s: versions, archived content, and cache are not supported",
+				lsmsg.Snapshot, bck)
+			return
+		}
+		lsmsg.SetFlag(apc.LsPresent)
+	}
+
 	// Vanilla HTTP buckets do not support remote listing.
 	// LsArchDir needs files locally to read archive content.
 	if bck.IsHTTP() || lsmsg.IsFlagSet(apc.LsArchDir) {
@@ -1455,7 +1521,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	}
 	apireq := apiReqAlloc(1, apc.URLPathObjects.L, false)
 	defer apiReqFree(apireq)
-	if msg.Action == apc.ActRenameObject {
+	if msg.Action == apc.ActRenameObject || msg.Action == apc.ActExtractArch {
 		apireq.after = 2
 	}
 	if err := p.parseReq(w, r, apireq); err != nil {
@@ -1516,6 +1582,11 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		w.Write([]byte(xactID))
+	case apc.ActExtractArch:
+		if err := p.checkAccess(w, r, bck, apc.AceGET); err != nil {
+			return
+		}
+		p.extractArch(w, r, bck, apireq.items[1], msg)
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
@@ -2180,6 +2251,53 @@ func (p *proxy) objMv(w http.ResponseWriter, r *http.Request, bck *cluster.Bck,
 	p.statsT.Add(stats.RenameCount, 1)
 }
 
+// extract archived files (see cmn.ExtractArchMsg): check PUT permission for the destination
+// and redirect to the target that has the archive
+func (p *proxy) extractArch(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string, msg *apc.ActionMsg) {
+	xmsg := &cmn.ExtractArchMsg{}
+	if err := cos.MorphMarshal(msg.Value, xmsg); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if err := xmsg.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if xmsg.ToBck.IsEmpty() {
+		if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+	} else {
+		bckArgs := allocInitBckArgs()
+		{
+			bckArgs.p = p
+			bckArgs.w = w
+			bckArgs.r = r
+			bckArgs.bck = cluster.CloneBck(&xmsg.ToBck)
+			bckArgs.perms = apc.AcePUT
+			bckArgs.createAIS = false
+			bckArgs.headRemB = shouldHeadRemB()
+		}
+		_, err := bckArgs.initAndTry(xmsg.ToBck.Name)
+		freeInitBckArgs(bckArgs)
+		if err != nil {
+			return
+		}
+	}
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%q %s/%s => %s", msg.Action, bck.Name, objName, si)
+	}
+	// NOTE: 307 - to have the client resend the request body
+	redirectURL := p.redirectURL(r, si, time.Now() /*started*/, cmn.NetIntraData)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
 func (p *proxy) doListRange(method, bucket string, msg *apc.ActionMsg, query url.Values) (xactID string, err error) {
 	var (
 		smap   = p.owner.smap.get()
@@ -2403,6 +2521,17 @@ func (p *proxy) httpdaeput(w http.ResponseWriter, r *http.Request) {
 		if err := p.owner.config.resetDaemonConfig(); err != nil {
 			p.writeErr(w, r, err)
 		}
+	case apc.ActAddEvents:
+		if !p.ensureIntraControl(w, r, false /* from primary */) {
+			return
+		}
+		var events []cmn.Event
+		if err := cos.MorphMarshal(msg.Value, &events); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		smap := p.owner.smap.get()
+		p.evlog.recv(events, smap.Primary != nil && smap.Primary.ID() == r.Header.Get(apc.HdrCallerID))
 	case apc.ActDecommission:
 		if !p.ensureIntraControl(w, r, true /* from primary */) {
 			return
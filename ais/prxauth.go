@@ -7,6 +7,7 @@ package ais
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/OneOfOne/xxhash"
 )
 
 type (
@@ -106,12 +108,13 @@ func (a *authManager) revokedTokenList() (allRevoked *tokenList) {
 //   - must not be expired
 //   - must have all mandatory fields: userID, creds, issued, expires
 // Returns decrypted token information if it is valid
-func (a *authManager) validateToken(token string) (tk *tok.Token, err error) {
+// (and whether this proxy sees the token for the first time)
+func (a *authManager) validateToken(token string) (tk *tok.Token, added bool, err error) {
 	a.Lock()
 	if _, ok := a.revokedTokens[token]; ok {
 		tk, err = nil, fmt.Errorf("%v: %s", tok.ErrTokenRevoked, tk)
 	} else {
-		tk, err = a.validateAddRm(token, time.Now())
+		tk, added, err = a.validateAddRm(token, time.Now())
 	}
 	a.Unlock()
 	return
@@ -119,7 +122,8 @@ func (a *authManager) validateToken(token string) (tk *tok.Token, err error) {
 
 // Decrypts and validates token. Adds it to authManager.token if not found. Removes if expired.
 // Must be called under lock.
-func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, error) {
+func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, bool, error) {
+	var added bool
 	tk, ok := a.tkList[token]
 	if !ok || tk == nil {
 		var (
@@ -128,15 +132,16 @@ func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, er
 		)
 		if tk, err = tok.DecryptToken(token, secret); err != nil {
 			glog.Error(err)
-			return nil, tok.ErrInvalidToken
+			return nil, false, tok.ErrInvalidToken
 		}
 		a.tkList[token] = tk
+		added = true
 	}
 	if tk.Expires.Before(now) {
 		delete(a.tkList, token)
-		return nil, fmt.Errorf("%v: %s", tok.ErrTokenExpired, tk)
+		return nil, false, fmt.Errorf("%v: %s", tok.ErrTokenExpired, tk)
 	}
-	return tk, nil
+	return tk, added, nil
 }
 
 ///////////////
@@ -210,14 +215,25 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 	if err != nil {
 		return nil, err
 	}
-	tk, err := p.authn.validateToken(token)
+	tk, added, err := p.authn.validateToken(token)
 	if err != nil {
 		glog.Errorf("invalid token: %v", err)
 		return nil, err
 	}
+	if added {
+		// first time this proxy sees the token - effectively, a login;
+		// the fingerprint allows the primary to record it only once (see evlog.isRelogin)
+		p.evlog.add(&cmn.Event{Kind: cmn.EvKindAuth, Action: cmn.EvActLogin, Actor: tk.UserID,
+			TokenFP: tokFingerprint(token)})
+	}
 	return tk, nil
 }
 
+// identifies a token without revealing it (e.g., in the event log)
+func tokFingerprint(token string) string {
+	return strconv.FormatUint(xxhash.ChecksumString64S(token, cos.MLCG32), 16)
+}
+
 // When AuthN is on, accessing a bucket requires two permissions:
 //   - access to the bucket is granted to a user
 //   - bucket ACL allows the required operation
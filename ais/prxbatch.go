@@ -0,0 +1,79 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// GET multiple objects as a single archive (see cmn.GetBatchMsg):
+// validate, check GET permissions for all buckets in question, and redirect
+// to a randomly selected (designated) target that will then assemble the
+// objects across the cluster and stream the result (see t.getBatch)
+func (p *proxy) getBatch(w http.ResponseWriter, r *http.Request, qbck *cmn.QueryBcks, msg *apc.ActionMsg, dpq *dpq) {
+	if !qbck.IsBucket() {
+		p.writeErrf(w, r, "%s: %q requires bucket (have %q)", p.si, msg.Action, qbck)
+		return
+	}
+	gbmsg := &cmn.GetBatchMsg{}
+	if err := cos.MorphMarshal(msg.Value, gbmsg); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if err := gbmsg.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	// the bucket of the request (default) and all the other buckets
+	bcks := make(map[string]*cmn.Bck, 2)
+	bcks[(*cmn.Bck)(qbck).MakeUname("")] = (*cmn.Bck)(qbck)
+	for i := range gbmsg.In {
+		bck := &gbmsg.In[i].Bck
+		if bck.IsEmpty() {
+			continue
+		}
+		bcks[bck.MakeUname("")] = bck
+	}
+	for _, bck := range bcks {
+		bckArgs := allocInitBckArgs()
+		{
+			bckArgs.p = p
+			bckArgs.w = w
+			bckArgs.r = r
+			bckArgs.bck = cluster.CloneBck(bck)
+			bckArgs.dpq = dpq
+			bckArgs.perms = apc.AceGET
+			bckArgs.createAIS = false
+			bckArgs.headRemB = shouldHeadRemB()
+		}
+		_, err := bckArgs.initAndTry(bck.Name)
+		freeInitBckArgs(bckArgs)
+		if err != nil {
+			return
+		}
+	}
+
+	// redirect (NOTE: 307 - to have the client resend the request body)
+	si, err := p.owner.smap.get().GetRandTarget()
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s %s(%d) => %s", r.Method, msg.Action, len(gbmsg.In), si)
+	}
+	redirectURL := p.redirectURL(r, si, time.Now() /*started*/, cmn.NetIntraData)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+
+	p.statsT.Add(stats.GetCount, int64(len(gbmsg.In)))
+}
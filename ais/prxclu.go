@@ -67,6 +67,14 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.ic.writeStatus(w, r)
 	case apc.GetWhatMountpaths:
 		p.queryClusterMountpaths(w, r, what)
+	case apc.GetWhatEvents:
+		p.queryClusterEvents(w, r, what)
+	case apc.GetWhatHealth:
+		p.queryClusterHealth(w, r, what)
+	case apc.GetWhatSched:
+		p.querySched(w, r, what)
+	case apc.GetWhatPipeline:
+		p.queryPipelines(w, r, what)
 	case apc.GetWhatRemoteAIS:
 		remoteAIS, err := p.getRemoteAISInfo()
 		if err != nil {
@@ -576,8 +584,9 @@ func (p *proxy) _updFinal(ctx *smapModifier, clone *smapX) {
 		tokens = p.authn.revokedTokenList()
 		bmd    = p.owner.bmd.get()
 		etlMD  = p.owner.etl.get()
+		schMD  = p.sched.owner.get()
 		aisMsg = p.newAmsg(ctx.msg, bmd)
-		pairs  = make([]revsPair, 0, 5)
+		pairs  = make([]revsPair, 0, 6)
 	)
 	if config, err := p.owner.config.get(); err != nil {
 		glog.Error(err)
@@ -588,6 +597,9 @@ func (p *proxy) _updFinal(ctx *smapModifier, clone *smapX) {
 	if etlMD != nil && etlMD.version() > 0 {
 		pairs = append(pairs, revsPair{etlMD, aisMsg})
 	}
+	if schMD != nil && schMD.version() > 0 && ctx.nsi.IsProxy() {
+		pairs = append(pairs, revsPair{schMD, aisMsg})
+	}
 	if ctx.rmd != nil && ctx.nsi.IsTarget() && mustRunRebalance(ctx, clone) {
 		pairs = append(pairs, revsPair{ctx.rmd, aisMsg})
 		nl := xact.NewXactNL(xact.RebID2S(ctx.rmd.version()), apc.ActRebalance, &clone.Smap, nil)
@@ -732,6 +744,7 @@ func (p *proxy) cluputJSON(w http.ResponseWriter, r *http.Request) {
 	case apc.ActResetConfig:
 		p.resetCluCfgPersistent(w, r, msg)
 	case apc.ActShutdown, apc.ActDecommission:
+		p.evlog.add(&cmn.Event{Kind: cmn.EvKindNode, Action: msg.Action, Actor: p.actor(r), Msg: "entire cluster"})
 		args := allocBcArgs()
 		args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathDae.S, Body: cos.MustMarshal(msg)}
 		args.to = cluster.AllNodes
@@ -748,6 +761,10 @@ func (p *proxy) cluputJSON(w http.ResponseWriter, r *http.Request) {
 		p.rmNode(w, r, msg)
 	case apc.ActStopMaintenance:
 		p.stopMaintenance(w, r, msg)
+	case apc.ActSchedAdd, apc.ActSchedRemove:
+		p.schedJob(w, r, msg)
+	case apc.ActPipelineRun, apc.ActPipelineStop:
+		p.pipelineAct(w, r, msg)
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
@@ -777,7 +794,10 @@ func (p *proxy) setCluCfgPersistent(w http.ResponseWriter, r *http.Request, toUp
 	// do
 	if _, err := p.owner.config.modify(ctx); err != nil {
 		p.writeErr(w, r, err)
+		return
 	}
+	p.evlog.add(&cmn.Event{Kind: cmn.EvKindConfig, Action: msg.Action, Actor: p.actor(r),
+		Msg: cos.MustMarshalToString(toUpdate)})
 }
 
 func whingeToUpdate(what, from, to string) {
@@ -791,6 +811,7 @@ func (p *proxy) resetCluCfgPersistent(w http.ResponseWriter, r *http.Request, ms
 		p.writeErr(w, r, err)
 		return
 	}
+	p.evlog.add(&cmn.Event{Kind: cmn.EvKindConfig, Action: msg.Action, Actor: p.actor(r)})
 	body := cos.MustMarshal(msg)
 
 	args := allocBcArgs()
@@ -804,6 +825,8 @@ func (p *proxy) setCluCfgTransient(w http.ResponseWriter, r *http.Request, toUpd
 		p.writeErr(w, r, err)
 		return
 	}
+	p.evlog.add(&cmn.Event{Kind: cmn.EvKindConfig, Action: msg.Action, Actor: p.actor(r),
+		Msg: apc.ActTransient + " " + cos.MustMarshalToString(toUpdate)})
 	q := url.Values{}
 	q.Add(apc.ActTransient, "true")
 
@@ -852,7 +875,15 @@ func (p *proxy) xactStart(w http.ResponseWriter, r *http.Request, msg *apc.Actio
 	}
 
 	// all the rest `startable` (see xaction/api.go)
-	body := cos.MustMarshal(apc.ActionMsg{Action: msg.Action, Value: xactMsg})
+	if err := p.bcastXactStart(msg.Action, &xactMsg); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	w.Write([]byte(xactMsg.ID))
+}
+
+func (p *proxy) bcastXactStart(action string, xactMsg *xact.QueryMsg) (err error) {
+	body := cos.MustMarshal(apc.ActionMsg{Action: action, Value: xactMsg})
 	args := allocBcArgs()
 	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathXactions.S, Body: body}
 	args.to = cluster.Targets
@@ -862,7 +893,7 @@ func (p *proxy) xactStart(w http.ResponseWriter, r *http.Request, msg *apc.Actio
 		if res.err == nil {
 			continue
 		}
-		p.writeErr(w, r, res.toErr())
+		err = res.toErr()
 		freeBcastRes(results)
 		return
 	}
@@ -870,7 +901,7 @@ func (p *proxy) xactStart(w http.ResponseWriter, r *http.Request, msg *apc.Actio
 	smap := p.owner.smap.get()
 	nl := xact.NewXactNL(xactMsg.ID, xactMsg.Kind, &smap.Smap, nil)
 	p.ic.registerEqual(regIC{smap: smap, nl: nl})
-	w.Write([]byte(xactMsg.ID))
+	return
 }
 
 func (p *proxy) xactStop(w http.ResponseWriter, r *http.Request, msg *apc.ActionMsg) {
@@ -879,7 +910,13 @@ func (p *proxy) xactStop(w http.ResponseWriter, r *http.Request, msg *apc.Action
 		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
 		return
 	}
-	body := cos.MustMarshal(apc.ActionMsg{Action: msg.Action, Value: xactMsg})
+	if err := p.bcastXactStop(msg.Action, &xactMsg); err != nil {
+		p.writeErr(w, r, err)
+	}
+}
+
+func (p *proxy) bcastXactStop(action string, xactMsg *xact.QueryMsg) (err error) {
+	body := cos.MustMarshal(apc.ActionMsg{Action: action, Value: xactMsg})
 	args := allocBcArgs()
 	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathXactions.S, Body: body}
 	args.to = cluster.Targets
@@ -889,11 +926,11 @@ func (p *proxy) xactStop(w http.ResponseWriter, r *http.Request, msg *apc.Action
 		if res.err == nil {
 			continue
 		}
-		p.writeErr(w, r, res.toErr())
-		freeBcastRes(results)
-		return
+		err = res.toErr()
+		break
 	}
 	freeBcastRes(results)
+	return
 }
 
 func (p *proxy) rebalanceCluster(w http.ResponseWriter, r *http.Request) {
@@ -1033,6 +1070,7 @@ func (p *proxy) rmNode(w http.ResponseWriter, r *http.Request, msg *apc.ActionMs
 			p.writeErr(w, r, cmn.NewErrFailedTo(p, msg.Action, si, err))
 			return
 		}
+		p.evlog.add(&cmn.Event{Kind: cmn.EvKindNode, Action: msg.Action, Actor: p.actor(r), Msg: si.StringEx()})
 		if msg.Action == apc.ActDecommissionNode || msg.Action == apc.ActShutdownNode {
 			errCode, err := p.callRmSelf(msg, si, true /*skipReb*/)
 			if err != nil {
@@ -1047,6 +1085,8 @@ func (p *proxy) rmNode(w http.ResponseWriter, r *http.Request, msg *apc.ActionMs
 		p.writeErr(w, r, cmn.NewErrFailedTo(p, msg.Action, si, err))
 		return
 	}
+	p.evlog.add(&cmn.Event{Kind: cmn.EvKindNode, Action: msg.Action, Actor: p.actor(r), Msg: si.StringEx(),
+		XactID: rebID})
 	if rebID != "" {
 		w.Write([]byte(rebID))
 	}
@@ -1098,6 +1138,8 @@ func (p *proxy) stopMaintenance(w http.ResponseWriter, r *http.Request, msg *apc
 		p.writeErr(w, r, err)
 		return
 	}
+	p.evlog.add(&cmn.Event{Kind: cmn.EvKindNode, Action: msg.Action, Actor: p.actor(r), Msg: si.StringEx(),
+		XactID: rebID})
 	if rebID != "" {
 		w.Write([]byte(rebID))
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/nl"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -127,11 +128,10 @@ func (p *proxy) broadcastDownloadAdminRequest(method, path string, msg *download
 	}
 }
 
-func (p *proxy) broadcastStartDownloadRequest(r *http.Request, id string, body []byte) (errCode int, err error) {
-	query := r.URL.Query()
+func (p *proxy) broadcastStartDownloadRequest(query url.Values, id string, body []byte) (errCode int, err error) {
 	query.Set(apc.QparamUUID, id)
 	args := allocBcArgs()
-	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: r.URL.Path, Body: body, Query: query}
+	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: apc.URLPathDownload.S, Body: body, Query: query}
 	config := cmn.GCO.Get()
 	args.timeout = config.Timeout.MaxHostBusy.D()
 	results := p.bcastGroup(args)
@@ -157,6 +157,10 @@ func (p *proxy) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet, http.MethodDelete:
 		p.httpDownloadAdmin(w, r)
 	case http.MethodPost:
+		if items, err := cmn.MatchRESTItems(r.URL.Path, 0, false, apc.URLPathDownload.L); err == nil && len(items) > 0 {
+			p.httpDownloadRetry(w, r, items[0])
+			return
+		}
 		p.httpDownloadPost(w, r)
 	default:
 		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost)
@@ -246,18 +250,86 @@ func (p *proxy) httpDownloadPost(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	id := cos.GenUUID()
+	id, errCode, err := p.startDownload(r.URL.Query(), dlb.Type, body, progressInterval)
+	if err != nil {
+		p.writeErrStatusf(w, r, errCode, "Error starting download: %v.", err.Error())
+		return
+	}
+	_respWithID(w, id)
+}
+
+// POST /v1/download/retry?id=...
+func (p *proxy) httpDownloadRetry(w http.ResponseWriter, r *http.Request, action string) {
+	if action != apc.Retry {
+		p.writeErrAct(w, r, action)
+		return
+	}
+	payload := &downloader.DlAdminBody{}
+	if err := cmn.ReadJSON(w, r, payload); err != nil {
+		return
+	}
+	if err := payload.Validate(true /*requireID*/); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
 	smap := p.owner.smap.get()
+	srcs, errCode, err := p.broadcastRetryDownloadRequest(payload)
+	if err != nil {
+		p.writeErr(w, r, err, errCode)
+		return
+	}
+	// new listener (replacing the finished one) - only the targets that have the job
+	kind := string(downloader.DlTypeMulti)
+	if prev, ok := p.notifs.entry(payload.ID); ok {
+		kind = prev.Kind()
+	}
+	listener := &downloader.NotifDownloadListerner{
+		NotifListenerBase: *nl.NewNLB(payload.ID, kind, &smap.Smap, srcs, downloader.DownloadProgressInterval),
+	}
+	listener.SetOwner(equalIC)
+	p.ic.registerEqual(regIC{nl: listener, smap: smap})
+	_respWithID(w, payload.ID)
+}
 
-	if errCode, err := p.broadcastStartDownloadRequest(r, id, body); err != nil {
-		p.writeErrStatusf(w, r, errCode, "Error starting download: %v.", err.Error())
+// returns the targets that are retrying the job
+func (p *proxy) broadcastRetryDownloadRequest(msg *downloader.DlAdminBody) (srcs cluster.NodeMap, errCode int, err error) {
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: apc.URLPathDownloadRetry.S, Body: cos.MustMarshal(msg)}
+	args.timeout = cmn.GCO.Get().Timeout.MaxHostBusy.D()
+	results := p.bcastGroup(args)
+	defer freeBcastRes(results)
+	freeBcArgs(args)
+	srcs = make(cluster.NodeMap, len(results))
+	for _, res := range results {
+		switch {
+		case res.err == nil:
+			srcs[res.si.ID()] = res.si
+		case res.status == http.StatusNotFound:
+			err = res.err
+		default:
+			return nil, res.status, res.err
+		}
+	}
+	if len(srcs) == 0 {
+		if err == nil {
+			err = cmn.NewErrNoNodes(apc.Target)
+		}
+		return nil, http.StatusNotFound, err
+	}
+	return srcs, http.StatusOK, nil
+}
+
+func (p *proxy) startDownload(query url.Values, dlType downloader.DlType, body []byte,
+	progressInterval time.Duration) (id string, errCode int, err error) {
+	id = cos.GenUUID()
+	smap := p.owner.smap.get()
+	if errCode, err = p.broadcastStartDownloadRequest(query, id, body); err != nil {
 		return
 	}
-	nl := downloader.NewDownloadNL(id, string(dlb.Type), &smap.Smap, progressInterval)
+	nl := downloader.NewDownloadNL(id, string(dlType), &smap.Smap, progressInterval)
 	nl.SetOwner(equalIC)
 	p.ic.registerEqual(regIC{nl: nl, smap: smap})
-
-	_respWithID(w, id)
+	return
 }
 
 // Helper methods
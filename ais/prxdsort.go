@@ -36,6 +36,15 @@ func (p *proxy) proxyStartSortHandler(w http.ResponseWriter, r *http.Request) {
 	if _, err = args.initAndTry(bck.Name); err != nil {
 		return
 	}
+	if parsedRS.Split != nil {
+		for i := range parsedRS.Split.Outputs {
+			bck = cluster.CloneBck(&parsedRS.Split.Outputs[i].OutputBck)
+			args = bckInitArgs{p: p, w: w, r: r, bck: bck, headRemB: true, perms: apc.AcePUT}
+			if _, err = args.initAndTry(bck.Name); err != nil {
+				return
+			}
+		}
+	}
 
 	dsort.ProxyStartSortHandler(w, r, parsedRS)
 }
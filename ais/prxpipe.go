@@ -0,0 +1,717 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/dsort"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xs"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Pipelines (DAGs of jobs):
+// - a pipeline (cmn.Pipeline) is submitted to the primary that validates it and
+//   from then on tracks it in memory;
+// - the primary starts each step (download, dsort, etl-bck, archive, copy-bck)
+//   as soon as all its predecessors succeed - the same way it'd start it upon
+//   user request;
+// - steps that depend on a failed (or aborted) step are skipped, and the entire
+//   pipeline fails;
+// - step completion is detected via notification listeners (see notifs.done)
+//   or, for jobs that do not have listeners (dsort, archive), by periodic polling;
+//   archive, in particular, is an on-demand xaction that may be shared by multiple
+//   archiving requests - the step is done when none of the targets reports its
+//   request (transaction) as pending;
+// - running steps are polled, and ready steps started (or, upon user stop, aborted)
+//   without holding the pipeline's lock - in the meantime, a step that is being
+//   started is "starting".
+// NOTE: pipelines do not survive primary failover.
+
+const (
+	pipesName  = "pipelines"
+	pipesIval  = 5 * time.Second
+	pipesGrace = 10 * time.Second // time for a started dsort or listener to show up (see pipeStepDone)
+	pipesKeep  = time.Hour        // time to keep finished pipelines
+)
+
+type (
+	pipeline struct {
+		cmn.PipelineStatus
+		spec   *cmn.Pipeline
+		sorted []*cmn.PipelineStep
+		out    map[string]cmn.Bck // step name => output bucket
+		txns   map[string]string  // archive step name => txn UUID (see createArchMultiObj)
+		mu     sync.Mutex
+		busy   atomic.Bool // periodic advance in progress (see housekeep)
+		// user stop is in progress: start no more steps, abort the ones being started
+		stopping bool
+	}
+	pipelines struct {
+		p  *proxy
+		m  map[string]*pipeline // pipeline ID => pipeline
+		mu sync.RWMutex
+	}
+)
+
+func (pp *pipelines) init(p *proxy) {
+	pp.p = p
+	pp.m = make(map[string]*pipeline, 4)
+	hk.Reg(pipesName+hk.NameSuffix, pp.housekeep, pipesIval)
+}
+
+func (pp *pipelines) get(id string) (pl *pipeline) {
+	pp.mu.RLock()
+	pl = pp.m[id]
+	pp.mu.RUnlock()
+	return
+}
+
+func (pp *pipelines) list() (pls []*pipeline) {
+	pp.mu.RLock()
+	pls = make([]*pipeline, 0, len(pp.m))
+	for _, pl := range pp.m {
+		pls = append(pls, pl)
+	}
+	pp.mu.RUnlock()
+	sort.Slice(pls, func(i, j int) bool { return pls[i].Started < pls[j].Started })
+	return
+}
+
+func (pp *pipelines) housekeep() time.Duration {
+	p := pp.p
+	if !p.ClusterStarted() || !p.owner.smap.get().isPrimary(p.si) {
+		return pipesIval
+	}
+	now := time.Now().UnixNano()
+	pp.mu.Lock()
+	for id, pl := range pp.m {
+		if ended := pl.ended(); ended != 0 && time.Duration(now-ended) > pipesKeep {
+			delete(pp.m, id)
+		}
+	}
+	pp.mu.Unlock()
+	// (not to stall housekeeping behind cluster-wide polls and step starts)
+	for _, pl := range pp.list() {
+		if pl.busy.CAS(false, true) {
+			go func(pl *pipeline) {
+				pl.advance(p)
+				pl.busy.Store(false)
+			}(pl)
+		}
+	}
+	return pipesIval
+}
+
+// called upon (any) job completion - see notifs.done
+func (pp *pipelines) jobDone(jobID string) {
+	for _, pl := range pp.list() {
+		if pl.running(jobID) {
+			go pl.advance(pp.p)
+		}
+	}
+}
+
+//////////////
+// pipeline //
+//////////////
+
+func newPipeline(spec *cmn.Pipeline) (pl *pipeline, err error) {
+	pl = &pipeline{spec: spec, out: make(map[string]cmn.Bck, len(spec.Steps)), txns: make(map[string]string, 2)}
+	if pl.sorted, err = spec.TopoSort(); err != nil {
+		return nil, err
+	}
+	pl.ID = cos.GenUUID()
+	pl.Name = spec.Name
+	pl.State = cmn.PipeStateRunning
+	pl.Started = time.Now().UnixNano()
+	pl.Steps = make([]cmn.PipelineStepStatus, 0, len(spec.Steps))
+	for _, step := range spec.Steps {
+		pl.Steps = append(pl.Steps, cmn.PipelineStepStatus{Name: step.Name, Action: step.Action, State: cmn.PipeStatePending})
+	}
+	return
+}
+
+func (pl *pipeline) String() string { return fmt.Sprintf("pipeline %q[%s]", pl.Name, pl.ID) }
+
+func (pl *pipeline) ended() int64 {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	return pl.Ended
+}
+
+func (pl *pipeline) running(jobID string) bool {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	for i := range pl.Steps {
+		if ss := &pl.Steps[i]; ss.JobID == jobID && ss.State == cmn.PipeStateRunning {
+			return true
+		}
+	}
+	return false
+}
+
+func (pl *pipeline) status() *cmn.PipelineStatus {
+	pl.mu.Lock()
+	status := pl.PipelineStatus
+	status.Steps = append([]cmn.PipelineStepStatus(nil), pl.Steps...)
+	pl.mu.Unlock()
+	return &status
+}
+
+// check running steps; start the ones that are ready, skip the ones that never will be
+func (pl *pipeline) advance(p *proxy) {
+	type polled struct {
+		cmn.PipelineStepStatus
+		txnID   string
+		done    bool
+		aborted bool
+		err     error
+	}
+	// 1. snapshot running steps
+	pl.mu.Lock()
+	if pl.Finished() {
+		pl.mu.Unlock()
+		return
+	}
+	running := make([]polled, 0, len(pl.Steps))
+	for i := range pl.Steps {
+		if ss := &pl.Steps[i]; ss.State == cmn.PipeStateRunning {
+			running = append(running, polled{PipelineStepStatus: *ss, txnID: pl.txns[ss.Name]})
+		}
+	}
+	pl.mu.Unlock()
+
+	// 2. poll (cluster-wide) without holding the lock
+	for i := range running {
+		res := &running[i]
+		res.done, res.aborted, res.err = p.pipeStepDone(&res.PipelineStepStatus, res.txnID)
+	}
+
+	// 3. record the results
+	pl.mu.Lock()
+	if pl.Finished() { // (stopped in the meantime)
+		pl.mu.Unlock()
+		return
+	}
+	for i := range running {
+		res := &running[i]
+		ss := pl.Step(res.Name)
+		// (may have been updated by another advance or stop in the meantime)
+		if !res.done || ss.State != cmn.PipeStateRunning || ss.JobID != res.JobID {
+			continue
+		}
+		ss.Ended = time.Now().UnixNano()
+		switch {
+		case res.aborted:
+			ss.State = cmn.PipeStateAborted
+		case res.err != nil:
+			ss.State = cmn.PipeStateFailed
+		default:
+			ss.State = cmn.PipeStateSucceeded
+		}
+		if res.err != nil {
+			ss.Err = res.err.Error()
+		}
+		glog.Infof("%s: %s step %q (%s[%s]) %s", p, pl, ss.Name, ss.Action, ss.JobID, ss.State)
+	}
+
+	// 4. start the steps that are ready (cluster-wide) without holding the lock;
+	//    repeat while the results make for more (skipped) steps
+	for {
+		ready := pl.schedule()
+		if len(ready) == 0 {
+			break
+		}
+		pl.mu.Unlock()
+		for _, st := range ready {
+			st.jobID, st.txnID, st.err = p.startPipeStep(st.step, &st.bck)
+		}
+		pl.mu.Lock()
+		pl.started(p, ready)
+	}
+	pl.finalize(p)
+	pl.mu.Unlock()
+}
+
+// (see schedule and started)
+type pipeStart struct {
+	step  *cmn.PipelineStep
+	bck   cmn.Bck
+	jobID string
+	txnID string
+	err   error
+}
+
+// in topological order, so that skipping propagates downstream in a single pass;
+// the steps that are ready to start are marked "starting" and returned
+// (under lock)
+func (pl *pipeline) schedule() (ready []*pipeStart) {
+	if pl.stopping {
+		return
+	}
+	for _, step := range pl.sorted {
+		ss := pl.Step(step.Name)
+		if ss.State != cmn.PipeStatePending {
+			continue
+		}
+		ok := true
+		for _, name := range step.Deps() {
+			dep := pl.Step(name)
+			switch dep.State {
+			case cmn.PipeStateSucceeded:
+				continue
+			case cmn.PipeStateFailed, cmn.PipeStateAborted, cmn.PipeStateSkipped:
+				ss.State, ss.Err = cmn.PipeStateSkipped, fmt.Sprintf("upstream step %q %s", name, dep.State)
+			}
+			ok = false
+			break
+		}
+		if !ok {
+			continue
+		}
+		bck := step.Bck
+		if step.Input != "" {
+			bck = pl.out[step.Input]
+		}
+		ss.State, ss.Bck, ss.Started = cmn.PipeStateStarting, bck, time.Now().UnixNano()
+		ready = append(ready, &pipeStart{step: step, bck: bck})
+	}
+	return
+}
+
+// record the outcome of startPipeStep (under lock)
+func (pl *pipeline) started(p *proxy, ready []*pipeStart) {
+	for _, st := range ready {
+		var (
+			step = st.step
+			ss   = pl.Step(step.Name)
+			ev   = &cmn.Event{Kind: cmn.EvKindXaction, Action: step.Action, Actor: pipesName, Bck: st.bck.String(),
+				Msg: fmt.Sprintf("%s, step %q", pl, step.Name), XactID: st.jobID}
+		)
+		switch {
+		case st.err != nil:
+			ss.State, ss.Err, ss.Ended = cmn.PipeStateFailed, st.err.Error(), time.Now().UnixNano()
+			ev.Err = ss.Err
+			glog.Errorf("%s: %s failed to start step %q: %v", p, pl, step.Name, st.err)
+		case pl.stopping:
+			// stopped while starting - abort asynchronously, so as not to hold the lock
+			ss.State, ss.JobID, ss.Err, ss.Ended = cmn.PipeStateAborted, st.jobID, "pipeline stopped", time.Now().UnixNano()
+			go pl.abort(p, *ss)
+		default:
+			ss.State, ss.JobID = cmn.PipeStateRunning, st.jobID
+			if st.txnID != "" {
+				pl.txns[step.Name] = st.txnID
+			}
+			pl.out[step.Name] = step.OutBck(&st.bck)
+			glog.Infof("%s: %s started step %q (%s[%s])", p, pl, step.Name, step.Action, st.jobID)
+		}
+		p.evlog.add(ev)
+	}
+}
+
+// (under lock)
+func (pl *pipeline) finalize(p *proxy) {
+	state := cmn.PipeStateSucceeded
+	for i := range pl.Steps {
+		switch ss := &pl.Steps[i]; ss.State {
+		case cmn.PipeStatePending, cmn.PipeStateStarting, cmn.PipeStateRunning:
+			return
+		case cmn.PipeStateAborted:
+			state = cmn.PipeStateAborted
+		case cmn.PipeStateFailed:
+			if state != cmn.PipeStateAborted {
+				state = cmn.PipeStateFailed
+			}
+			if pl.Err == "" {
+				pl.Err = fmt.Sprintf("step %q: %s", ss.Name, ss.Err)
+			}
+		}
+	}
+	pl.State, pl.Ended = state, time.Now().UnixNano()
+	glog.Infof("%s: %s %s (%v)", p, pl, pl.State, pl.Elapsed())
+	p.evlog.add(&cmn.Event{Kind: cmn.EvKindXaction, Action: apc.ActPipelineRun, Actor: pipesName,
+		Msg: fmt.Sprintf("%s %s", pl, pl.State), XactID: pl.ID, Err: pl.Err})
+}
+
+// user abort: skip pending steps and abort running ones (the steps that are
+// being started get aborted once started - see `started`)
+func (pl *pipeline) stop(p *proxy) (err error) {
+	pl.mu.Lock()
+	if pl.Finished() || pl.stopping {
+		err = fmt.Errorf("%s has already finished or is being stopped (%s)", pl, pl.State)
+		pl.mu.Unlock()
+		return
+	}
+	pl.stopping = true
+	running := make([]cmn.PipelineStepStatus, 0, len(pl.Steps))
+	for i := range pl.Steps {
+		ss := &pl.Steps[i]
+		switch ss.State {
+		case cmn.PipeStatePending:
+			ss.State, ss.Err = cmn.PipeStateSkipped, "pipeline stopped"
+		case cmn.PipeStateRunning:
+			ss.State, ss.Err, ss.Ended = cmn.PipeStateAborted, "pipeline stopped", time.Now().UnixNano()
+			running = append(running, *ss)
+		}
+	}
+	pl.finalize(p)
+	pl.mu.Unlock()
+
+	// abort (cluster-wide) without holding the lock
+	for i := range running {
+		if errAbrt := pl.abort(p, running[i]); errAbrt != nil {
+			err = errAbrt
+		}
+	}
+	return
+}
+
+func (pl *pipeline) abort(p *proxy, ss cmn.PipelineStepStatus) (err error) {
+	if err = p.abortPipeStep(&ss); err != nil {
+		glog.Errorf("%s: %s failed to abort step %q (%s[%s]): %v", p, pl, ss.Name, ss.Action, ss.JobID, err)
+	}
+	return
+}
+
+//
+// steps: start, check, abort
+//
+
+// start step the same way `httpDownloadPost`, `proxyStartSortHandler`, and `hpostBucket` do
+// (txnID - archive only)
+func (p *proxy) startPipeStep(step *cmn.PipelineStep, inBck *cmn.Bck) (jobID, txnID string, err error) {
+	switch step.Action {
+	case apc.ActDownload:
+		jobID, err = p.startPipeDownload(step, inBck)
+		return
+	case apc.ActDsort:
+		jobID, err = p.startPipeDsort(step, inBck)
+		return
+	}
+	var (
+		msg     = &apc.ActionMsg{Action: step.Action, Value: step.Value}
+		bckFrom *cluster.Bck
+		bckTo   *cluster.Bck
+	)
+	if bckFrom, err = p.pipeInitBck(inBck, false); err != nil {
+		return
+	}
+	switch step.Action {
+	case apc.ActCopyBck, apc.ActETLBck:
+		tcbMsg := &apc.TCBMsg{}
+		if step.Action == apc.ActETLBck {
+			if err = cos.MorphMarshal(step.Value, tcbMsg); err != nil {
+				return
+			}
+			if err = tcbMsg.Validate(); err != nil {
+				return
+			}
+		} else if step.Value != nil {
+			if err = cos.MorphMarshal(step.Value, &tcbMsg.CopyBckMsg); err != nil {
+				return
+			}
+		}
+		msg.Value = tcbMsg
+		bckTo = cluster.CloneBck(&step.BckTo)
+		if err = bckTo.Init(p.owner.bmd); err != nil {
+			if !cmn.IsErrBckNotFound(err) || !bckTo.IsAIS() {
+				return
+			}
+			err = nil // (created on the fly)
+		}
+		if bckFrom.Equal(bckTo, false, true) {
+			err = fmt.Errorf("cannot %s bucket %q onto itself", step.Action, bckFrom)
+			return
+		}
+		jobID, err = p.tcb(bckFrom, bckTo, msg, tcbMsg.DryRun)
+	case apc.ActArchive:
+		archMsg := &cmn.ArchiveMsg{}
+		if err = cos.MorphMarshal(step.Value, archMsg); err != nil {
+			return
+		}
+		if !step.BckTo.IsEmpty() {
+			archMsg.ToBck = step.BckTo
+		}
+		bckTo = bckFrom
+		if !archMsg.ToBck.IsEmpty() {
+			if bckTo, err = p.pipeInitBck(&archMsg.ToBck, true); err != nil {
+				return
+			}
+		}
+		if _, err = cos.Mime(archMsg.Mime, archMsg.ArchName); err != nil {
+			return
+		}
+		msg.Value = archMsg
+		jobID, txnID, err = p.createArchMultiObj(bckFrom, bckTo, msg)
+	default:
+		err = fmt.Errorf("%q cannot be pipelined", step.Action)
+	}
+	return
+}
+
+func (p *proxy) startPipeDownload(step *cmn.PipelineStep, bck *cmn.Bck) (jobID string, err error) {
+	var (
+		dlb              downloader.DlBody
+		dlBase           downloader.DlBase
+		body             []byte
+		m                = make(map[string]interface{}, 8)
+		progressInterval = downloader.DownloadProgressInterval
+	)
+	if err = cos.MorphMarshal(step.Value, &m); err != nil {
+		return
+	}
+	m["bucket"] = bck // (destination)
+	if body, err = jsoniter.Marshal(m); err != nil {
+		return
+	}
+	if err = jsoniter.Unmarshal(body, &dlb); err != nil {
+		return
+	}
+	if err = jsoniter.Unmarshal(dlb.RawMessage, &dlBase); err != nil {
+		return
+	}
+	if dlBase.ProgressInterval != "" {
+		if progressInterval, err = time.ParseDuration(dlBase.ProgressInterval); err != nil {
+			return
+		}
+	}
+	if _, err = p.pipeInitBck(bck, true); err != nil {
+		return
+	}
+	jobID, _, err = p.startDownload(url.Values{}, dlb.Type, body, progressInterval)
+	return
+}
+
+func (p *proxy) startPipeDsort(step *cmn.PipelineStep, bck *cmn.Bck) (jobID string, err error) {
+	rs := &dsort.RequestSpec{}
+	if err = cos.MorphMarshal(step.Value, rs); err != nil {
+		return
+	}
+	rs.Bck = *bck
+	if !step.BckTo.IsEmpty() {
+		rs.OutputBck = step.BckTo
+	}
+	parsedRS, err := rs.Parse()
+	if err != nil {
+		return
+	}
+	if _, err = p.pipeInitBck(&parsedRS.Bck, false); err != nil {
+		return
+	}
+	if _, err = p.pipeInitBck(&parsedRS.OutputBck, true); err != nil {
+		return
+	}
+	jobID, _, err = dsort.ProxyStartSort(parsedRS)
+	return
+}
+
+// initialize bucket; when requested, create ais:// bucket that does not exist
+func (p *proxy) pipeInitBck(b *cmn.Bck, create bool) (bck *cluster.Bck, err error) {
+	bck = cluster.CloneBck(b)
+	if err = bck.Init(p.owner.bmd); err == nil || !create || !cmn.IsErrBckNotFound(err) || !bck.IsAIS() {
+		return
+	}
+	if err = p.createBucket(&apc.ActionMsg{Action: apc.ActCreateBck}, bck); err != nil && !cmn.IsErrBucketAlreadyExists(err) {
+		return
+	}
+	err = bck.Init(p.owner.bmd)
+	return
+}
+
+func (p *proxy) pipeStepDone(ss *cmn.PipelineStepStatus, txnID string) (done, aborted bool, err error) {
+	grace := time.Since(time.Unix(0, ss.Started)) < pipesGrace
+	switch ss.Action {
+	case apc.ActDsort:
+		allMetrics, errCode, errM := dsort.ProxyMetrics(ss.JobID)
+		if errM != nil {
+			if errCode == http.StatusNotFound && grace {
+				return
+			}
+			return true, false, errM
+		}
+		done = len(allMetrics) > 0
+		for _, metrics := range allMetrics {
+			if metrics.Aborted.Load() {
+				aborted = true
+				if len(metrics.Errors) > 0 {
+					err = errors.New(strings.Join(metrics.Errors, "; "))
+				}
+			}
+			done = done && metrics.Creation.Finished
+		}
+		if aborted {
+			done = true
+			if err == nil {
+				err = cmn.NewErrAborted(dsort.DSortName+"["+ss.JobID+"]", "", nil)
+			}
+		}
+		return
+	case apc.ActArchive:
+		return p.pipeArchDone(ss.JobID, txnID)
+	}
+	nl, exists := p.notifs.entry(ss.JobID)
+	if !exists {
+		if grace {
+			return
+		}
+		return true, false, fmt.Errorf("%s[%s] not found", ss.Action, ss.JobID)
+	}
+	if !nl.Finished() {
+		return
+	}
+	done, aborted, err = true, nl.Aborted(), nl.Err()
+	if aborted && err == nil {
+		err = cmn.NewErrAborted(nl.String(), "", nil)
+	}
+	return
+}
+
+// done when none of the targets has the (archiving) transaction pending -
+// the transaction is added at begin time (see xs.XactCreateArchMultiObj.Begin)
+func (p *proxy) pipeArchDone(xactID, txnID string) (done, aborted bool, err error) {
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathXactions.S,
+		Query:  url.Values{apc.QparamWhat: []string{apc.GetWhatXactStats}, apc.QparamUUID: []string{xactID}},
+	}
+	args.to = cluster.Targets
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	done = true
+	for _, res := range results {
+		if res.status == http.StatusNotFound {
+			continue
+		}
+		if res.err != nil {
+			err, done = res.toErr(), false
+			break
+		}
+		snap := &xact.SnapExt{}
+		if errU := jsoniter.Unmarshal(res.bytes, snap); errU != nil {
+			err, done = errU, false
+			break
+		}
+		if snap.IsAborted() {
+			aborted = true
+			err = cmn.NewErrAborted(apc.ActArchive+"["+xactID+"]", "node "+res.si.ID(), nil)
+			break
+		}
+		if snap.Finished() {
+			continue
+		}
+		ext := &xs.ArchStatsExt{}
+		if errM := cos.MorphMarshal(snap.Ext, ext); errM != nil {
+			err, done = errM, false
+			break
+		}
+		done = done && !cos.StringInSlice(txnID, ext.Pending)
+	}
+	freeBcastRes(results)
+	if err != nil && !aborted {
+		glog.Errorf("%s: failed to query %s[%s]: %v", p, apc.ActArchive, xactID, err)
+		err = nil // retry next time
+	}
+	return done || aborted, aborted, err
+}
+
+func (p *proxy) abortPipeStep(ss *cmn.PipelineStepStatus) (err error) {
+	switch ss.Action {
+	case apc.ActDownload:
+		_, _, err = p.broadcastDownloadAdminRequest(http.MethodDelete, apc.URLPathDownloadAbort.S,
+			&downloader.DlAdminBody{ID: ss.JobID})
+	case apc.ActDsort:
+		_, err = dsort.ProxyAbortSort(ss.JobID)
+	default:
+		err = p.bcastXactStop(apc.ActXactStop, &xact.QueryMsg{ID: ss.JobID, Kind: ss.Action})
+	}
+	return
+}
+
+//
+// API: run, stop (primary only), and query
+//
+
+func (p *proxy) pipelineAct(w http.ResponseWriter, r *http.Request, msg *apc.ActionMsg) {
+	switch msg.Action {
+	case apc.ActPipelineRun:
+		spec := &cmn.Pipeline{}
+		if err := cos.MorphMarshal(msg.Value, spec); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		if err := spec.Validate(); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		pl, err := newPipeline(spec)
+		if err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		p.pipes.mu.Lock()
+		p.pipes.m[pl.ID] = pl
+		p.pipes.mu.Unlock()
+		p.evlog.add(&cmn.Event{Kind: cmn.EvKindXaction, Action: msg.Action, Actor: p.actor(r),
+			Msg: pl.String(), XactID: pl.ID})
+		pl.advance(p)
+		w.Write([]byte(pl.ID))
+	case apc.ActPipelineStop:
+		pl := p.pipes.get(msg.Name)
+		if pl == nil {
+			p.writeErr(w, r, cmn.NewErrNotFound("%s: pipeline %q", p.si, msg.Name), http.StatusNotFound)
+			return
+		}
+		if err := pl.stop(p); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		p.evlog.add(&cmn.Event{Kind: cmn.EvKindXaction, Action: msg.Action, Actor: p.actor(r),
+			Msg: pl.String(), XactID: pl.ID})
+	default:
+		p.writeErrAct(w, r, msg.Action)
+	}
+}
+
+// GET /v1/cluster?what=pipeline[&uuid=...] (forwarded to primary)
+func (p *proxy) queryPipelines(w http.ResponseWriter, r *http.Request, what string) {
+	if p.forwardCP(w, r, nil, what) {
+		return
+	}
+	if id := r.URL.Query().Get(apc.QparamUUID); id != "" {
+		pl := p.pipes.get(id)
+		if pl == nil {
+			p.writeErr(w, r, cmn.NewErrNotFound("%s: pipeline %q", p.si, id), http.StatusNotFound)
+			return
+		}
+		p.writeJSON(w, r, pl.status(), what)
+		return
+	}
+	var (
+		pls    = p.pipes.list()
+		status = make([]*cmn.PipelineStatus, 0, len(pls))
+	)
+	for _, pl := range pls {
+		status = append(status, pl.status())
+	}
+	p.writeJSON(w, r, status, what)
+}
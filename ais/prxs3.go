@@ -74,6 +74,10 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if _, versions := q[s3compat.QparamVersions]; versions {
+				p.bckListVersionsS3(w, r, apiItems[0])
+				return
+			}
 			// only bucket name - list objects in the bucket
 			p.bckListS3(w, r, apiItems[0])
 			return
@@ -301,6 +305,31 @@ func (p *proxy) bckListS3(w http.ResponseWriter, r *http.Request, bucket string)
 	sgl.Free()
 }
 
+// GET s3/bk-name?versions
+func (p *proxy) bckListVersionsS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := cluster.NewBck(bucket, apc.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	lsmsg := apc.ListObjsMsg{UUID: cos.GenUUID(), TimeFormat: time.RFC3339}
+	lsmsg.AddProps(apc.GetPropsSize, apc.GetPropsChecksum, apc.GetPropsAtime, apc.GetPropsVersion)
+	s3compat.FillMsgFromS3VersionsQuery(r.URL.Query(), &lsmsg)
+	objList, err := p.listObjectsAIS(bck, &lsmsg)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	resp := s3compat.NewListVersionsResult()
+	resp.KeyMarker = lsmsg.ContinuationToken
+	resp.FillFromAisBckList(objList, &lsmsg)
+	sgl := memsys.PageMM().NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
 // PUT s3/bckName/objName - with HeaderObjSrc in request header - a source
 func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	started := time.Now()
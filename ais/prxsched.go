@@ -0,0 +1,379 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Job scheduler:
+// - scheduled jobs (cmn.SchedJob) are added, updated, and removed via the primary
+//   that stores them in the replicated schMD (see schmeta.go);
+// - every minute, the primary starts the jobs that are due (as per their respective
+//   cron expressions) - the same way it'd start them upon user request;
+// - a run is skipped if the xaction started by the previous run is still running;
+// - each run (started, skipped, or failed to start) is recorded in the (replicated)
+//   event log rather than schMD - the job's history is then reconstructed from the
+//   events (see runs()), so that it survives restarts and primary failover;
+// - a newly elected (or restarted) primary catches up with the run that has become due
+//   during the last `schedCatchUp` interval but wasn't recorded.
+
+const (
+	schedName    = "scheduler"
+	schedSkipped = " skipped - previous run still in progress"
+	schedCatchUp = 10 * time.Minute
+)
+
+type scheduler struct {
+	p     *proxy
+	owner schMDOwner
+	next  map[string]int64 // job name => next time (Unix nano); primary only
+	mu    sync.Mutex
+}
+
+func (s *scheduler) init(p *proxy, config *cmn.Config) {
+	s.p = p
+	s.owner.init(config)
+	s.next = make(map[string]int64, 4)
+	hk.Reg(schedName+hk.NameSuffix, s.housekeep, schedIval(time.Now()))
+}
+
+// a second past the next minute boundary
+func schedIval(now time.Time) time.Duration {
+	return now.Truncate(time.Minute).Add(time.Minute + time.Second).Sub(now)
+}
+
+func schedRunMsg(name string) string { return fmt.Sprintf("scheduled job %q", name) }
+
+func (s *scheduler) housekeep() time.Duration {
+	var (
+		p   = s.p
+		now = time.Now()
+	)
+	if !p.ClusterStarted() || !p.owner.smap.get().isPrimary(p.si) {
+		s.mu.Lock()
+		for name := range s.next {
+			delete(s.next, name) // non-primary: start over when elected
+		}
+		s.mu.Unlock()
+		return schedIval(now)
+	}
+	for _, job := range s.due(s.owner.get(), now) {
+		s.run(job)
+	}
+	return schedIval(time.Now())
+}
+
+// returns the jobs to run now and advances their respective next times
+func (s *scheduler) due(md *schMD, now time.Time) (due []*cmn.SchedJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name := range s.next {
+		if _, ok := md.Jobs[name]; !ok {
+			delete(s.next, name)
+		}
+	}
+	for name, job := range md.Jobs {
+		if job.Disabled {
+			delete(s.next, name)
+			continue
+		}
+		cron, err := cos.ParseCron(job.Cron)
+		if err != nil {
+			glog.Errorf("%s: %s %q: %v", s.p, schedName, name, err) // (validated when added)
+			continue
+		}
+		next, ok := s.next[name]
+		if !ok {
+			next = s.missed(job, cron, now) // (just elected or restarted)
+		}
+		if next != 0 && next <= now.UnixNano() {
+			due = append(due, job)
+			next = 0
+		}
+		if next == 0 {
+			t := cron.Next(now)
+			if t.IsZero() {
+				delete(s.next, name)
+				continue
+			}
+			next = t.UnixNano()
+		}
+		s.next[name] = next
+	}
+	return
+}
+
+// the most recent scheduled time that has passed without being run or 0 (none);
+// looks back no further than `schedCatchUp` and the job's creation
+func (s *scheduler) missed(job *cmn.SchedJob, cron *cos.Cron, now time.Time) (slot int64) {
+	since := cos.MaxI64(now.Add(-schedCatchUp).UnixNano(), job.Created)
+	if last := s.lastRun(job.Name); last != nil {
+		since = cos.MaxI64(since, last.Time)
+	}
+	for t := cron.Next(time.Unix(0, since)); !t.IsZero() && !t.After(now); t = cron.Next(t) {
+		slot = t.UnixNano()
+	}
+	return
+}
+
+// job's run history (most recent last) from the event log
+func (s *scheduler) runs(name string) (runs []cmn.SchedRun) {
+	msg := schedRunMsg(name)
+	events := s.p.evlog.query(&cmn.EventQuery{Kinds: []string{cmn.EvKindXaction}, Actor: schedName})
+	for i := range events {
+		ev := &events[i]
+		switch ev.Msg {
+		case msg:
+			runs = append(runs, cmn.SchedRun{Time: ev.Time, XactID: ev.XactID, Err: ev.Err})
+		case msg + schedSkipped:
+			runs = append(runs, cmn.SchedRun{Time: ev.Time, Skipped: true})
+		}
+	}
+	if len(runs) > cmn.SchedRunsMax {
+		runs = runs[len(runs)-cmn.SchedRunsMax:]
+	}
+	return
+}
+
+func (s *scheduler) lastRun(name string) *cmn.SchedRun {
+	runs := s.runs(name)
+	if len(runs) == 0 {
+		return nil
+	}
+	return &runs[len(runs)-1]
+}
+
+// the xaction started by the previous run is still running
+func (s *scheduler) overlaps(name string) (nl.NotifListener, bool) {
+	runs := s.runs(name)
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].XactID == "" {
+			continue // skipped or failed to start
+		}
+		entry, exists := s.p.notifs.entry(runs[i].XactID)
+		return entry, exists && !entry.Finished()
+	}
+	return nil, false
+}
+
+func (s *scheduler) run(job *cmn.SchedJob) {
+	var (
+		p  = s.p
+		ev = &cmn.Event{Kind: cmn.EvKindXaction, Action: job.Kind, Actor: schedName, Msg: schedRunMsg(job.Name)}
+	)
+	if !job.Bck.IsEmpty() {
+		ev.Bck = job.Bck.String()
+	}
+	if prev, yes := s.overlaps(job.Name); yes {
+		ev.Msg += schedSkipped
+		glog.Warningf("%s: skipping scheduled job %q - previous run %s is still in progress", p, job.Name, prev)
+	} else {
+		xactID, err := p.startSchedJob(job)
+		ev.XactID = xactID
+		if err != nil {
+			ev.Err = err.Error()
+			glog.Errorf("%s: failed to start scheduled job %q: %v", p, job.Name, err)
+		} else {
+			glog.Infof("%s: started scheduled job %q (%s[%s])", p, job.Name, job.Kind, xactID)
+		}
+	}
+	p.evlog.add(ev)
+}
+
+// start xaction the same way `hpostBucket`, `xactStart`, and `httpDownloadPost` do
+func (p *proxy) startSchedJob(job *cmn.SchedJob) (xactID string, err error) {
+	msg := &apc.ActionMsg{Action: job.Kind, Value: job.Value}
+	switch job.Kind {
+	case apc.ActLRU, apc.ActStoreCleanup:
+		xactMsg := xact.QueryMsg{ID: cos.GenUUID(), Kind: job.Kind}
+		if !job.Bck.IsEmpty() {
+			xactMsg.Bck = job.Bck
+		}
+		err = p.bcastXactStart(apc.ActXactStart, &xactMsg)
+		return xactMsg.ID, err
+	case apc.ActDownload:
+		var (
+			dlb  downloader.DlBody
+			body []byte
+		)
+		if body, err = jsoniter.Marshal(job.Value); err != nil {
+			return
+		}
+		if err = jsoniter.Unmarshal(body, &dlb); err != nil {
+			return
+		}
+		xactID, _, err = p.startDownload(url.Values{}, dlb.Type, body, downloader.DownloadProgressInterval)
+		return
+	}
+	bck := cluster.CloneBck(&job.Bck)
+	if err = bck.Init(p.owner.bmd); err != nil {
+		return
+	}
+	switch job.Kind {
+	case apc.ActCopyBck:
+		tcbMsg := &apc.TCBMsg{}
+		if msg.Value != nil {
+			if err = cos.MorphMarshal(msg.Value, &tcbMsg.CopyBckMsg); err != nil {
+				return
+			}
+		}
+		msg.Value = tcbMsg
+		bckTo := cluster.CloneBck(&job.BckTo)
+		if err = bckTo.Init(p.owner.bmd); err != nil {
+			if !cmn.IsErrBckNotFound(err) || !bckTo.IsAIS() {
+				return
+			}
+			err = nil // (created on the fly)
+		}
+		xactID, err = p.tcb(bck, bckTo, msg, tcbMsg.DryRun)
+	case apc.ActMakeNCopies:
+		xactID, err = p.makeNCopies(msg, bck)
+	case apc.ActECEncode:
+		xactID, err = p.ecEncode(bck, msg)
+	case apc.ActPrefetchObjects:
+		if bck.IsAIS() {
+			err = fmt.Errorf(fmtNotRemote, bck.Name)
+			return
+		}
+		xactID, err = p.doListRange(http.MethodPost, bck.Name, msg, bck.AddToQuery(nil))
+	default:
+		err = fmt.Errorf("%q cannot be scheduled", job.Kind)
+	}
+	return
+}
+
+//
+// API: add, update, remove (primary only)
+//
+
+func (p *proxy) schedJob(w http.ResponseWriter, r *http.Request, msg *apc.ActionMsg) {
+	ctx := &schMDModifier{final: p._syncSchMDFinal, msg: msg, wait: true}
+	switch msg.Action {
+	case apc.ActSchedAdd:
+		job := &cmn.SchedJob{}
+		if err := cos.MorphMarshal(msg.Value, job); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		if err := job.Validate(); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		if err := validateSchedDownload(job); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		if !job.Bck.IsEmpty() {
+			bck := cluster.CloneBck(&job.Bck)
+			if err := bck.Init(p.owner.bmd); err != nil {
+				p.writeErr(w, r, err)
+				return
+			}
+		}
+		ctx.pre, ctx.job = _addSchedJobPre, job
+	case apc.ActSchedRemove:
+		ctx.pre, ctx.name = _rmSchedJobPre, msg.Name
+	default:
+		p.writeErrAct(w, r, msg.Action)
+		return
+	}
+	if _, err := p.sched.owner.modify(ctx); err != nil {
+		if cmn.IsErrNotFound(err) {
+			p.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			p.writeErr(w, r, err)
+		}
+		return
+	}
+	p.evlog.add(&cmn.Event{Kind: cmn.EvKindXaction, Action: msg.Action, Actor: p.actor(r),
+		Msg: fmt.Sprintf("scheduled job %q", ctx.jobName())})
+}
+
+func (ctx *schMDModifier) jobName() string {
+	if ctx.job != nil {
+		return ctx.job.Name
+	}
+	return ctx.name
+}
+
+// scheduled jobs are stored in schMD - replicated and persisted on all proxies - and
+// therefore cannot carry credentials (the downloader keeps those only in memory)
+func validateSchedDownload(job *cmn.SchedJob) error {
+	if job.Kind != apc.ActDownload {
+		return nil
+	}
+	var base struct {
+		Creds *downloader.DlCreds `json:"creds"` // (all download types - see downloader.DlBase)
+	}
+	b, err := jsoniter.Marshal(job.Value)
+	if err == nil {
+		err = jsoniter.Unmarshal(b, &base)
+	}
+	if err != nil {
+		return err
+	}
+	if base.Creds != nil {
+		return fmt.Errorf("scheduled job %q: download credentials ('creds') cannot be scheduled", job.Name)
+	}
+	return nil
+}
+
+func _addSchedJobPre(ctx *schMDModifier, clone *schMD) error {
+	job := ctx.job
+	job.Created, job.Runs = time.Now().UnixNano(), nil // (history is in the event log)
+	if prev, ok := clone.Jobs[job.Name]; ok {
+		job.Created = prev.Created
+	}
+	clone.Jobs[job.Name] = job
+	return nil
+}
+
+func _rmSchedJobPre(ctx *schMDModifier, clone *schMD) error {
+	if _, ok := clone.Jobs[ctx.name]; !ok {
+		return cmn.NewErrNotFound("scheduled job %q", ctx.name)
+	}
+	delete(clone.Jobs, ctx.name)
+	return nil
+}
+
+func (p *proxy) _syncSchMDFinal(ctx *schMDModifier, clone *schMD) {
+	wg := p.metasyncer.sync(revsPair{clone, p.newAmsg(ctx.msg, nil)})
+	if ctx.wait {
+		wg.Wait()
+	}
+}
+
+// GET /v1/cluster?what=sched (any proxy)
+func (p *proxy) querySched(w http.ResponseWriter, r *http.Request, what string) {
+	md := p.sched.owner.get()
+	if md == nil {
+		p.writeErr(w, r, errors.New("scheduler is not initialized"))
+		return
+	}
+	resp := cmn.SchedMD{Jobs: make(map[string]*cmn.SchedJob, len(md.Jobs)), Version: md.Version}
+	for name, job := range md.Jobs {
+		j := *job
+		j.Runs = p.sched.runs(name)
+		resp.Jobs[name] = &j
+	}
+	p.writeJSON(w, r, &resp, what)
+}
@@ -0,0 +1,156 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+func newTestScheduler(t *testing.T) *scheduler {
+	p := newPrimary()
+	e := &p.evlog
+	e.p, e.fpath, e.logins = p, filepath.Join(t.TempDir(), "events"), make(map[string]struct{})
+	fh, err := os.OpenFile(e.fpath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+	tassert.CheckFatal(t, err)
+	e.fh = fh
+	t.Cleanup(func() { e.fh.Close() })
+	p.notifs.nls, p.notifs.fin = newListeners(), newListeners()
+	return &scheduler{p: p, next: make(map[string]int64)}
+}
+
+// as if recorded by the (previous) primary
+func (s *scheduler) testRecord(name string, at time.Time, xactID string, skipped bool) {
+	ev := cmn.Event{Kind: cmn.EvKindXaction, Action: apc.ActLRU, Actor: schedName, Msg: schedRunMsg(name),
+		Time: at.UnixNano(), XactID: xactID}
+	if skipped {
+		ev.Msg += schedSkipped
+	}
+	s.p.evlog.append([]cmn.Event{ev}, true /*assign IDs*/)
+}
+
+func dueNames(due []*cmn.SchedJob) (names []string) {
+	for _, job := range due {
+		names = append(names, job.Name)
+	}
+	return
+}
+
+func TestSchedDue(t *testing.T) {
+	var (
+		s       = newTestScheduler(t)
+		md      = newSchMD()
+		t0      = time.Date(2022, 6, 1, 10, 0, 30, 0, time.Local)
+		created = t0.Add(-20 * time.Second).UnixNano()
+	)
+	md.Jobs["minutely"] = &cmn.SchedJob{Name: "minutely", Cron: "* * * * *", Kind: apc.ActLRU, Created: created}
+	md.Jobs["daily"] = &cmn.SchedJob{Name: "daily", Cron: "0 3 * * *", Kind: apc.ActLRU, Created: created}
+	md.Jobs["disabled"] = &cmn.SchedJob{Name: "disabled", Cron: "* * * * *", Kind: apc.ActLRU, Created: created,
+		Disabled: true}
+
+	// created at 10:00:10 - the 10:00 slot is not missed
+	due := s.due(md, t0)
+	tassert.Errorf(t, len(due) == 0, "expected nothing due, got %v", dueNames(due))
+	tassert.Errorf(t, s.next["minutely"] == t0.Truncate(time.Minute).Add(time.Minute).UnixNano(), "unexpected next time")
+	_, ok := s.next["disabled"]
+	tassert.Errorf(t, !ok, "disabled job must not be scheduled")
+
+	due = s.due(md, t0.Add(31*time.Second)) // 10:01:01
+	tassert.Errorf(t, len(due) == 1 && due[0].Name == "minutely", "expected minutely due, got %v", dueNames(due))
+	due = s.due(md, t0.Add(40*time.Second))
+	tassert.Errorf(t, len(due) == 0, "expected nothing due, got %v", dueNames(due))
+
+	// removed
+	delete(md.Jobs, "daily")
+	s.due(md, t0.Add(40*time.Second))
+	_, ok = s.next["daily"]
+	tassert.Errorf(t, !ok, "removed job must not be scheduled")
+}
+
+func TestSchedFailover(t *testing.T) {
+	var (
+		t0  = time.Date(2022, 6, 1, 10, 2, 5, 0, time.Local)
+		md  = newSchMD()
+		job = &cmn.SchedJob{Name: "minutely", Cron: "* * * * *", Kind: apc.ActLRU,
+			Created: t0.Add(-time.Hour).UnixNano()}
+	)
+	md.Jobs[job.Name] = job
+
+	// the previous primary ran it at 10:01:01 and went down - 10:02 is due
+	s := newTestScheduler(t)
+	s.testRecord(job.Name, t0.Add(-64*time.Second), cos.GenUUID(), false)
+	due := s.due(md, t0)
+	tassert.Errorf(t, len(due) == 1, "expected missed run due, got %v", dueNames(due))
+	due = s.due(md, t0.Add(10*time.Second))
+	tassert.Errorf(t, len(due) == 0, "expected missed run to run once, got %v", dueNames(due))
+
+	// ... ran it at 10:02:01 - nothing's due
+	s = newTestScheduler(t)
+	s.testRecord(job.Name, t0.Add(-4*time.Second), cos.GenUUID(), false)
+	due = s.due(md, t0)
+	tassert.Errorf(t, len(due) == 0, "expected nothing due, got %v", dueNames(due))
+
+	// cluster down for hours: no catching up beyond schedCatchUp
+	daily := &cmn.SchedJob{Name: "daily", Cron: "0 3 * * *", Kind: apc.ActLRU, Created: t0.Add(-72 * time.Hour).UnixNano()}
+	md = newSchMD()
+	md.Jobs[daily.Name] = daily
+	s = newTestScheduler(t)
+	due = s.due(md, t0)
+	tassert.Errorf(t, len(due) == 0, "expected nothing due, got %v", dueNames(due))
+	tassert.Errorf(t, s.next[daily.Name] == time.Date(2022, 6, 2, 3, 0, 0, 0, time.Local).UnixNano(),
+		"unexpected next time %v", time.Unix(0, s.next[daily.Name]))
+}
+
+func TestSchedOverlap(t *testing.T) {
+	var (
+		s       = newTestScheduler(t)
+		now     = time.Now()
+		xactID  = cos.GenUUID()
+		targets = cluster.NodeMap{"t1": cluster.NewSnode("t1", apc.Target, cluster.NetInfo{}, cluster.NetInfo{},
+			cluster.NetInfo{})}
+	)
+	_, yes := s.overlaps("job")
+	tassert.Errorf(t, !yes, "expected no overlap without runs")
+
+	s.testRecord("job", now.Add(-2*time.Minute), xactID, false)
+	s.testRecord("job", now.Add(-time.Minute), "", true /*skipped*/)
+	s.testRecord("other", now, cos.GenUUID(), false)
+
+	// not tracked (e.g., long gone)
+	_, yes = s.overlaps("job")
+	tassert.Errorf(t, !yes, "expected no overlap with unknown xaction")
+
+	// still running (previous skipped run doesn't count)
+	nl := xact.NewXactNL(xactID, apc.ActLRU, &s.p.owner.smap.get().Smap, targets)
+	s.p.notifs.nls.add(nl, false /*locked*/)
+	_, yes = s.overlaps("job")
+	tassert.Errorf(t, yes, "expected overlap with running %s", nl)
+
+	nl.MarkFinished(targets["t1"])
+	nl.Callback(nl, time.Now().UnixNano())
+	_, yes = s.overlaps("job")
+	tassert.Errorf(t, !yes, "expected no overlap with finished %s", nl)
+
+	runs := s.runs("job")
+	tassert.Errorf(t, len(runs) == 2 && runs[0].XactID == xactID && runs[1].Skipped, "unexpected history %+v", runs)
+}
+
+func TestSchedDownloadCreds(t *testing.T) {
+	job := &cmn.SchedJob{Name: "dl", Cron: "@daily", Kind: apc.ActDownload,
+		Value: map[string]interface{}{"type": "single", "link": "s3://b/o", "creds": map[string]string{
+			"s3_access_key_id": "id", "s3_secret_access_key": "secret"}}}
+	tassert.Errorf(t, validateSchedDownload(job) != nil, "expected credentials rejected")
+	job.Value = map[string]interface{}{"type": "single", "link": "https://example.com/o"}
+	tassert.CheckError(t, validateSchedDownload(job))
+}
@@ -0,0 +1,145 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/OneOfOne/xxhash"
+)
+
+// bucket snapshots (ais:// buckets only):
+// - take:    { begin -- add snapshot to BMD -- metasync -- commit (x-snapshot-bck) }
+// - restore: { begin -- commit (x-restore-snapshot) }
+// - delete:  { begin -- remove snapshot from BMD -- metasync -- commit }
+// snapshots are not rebalanced - restore is rejected once the cluster's (active) targets
+// differ from those at the time of the snapshot;
+// see also: cmn.BckSnap, cluster/lom_snap.go
+func (p *proxy) snapshotBck(bck *cluster.Bck, msg *apc.ActionMsg) (xactID string, err error) {
+	var (
+		snap = msg.Name
+		nlp  = bck.GetNameLockPair()
+	)
+	if err = cmn.ValidateSnapName(snap); err != nil {
+		return
+	}
+	if !bck.IsAIS() || bck.Backend() != nil {
+		err = fmt.Errorf("%s: bucket snapshots are supported only for ais:// buckets without backend, have %s",
+			p, bck)
+		return
+	}
+	if !nlp.TryLock(cmn.Timeout.CplaneOperation() / 2) {
+		err = cmn.NewErrBckIsBusy(bck.Bucket())
+		return
+	}
+	defer nlp.Unlock()
+
+	// 1. confirm existence
+	bprops, present := p.owner.bmd.get().Get(bck)
+	if !present {
+		err = cmn.NewErrBckNotFound(bck.Bucket())
+		return
+	}
+	s, exists := bprops.Snap(snap)
+	switch {
+	case msg.Action == apc.ActSnapshotBck && exists:
+		err = fmt.Errorf("%s: snapshot %q of %s already exists", p, snap, bck)
+		return
+	case msg.Action != apc.ActSnapshotBck && !exists:
+		err = cmn.NewErrNotFound("%s: snapshot %q of %s", p.si, snap, bck)
+		return
+	case msg.Action == apc.ActRestoreSnap && s.Targets != snapTargets(p.owner.smap.get()):
+		err = fmt.Errorf("%s: cannot restore %s from snapshot %q: cluster membership has changed since",
+			p, bck, snap)
+		return
+	}
+
+	// 2. begin
+	var (
+		waitmsync = msg.Action != apc.ActRestoreSnap
+		c         = p.prepTxnClient(msg, bck, waitmsync)
+	)
+	if err = c.begin(bck); err != nil {
+		return
+	}
+
+	// 3. update BMD locally & metasync updated BMD
+	if waitmsync {
+		targets := snapTargets(c.smap)
+		ctx := &bmdModifier{
+			pre:   func(ctx *bmdModifier, clone *bucketMD) error { return _snapBMDPre(ctx, clone, targets) },
+			final: p._syncBMDFinal,
+			bcks:  []*cluster.Bck{bck},
+			wait:  waitmsync,
+			msg:   &c.msg.ActionMsg,
+			txnID: c.uuid,
+		}
+		bmd, errM := p.owner.bmd.modify(ctx)
+		if errM != nil {
+			debug.AssertNoErr(errM)
+			err = c.bcastAbort(bck, errM)
+			return
+		}
+		c.msg.BMDVersion = bmd.version()
+	}
+
+	// 4. IC
+	if msg.Action != apc.ActDeleteSnap {
+		nl := xact.NewXactNL(c.uuid, msg.Action, &c.smap.Smap, nil, bck.Bucket())
+		nl.SetOwner(equalIC)
+		p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query})
+	}
+
+	// 5. commit
+	xactID, err = c.commit(bck, c.cmtTout(waitmsync))
+	debug.Assertf(xactID == "" || xactID == c.uuid, "committed %q vs generated %q", xactID, c.uuid)
+	return
+}
+
+func _snapBMDPre(ctx *bmdModifier, clone *bucketMD, targets string) error {
+	var (
+		bck             = ctx.bcks[0]
+		snap            = ctx.msg.Name
+		bprops, present = clone.Get(bck)
+	)
+	if !present {
+		ctx.terminate = true
+		return nil
+	}
+	nprops := bprops.Clone()
+	nprops.Snapshots = make([]cmn.BckSnap, 0, len(bprops.Snapshots)+1)
+	for _, s := range bprops.Snapshots {
+		if s.Name != snap {
+			nprops.Snapshots = append(nprops.Snapshots, s)
+		}
+	}
+	if ctx.msg.Action == apc.ActSnapshotBck {
+		nprops.Snapshots = append(nprops.Snapshots, cmn.BckSnap{Name: snap, Created: time.Now().UnixNano(), Targets: targets})
+	}
+	clone.set(bck, nprops)
+	return nil
+}
+
+// identifies the set of active targets (that is, the objects' locations)
+func snapTargets(smap *smapX) string {
+	tids := make([]string, 0, len(smap.Tmap))
+	for tid, tsi := range smap.Tmap {
+		if !tsi.IsAnySet(cluster.NodeFlagsMaintDecomm) {
+			tids = append(tids, tid)
+		}
+	}
+	sort.Strings(tids)
+	return strconv.FormatUint(xxhash.ChecksumString64S(strings.Join(tids, ","), cos.MLCG32), 16)
+}
@@ -0,0 +1,41 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestSnapTargets(t *testing.T) {
+	newTarget := func(id string) *cluster.Snode {
+		return cluster.NewSnode(id, apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+	}
+	smap := newSmap()
+	smap.addTarget(newTarget("t1"))
+	smap.addTarget(newTarget("t2"))
+	digest := snapTargets(smap)
+
+	// proxies don't matter
+	smap.addProxy(cluster.NewSnode("p1", apc.Proxy, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{}))
+	tassert.Errorf(t, snapTargets(smap) == digest, "expected the same targets")
+
+	// new target
+	t3 := newTarget("t3")
+	smap.addTarget(t3)
+	tassert.Errorf(t, snapTargets(smap) != digest, "expected different targets upon join")
+
+	// (in maintenance - not an object location)
+	t3.Flags = t3.Flags.Set(cluster.NodeFlagMaint)
+	tassert.Errorf(t, snapTargets(smap) == digest, "expected the same active targets")
+
+	other := newSmap()
+	other.addTarget(newTarget("t2"))
+	other.addTarget(newTarget("t3"))
+	tassert.Errorf(t, snapTargets(other) != digest, "expected different targets")
+}
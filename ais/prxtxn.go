@@ -202,8 +202,10 @@ func (p *proxy) createBucket(msg *apc.ActionMsg, bck *cluster.Bck, remoteHeader
 	_, err := c.commit(bck, c.cmtTout(waitmsync))
 	if err != nil {
 		p.undoCreateBucket(msg, bck)
+		return err
 	}
-	return err
+	p.evlog.add(&cmn.Event{Kind: cmn.EvKindBucket, Action: msg.Action, Bck: bck.String()})
+	return nil
 }
 
 func _createBMDPre(ctx *bmdModifier, clone *bucketMD) (err error) {
@@ -391,6 +393,7 @@ func (p *proxy) _setPropsPre(ctx *bmdModifier, clone *bucketMD) (err error) {
 	ctx.needReMirror = _reMirror(bprops, ctx.setProps)
 	targetCnt, ctx.needReEC = _reEC(bprops, ctx.setProps, bck, p.owner.smap.get())
 	debug.Assert(!ctx.needReEC || ctx.setProps.Validate(targetCnt) == nil)
+	ctx.setProps.Snapshots = bprops.Snapshots // (not settable - see snapshotBck)
 	clone.set(bck, ctx.setProps)
 	return nil
 }
@@ -722,9 +725,12 @@ func _updatePropsBMDPre(ctx *bmdModifier, clone *bucketMD) error {
 	return nil
 }
 
-func (p *proxy) createArchMultiObj(bckFrom, bckTo *cluster.Bck, msg *apc.ActionMsg) (xactID string, err error) {
+// returns both the xaction ID and the ID of this transaction (the xaction being on-demand
+// and, therefore, possibly shared by multiple archiving requests)
+func (p *proxy) createArchMultiObj(bckFrom, bckTo *cluster.Bck, msg *apc.ActionMsg) (xactID, txnID string, err error) {
 	// begin
 	c := p.prepTxnClient(msg, bckFrom, false /*waitmsync*/)
+	txnID = c.uuid
 	_ = bckTo.AddUnameToQuery(c.req.Query, apc.QparamBucketTo)
 	if err = c.begin(bckFrom); err != nil {
 		return
@@ -863,6 +869,9 @@ func (p *proxy) destroyBucket(msg *apc.ActionMsg, bck *cluster.Bck) error {
 
 	// 3. Commit
 	_, err := c.commit(bck, c.cmtTout(waitmsync))
+	if err == nil {
+		p.evlog.add(&cmn.Event{Kind: cmn.EvKindBucket, Action: msg.Action, Bck: bck.String()})
+	}
 	return err
 }
 
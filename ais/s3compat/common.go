@@ -15,6 +15,8 @@ const (
 	QparamPolicy      = "policy"
 	QparamACL         = "acl"
 	QparamMultiDelete = "delete"
+	QparamVersions    = "versions"  // list object versions
+	QparamVersionID   = "versionId" // GET, HEAD, or DELETE a given version
 
 	versioningEnabled  = "Enabled"
 	versioningDisabled = "Suspended"
@@ -22,6 +24,8 @@ const (
 	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01"
 
 	// Headers
-	headerETag   = "ETag"
-	HeaderObjSrc = "x-amz-copy-source"
+	headerETag         = "ETag"
+	HeaderObjSrc       = "x-amz-copy-source"
+	HeaderVersionID    = "x-amz-version-id"
+	HeaderDeleteMarker = "x-amz-delete-marker"
 )
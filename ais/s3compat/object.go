@@ -41,6 +41,33 @@ type (
 		Class        string `xml:"StorageClass"`
 	}
 
+	// List object versions response (`?versions`)
+	ListVersionsResult struct {
+		Ns            string              `xml:"xmlns,attr"`
+		Prefix        string              `xml:"Prefix"`
+		KeyMarker     string              `xml:"KeyMarker"`
+		NextKeyMarker string              `xml:"NextKeyMarker,omitempty"`
+		MaxKeys       int                 `xml:"MaxKeys"`
+		IsTruncated   bool                `xml:"IsTruncated"`
+		Versions      []*ObjVersionInfo   `xml:"Version"`
+		DeleteMarkers []*DeleteMarkerInfo `xml:"DeleteMarker"`
+	}
+	ObjVersionInfo struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+		Size         int64  `xml:"Size"`
+		Class        string `xml:"StorageClass"`
+	}
+	DeleteMarkerInfo struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+	}
+
 	// Response for object copy request
 	CopyObjectResult struct {
 		LastModified string `xml:"LastModified"`
@@ -109,6 +136,66 @@ func (r *ListObjectResult) FillFromAisBckList(bckList *cmn.BucketList, lsmsg *ap
 	}
 }
 
+// NOTE: all versions of the same object are always listed on the same page,
+// and so "version-id-marker" is not needed (and is ignored)
+func FillMsgFromS3VersionsQuery(query url.Values, msg *apc.ListObjsMsg) {
+	msg.SetFlag(apc.LsVersions)
+	mxStr := query.Get("max-keys")
+	if pageSize, err := strconv.Atoi(mxStr); err == nil && pageSize > 0 {
+		msg.PageSize = uint(pageSize)
+	}
+	if prefix := query.Get("prefix"); prefix != "" {
+		msg.Prefix = prefix
+	}
+	if marker := query.Get("key-marker"); marker != "" {
+		msg.ContinuationToken = marker
+	}
+}
+
+func NewListVersionsResult() *ListVersionsResult {
+	return &ListVersionsResult{
+		Ns:            s3Namespace,
+		MaxKeys:       1000,
+		Versions:      make([]*ObjVersionInfo, 0),
+		DeleteMarkers: make([]*DeleteMarkerInfo, 0),
+	}
+}
+
+func (r *ListVersionsResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	cos.AssertNoErr(err)
+}
+
+func (r *ListVersionsResult) FillFromAisBckList(bckList *cmn.BucketList, lsmsg *apc.ListObjsMsg) {
+	r.Prefix = lsmsg.Prefix
+	r.IsTruncated = bckList.ContinuationToken != ""
+	r.NextKeyMarker = bckList.ContinuationToken
+	for i, e := range bckList.Entries {
+		var (
+			latest = i == 0 || bckList.Entries[i-1].Name != e.Name
+			mtime  = e.Atime
+		)
+		if mtime == "" {
+			mtime = cos.FormatUnixNano(defaultLastModified, lsmsg.TimeFormat)
+		}
+		if e.IsDelMarker() {
+			dm := &DeleteMarkerInfo{Key: e.Name, VersionID: e.Version, IsLatest: latest, LastModified: mtime}
+			r.DeleteMarkers = append(r.DeleteMarkers, dm)
+			continue
+		}
+		ov := &ObjVersionInfo{
+			Key:          e.Name,
+			VersionID:    e.Version,
+			IsLatest:     latest,
+			LastModified: mtime,
+			ETag:         e.Checksum,
+			Size:         e.Size,
+		}
+		r.Versions = append(r.Versions, ov)
+	}
+}
+
 func FormatTime(t time.Time) string {
 	s := t.UTC().Format(time.RFC1123)
 	return strings.Replace(s, "UTC", "GMT", 1) // expects: "%a, %d %b %Y %H:%M:%S GMT"
@@ -132,6 +219,13 @@ func SetETag(header http.Header, lom *cluster.LOM) {
 	}
 }
 
+// S3 version ID is the (numeric) ais version - see cmn.VersionConf.History
+func SetVersionHdr(header http.Header) {
+	if ver := header.Get(apc.HdrObjVersion); ver != "" {
+		header.Set(HeaderVersionID, ver)
+	}
+}
+
 func (r *CopyObjectResult) MustMarshal(sgl *memsys.SGL) {
 	sgl.Write([]byte(xml.Header))
 	err := xml.NewEncoder(sgl).Encode(r)
@@ -0,0 +1,200 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Scheduled jobs metadata (schMD): owned and modified by the primary, metasync-ed
+// to all nodes, and persisted by proxies only (targets ignore it) - see prxsched.go
+
+var schMDImmSize int64
+
+type (
+	schMD struct {
+		cmn.SchedMD
+		_sgl *memsys.SGL
+	}
+	schMDOwner struct {
+		sync.Mutex
+		schMD atomic.Pointer
+		fpath string
+	}
+	schMDModifier struct {
+		pre   func(ctx *schMDModifier, clone *schMD) error
+		final func(ctx *schMDModifier, clone *schMD)
+
+		msg  *apc.ActionMsg
+		job  *cmn.SchedJob // add/update
+		name string        // remove
+		wait bool
+	}
+)
+
+// interface guard
+var (
+	_ revs     = (*schMD)(nil)
+	_ jsp.Opts = (*schMD)(nil)
+)
+
+var schMDJspOpts = jsp.CCSign(cmn.MetaverSchMD)
+
+func newSchMD() *schMD {
+	md := &schMD{}
+	md.Jobs = make(map[string]*cmn.SchedJob, 4)
+	return md
+}
+
+func (*schMD) JspOpts() jsp.Options { return schMDJspOpts }
+
+// as revs
+func (*schMD) tag() string       { return revsSchMDTag }
+func (md *schMD) version() int64 { return md.Version }
+func (*schMD) jit(p *proxy) revs { return p.sched.owner.get() }
+func (*schMD) sgl() *memsys.SGL  { return nil }
+
+func (md *schMD) marshal() []byte {
+	if md._sgl != nil {
+		md._sgl.Free()
+	}
+	md._sgl = memsys.PageMM().NewSGL(schMDImmSize)
+	err := jsp.Encode(md._sgl, md, md.JspOpts())
+	debug.AssertNoErr(err)
+	schMDImmSize = cos.MaxI64(schMDImmSize, md._sgl.Len())
+	return md._sgl.Bytes()
+}
+
+func (md *schMD) String() string {
+	if md == nil {
+		return "SchMD <nil>"
+	}
+	return fmt.Sprintf("SchMD v%d(%d)", md.Version, len(md.Jobs))
+}
+
+// (jobs are copied-on-write - see schMDOwner.modify)
+func (md *schMD) clone() *schMD {
+	dst := newSchMD()
+	dst.Version = md.Version
+	for name, job := range md.Jobs {
+		dst.Jobs[name] = job
+	}
+	return dst
+}
+
+////////////////
+// schMDOwner //
+////////////////
+
+func (so *schMDOwner) init(config *cmn.Config) {
+	so.fpath = filepath.Join(config.ConfigDir, fname.Schmd)
+	md := newSchMD()
+	if _, err := jsp.LoadMeta(so.fpath, md); err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("failed to load %s from %s, err: %v", md, so.fpath, err)
+		}
+		md = newSchMD()
+	}
+	so.put(md)
+}
+
+func (so *schMDOwner) get() *schMD   { return (*schMD)(so.schMD.Load()) }
+func (so *schMDOwner) put(md *schMD) { so.schMD.Store(unsafe.Pointer(md)) }
+
+func (so *schMDOwner) putPersist(md *schMD, payload msPayload) (err error) {
+	if b := payload[revsSchMDTag]; b != nil {
+		var v *schMD
+		err = jsp.SaveMeta(so.fpath, v, bytes.NewBuffer(b)) // write metasync-sent bytes directly
+	} else {
+		err = jsp.SaveMeta(so.fpath, md, nil)
+	}
+	if err == nil {
+		so.put(md)
+	}
+	return
+}
+
+func (so *schMDOwner) modify(ctx *schMDModifier) (clone *schMD, err error) {
+	so.Lock()
+	md := so.get()
+	if md._sgl != nil {
+		md._sgl.Free()
+		md._sgl = nil
+	}
+	clone = md.clone()
+	if err = ctx.pre(ctx, clone); err == nil {
+		clone.Version++
+		err = so.putPersist(clone, nil)
+	}
+	so.Unlock()
+	if err == nil && ctx.final != nil {
+		ctx.final(ctx, clone)
+	}
+	return
+}
+
+//
+// metasync: receive
+//
+
+func (p *proxy) extractSchMD(payload msPayload, caller string) (newMD *schMD, msg *aisMsg, err error) {
+	value, ok := payload[revsSchMDTag]
+	if !ok {
+		return
+	}
+	newMD, msg = newSchMD(), &aisMsg{}
+	if _, err1 := jsp.Decode(io.NopCloser(bytes.NewBuffer(value)), newMD, newMD.JspOpts(), "extractSchMD"); err1 != nil {
+		err = fmt.Errorf(cmn.FmtErrUnmarshal, p.si, "new SchMD", cos.BHead(value), err1)
+		return
+	}
+	if msgValue, ok := payload[revsSchMDTag+revsActionTag]; ok {
+		if err1 := jsoniter.Unmarshal(msgValue, msg); err1 != nil {
+			err = fmt.Errorf(cmn.FmtErrUnmarshal, p.si, "action message", cos.BHead(msgValue), err1)
+			return
+		}
+	}
+	md := p.sched.owner.get()
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("extract %s%s", newMD, _msdetail(md.Version, msg, caller))
+	}
+	if newMD.version() <= md.version() {
+		if newMD.version() < md.version() {
+			err = newErrDowngrade(p.si, md.String(), newMD.String())
+		}
+		newMD = nil
+	}
+	return
+}
+
+func (p *proxy) receiveSchMD(newMD *schMD, payload msPayload) (err error) {
+	so := &p.sched.owner
+	so.Lock()
+	if md := so.get(); newMD.version() <= md.version() {
+		if newMD.version() < md.version() {
+			err = newErrDowngrade(p.si, md.String(), newMD.String())
+		}
+	} else {
+		err = so.putPersist(newMD, payload)
+	}
+	so.Unlock()
+	return
+}
@@ -22,7 +22,9 @@ import (
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/admit"
 	"github.com/NVIDIA/aistore/ais/backend"
+	"github.com/NVIDIA/aistore/ais/s3compat"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -58,19 +60,21 @@ type (
 	regstate struct {
 		sync.Mutex
 		disabled atomic.Bool // target was unregistered by internal event (e.g, all mountpaths are down)
+		running  atomic.Bool // joined cluster that was already up and running (as opposed to starting up)
 	}
 	backends map[string]cluster.BackendProvider
 	// main
 	target struct {
 		htrun
-		backend      backends
-		fshc         *health.FSHC
-		fsprg        fsprungroup
-		reb          *reb.Reb
-		res          *res.Res
-		db           dbdriver.Driver
-		transactions transactions
-		regstate     regstate // the state of being registered with the primary, can be (en/dis)abled via API
+		backend       backends
+		fshc          *health.FSHC
+		fsprg         fsprungroup
+		reb           *reb.Reb
+		res           *res.Res
+		db            dbdriver.Driver
+		transactions  transactions
+		regstate      regstate      // the state of being registered with the primary, can be (en/dis)abled via API
+		backendHealth backendHealth // cached remote backends' health (see health.go)
 	}
 )
 
@@ -304,13 +308,22 @@ func (t *target) Run() error {
 		glog.Errorln("")
 	}
 
-	// register object type and workfile type
+	// register object, workfile, object-version, snapshot, and archive-index types
 	if err := fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
 	if err := fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.Reg(fs.ObjVersionType, &fs.ObjVersionContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.Reg(fs.SnapshotType, &fs.SnapshotContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.Reg(fs.ArchIndexType, &fs.ArchIndexContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
 
 	// Init meta-owners and load local instances
 	t.owner.bmd.init()
@@ -343,6 +356,7 @@ func (t *target) Run() error {
 			return err
 		}
 		t.markNodeStarted()
+		reboot := !t.regstate.running.Load()
 		go func() {
 			smap := t.owner.smap.get()
 			cii := t.pollClusterStarted(config, smap.Primary)
@@ -357,6 +371,7 @@ func (t *target) Run() error {
 				}
 			}
 			t.markClusterStarted()
+			t.resumeXactions(reboot)
 
 			if t.fsprg.newVol && !config.TestingEnv() {
 				config := cmn.GCO.BeginUpdate()
@@ -388,6 +403,7 @@ func (t *target) Run() error {
 	mirror.Init()
 
 	xreg.RegWithHK()
+	admit.RegWithHK()
 
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
@@ -421,6 +437,7 @@ func (t *target) endStartupStandby() (err error) {
 	daemon.cli.target.standby = false
 	t.markNodeStarted()
 	t.markClusterStarted()
+	go t.resumeXactions(false /*reboot*/)
 	t.regstate.disabled.Store(false)
 	tstats := t.statsT.(*stats.Trunner)
 	tstats.Standby(false)
@@ -623,6 +640,13 @@ func (t *target) httpbckget(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 		t.bsumm(w, r, query, msg.Action, bck, &bsumMsg)
+	case apc.ActGetBatch:
+		qbck, err := newQbckFromQ(bckName, r.URL.Query(), nil)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		t.getBatch(w, r, (*cluster.Bck)(qbck), msg)
 	default:
 		t.writeErrAct(w, r, msg.Action)
 	}
@@ -970,6 +994,13 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 			return lom
 		}
 	}
+	if dpq.snapshot != "" {
+		t.getObjSnap(w, r, lom, dpq.snapshot)
+		return lom
+	}
+	if dpq.versionID != "" && t.getObjVersion(w, r, lom, dpq.versionID) {
+		return lom
+	}
 	// isETLRequest (TODO: !4455 comment)
 	if dpq.uuid != "" {
 		t.doETL(w, r, dpq.uuid, bck, lom.ObjName)
@@ -1023,6 +1054,10 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request) {
 	if err := t.parseReq(w, r, apireq); err != nil {
 		return
 	}
+	if apireq.dpq.snapshot != "" {
+		t.writeErrStatusf(w, r, http.StatusMethodNotAllowed, "%s: bucket snapshots are read-only", t.si)
+		return
+	}
 
 	// prep and check
 	var (
@@ -1047,6 +1082,13 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	// admission control (client PUTs only)
+	if !t2tput {
+		if err := admit.Check(""); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+	}
 
 	// init
 	lom := cluster.AllocLOM(objName)
@@ -1137,6 +1179,18 @@ func (t *target) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if apireq.query.Get(apc.QparamSnapshot) != "" {
+		t.writeErrStatusf(w, r, http.StatusMethodNotAllowed, "%s: bucket snapshots are read-only", t.si)
+		return
+	}
+	if ver := apireq.query.Get(apc.QparamVersionID); ver != "" && !evict {
+		t.delObjVersion(w, r, lom, ver)
+		return
+	}
+	if filename := apireq.query.Get(apc.QparamArchpath); filename != "" && !evict {
+		t.delFromArch(w, r, lom, filename)
+		return
+	}
 
 	errCode, err := t.DeleteObject(lom, evict)
 	if err != nil {
@@ -1165,6 +1219,12 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		t.objMv(w, r, msg)
+	case apc.ActExtractArch:
+		if isRedirect(r.URL.Query()) == "" {
+			t.writeErrf(w, r, "%s: %s-%s(obj) is expected to be redirected", t.si, r.Method, msg.Action)
+			return
+		}
+		t.extractArch(w, r, msg)
 	default:
 		t.writeErrAct(w, r, msg.Action)
 	}
@@ -1213,6 +1273,15 @@ func (t *target) headObject(w http.ResponseWriter, r *http.Request, query url.Va
 		invalidHandler(w, r, err)
 		return
 	}
+	if snap := query.Get(apc.QparamSnapshot); snap != "" {
+		t.headObjSnap(w, r, lom, snap, silent)
+		return
+	}
+	if ver := cos.Either(query.Get(apc.QparamVersionID), query.Get(s3compat.QparamVersionID)); ver != "" {
+		if t.headObjVersion(w, r, lom, ver, silent) {
+			return
+		}
+	}
 	err := lom.Load(true /*cache it*/, false /*locked*/)
 	if err == nil {
 		if mustBeLocal > 0 {
@@ -1280,7 +1349,11 @@ func (t *target) headObject(w http.ResponseWriter, r *http.Request, query url.Va
 		}
 	}
 
-	// to header
+	objPropsToHdr(&op, hdr, hasEC)
+}
+
+// NOTE: compare with api.HeadObject()
+func objPropsToHdr(op *cmn.ObjectProps, hdr http.Header, hasEC bool) {
 	cmn.ToHeader(&op.ObjAttrs, hdr)
 	errIter := cmn.IterFields(op, func(tag string, field cmn.IterField) (err error, b bool) {
 		if !hasEC && strings.HasPrefix(tag, "ec.") {
@@ -1496,7 +1569,7 @@ func (t *target) _listBcks(qbck *cmn.QueryBcks, cfg *cmn.Config) (names cmn.Bcks
 		names = selectBMDBuckets(t.owner.bmd.get(), qbck)
 	} else {
 		bck := cluster.NewBck("", qbck.Provider, qbck.Ns)
-		names, errCode, err = t.Backend(bck).ListBuckets(*qbck)
+		names, errCode, err = t.Backend(bck).ListBuckets(context.Background(), *qbck)
 		sort.Sort(names)
 	}
 	return
@@ -1561,6 +1634,7 @@ func (t *target) doAppendArch(r *http.Request, lom *cluster.LOM, started time.Ti
 		r:        r.Body,
 		filename: filename,
 		mime:     mime,
+		mode:     dpq.archmode, // apc.QparamArchmode
 	}
 	if sizeStr != "" {
 		if size, ers := strconv.ParseInt(sizeStr, 10, 64); ers == nil {
@@ -1625,7 +1699,12 @@ func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
 	}
 	if delFromAIS {
 		size := lom.SizeBytes()
-		aisErr = lom.Remove()
+		lom.CowSnap() // (no-op unless ais:// - in which case, evict is also a deletion)
+		if !evict && lom.HistoryEnabled() {
+			aisErr = lom.RemoveToHistory()
+		} else {
+			aisErr = lom.Remove()
+		}
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
 				if backendErr != nil {
@@ -1693,6 +1772,7 @@ func (t *target) objMv(w http.ResponseWriter, r *http.Request, msg *apc.ActionMs
 	}
 	// TODO: combine copy+delete under a single write lock
 	lom.Lock(true)
+	lom.CowSnap()
 	if err = lom.Remove(); err != nil {
 		glog.Warningf("%s: failed to delete renamed object %s (new name %s): %v", t, lom, msg.Name, err)
 	}
@@ -0,0 +1,387 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archidx"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xs"
+)
+
+// Existing archives (shards) - one archived file at a time:
+// - APPEND to TAR is done in place (see appendArchObjInfo.appendToArch);
+// - APPEND to all other formats, as well as REPLACE and DELETE, rewrite the archive
+//   into a workfile that then replaces the original (see rewrite below);
+// - either way, the modified archive gets versioned and checksummed the same way
+//   PUT does it (see target.commitWorkfile);
+// - EXTRACT stores archived files as individual objects in a destination bucket.
+
+// in addition to apc.QparamArchmode enum
+const archModeDelete = "delete"
+
+type (
+	extractCtx struct {
+		t       *target
+		msg     *cmn.ExtractArchMsg
+		lom     *cluster.LOM // source archive
+		bckTo   *cluster.Bck
+		smap    *smapX
+		started time.Time
+		cnt     int64
+	}
+)
+
+func errExistsInArch(filename, archname string) error {
+	return cmn.NewErrHTTP(nil, fmt.Sprintf("file %q already exists in archive %q (use %s=%s to replace)",
+		filename, archname, apc.QparamArchmode, apc.ArchReplace), http.StatusConflict)
+}
+
+//////////////////////////////////////////
+// REWRITE: add, replace, delete (file) //
+//////////////////////////////////////////
+
+// copy the archive into a workfile while skipping (DELETE) or substituting in place (REPLACE)
+// the named file; when not found, add the new file at the end (APPEND, REPLACE)
+// NOTE: caller must hold exclusive lock
+func (aaoi *appendArchObjInfo) rewrite() (errCode int, err error) {
+	var (
+		lom      = aaoi.lom
+		archname = lom.FullName()
+		found    bool
+		arch     *xs.ArchStream
+		wfh      *os.File
+		cksum    cos.CksumHashSize
+	)
+	fh, err := os.Open(lom.FQN)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	mime, err := archMime(aaoi.t, aaoi.mime, lom.ObjName, fh)
+	if err != nil {
+		cos.Close(fh)
+		return http.StatusBadRequest, err
+	}
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileEditArch)
+	if wfh, err = cos.CreateFile(workFQN); err != nil {
+		cos.Close(fh)
+		return http.StatusInternalServerError, err
+	}
+	cksum.Init(lom.CksumType())
+	if arch, err = xs.NewArchStream(mime, cos.NewWriterMulti(wfh, &cksum)); err != nil {
+		errCode = http.StatusBadRequest
+		goto rerr
+	}
+	err = iterArch(fh, mime, lom.SizeBytes(), func(filename string, oah cmn.ObjAttrsHolder, reader io.Reader) error {
+		if filename != aaoi.filename && !archNamesEq(filename, aaoi.filename) {
+			return arch.Write(filename, oah, reader)
+		}
+		found = true
+		switch aaoi.mode {
+		case archModeDelete:
+			return nil
+		case apc.ArchReplace:
+			return arch.Write(filename, aaoi.attrs(), aaoi.r)
+		default:
+			return errExistsInArch(aaoi.filename, archname)
+		}
+	})
+	if err == nil && !found {
+		if aaoi.mode == archModeDelete {
+			err = notFoundInArch(aaoi.filename, archname)
+		} else {
+			err = arch.Write(aaoi.filename, aaoi.attrs(), aaoi.r)
+		}
+	}
+	arch.Fini()
+	if err != nil {
+		goto rerr
+	}
+	cos.Close(fh)
+	if err = wfh.Close(); err != nil {
+		wfh = nil
+		goto rerr
+	}
+	cksum.Finalize()
+	if err = aaoi.setAttrs(workFQN, &cksum.CksumHash); err == nil {
+		lom.CowSnap() // (bucket snapshot in progress)
+		if err = aaoi.finalize(workFQN); err == nil {
+			return 0, nil
+		}
+	}
+	if errRm := cos.RemoveFile(workFQN); errRm != nil {
+		glog.Errorf("nested error: %v --> %v", err, errRm)
+	}
+	return aaoi.errCode(err), err
+rerr:
+	cos.Close(fh)
+	if wfh != nil {
+		cos.Close(wfh)
+	}
+	if errRm := cos.RemoveFile(workFQN); errRm != nil {
+		glog.Errorf("nested error: %v --> %v", err, errRm)
+	}
+	if errCode == 0 {
+		errCode = aaoi.errCode(err)
+	}
+	return errCode, err
+}
+
+func (aaoi *appendArchObjInfo) attrs() cmn.ObjAttrsHolder {
+	return &cmn.ObjAttrs{Size: aaoi.size, Atime: aaoi.started.UnixNano()}
+}
+
+// modified archive: new size and checksum (when not provided, computed over the entire `fqn`)
+func (aaoi *appendArchObjInfo) setAttrs(fqn string, cksum *cos.CksumHash) error {
+	lom := aaoi.lom
+	st, err := os.Stat(fqn)
+	if err != nil {
+		return err
+	}
+	if cksum == nil {
+		fh, err := os.Open(fqn)
+		if err != nil {
+			return err
+		}
+		_, cksum, err = cos.CopyAndChecksum(io.Discard, fh, nil, lom.CksumType())
+		cos.Close(fh)
+		if err != nil {
+			return err
+		}
+	}
+	lom.SetSize(st.Size())
+	if cksum == nil || cksum.Type() == cos.ChecksumNone {
+		lom.SetCksum(cos.NewCksum(cos.ChecksumNone, ""))
+	} else {
+		lom.SetCksum(cksum.Clone())
+	}
+	lom.SetAtimeUnix(aaoi.started.UnixNano())
+	return nil
+}
+
+func (*appendArchObjInfo) errCode(err error) int {
+	switch {
+	case cmn.IsErrCapacityExceeded(err):
+		return http.StatusInsufficientStorage
+	case cmn.IsErrNotFound(err):
+		return http.StatusNotFound
+	}
+	if herr, ok := err.(*cmn.ErrHTTP); ok {
+		return herr.Status
+	}
+	return http.StatusInternalServerError
+}
+
+// rebuild the index of the modified archive, if indexed (see cmn/archidx)
+func (aaoi *appendArchObjInfo) indexArch() {
+	if !cmn.Features.IsSet(feat.IndexArchivesOnPut) {
+		return
+	}
+	if mime, err := cos.Mime("", aaoi.lom.ObjName); err != nil || !archidx.Indexable(mime) {
+		return
+	}
+	if _, err := aaoi.lom.BuildArchIndex(); err != nil {
+		glog.Errorf("%s: failed to index %s: %v", aaoi.t, aaoi.lom, err)
+	}
+}
+
+// DELETE /v1/objects/<bucket-name>/<object-name>?archpath=<filename>
+func (t *target) delFromArch(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, filename string) {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			t.writeErr(w, r, err)
+		}
+		return
+	}
+	aaoi := &appendArchObjInfo{
+		started:  time.Now(),
+		t:        t,
+		lom:      lom,
+		filename: filename,
+		mime:     r.URL.Query().Get(apc.QparamArchmime),
+		mode:     archModeDelete,
+	}
+	if errCode, err := aaoi.rewrite(); err != nil {
+		t.writeErr(w, r, err, errCode)
+	}
+}
+
+/////////////
+// EXTRACT //
+/////////////
+
+// POST /v1/objects/<bucket-name>/<object-name> { action: extract-arch } (redirected)
+// responds with the number of extracted files
+func (t *target) extractArch(w http.ResponseWriter, r *http.Request, msg *apc.ActionMsg) {
+	apireq := apiReqAlloc(2, apc.URLPathObjects.L, false)
+	defer apiReqFree(apireq)
+	if err := t.parseReq(w, r, apireq); err != nil {
+		return
+	}
+	xmsg := &cmn.ExtractArchMsg{}
+	if err := cos.MorphMarshal(msg.Value, xmsg); err != nil {
+		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+		return
+	}
+	if err := xmsg.Validate(); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	lom := cluster.AllocLOM(apireq.items[1])
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(apireq.bck.Bucket()); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	bckTo := lom.Bck()
+	if !xmsg.ToBck.IsEmpty() {
+		bckTo = cluster.CloneBck(&xmsg.ToBck)
+		if err := bckTo.Init(t.owner.bmd); err != nil {
+			if cmn.IsErrRemoteBckNotFound(err) {
+				t.BMDVersionFixup(r)
+				err = bckTo.Init(t.owner.bmd)
+			}
+			if err != nil {
+				t.writeErr(w, r, err)
+				return
+			}
+		}
+	}
+	ex := &extractCtx{t: t, msg: xmsg, lom: lom, bckTo: bckTo, smap: t.owner.smap.get(), started: time.Now()}
+	if errCode, err := ex.do(); err != nil {
+		t.writeErr(w, r, err, errCode)
+		return
+	}
+	t.writeJSON(w, r, ex.cnt, msg.Action)
+}
+
+func (ex *extractCtx) do() (int, error) {
+	lom := ex.lom
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return http.StatusNotFound, err
+		}
+		return http.StatusInternalServerError, err
+	}
+	fh, err := os.Open(lom.FQN)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	defer cos.Close(fh)
+	mime, err := archMime(ex.t, ex.msg.Mime, lom.ObjName, fh)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err := iterArch(fh, mime, lom.SizeBytes(), ex.extract); err != nil {
+		if cmn.IsErrCapacityExceeded(err) {
+			return http.StatusInsufficientStorage, err
+		}
+		return http.StatusInternalServerError, err
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: %s %s => %s/%s (%d files) in %v", ex.t, apc.ActExtractArch, lom, ex.bckTo, ex.msg.Prefix,
+			ex.cnt, time.Since(ex.started))
+	}
+	return 0, nil
+}
+
+func (ex *extractCtx) extract(filename string, oah cmn.ObjAttrsHolder, reader io.Reader) (err error) {
+	if !ex.msg.Selected(filename) {
+		return nil
+	}
+	objName, err := ex.msg.ObjName(filename)
+	if err != nil {
+		return ex.fail(filename, err)
+	}
+	lom := cluster.AllocLOM(objName)
+	if err = lom.InitBck(ex.bckTo.Bucket()); err != nil {
+		goto ret
+	}
+	if lom.Uname() == ex.lom.Uname() {
+		err = fmt.Errorf("cannot overwrite the archive %s with its own (archived) file %q", ex.lom, filename)
+		goto ret
+	}
+	if tsi, errV := cluster.HrwTarget(lom.Uname(), &ex.smap.Smap); errV != nil {
+		err = errV
+	} else if tsi.ID() == ex.t.SID() {
+		params := &cluster.PutObjectParams{
+			Reader:  io.NopCloser(reader),
+			Atime:   ex.started,
+			WorkTag: fs.WorkfileExtractArch,
+			OWT:     cmn.OwtPut,
+		}
+		err = ex.t.PutObject(lom, params)
+	} else {
+		err = ex.put(tsi, objName, oah, reader)
+	}
+ret:
+	cluster.FreeLOM(lom)
+	if err != nil {
+		return ex.fail(filename, err)
+	}
+	ex.cnt++
+	return
+}
+
+func (ex *extractCtx) fail(filename string, err error) error {
+	if !ex.msg.ContinueOnError {
+		return err
+	}
+	glog.Warningf("%s: %s %s: failed to extract %q: %v", ex.t, apc.ActExtractArch, ex.lom, filename, err)
+	return nil
+}
+
+// PUT archived file => (HRW) destination target
+func (ex *extractCtx) put(tsi *cluster.Snode, objName string, oah cmn.ObjAttrsHolder, reader io.Reader) error {
+	hdr := make(http.Header, 2)
+	hdr.Set(apc.HdrT2TPutterID, ex.t.SID())
+	query := ex.bckTo.AddToQuery(nil)
+	query.Set(apc.QparamOWT, cmn.OwtPut.ToS())
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodPut
+		reqArgs.Base = tsi.URL(cmn.NetIntraData)
+		reqArgs.Path = apc.URLPathObjects.Join(ex.bckTo.Name, objName)
+		reqArgs.Query = query
+		reqArgs.Header = hdr
+		reqArgs.BodyR = io.NopCloser(reader)
+	}
+	req, _, cancel, err := reqArgs.ReqWithTimeout(cmn.GCO.Get().Timeout.SendFile.D())
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	req.ContentLength = oah.SizeBytes()
+	resp, err := ex.t.client.data.Do(req) // nolint:bodyclose // closed below
+	if err != nil {
+		return cmn.NewErrFailedTo(ex.t, "PUT "+ex.bckTo.Name+"/"+objName, tsi, err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(resp.Body)
+		err = cmn.S2HTTPErr(req, string(b), resp.StatusCode)
+	} else {
+		cos.DrainReader(resp.Body)
+	}
+	resp.Body.Close()
+	return err
+}
@@ -0,0 +1,227 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xs"
+)
+
+// (versioned and checksummed - as far as PUT is concerned)
+func addArchTestBucket(tb testing.TB, name string, history int) *cluster.Bck {
+	bck := cluster.NewBck(name, apc.ProviderAIS, cmn.NsGlobal)
+	bmd := t.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.BucketProps{
+		Cksum:      cmn.CksumConf{Type: cos.ChecksumXXHash},
+		Versioning: cmn.VersionConf{Enabled: true, History: history},
+	})
+	t.owner.bmd.putPersist(bmd, nil)
+	tassert.CheckFatal(tb, bck.Init(t.owner.bmd))
+	errs := fs.CreateBucket("test", bck.Bucket(), false /*nilbmd*/)
+	tassert.Fatalf(tb, len(errs) == 0, "failed to create %s: %v", bck, errs)
+	return bck
+}
+
+func createTestArch(tb testing.TB, bck *cluster.Bck, objName string, files map[string]string) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tb, lom.InitBck(bck.Bucket()))
+	mime, err := cos.Mime("", objName)
+	tassert.CheckFatal(tb, err)
+	fh, err := cos.CreateFile(lom.FQN)
+	tassert.CheckFatal(tb, err)
+	arch, err := xs.NewArchStream(mime, fh)
+	tassert.CheckFatal(tb, err)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		oa := &cmn.ObjAttrs{Size: int64(len(files[name])), Atime: time.Now().UnixNano()}
+		tassert.CheckFatal(tb, arch.Write(name, oa, strings.NewReader(files[name])))
+	}
+	arch.Fini()
+	tassert.CheckFatal(tb, fh.Close())
+
+	st, err := os.Stat(lom.FQN)
+	tassert.CheckFatal(tb, err)
+	lom.SetSize(st.Size())
+	_, err = lom.ComputeSetCksum()
+	tassert.CheckFatal(tb, err)
+	lom.SetVersion("1")
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	tassert.CheckFatal(tb, lom.Persist())
+}
+
+// archive op (as in: doAppendArch and delFromArch) followed by validation of the resulting object
+func editTestArch(tb testing.TB, bck *cluster.Bck, objName, mode, filename, content string) (errCode int, err error) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tb, lom.InitBck(bck.Bucket()))
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	tassert.CheckFatal(tb, lom.Load(false /*cache it*/, true /*locked*/))
+	aaoi := &appendArchObjInfo{
+		started:  time.Now(),
+		t:        t,
+		lom:      lom,
+		r:        io.NopCloser(strings.NewReader(content)),
+		size:     int64(len(content)),
+		filename: filename,
+		mode:     mode,
+	}
+	if mode == archModeDelete {
+		return aaoi.rewrite()
+	}
+	return aaoi.appendObject()
+}
+
+// reload and check size, checksum, and version; return archived files
+func readTestArch(tb testing.TB, bck *cluster.Bck, objName, ver string) map[string]string {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tb, lom.InitBck(bck.Bucket()))
+	lom.Uncache(true /*delDirty*/)
+	tassert.CheckFatal(tb, lom.Load(false /*cache it*/, false /*locked*/))
+
+	tassert.Errorf(tb, lom.Version() == ver, "%s: expected version %s, got %s", lom, ver, lom.Version())
+	st, err := os.Stat(lom.FQN)
+	tassert.CheckFatal(tb, err)
+	tassert.Errorf(tb, lom.SizeBytes() == st.Size(), "%s: size %d vs %d", lom, lom.SizeBytes(), st.Size())
+	cksum, err := lom.ComputeCksum(cos.ChecksumXXHash)
+	tassert.CheckFatal(tb, err)
+	tassert.Errorf(tb, !lom.Checksum().IsEmpty() && cksum.Equal(lom.Checksum()), "%s: checksum %s vs computed %s",
+		lom, lom.Checksum(), cksum)
+
+	fh, err := os.Open(lom.FQN)
+	tassert.CheckFatal(tb, err)
+	defer fh.Close()
+	mime, err := cos.Mime("", objName)
+	tassert.CheckFatal(tb, err)
+	files := make(map[string]string, 4)
+	err = iterArch(fh, mime, st.Size(), func(filename string, _ cmn.ObjAttrsHolder, reader io.Reader) error {
+		b, err := io.ReadAll(reader)
+		files[filename] = string(b)
+		return err
+	})
+	tassert.CheckFatal(tb, err)
+	return files
+}
+
+func TestArchRewrite(tt *testing.T) {
+	bck := addArchTestBucket(tt, "arch-rewrite", 0)
+	for _, mime := range []string{cos.ExtTar, cos.ExtTgz, cos.ExtZip} {
+		tt.Run(mime, func(tt *testing.T) {
+			objName := "shard" + mime
+			createTestArch(tt, bck, objName, map[string]string{"a": "aaa", "b": "bbb"})
+
+			_, err := editTestArch(tt, bck, objName, "", "c", "ccc")
+			tassert.CheckFatal(tt, err)
+			files := readTestArch(tt, bck, objName, "2")
+			tassert.Errorf(tt, len(files) == 3 && files["c"] == "ccc", "append: unexpected %v", files)
+
+			_, err = editTestArch(tt, bck, objName, apc.ArchReplace, "a", "new content of a")
+			tassert.CheckFatal(tt, err)
+			files = readTestArch(tt, bck, objName, "3")
+			tassert.Errorf(tt, len(files) == 3 && files["a"] == "new content of a", "replace: unexpected %v", files)
+
+			_, err = editTestArch(tt, bck, objName, archModeDelete, "b", "")
+			tassert.CheckFatal(tt, err)
+			files = readTestArch(tt, bck, objName, "4")
+			tassert.Errorf(tt, len(files) == 2 && files["a"] == "new content of a" && files["c"] == "ccc",
+				"delete: unexpected %v", files)
+
+			// no changes (NOTE: TAR gets appended in place - no checking for duplicates)
+			if mime != cos.ExtTar {
+				errCode, err := editTestArch(tt, bck, objName, apc.ArchAppend, "a", "x")
+				tassert.Errorf(tt, err != nil && errCode == http.StatusConflict, "expected conflict, got %v(%d)", err, errCode)
+			}
+			errCode, err := editTestArch(tt, bck, objName, archModeDelete, "nonexistent", "")
+			tassert.Errorf(tt, err != nil && errCode == http.StatusNotFound, "expected not found, got %v(%d)", err, errCode)
+			files = readTestArch(tt, bck, objName, "4")
+			tassert.Errorf(tt, len(files) == 2, "unexpected %v", files)
+		})
+	}
+}
+
+// TAR, when version history is enabled, gets rewritten as well (rather than appended in place)
+func TestArchRewriteHistory(tt *testing.T) {
+	const objName = "shard.tar"
+	bck := addArchTestBucket(tt, "arch-history", 3)
+	createTestArch(tt, bck, objName, map[string]string{"a": "aaa"})
+
+	_, err := editTestArch(tt, bck, objName, apc.ArchAppend, "b", "bbb")
+	tassert.CheckFatal(tt, err)
+	files := readTestArch(tt, bck, objName, "2")
+	tassert.Errorf(tt, len(files) == 2 && files["b"] == "bbb", "unexpected %v", files)
+
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tt, lom.InitBck(bck.Bucket()))
+	vers, err := lom.ListVersions()
+	tassert.CheckFatal(tt, err)
+	tassert.Fatalf(tt, len(vers) == 1 && vers[0].Ver == "1", "expected previous version 1, got %+v", vers)
+}
+
+// archived names that climb up (or are absolute) must not resolve outside the destination bucket
+func TestArchExtractSlip(tt *testing.T) {
+	const objName = "slip.tar"
+	bck := addArchTestBucket(tt, "arch-extract-slip", 0)
+	createTestArch(tt, bck, objName, map[string]string{
+		"a":                "aaa",
+		"../../slip-up":    "evil",
+		"b/../../slip-bck": "evil",
+		"/tmp/slip-abs":    "evil",
+	})
+	smap := newSmap()
+	smap.addTarget(t.si)
+
+	for _, coer := range []bool{false, true} {
+		lom := cluster.AllocLOM(objName)
+		tassert.CheckFatal(tt, lom.InitBck(bck.Bucket()))
+		ex := &extractCtx{
+			t:       t,
+			msg:     &cmn.ExtractArchMsg{ContinueOnError: coer},
+			lom:     lom,
+			bckTo:   bck,
+			smap:    smap,
+			started: time.Now(),
+		}
+		_, err := ex.do()
+		cluster.FreeLOM(lom)
+		if coer {
+			tassert.CheckFatal(tt, err)
+			tassert.Errorf(tt, ex.cnt == 1, "expected 1 extracted file, got %d", ex.cnt)
+		} else {
+			tassert.Errorf(tt, err != nil, "expected error (invalid archived filename)")
+		}
+	}
+
+	// nothing outside the bucket
+	err := filepath.Walk(testMountpath, func(fqn string, _ os.FileInfo, err error) error {
+		if err == nil && strings.Contains(filepath.Base(fqn), "slip-") {
+			tt.Errorf("unexpected %q", fqn)
+		}
+		return err
+	})
+	tassert.CheckFatal(tt, err)
+	_, err = os.Stat("/tmp/slip-abs")
+	tassert.Errorf(tt, os.IsNotExist(err), "unexpected /tmp/slip-abs (%v)", err)
+}
@@ -0,0 +1,319 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xs"
+)
+
+// GET batch (see cmn.GetBatchMsg and p.getBatch): the designated target (DT) gets
+// the requested objects - locally or from other targets - and streams them back
+// as a single archive formatted by xs.ArchStream.
+// - up to `gbWorkers` objects are being fetched concurrently;
+// - at most `gbWindow` fetched objects are being held at any point in time -
+//   in particular, when waiting for the next in-order object;
+// - each fetched object is held in memory up to `gbSpillLimit` bytes (or less, under
+//   memory pressure) and spills to a work file on a local mountpath otherwise - that is,
+//   the memory used by a single GET batch is bounded by gbWindow * gbSpillLimit;
+// - an error that occurs before anything's been written is returned to the client;
+//   otherwise (and unless ContinueOnError), the response is terminated with
+//   the archive left incomplete (e.g., TAR with no trailer).
+
+const (
+	gbWorkers    = 16
+	gbWindow     = 2 * gbWorkers
+	gbSpillLimit = 4 * cos.MiB
+)
+
+type (
+	gbItem struct {
+		sgl   *memsys.SpillSGL // nil when failed to init
+		lom   *cluster.LOM     // (freed along with the item - the error may reference it)
+		err   error
+		idx   int
+		code  int
+		atime int64
+	}
+	gbCtx struct {
+		t    *target
+		msg  *cmn.GetBatchMsg
+		bcks map[string]*cluster.Bck // by bucket uname
+		smap *smapX
+		code int // status of the (first) failure
+		// pipeline
+		workCh chan int
+		doneCh chan *gbItem
+		sema   chan struct{}
+		stopCh chan struct{}
+		wg     sync.WaitGroup
+	}
+)
+
+func (t *target) getBatch(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *aisMsg) {
+	gbmsg := &cmn.GetBatchMsg{}
+	if err := cos.MorphMarshal(msg.Value, gbmsg); err != nil {
+		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+		return
+	}
+	if err := gbmsg.Validate(); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	gb := &gbCtx{t: t, msg: gbmsg, bcks: make(map[string]*cluster.Bck, 2), smap: t.owner.smap.get()}
+	for i := range gbmsg.In {
+		in := &gbmsg.In[i]
+		if in.Bck.IsEmpty() {
+			in.Bck = *bck.Bucket()
+		}
+		uname := in.Bck.MakeUname("")
+		if _, ok := gb.bcks[uname]; ok {
+			continue
+		}
+		b := cluster.CloneBck(&in.Bck)
+		if err := b.Init(t.owner.bmd); err != nil {
+			if cmn.IsErrRemoteBckNotFound(err) {
+				t.BMDVersionFixup(r)
+				err = b.Init(t.owner.bmd)
+			}
+			if err != nil {
+				t.writeErr(w, r, err)
+				return
+			}
+		}
+		gb.bcks[uname] = b
+	}
+	gb.run(w, r)
+}
+
+func (gb *gbCtx) run(w http.ResponseWriter, r *http.Request) {
+	var (
+		started bool
+		err     error
+		n       = len(gb.msg.In)
+		pending = make(map[int]*gbItem, gbWindow)
+		next    int
+		begin   = mono.NanoTime()
+	)
+	w.Header().Set(cos.HdrContentType, cos.ContentBinary)
+	arch, err := xs.NewArchStream(gb.msg.Mime, w)
+	if err != nil {
+		gb.t.writeErr(w, r, err)
+		return
+	}
+
+	gb.workCh = make(chan int)
+	gb.doneCh = make(chan *gbItem, gbWindow)
+	gb.sema = make(chan struct{}, gbWindow)
+	gb.stopCh = make(chan struct{})
+	gb.wg.Add(gbWorkers)
+	for i := 0; i < gbWorkers; i++ {
+		go gb.worker()
+	}
+	go gb.dispatch()
+	go func() {
+		gb.wg.Wait()
+		close(gb.doneCh)
+	}()
+
+	// write (in order, unless unordered)
+	for item := range gb.doneCh {
+		if err != nil { // aborted: drain
+			gb.free(item)
+			continue
+		}
+		if gb.msg.Unordered {
+			err = gb.write(arch, item, &started)
+		} else {
+			pending[item.idx] = item
+			for err == nil {
+				item, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				err = gb.write(arch, item, &started)
+			}
+		}
+		if err != nil {
+			close(gb.stopCh)
+			for idx, item := range pending {
+				delete(pending, idx)
+				gb.free(item)
+			}
+		}
+	}
+	if err != nil {
+		if started {
+			glog.Errorf("%s: %s(%d) failed: %v", gb.t, apc.ActGetBatch, n, err)
+		} else {
+			code := gb.code
+			if code == 0 {
+				code = http.StatusInternalServerError
+			}
+			gb.t.writeErr(w, r, err, code)
+		}
+		return
+	}
+	arch.Fini()
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: %s(%d) in %v", gb.t, apc.ActGetBatch, n, time.Duration(mono.SinceNano(begin)))
+	}
+}
+
+func (gb *gbCtx) dispatch() {
+	defer close(gb.workCh)
+	for i := range gb.msg.In {
+		select {
+		case gb.sema <- struct{}{}:
+		case <-gb.stopCh:
+			return
+		}
+		select {
+		case gb.workCh <- i:
+		case <-gb.stopCh:
+			<-gb.sema // (never dispatched)
+			return
+		}
+	}
+}
+
+func (gb *gbCtx) worker() {
+	defer gb.wg.Done()
+	for idx := range gb.workCh {
+		gb.doneCh <- gb.fetch(idx)
+	}
+}
+
+func (gb *gbCtx) fetch(idx int) (item *gbItem) {
+	var (
+		in  = &gb.msg.In[idx]
+		bck = gb.bcks[in.Bck.MakeUname("")]
+		lom = cluster.AllocLOM(in.ObjName)
+	)
+	item = &gbItem{idx: idx, lom: lom}
+	if item.err = lom.InitBck(bck.Bucket()); item.err != nil {
+		return
+	}
+	item.sgl = gb.t.gmm.NewSpillSGL(0, gbSpillLimit, fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileGetBatch))
+	tsi, err := cluster.HrwTarget(lom.Uname(), &gb.smap.Smap)
+	if err != nil {
+		item.err = err
+	} else if tsi.ID() == gb.t.SID() {
+		item.lom = gb.getLocal(lom, in, item)
+	} else {
+		gb.getRemote(tsi, bck, in, item)
+	}
+	return
+}
+
+func (gb *gbCtx) getLocal(lom *cluster.LOM, in *cmn.GetBatchIn, item *gbItem) *cluster.LOM {
+	goi := allocGetObjInfo()
+	{
+		goi.atime = time.Now().UnixNano()
+		goi.nanotim = mono.NanoTime()
+		goi.t = gb.t
+		goi.lom = lom
+		goi.w = item.sgl
+		goi.ctx = context.Background()
+		goi.archive = archiveQuery{filename: in.ArchPath}
+	}
+	item.code, item.err = goi.getObject()
+	item.atime = goi.lom.AtimeUnix()
+	lom = goi.lom
+	freeGetObjInfo(goi)
+	return lom
+}
+
+func (gb *gbCtx) getRemote(tsi *cluster.Snode, bck *cluster.Bck, in *cmn.GetBatchIn, item *gbItem) {
+	query := bck.AddToQuery(nil)
+	if in.ArchPath != "" {
+		query.Set(apc.QparamArchpath, in.ArchPath)
+	}
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodGet
+		reqArgs.Base = tsi.URL(cmn.NetIntraData)
+		reqArgs.Header = http.Header{
+			apc.HdrCallerID:   []string{gb.t.SID()},
+			apc.HdrCallerName: []string{gb.t.callerName()},
+		}
+		reqArgs.Path = apc.URLPathObjects.Join(bck.Name, in.ObjName)
+		reqArgs.Query = query
+	}
+	req, _, cancel, err := reqArgs.ReqWithTimeout(cmn.GCO.Get().Timeout.SendFile.D())
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		item.err = err
+		return
+	}
+	defer cancel()
+	resp, err := gb.t.client.data.Do(req) // nolint:bodyclose // closed below
+	if err != nil {
+		item.err = err
+		return
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(resp.Body)
+		item.err, item.code = cmn.S2HTTPErr(req, string(b), resp.StatusCode), resp.StatusCode
+	} else {
+		oa := cmn.ObjAttrs{}
+		oa.FromHeader(resp.Header)
+		item.atime = oa.Atime
+		_, item.err = io.Copy(item.sgl, resp.Body)
+	}
+	resp.Body.Close()
+}
+
+func (gb *gbCtx) write(arch *xs.ArchStream, item *gbItem, started *bool) (err error) {
+	var (
+		in       = &gb.msg.In[item.idx]
+		fullname = gb.msg.FullName(in)
+		oa       = cmn.ObjAttrs{Atime: item.atime}
+		reader   io.Reader
+	)
+	if item.err != nil {
+		if !gb.msg.ContinueOnError {
+			err, gb.code = item.err, item.code
+			gb.free(item)
+			return
+		}
+		glog.Warningf("%s: %s %s: %v", gb.t, apc.ActGetBatch, fullname, item.err)
+		fullname = filepath.Join(cmn.GetBatchMissingDir, fullname)
+		oa.Atime, reader = time.Now().UnixNano(), cos.NopReader(0)
+	} else {
+		oa.Size, reader = item.sgl.Size(), item.sgl
+	}
+	if oa.Atime == 0 {
+		oa.Atime = time.Now().UnixNano()
+	}
+	*started = true
+	err = arch.Write(fullname, &oa, reader)
+	gb.free(item)
+	return
+}
+
+func (gb *gbCtx) free(item *gbItem) {
+	if item.sgl != nil {
+		item.sgl.Free() // (removes the work file if spilled)
+	}
+	cluster.FreeLOM(item.lom)
+	<-gb.sema
+}
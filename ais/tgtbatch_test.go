@@ -0,0 +1,179 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// (plain objects are not archives)
+const failBatchTestIn = "nonexistent"
+
+func putBatchTestObj(tb testing.TB, bck *cluster.Bck, objName string, content []byte) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tb, lom.InitBck(bck.Bucket()))
+	fh, err := cos.CreateFile(lom.FQN)
+	tassert.CheckFatal(tb, err)
+	_, err = fh.Write(content)
+	tassert.CheckFatal(tb, err)
+	tassert.CheckFatal(tb, fh.Close())
+	lom.SetSize(int64(len(content)))
+	_, err = lom.ComputeSetCksum()
+	tassert.CheckFatal(tb, err)
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	tassert.CheckFatal(tb, lom.Persist())
+}
+
+// GET batch of `names` (all local) and return the resulting archive (and the context for checking);
+// the object named `failed` (if any) fails to GET - see failBatchTestIn
+func runTestBatch(tb testing.TB, bck *cluster.Bck, names []string, failed string,
+	coer bool) (*gbCtx, *httptest.ResponseRecorder) {
+	smap := newSmap()
+	smap.addTarget(t.si)
+	msg := &cmn.GetBatchMsg{ContinueOnError: coer}
+	for _, name := range names {
+		in := cmn.GetBatchIn{Bck: *bck.Bucket(), ObjName: name}
+		if name == failed {
+			in.ArchPath = failBatchTestIn
+		}
+		msg.In = append(msg.In, in)
+	}
+	tassert.CheckFatal(tb, msg.Validate())
+	gb := &gbCtx{t: t, msg: msg, bcks: map[string]*cluster.Bck{bck.MakeUname(""): bck}, smap: smap}
+	w := httptest.NewRecorder()
+	gb.run(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	return gb, w
+}
+
+// return archived files in the order of appearance; stop at the first error (incomplete archive)
+func readTestBatch(body []byte) (names []string, files map[string]string, err error) {
+	files = make(map[string]string, 8)
+	tr := tar.NewReader(bytes.NewReader(body))
+	for {
+		var hdr *tar.Header
+		if hdr, err = tr.Next(); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+		var b []byte
+		if b, err = io.ReadAll(tr); err != nil {
+			return
+		}
+		names = append(names, hdr.Name)
+		files[hdr.Name] = string(b)
+	}
+}
+
+func genBatchTestNames(prefix string, num int) (names []string) {
+	names = make([]string, 0, num)
+	for i := 0; i < num; i++ {
+		names = append(names, fmt.Sprintf("%s-%03d", prefix, i))
+	}
+	return
+}
+
+func TestGetBatchOrder(tt *testing.T) {
+	const (
+		num   = 3 * gbWindow
+		large = 7 // greater than gbSpillLimit (spills to a work file)
+	)
+	bck := addArchTestBucket(tt, "get-batch-order", 0)
+	names := genBatchTestNames("obj", num)
+	for i, name := range names {
+		content := []byte(name)
+		if i == large {
+			content = bytes.Repeat([]byte("x"), gbSpillLimit+cos.KiB)
+		}
+		putBatchTestObj(tt, bck, name, content)
+	}
+
+	gb, w := runTestBatch(tt, bck, names, "", false)
+	tassert.Fatalf(tt, w.Code == http.StatusOK, "expected %d, got %d", http.StatusOK, w.Code)
+	tassert.Errorf(tt, len(gb.sema) == 0, "sema: %d outstanding", len(gb.sema))
+	for _, mi := range fs.GetAvail() {
+		workfiles, _ := filepath.Glob(filepath.Join(mi.MakePathCT(bck.Bucket(), fs.WorkfileType), "*"))
+		tassert.Errorf(tt, len(workfiles) == 0, "unexpected work files %v", workfiles)
+	}
+
+	out, files, err := readTestBatch(w.Body.Bytes())
+	tassert.CheckFatal(tt, err)
+	tassert.Fatalf(tt, len(out) == num, "expected %d files, got %d", num, len(out))
+	for i, name := range names {
+		fullname := filepath.Join(bck.Name, name)
+		tassert.Errorf(tt, out[i] == fullname, "[%d]: expected %q, got %q", i, fullname, out[i])
+		if i == large {
+			tassert.Errorf(tt, len(files[fullname]) == gbSpillLimit+cos.KiB, "%s: wrong size %d",
+				fullname, len(files[fullname]))
+		} else {
+			tassert.Errorf(tt, files[fullname] == name, "%s: wrong content %q", fullname, files[fullname])
+		}
+	}
+}
+
+func TestGetBatchAbort(tt *testing.T) {
+	const num = 3 * gbWindow
+	bck := addArchTestBucket(tt, "get-batch-abort", 0)
+	names := genBatchTestNames("obj", num)
+	for _, name := range names {
+		putBatchTestObj(tt, bck, name, []byte(name))
+	}
+
+	// nothing written yet: the client gets the error
+	gb, w := runTestBatch(tt, bck, names, names[0], false)
+	tassert.Errorf(tt, w.Code >= http.StatusBadRequest, "expected error status, got %d", w.Code)
+	tassert.Errorf(tt, len(gb.sema) == 0, "sema: %d outstanding", len(gb.sema))
+
+	// in the middle: incomplete archive, with all the fetched (and pending) objects drained
+	idx := gbWindow + 1
+	gb, w = runTestBatch(tt, bck, names, names[idx], false)
+	tassert.Errorf(tt, w.Code == http.StatusOK, "expected %d, got %d", http.StatusOK, w.Code)
+	tassert.Errorf(tt, len(gb.sema) == 0, "sema: %d outstanding", len(gb.sema))
+	out, _, _ := readTestBatch(w.Body.Bytes())
+	tassert.Errorf(tt, len(out) == idx, "expected %d files prior to the failure, got %d", idx, len(out))
+}
+
+func TestGetBatchContinueOnError(tt *testing.T) {
+	bck := addArchTestBucket(tt, "get-batch-coer", 0)
+	names := genBatchTestNames("obj", 5)
+	for _, name := range names {
+		putBatchTestObj(tt, bck, name, []byte(name))
+	}
+	failed := names[2]
+
+	gb, w := runTestBatch(tt, bck, names, failed, true)
+	tassert.Fatalf(tt, w.Code == http.StatusOK, "expected %d, got %d", http.StatusOK, w.Code)
+	tassert.Errorf(tt, len(gb.sema) == 0, "sema: %d outstanding", len(gb.sema))
+
+	out, files, err := readTestBatch(w.Body.Bytes())
+	tassert.CheckFatal(tt, err)
+	tassert.Fatalf(tt, len(out) == len(names), "expected %d files, got %d", len(names), len(out))
+	for i, name := range names {
+		fullname := filepath.Join(bck.Name, name)
+		if name == failed {
+			fullname = filepath.Join(cmn.GetBatchMissingDir, fullname, failBatchTestIn)
+			tassert.Errorf(tt, files[fullname] == "", "%s: expected empty, got %q", fullname, files[fullname])
+		} else {
+			tassert.Errorf(tt, files[fullname] == name, "%s: wrong content %q", fullname, files[fullname])
+		}
+		tassert.Errorf(tt, out[i] == fullname, "[%d]: expected %q, got %q", i, fullname, out[i])
+	}
+}
@@ -54,6 +54,7 @@ func (t *target) joinCluster(action string, primaryURLs ...string) (status int,
 	if len(res.bytes) == 0 {
 		return
 	}
+	t.regstate.running.Store(true)
 	err = t.recvCluMetaBytes(action, res.bytes, "")
 	return
 }
@@ -908,6 +909,10 @@ func (t *target) metasyncHandlerPost(w http.ResponseWriter, r *http.Request) {
 
 // GET /v1/health (apc.Health)
 func (t *target) healthHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if responded := t.healthDetailed(w, r, query, t.healthComps); responded {
+		return
+	}
 	if t.regstate.disabled.Load() && daemon.cli.target.standby {
 		glog.Warningf("[health] %s: standing by...", t.si)
 	} else if !t.NodeStarted() {
@@ -918,7 +923,6 @@ func (t *target) healthHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// cluster info piggy-back
-	query := r.URL.Query()
 	getCii := cos.IsParseBool(query.Get(apc.QparamClusterInfo))
 	if getCii {
 		debug.Assert(!query.Has(apc.QparamRebStatus))
@@ -1007,9 +1011,10 @@ func (t *target) enable() error {
 // HeadObj* where target acts as a client
 //
 
-// HeadObjT2T checks with a given target to see if it has the object.
+// HeadObjT2T checks with a given target to see if it has the object;
+// errCode is the HTTP status (zero if the target didn't respond, e.g. timed out)
 // (compare with api.HeadObject)
-func (t *target) HeadObjT2T(lom *cluster.LOM, tsi *cluster.Snode) (ok bool) {
+func (t *target) HeadObjT2T(lom *cluster.LOM, tsi *cluster.Snode) (ok bool, errCode int) {
 	q := lom.Bck().AddToQuery(nil)
 	q.Set(apc.QparamSilent, "true")
 	q.Set(apc.QparamHeadObj, strconv.Itoa(apc.HeadObjAvoidRemote))
@@ -1029,7 +1034,37 @@ func (t *target) HeadObjT2T(lom *cluster.LOM, tsi *cluster.Snode) (ok bool) {
 		cargs.timeout = cmn.Timeout.CplaneOperation()
 	}
 	res := t.call(cargs)
-	ok = res.err == nil
+	ok, errCode = res.err == nil, res.status
+	freeCargs(cargs)
+	freeCR(res)
+	return
+}
+
+// headObjAttrsT2T returns object attributes as reported by a given target
+// (including cold HEAD if the bucket is remote and the object is not present)
+func (t *target) headObjAttrsT2T(lom *cluster.LOM, tsi *cluster.Snode) (oa *cmn.ObjAttrs, err error) {
+	q := lom.Bck().AddToQuery(nil)
+	q.Set(apc.QparamSilent, "true")
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodHead,
+			Header: http.Header{
+				apc.HdrCallerID:   []string{t.SID()},
+				apc.HdrCallerName: []string{t.callerName()},
+			},
+			Base:  tsi.URL(cmn.NetIntraControl),
+			Path:  apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName),
+			Query: q,
+		}
+		cargs.timeout = cmn.Timeout.CplaneOperation()
+	}
+	res := t.call(cargs)
+	if err = res.err; err == nil {
+		oa = &cmn.ObjAttrs{}
+		oa.Cksum = oa.FromHeader(res.header)
+	}
 	freeCargs(cargs)
 	freeCR(res)
 	return
@@ -42,9 +42,28 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	downloaderXact := xctn.(*downloader.Downloader)
 	switch r.Method {
 	case http.MethodPost:
-		if _, err := t.checkRESTItems(w, r, 0, false, apc.URLPathDownload.L); err != nil {
+		items, err := t.checkRESTItems(w, r, 0, false, apc.URLPathDownload.L)
+		if err != nil {
 			return
 		}
+		if len(items) > 0 {
+			if items[0] != apc.Retry {
+				t.writeErrAct(w, r, items[0])
+				return
+			}
+			payload := &downloader.DlAdminBody{}
+			if err := cmn.ReadJSON(w, r, payload); err != nil {
+				return
+			}
+			if err := payload.Validate(true /*requireID*/); err != nil {
+				debug.Assert(false)
+				t.writeErr(w, r, err)
+				return
+			}
+			notif := t.newDownloadNotif(downloader.DownloadProgressInterval)
+			response, statusCode, respErr = downloaderXact.RetryJob(payload.ID, notif)
+			break
+		}
 		var (
 			uuid             = r.URL.Query().Get(apc.QparamUUID)
 			dlb              = downloader.DlBody{}
@@ -90,15 +109,7 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 			glog.Infof("Downloading: %s", dlJob.ID())
 		}
 
-		dlJob.AddNotif(&downloader.NotifDownload{
-			NotifBase: nl.NotifBase{
-				When:     cluster.UponProgress,
-				Interval: progressInterval,
-				Dsts:     []string{equalIC},
-				F:        t.callerNotifyFin,
-				P:        t.callerNotifyProgress,
-			},
-		}, dlJob)
+		dlJob.AddNotif(t.newDownloadNotif(progressInterval), dlJob)
 		response, statusCode, respErr = downloaderXact.Download(dlJob)
 	case http.MethodGet:
 		if _, err := t.checkRESTItems(w, r, 0, false, apc.URLPathDownload.L); err != nil {
@@ -169,3 +180,15 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+func (t *target) newDownloadNotif(progressInterval time.Duration) *downloader.NotifDownload {
+	return &downloader.NotifDownload{
+		NotifBase: nl.NotifBase{
+			When:     cluster.UponProgress,
+			Interval: progressInterval,
+			Dsts:     []string{equalIC},
+			F:        t.callerNotifyFin,
+			P:        t.callerNotifyProgress,
+		},
+	}
+}
@@ -117,6 +117,10 @@ func (t *target) CopyObject(lom *cluster.LOM, params *cluster.CopyObjectParams,
 	if params.ObjNameTo != "" {
 		objNameTo = params.ObjNameTo
 	}
+	if params.Sync && coi.synced(lom, objNameTo) {
+		freeCopyObjInfo(coi)
+		return
+	}
 	if params.DP != nil { // NOTE: w/ transformation
 		return coi.copyReader(lom, objNameTo)
 	}
@@ -296,8 +300,10 @@ func (t *target) promoteLocal(params *cluster.PromoteParams, lom *cluster.LOM) (
 func (t *target) promoteRemote(params *cluster.PromoteParams, lom *cluster.LOM, tsi *cluster.Snode) error {
 	lom.FQN = params.SrcFQN
 	// when not overwriting check w/ remote target first (and separately)
-	if !params.OverwriteDst && t.HeadObjT2T(lom, tsi) {
-		return nil
+	if !params.OverwriteDst {
+		if ok, _ := t.HeadObjT2T(lom, tsi); ok {
+			return nil
+		}
 	}
 	coi := allocCopyObjInfo()
 	{
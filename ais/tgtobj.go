@@ -23,8 +23,10 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archidx"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
@@ -123,6 +125,7 @@ type (
 		size     int64
 		filename string // path inside an archive
 		mime     string // archive type
+		mode     string // apc.QparamArchmode enum (and archModeDelete)
 	}
 )
 
@@ -228,9 +231,31 @@ func (poi *putObjInfo) finalize() (errCode int, err error) {
 		}
 	}
 	poi.t.putMirror(poi.lom)
+	if cmn.Features.IsSet(feat.IndexArchivesOnPut) {
+		poi.indexArch()
+	}
 	return
 }
 
+// build archive index (see cmn/archidx) - failure to do so does not fail the PUT
+func (poi *putObjInfo) indexArch() {
+	switch poi.owt {
+	case cmn.OwtPut, cmn.OwtMigrate, cmn.OwtPromote, cmn.OwtFinalize:
+	default:
+		return // not indexing cold-GET(s)
+	}
+	lom := poi.lom
+	if mime, err := cos.Mime("", lom.ObjName); err != nil || !archidx.Indexable(mime) {
+		return
+	}
+	lom.Lock(false)
+	_, err := lom.BuildArchIndex()
+	lom.Unlock(false)
+	if err != nil {
+		glog.Errorf("%s: failed to index %s: %v", poi.t, lom, err)
+	}
+}
+
 // poi.workFQN => LOM
 func (poi *putObjInfo) fini() (errCode int, err error) {
 	var (
@@ -279,10 +304,41 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 		defer lom.Unlock(true)
 	}
 
-	// ais versioning
-	if bck.IsAIS() && lom.VersionConf().Enabled {
-		if poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote {
-			if poi.skipVC {
+	if bck.IsAIS() {
+		lom.CowSnap() // (bucket snapshot in progress)
+	}
+	if err = poi.t.commitWorkfile(lom, poi.workFQN, poi.owt, poi.skipVC); err != nil {
+		return
+	}
+	if lom.AtimeUnix() == 0 {
+		lom.SetAtimeUnix(poi.atime.UnixNano())
+		debug.Assert(lom.AtimeUnix() != 0)
+	}
+	err = lom.Persist()
+	return
+}
+
+// via backend.PutObj()
+// workfile => object, with ais versioning and version history (if enabled);
+// old copies (if any) get removed; exclusive lock is required
+func (t *target) commitWorkfile(lom *cluster.LOM, workFQN string, owt cmn.OWT, skipVC bool) (err error) {
+	var (
+		hfqn    string
+		history bool
+	)
+	if lom.Bck().IsAIS() && lom.VersionConf().Enabled {
+		if owt == cmn.OwtPut || owt == cmn.OwtFinalize || owt == cmn.OwtPromote {
+			if history = lom.HistoryEnabled(); history {
+				// keep the current version and continue numbering from the most recent one
+				var latest string
+				if latest, hfqn, err = lom.PreserveVersion(); err != nil {
+					return
+				}
+				if remSrc, ok := lom.GetCustomKey(cmn.SourceObjMD); skipVC || !ok || remSrc == "" {
+					lom.SetVersion(latest)
+				}
+			}
+			if skipVC {
 				err = lom.IncVersion()
 				debug.Assert(err == nil)
 			} else if remSrc, ok := lom.GetCustomKey(cmn.SourceObjMD); !ok || remSrc == "" {
@@ -292,24 +348,25 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 			}
 		}
 	}
-	if err = cos.Rename(poi.workFQN, lom.FQN); err != nil {
-		err = cmn.NewErrFailedTo(poi.t, "rename", lom, err)
-		return
+	if err = cos.Rename(workFQN, lom.FQN); err != nil {
+		if hfqn != "" {
+			if errRm := cos.RemoveFile(hfqn); errRm != nil {
+				glog.Errorf("nested error: %v --> %v", err, errRm)
+			}
+		}
+		return cmn.NewErrFailedTo(t, "rename", lom, err)
+	}
+	if history {
+		lom.CommitHistory(hfqn)
 	}
 	if lom.HasCopies() {
 		if errdc := lom.DelAllCopies(); errdc != nil {
-			glog.Errorf("PUT (%s): failed to delete old copies [%v], proceeding to PUT anyway...", poi.loghdr(), errdc)
+			glog.Errorf("%s: failed to delete old copies of %s [%v], proceeding anyway...", t, lom, errdc)
 		}
 	}
-	if lom.AtimeUnix() == 0 {
-		lom.SetAtimeUnix(poi.atime.UnixNano())
-		debug.Assert(lom.AtimeUnix() != 0)
-	}
-	err = lom.Persist()
-	return
+	return nil
 }
 
-// via backend.PutObj()
 func (poi *putObjInfo) putRemote() (errCode int, err error) {
 	var (
 		lom     = poi.lom
@@ -699,7 +756,7 @@ func (goi *getObjInfo) restoreFromAny(skipLomRestore bool) (doubleCheck bool, er
 		doubleCheck = true
 	}
 	if running && tsi.ID() != goi.t.si.ID() {
-		if goi.t.HeadObjT2T(goi.lom, tsi) {
+		if ok, _ := goi.t.HeadObjT2T(goi.lom, tsi); ok {
 			gfnNode = tsi
 			goto gfn
 		}
@@ -1186,6 +1243,50 @@ func (coi *copyObjInfo) copyObject(lom *cluster.LOM, objNameTo string) (size int
 	return
 }
 
+// sync (incremental copy): whether the destination already has an identical object;
+// for remote buckets, the in-cluster copy (if present) takes precedence over the backend
+func (coi *copyObjInfo) synced(lom *cluster.LOM, objNameTo string) bool {
+	var (
+		oa  *cmn.ObjAttrs
+		err error
+	)
+	dst := cluster.AllocLOM(objNameTo)
+	defer cluster.FreeLOM(dst)
+	if err = dst.InitBck(coi.BckTo.Bucket()); err != nil {
+		return false
+	}
+	tsi, local, err := dst.HrwTarget(coi.t.owner.smap.Get())
+	if err != nil {
+		return false
+	}
+	if !local {
+		oa, err = coi.t.headObjAttrsT2T(dst, tsi)
+	} else if err = dst.Load(false /*cache it*/, false /*locked*/); err == nil {
+		oa = dst.ObjAttrs()
+	} else if cmn.IsObjNotExist(err) && dst.Bck().IsRemote() {
+		oa, _, err = coi.t.Backend(dst.Bck()).HeadObj(context.Background(), dst)
+	}
+	if err != nil {
+		return false
+	}
+	return syncEqual(lom, oa)
+}
+
+// same size and, if available, same checksum; otherwise, same version (or other
+// remote metadata - see cmn.ObjAttrs.Equal)
+func syncEqual(lom *cluster.LOM, oa *cmn.ObjAttrs) bool {
+	if lom.SizeBytes(true) != oa.Size {
+		return false
+	}
+	if cksum := lom.Checksum(); !cksum.IsEmpty() && !oa.Cksum.IsEmpty() && cksum.Ty() == oa.Cksum.Ty() {
+		return cksum.Equal(oa.Cksum)
+	}
+	if ver := lom.Version(true); ver != "" && oa.Ver != "" {
+		return ver == oa.Ver
+	}
+	return lom.Equal(oa)
+}
+
 /////////////////
 // COPY READER //
 /////////////////
@@ -1444,19 +1545,12 @@ func (aaoi *appendArchObjInfo) appendToArch(fqn string) error {
 	if err != nil {
 		return err
 	}
-
-	st, err := os.Stat(fqn)
-	if err != nil {
-		return err
-	}
-	aaoi.lom.SetSize(st.Size())
-	aaoi.lom.SetCksum(cos.NewCksum(cos.ChecksumNone, ""))
-	aaoi.lom.SetAtimeUnix(aaoi.started.UnixNano())
-	return nil
+	return aaoi.setAttrs(fqn, nil /*compute checksum*/)
 }
 
+// same as PUT: version (and history), checksum (see setAttrs)
 func (aaoi *appendArchObjInfo) finalize(fqn string) error {
-	if err := os.Rename(fqn, aaoi.lom.FQN); err != nil {
+	if err := aaoi.t.commitWorkfile(aaoi.lom, fqn, cmn.OwtPut, false /*skip VC*/); err != nil {
 		return err
 	}
 	aaoi.lom.SetAtimeUnix(aaoi.started.UnixNano())
@@ -1469,10 +1563,15 @@ func (aaoi *appendArchObjInfo) finalize(fqn string) error {
 		}
 	}
 	aaoi.t.putMirror(aaoi.lom)
+	aaoi.indexArch()
 	return nil
 }
 
 func (aaoi *appendArchObjInfo) begin() (string, error) {
+	// appending in place - copy if shared with a snapshot
+	if err := aaoi.lom.CowInPlace(nil); err != nil {
+		return "", err
+	}
 	workFQN := fs.CSM.Gen(aaoi.lom, fs.WorkfileType, fs.WorkfileAppendToArch)
 	if err := os.Rename(aaoi.lom.FQN, workFQN); err != nil {
 		return "", err
@@ -1491,10 +1590,18 @@ func (aaoi *appendArchObjInfo) appendObject() (errCode int, err error) {
 	if aaoi.filename == "" {
 		return http.StatusBadRequest, errors.New("archive path is not defined")
 	}
+	switch aaoi.mode {
+	case "", apc.ArchAppend:
+	case apc.ArchReplace:
+		return aaoi.rewrite()
+	default:
+		return http.StatusBadRequest, fmt.Errorf("invalid %s=%q", apc.QparamArchmode, aaoi.mode)
+	}
 	// Standard library does not support appending to archive of any type.
-	// For TAR there is a working workaround, so allow appending to TAR only.
-	if aaoi.mime != cos.ExtTar {
-		return http.StatusBadRequest, fmt.Errorf("append is supported only for %s archives", cos.ExtTar)
+	// For TAR there is a working workaround (in place); all other formats get rewritten.
+	// So does TAR when version history is enabled (the current version is to be preserved).
+	if mime, err := cos.Mime(aaoi.mime, aaoi.lom.ObjName); err != nil || mime != cos.ExtTar || aaoi.lom.HistoryEnabled() {
+		return aaoi.rewrite()
 	}
 	workFQN, err := aaoi.begin()
 	if err != nil {
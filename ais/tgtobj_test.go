@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/devtools/readers"
+	"github.com/NVIDIA/aistore/devtools/tassert"
 	"github.com/NVIDIA/aistore/fs"
 )
 
@@ -60,6 +61,7 @@ func TestMain(m *testing.M) {
 	fs.TestDisableValidation()
 	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.Reg(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
 
 	// target
 	config := cmn.GCO.Get()
@@ -266,3 +268,33 @@ func BenchmarkObjGetDiscard(b *testing.B) {
 		})
 	}
 }
+
+func TestSyncEqual(t *testing.T) {
+	var (
+		xx  = cos.NewCksum(cos.ChecksumXXHash, "a")
+		md5 = cos.NewCksum(cos.ChecksumMD5, "b")
+	)
+	tests := []struct {
+		name   string
+		size   int64
+		cksum  *cos.Cksum
+		ver    string
+		oa     cmn.ObjAttrs
+		synced bool
+	}{
+		{"size differs", 10, xx, "", cmn.ObjAttrs{Size: 11, Cksum: xx}, false},
+		{"same checksum", 10, xx, "1", cmn.ObjAttrs{Size: 10, Cksum: xx, Ver: "2"}, true},
+		{"checksum differs", 10, xx, "1", cmn.ObjAttrs{Size: 10, Cksum: cos.NewCksum(cos.ChecksumXXHash, "c"), Ver: "1"}, false},
+		{"same version", 10, xx, "1", cmn.ObjAttrs{Size: 10, Cksum: md5, Ver: "1"}, true},
+		{"version differs", 10, xx, "1", cmn.ObjAttrs{Size: 10, Cksum: md5, Ver: "2"}, false},
+		{"size only", 10, nil, "", cmn.ObjAttrs{Size: 10}, false},
+	}
+	for _, test := range tests {
+		lom := &cluster.LOM{ObjName: "obj"}
+		lom.SetSize(test.size)
+		lom.SetCksum(test.cksum)
+		lom.SetVersion(test.ver)
+		synced := syncEqual(lom, &test.oa)
+		tassert.Errorf(t, synced == test.synced, "%s: expected synced=%t", test.name, test.synced)
+	}
+}
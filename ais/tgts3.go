@@ -218,6 +218,7 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 	lom := cluster.AllocLOM(objName)
 	t.headObject(w, r, r.URL.Query(), bck, lom)
 	s3compat.SetETag(w.Header(), lom) // add etag/md5
+	s3compat.SetVersionHdr(w.Header())
 	cluster.FreeLOM(lom)
 }
 
@@ -239,6 +240,10 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 		t.writeErr(w, r, err)
 		return
 	}
+	if ver := r.URL.Query().Get(s3compat.QparamVersionID); ver != "" {
+		t.delObjVersion(w, r, lom, ver)
+		return
+	}
 	errCode, err := t.DeleteObject(lom, false)
 	if err != nil {
 		if errCode == http.StatusNotFound {
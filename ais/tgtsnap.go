@@ -0,0 +1,182 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// bucket snapshots: take, restore, and delete (2PC), and read-only access
+// to the objects in a given snapshot (apc.QparamSnapshot) - see cluster/lom_snap.go
+
+func (t *target) snapshotBck(c *txnServerCtx) (string, error) {
+	if err := c.bck.Init(t.owner.bmd); err != nil {
+		return "", err
+	}
+	snap := c.msg.Name
+	switch c.phase {
+	case apc.ActBegin:
+		if err := t.validateSnap(c.bck, c.msg); err != nil {
+			return "", err
+		}
+		nlp := c.bck.GetNameLockPair()
+		if !nlp.TryLock(c.timeout.netw / 4) {
+			return "", cmn.NewErrBckIsBusy(c.bck.Bucket())
+		}
+		txn := newTxnSnapshot(c, c.bck)
+		if err := t.transactions.begin(txn); err != nil {
+			nlp.Unlock()
+			return "", err
+		}
+		txn.nlps = []cmn.NLP{nlp}
+	case apc.ActAbort:
+		t.transactions.find(c.uuid, apc.ActAbort)
+	case apc.ActCommit:
+		if c.msg.Action == apc.ActRestoreSnap {
+			if _, err := t.transactions.find(c.uuid, apc.ActCommit); err != nil {
+				return "", err
+			}
+		} else {
+			txn, err := t.transactions.find(c.uuid, "")
+			if err != nil {
+				return "", err
+			}
+			// wait for newBMD w/timeout
+			if err = t.transactions.wait(txn, c.timeout.netw, c.timeout.host); err != nil {
+				return "", cmn.NewErrFailedTo(t, "commit", txn, err)
+			}
+		}
+		var rns xreg.RenewRes
+		switch c.msg.Action {
+		case apc.ActDeleteSnap:
+			return "", cluster.RemoveSnap(c.bck.Bucket(), snap)
+		case apc.ActSnapshotBck:
+			rns = xreg.RenewSnapshot(t, c.bck, c.uuid, snap)
+		default:
+			rns = xreg.RenewRestoreSnap(t, c.bck, c.uuid, snap)
+		}
+		if rns.Err != nil {
+			return "", rns.Err
+		}
+		xctn := rns.Entry.Get()
+		c.addNotif(xctn) // notify upon completion
+		xact.GoRunW(xctn)
+		return xctn.ID(), nil
+	default:
+		debug.Assert(false)
+	}
+	return "", nil
+}
+
+func (t *target) validateSnap(bck *cluster.Bck, msg *aisMsg) error {
+	if !bck.IsAIS() || bck.Backend() != nil {
+		return fmt.Errorf("%s: bucket snapshots are supported only for ais:// buckets without backend, have %s",
+			t, bck)
+	}
+	_, exists := bck.Props.Snap(msg.Name)
+	switch {
+	case msg.Action == apc.ActSnapshotBck && exists:
+		return fmt.Errorf("%s: snapshot %q of %s already exists", t, msg.Name, bck)
+	case msg.Action != apc.ActSnapshotBck && !exists:
+		return cmn.NewErrNotFound("%s: snapshot %q of %s", t.si, msg.Name, bck)
+	}
+	for _, kind := range []string{apc.ActSnapshotBck, apc.ActRestoreSnap} {
+		if e := xreg.GetRunning(xreg.XactFilter{Kind: kind, Bck: bck}); e != nil {
+			return fmt.Errorf("%s: cannot %s %q while %s is running", t, msg.Action, msg.Name, e.Get())
+		}
+	}
+	if msg.Action == apc.ActDeleteSnap {
+		return nil
+	}
+	if cs := fs.GetCapStatus(); cs.Err != nil {
+		return cs.Err
+	}
+	return xreg.LimitedCoexistence(t.si, bck, msg.Action)
+}
+
+// (400) snapshots exist only in ais:// buckets; (404) no such snapshot
+func (t *target) _snapBck(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, snap string) bool {
+	if !lom.Bck().IsAIS() {
+		t.writeErrf(w, r, "%s: cannot access snapshot %q of %s: snapshots are supported only for ais:// buckets",
+			t.si, snap, lom.Bck())
+		return false
+	}
+	if _, ok := lom.Bprops().Snap(snap); !ok {
+		err := cmn.NewErrNotFound("%s: snapshot %q of %s", t.si, snap, lom.Bck())
+		t.writeErr(w, r, err, http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+func (t *target) loadObjSnap(lom *cluster.LOM, snap string) (*cluster.LOM, error) {
+	sfqn, mi, err := lom.FindSnap(snap)
+	if err != nil {
+		return nil, err
+	}
+	return lom.LoadSnapMD(sfqn, mi)
+}
+
+func (t *target) getObjSnap(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, snap string) {
+	if !t._snapBck(w, r, lom, snap) {
+		return
+	}
+	slom, err := t.loadObjSnap(lom, snap)
+	if err != nil {
+		t._verErr(w, r, lom, err, false)
+		return
+	}
+	defer cluster.FreeLOM(slom)
+	fh, err := os.Open(slom.FQN)
+	if err != nil {
+		t._verErr(w, r, slom, err, false)
+		return
+	}
+	var mtime time.Time
+	if finfo, err := fh.Stat(); err == nil {
+		mtime = finfo.ModTime()
+	}
+	hdr := w.Header()
+	cmn.ToHeader(slom.ObjAttrs(), hdr)
+	hdr.Set(cos.HdrContentType, cos.ContentBinary)
+	// (range reads and conditional requests included)
+	http.ServeContent(w, r, "", mtime, fh)
+	cos.Close(fh)
+	t.statsT.Add(stats.GetCount, 1)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("GET %s snapshot %s", lom, snap)
+	}
+}
+
+func (t *target) headObjSnap(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, snap string, silent bool) {
+	if !t._snapBck(w, r, lom, snap) {
+		return
+	}
+	slom, err := t.loadObjSnap(lom, snap)
+	if err != nil {
+		t._verErr(w, r, lom, err, silent)
+		return
+	}
+	op := cmn.ObjectProps{Name: lom.ObjName, Bck: *lom.Bucket(), Present: true, DaemonID: t.SID()}
+	op.ObjAttrs = *slom.ObjAttrs()
+	op.Mirror.Copies = 1
+	op.Mirror.Paths = []string{slom.MpathInfo().Path}
+	cluster.FreeLOM(slom)
+	objPropsToHdr(&op, w.Header(), false /*hasEC*/)
+}
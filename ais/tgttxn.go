@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/admit"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -88,6 +89,13 @@ func (t *target) txnHandler(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	// admission control: do not begin massive operations when overloaded
+	if xactRecord.MassiveBck && phase == apc.ActBegin {
+		if err := admit.Check(msg.Action); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+	}
 	switch msg.Action {
 	case apc.ActCreateBck, apc.ActAddRemoteBck:
 		err = t.createBucket(c)
@@ -133,6 +141,8 @@ func (t *target) txnHandler(w http.ResponseWriter, r *http.Request) {
 		xactID, err = t.tcobjs(c, tcoMsg, dp)
 	case apc.ActECEncode:
 		xactID, err = t.ecEncode(c)
+	case apc.ActSnapshotBck, apc.ActRestoreSnap, apc.ActDeleteSnap:
+		xactID, err = t.snapshotBck(c)
 	case apc.ActArchive:
 		xactID, err = t.createArchMultiObj(c)
 	case apc.ActStartMaintenance, apc.ActDecommissionNode, apc.ActShutdownNode:
@@ -0,0 +1,137 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// GET, HEAD, and DELETE a given version of the object (apc.QparamVersionID)
+// - see cmn.VersionConf.History and cluster/lom_hist.go.
+// Requests that specify the current version are handled as usual (the handlers below return false).
+
+func (t *target) _verErr(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, err error, silent bool) {
+	errCode := http.StatusInternalServerError
+	if cmn.IsErrNotFound(err) || cmn.IsObjNotExist(err) {
+		errCode = http.StatusNotFound
+	}
+	if silent {
+		t.writeErrSilent(w, r, err, errCode)
+		return
+	}
+	if errCode != http.StatusNotFound {
+		t.fsErr(err, lom.FQN)
+	}
+	t.writeErr(w, r, err, errCode)
+}
+
+// (400) previous versions exist only in ais:// buckets
+func (t *target) _verBck(w http.ResponseWriter, r *http.Request, lom *cluster.LOM) bool {
+	if lom.Bck().IsAIS() {
+		return true
+	}
+	t.writeErrf(w, r, "%s: cannot access versions of %s: version history is supported only for ais:// buckets",
+		t.si, lom)
+	return false
+}
+
+// delete marker: 405 (compare with S3 "x-amz-delete-marker")
+func (t *target) _delMarker(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, ov *cluster.ObjVersion) {
+	hdr := w.Header()
+	hdr.Set(apc.HdrObjDelMarker, "true")
+	hdr.Set(apc.HdrObjVersion, ov.Ver)
+	hdr.Set(s3compat.HeaderDeleteMarker, "true")
+	hdr.Set(s3compat.HeaderVersionID, ov.Ver)
+	t.writeErrSilentf(w, r, http.StatusMethodNotAllowed, "%s: version %q of %s is a delete marker",
+		t.si, ov.Ver, lom.FullName())
+}
+
+func (t *target) getObjVersion(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, ver string) bool {
+	if !t._verBck(w, r, lom) {
+		return true
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err == nil && lom.Version() == ver {
+		return false
+	}
+	hlom, ov, err := lom.LoadVersion(ver)
+	if err != nil {
+		t._verErr(w, r, lom, err, false)
+		return true
+	}
+	if hlom == nil {
+		t._delMarker(w, r, lom, ov)
+		return true
+	}
+	defer cluster.FreeLOM(hlom)
+	fh, err := os.Open(hlom.FQN)
+	if err != nil {
+		t._verErr(w, r, hlom, err, false)
+		return true
+	}
+	hdr := w.Header()
+	cmn.ToHeader(hlom.ObjAttrs(), hdr)
+	hdr.Set(cos.HdrContentType, cos.ContentBinary)
+	// (range reads and conditional requests included)
+	http.ServeContent(w, r, "", time.Unix(0, ov.Mtime), fh)
+	cos.Close(fh)
+	t.statsT.Add(stats.GetCount, 1)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("GET %s version %s", lom, ver)
+	}
+	return true
+}
+
+func (t *target) headObjVersion(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, ver string, silent bool) bool {
+	if !t._verBck(w, r, lom) {
+		return true
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err == nil && lom.Version() == ver {
+		return false
+	}
+	hlom, ov, err := lom.LoadVersion(ver)
+	if err != nil {
+		t._verErr(w, r, lom, err, silent)
+		return true
+	}
+	if hlom == nil {
+		t._delMarker(w, r, lom, ov)
+		return true
+	}
+	op := cmn.ObjectProps{Name: lom.ObjName, Bck: *lom.Bucket(), Present: true, DaemonID: t.SID()}
+	op.ObjAttrs = *hlom.ObjAttrs()
+	op.Mirror.Copies = 1
+	op.Mirror.Paths = []string{hlom.MpathInfo().Path}
+	cluster.FreeLOM(hlom)
+	objPropsToHdr(&op, w.Header(), false /*hasEC*/)
+	return true
+}
+
+func (t *target) delObjVersion(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, ver string) {
+	if !t._verBck(w, r, lom) {
+		return
+	}
+	lom.Lock(true)
+	err := lom.DeleteVersion(ver)
+	lom.Unlock(true)
+	if err != nil {
+		t._verErr(w, r, lom, err, true)
+		return
+	}
+	t.statsT.Add(stats.DeleteCount, 1)
+}
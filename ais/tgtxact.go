@@ -15,6 +15,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/res"
 	"github.com/NVIDIA/aistore/xact"
@@ -189,6 +190,9 @@ func (t *target) cmdXactStart(xactMsg *xact.QueryMsg, bck *cluster.Bck) error {
 	case apc.ActLoadLomCache:
 		rns := xreg.RenewBckLoadLomCache(t, xactMsg.ID, bck)
 		return rns.Err
+	case apc.ActIndexArch:
+		rns := xreg.RenewIndexArch(t, xactMsg.ID, bck)
+		return rns.Err
 	// 3. cannot start
 	case apc.ActPutCopies:
 		return fmt.Errorf("cannot start %q (is driven by PUTs into a mirrored bucket)", xactMsg)
@@ -202,3 +206,81 @@ func (t *target) cmdXactStart(xactMsg *xact.QueryMsg, bck *cluster.Bck) error {
 	}
 	return nil
 }
+
+// resume xactions interrupted by node restart or cluster reboot (see xact.Ckpt)
+// - copying and transforming buckets requires all targets to participate and is
+// tracked by IC (proxies) - resuming those is only possible when the entire cluster
+// reboots; in all other cases (e.g., when a single target restarts) their checkpoints
+// get discarded
+func (t *target) resumeXactions(reboot bool) {
+	for _, ckpt := range xact.LoadCkpts() {
+		if !reboot && (ckpt.Kind == apc.ActCopyBck || ckpt.Kind == apc.ActETLBck) {
+			glog.Warningf("%s: not resuming %s[%s] - the cluster did not reboot", t, ckpt.Kind, ckpt.ID)
+			fs.RemoveXactCkpt(ckpt.ID)
+			continue
+		}
+		if err := t.resumeXact(ckpt); err != nil {
+			glog.Errorf("%s: failed to resume %s[%s]: %v", t, ckpt.Kind, ckpt.ID, err)
+			fs.RemoveXactCkpt(ckpt.ID)
+		}
+	}
+}
+
+func (t *target) resumeXact(ckpt *xact.Ckpt) error {
+	bck := cluster.CloneBck(&ckpt.Bck)
+	if err := bck.Init(t.owner.bmd); err != nil {
+		return err
+	}
+	var custom interface{}
+	switch ckpt.Kind {
+	case apc.ActCopyBck, apc.ActETLBck:
+		var (
+			msg   = &apc.TCBMsg{}
+			bckTo = cluster.CloneBck(&ckpt.BckTo)
+			dp    cluster.DP
+		)
+		if err := cos.MorphMarshal(ckpt.Msg, msg); err != nil {
+			return err
+		}
+		if err := bckTo.Init(t.owner.bmd); err != nil {
+			return err
+		}
+		if ckpt.Kind == apc.ActETLBck {
+			var err error
+			if dp, err = t.etlDP(msg); err != nil {
+				return err
+			}
+		}
+		custom = &xreg.TCBArgs{Phase: apc.ActCommit, BckFrom: bck, BckTo: bckTo, DP: dp, Msg: msg}
+		bck = bckTo // NOTE: renewing to (not from), see xreg.RenewTCB
+	case apc.ActMakeNCopies:
+		args := &xreg.MNCArgs{}
+		if err := cos.MorphMarshal(ckpt.Msg, args); err != nil {
+			return err
+		}
+		custom = args
+	case apc.ActPrefetchObjects:
+		msg := &cmn.SelectObjsMsg{}
+		if err := cos.MorphMarshal(ckpt.Msg, msg); err != nil {
+			return err
+		}
+		custom = msg
+	default:
+		return fmt.Errorf(cmn.FmtErrUnsupported, ckpt.Kind, "resumable kind")
+	}
+	rns := xreg.RenewResumed(t, ckpt, bck, custom)
+	if rns.Err != nil {
+		return rns.Err
+	}
+	if rns.IsRunning() {
+		return nil
+	}
+	xctn := rns.Entry.Get()
+	glog.Infof("%s: resuming %s", t, xctn)
+	if ckpt.Kind == apc.ActPrefetchObjects {
+		go xctn.Run(nil)
+	} else {
+		xact.GoRunW(xctn)
+	}
+	return nil
+}
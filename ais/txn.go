@@ -112,6 +112,9 @@ type (
 	txnECEncode struct {
 		txnBckBase
 	}
+	txnSnapshot struct {
+		txnBckBase
+	}
 	txnArchMultiObj struct {
 		txnBckBase
 		xarch *xs.XactCreateArchMultiObj
@@ -138,6 +141,7 @@ var (
 	_ txn = (*txnTCB)(nil)
 	_ txn = (*txnTCObjs)(nil)
 	_ txn = (*txnECEncode)(nil)
+	_ txn = (*txnSnapshot)(nil)
 	_ txn = (*txnPromote)(nil)
 )
 
@@ -568,6 +572,17 @@ func newTxnECEncode(c *txnServerCtx, bck *cluster.Bck) (txn *txnECEncode) {
 	return
 }
 
+/////////////////
+// txnSnapshot //
+/////////////////
+
+func newTxnSnapshot(c *txnServerCtx, bck *cluster.Bck) (txn *txnSnapshot) {
+	txn = &txnSnapshot{}
+	txn.init(bck)
+	txn.fillFromCtx(c)
+	return
+}
+
 ///////////////////////////
 // txnCreateArchMultiObj //
 ///////////////////////////
@@ -26,6 +26,9 @@ const (
 	ActETLBck         = "etl-bck"
 	ActElection       = "election"
 	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
+	ActExtractArch    = "extract-arch"     // extract archived files as objects (see cmn.ExtractArchMsg)
+	ActGetBatch       = "get-batch"        // GET multiple objects as a single (streamed) archive
+	ActIndexArch      = "index-arch"       // index tar, tar.gz, and zip archives (see cmn/archidx)
 	ActInvalListCache = "inval-listobj-cache"
 	ActLRU            = "lru"
 	ActList           = "list"
@@ -44,6 +47,9 @@ const (
 	ActSetBprops      = "set-bprops"
 	ActSetConfig      = "set-config"
 	ActShutdown       = "shutdown"
+	ActSnapshotBck    = "snapshot-bck"     // take bucket snapshot
+	ActRestoreSnap    = "restore-snapshot" // restore (roll back) bucket to a given snapshot
+	ActDeleteSnap     = "delete-snapshot"
 	ActStartGFN       = "start-gfn"
 	ActStoreCleanup   = "cleanup-store"
 
@@ -76,6 +82,18 @@ const (
 	ActListenToNotif     = "watch-xaction"
 	ActMergeOwnershipTbl = "ic-merge-own-tbl"
 	ActRegGlobalXaction  = "reg-global-xaction"
+
+	// cluster event log (internal use only)
+	ActAddEvents = "add-events"
+
+	// scheduled jobs (see cmn.SchedJob)
+	ActSchedAdd    = "sched-add" // add or update
+	ActSchedRemove = "sched-rm"
+
+	// pipelines (see cmn.Pipeline)
+	ActDsort        = "dsort" // (pipeline step only)
+	ActPipelineRun  = "pipeline-run"
+	ActPipelineStop = "pipeline-stop"
 )
 
 const (
@@ -125,6 +143,7 @@ const (
 	HdrObjAtime     = HeaderPrefix + "atime"          // Object access time.
 	HdrObjCustomMD  = HeaderPrefix + "custom-md"      // Object custom metadata.
 	HdrObjVersion   = HeaderPrefix + "version"        // Object version/generation - ais or cloud.
+	HdrObjDelMarker = HeaderPrefix + "delete-marker"  // The requested (previous) version is a delete marker.
 
 	// Append object header.
 	HdrAppendHandle = HeaderPrefix + "append-handle"
@@ -215,6 +234,13 @@ const (
 	// Archive filename and format (mime type)
 	QparamArchpath = "archpath"
 	QparamArchmime = "archmime"
+	QparamArchmode = "archmode" // PUT into existing archive: enum { ArchAppend, ArchReplace }
+
+	// GET, HEAD, or DELETE a given object version (see cmn.VersionConf.History)
+	QparamVersionID = "version_id"
+
+	// GET or HEAD object from a given bucket snapshot (read-only; see cmn.BckSnap)
+	QparamSnapshot = "snapshot"
 
 	// Skip loading existing object's metadata, in part to
 	// compare its Checksum and update its existing Version (if exists).
@@ -222,13 +248,23 @@ const (
 	// - we massively write new content into a bucket, and/or
 	// - we simply don't care.
 	QparamSkipVC = "skip_vc"
+
+	// Cluster event log query (see cmn.EventQuery)
+	QparamEvSince = "since" // Unix time (nanoseconds)
+	QparamEvUntil = "until" // ditto
+	QparamEvKind  = "ev_kind"
+	QparamEvActor = "actor"
+	QparamEvLimit = "limit"
 )
 
 // health
 const (
 	QparamHealthReadiness = "readiness" // to be used by external watchdogs (e.g. K8s)
+	QparamHealthReady     = "ready"     // true: readiness as per `config.Keepalive.Readiness` criteria
 	QparamAskPrimary      = "apr"       // true: the caller is directing health request to primary
 	QparamPrimaryReadyReb = "prr"       // true: check whether primary is ready to start rebalancing cluster
+	QparamHealthDetails   = "details"   // true: return detailed component status (cmn.NodeHealth)
+	QparamHealthBackends  = "backends"  // true: include remote backend reachability (with details)
 )
 
 // Internal query params.
@@ -276,6 +312,12 @@ const (
 	FlushOp  = "flush"
 )
 
+// QparamArchmode enum
+const (
+	ArchAppend  = "append"  // add new file (default)
+	ArchReplace = "replace" // replace existing file in place or, if doesn't exist, add
+)
+
 // QparamTaskAction enum
 const (
 	TaskStart  = Start
@@ -302,6 +344,10 @@ const (
 	GetWhatSysInfo       = "sysinfo"
 	GetWhatTargetIPs     = "target_ips"
 	GetWhatLog           = "log"
+	GetWhatEvents        = "events"
+	GetWhatHealth        = "health"
+	GetWhatSched         = "sched"
+	GetWhatPipeline      = "pipeline"
 )
 
 // Internal "what" values.
@@ -332,8 +378,10 @@ const (
 	ObjStatusDeleted // TODO: reserved for future when we introduce delayed delete of the object/bucket
 
 	// Flags
-	EntryIsCached = 1 << (EntryStatusBits + 1)
-	EntryInArch   = 1 << (EntryStatusBits + 2)
+	EntryIsCached    = 1 << (EntryStatusBits + 1)
+	EntryInArch      = 1 << (EntryStatusBits + 2)
+	EntryIsPrevVer   = 1 << (EntryStatusBits + 3) // previous (non-current) version (see LsVersions)
+	EntryIsDelMarker = 1 << (EntryStatusBits + 4) // delete marker (ditto)
 )
 
 // List objects default page size
@@ -396,8 +444,10 @@ const (
 	Records     = "records"
 	Shards      = "shards"
 	FinishedAck = "finished_ack"
+	Created     = "created"
 	List        = "list"
 	Remove      = "remove"
+	Retry       = "retry"
 	Next        = "next"
 	Peek        = "peek"
 	Discard     = "discard"
@@ -53,6 +53,12 @@ const (
 
 	// cache list-objects results and use this cache to speed-up
 	UseListObjsCache
+
+	// LsVersions includes previous versions and delete markers (ais:// buckets
+	// with `versioning.history` - see cmn.VersionConf). Entries of the same object
+	// are listed together: current version first, followed by previous versions
+	// and delete markers, newest first. Archives are not expanded (LsArchDir).
+	LsVersions
 )
 
 // ListObjsMsg and HEAD(object) enum
@@ -82,6 +88,7 @@ type (
 		ContinuationToken string `json:"continuation_token"` // `BucketList.ContinuationToken`
 		Flags             uint64 `json:"flags,string"`       // enum {LsPresent, ...} - see above
 		PageSize          uint   `json:"pagesize"`           // max entries returned by list objects call
+		Snapshot          string `json:"snapshot,omitempty"` // list a given bucket snapshot (see cmn.BckSnap)
 	}
 )
 
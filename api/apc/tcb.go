@@ -6,19 +6,34 @@ package apc
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
 )
 
 var ErrETLMissingUUID = errors.New("ETL UUID can't be empty")
 
+// CopyBckMsg.Since: since the last successful sync
+const SyncSinceLast = "last"
+
 // copy & (offline) transform bucket to bucket
 type (
 	CopyBckMsg struct {
 		Prefix string `json:"prefix"`  // Prefix added to each resulting object.
 		DryRun bool   `json:"dry_run"` // Don't perform any PUT
 		Force  bool   `json:"force"`   // Force running in presence of a potential "limited coexistence" type conflict
+		// Incremental copy (aka sync): copy only the objects that are missing at the destination
+		// or differ in size, checksum, or version.
+		Sync bool `json:"sync,omitempty"`
+		// Sync only: remove destination objects that do not exist in the source bucket.
+		Prune bool `json:"prune,omitempty"`
+		// Sync only: copy only the objects modified after a given time - either RFC3339 timestamp
+		// or `SyncSinceLast` (the time of the last successful sync of the same two buckets).
+		// NOTE: modified means written in the cluster; for remote buckets - cached (not the
+		// backend's last-modified time).
+		Since string `json:"since,omitempty"`
 	}
 	TCBMsg struct {
 		// Resulting objects names will have this extension. Warning: if in a source bucket exist two objects with the
@@ -34,6 +49,34 @@ type (
 	}
 )
 
+////////////////
+// CopyBckMsg //
+////////////////
+
+func (msg *CopyBckMsg) ValidateSync() error {
+	if !msg.Sync {
+		if msg.Prune || msg.Since != "" {
+			return errors.New("prune and since options require sync")
+		}
+		return nil
+	}
+	_, err := msg.SinceTime()
+	return err
+}
+
+// SinceTime returns the `Since` timestamp (Unix nanoseconds), or zero if not
+// specified or `SyncSinceLast` (in which case it is resolved by each target)
+func (msg *CopyBckMsg) SinceTime() (int64, error) {
+	if msg.Since == "" || msg.Since == SyncSinceLast {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, msg.Since)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sync since %q (expecting RFC3339 time or %q): %v", msg.Since, SyncSinceLast, err)
+	}
+	return t.UnixNano(), nil
+}
+
 ////////////
 // TCBMsg //
 ////////////
@@ -0,0 +1,54 @@
+// Package apc: API constants and message types
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestCopyBckMsgValidateSync(t *testing.T) {
+	tests := []struct {
+		msg   apc.CopyBckMsg
+		valid bool
+	}{
+		{apc.CopyBckMsg{}, true},
+		{apc.CopyBckMsg{Prune: true}, false},
+		{apc.CopyBckMsg{Since: apc.SyncSinceLast}, false},
+		{apc.CopyBckMsg{Sync: true}, true},
+		{apc.CopyBckMsg{Sync: true, Prune: true, Since: apc.SyncSinceLast}, true},
+		{apc.CopyBckMsg{Sync: true, Since: "2022-06-01T10:00:00Z"}, true},
+		{apc.CopyBckMsg{Sync: true, Since: "2022-06-01"}, false},
+		{apc.CopyBckMsg{Sync: true, Since: "1h"}, false},
+	}
+	for _, test := range tests {
+		err := test.msg.ValidateSync()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got %v", test.msg, test.valid, err)
+	}
+}
+
+func TestCopyBckMsgSinceTime(t *testing.T) {
+	msg := &apc.CopyBckMsg{Sync: true}
+	since, err := msg.SinceTime()
+	tassert.Errorf(t, err == nil && since == 0, "expected zero, got %d (%v)", since, err)
+
+	// resolved by targets
+	msg.Since = apc.SyncSinceLast
+	since, err = msg.SinceTime()
+	tassert.Errorf(t, err == nil && since == 0, "expected zero, got %d (%v)", since, err)
+
+	msg.Since = "2022-06-01T10:00:00+02:00"
+	since, err = msg.SinceTime()
+	tassert.CheckFatal(t, err)
+	expected := time.Date(2022, 6, 1, 8, 0, 0, 0, time.UTC).UnixNano()
+	tassert.Errorf(t, since == expected, "expected %d, got %d", expected, since)
+
+	msg.Since = "yesterday"
+	_, err = msg.SinceTime()
+	tassert.Errorf(t, err != nil, "expected error")
+}
@@ -62,11 +62,13 @@ var (
 	URLPathdSortRecords = urlpath(Version, Sort, Records)
 	URLPathdSortMetrics = urlpath(Version, Sort, Metrics)
 	URLPathdSortAck     = urlpath(Version, Sort, FinishedAck)
+	URLPathdSortCreated = urlpath(Version, Sort, Created)
 	URLPathdSortRemove  = urlpath(Version, Sort, Remove)
 
 	URLPathDownload       = urlpath(Version, Download)
 	URLPathDownloadAbort  = urlpath(Version, Download, Abort)
 	URLPathDownloadRemove = urlpath(Version, Download, Remove)
+	URLPathDownloadRetry  = urlpath(Version, Download, Retry)
 
 	URLPathETL       = urlpath(Version, ETL)
 	URLPathETLObject = urlpath(Version, ETL, ETLObject)
@@ -559,6 +559,46 @@ func ECEncodeBucket(baseParams BaseParams, bck cmn.Bck, data, parity int) (xactI
 	return
 }
 
+// SnapshotBucket takes a named point-in-time snapshot of a given ais:// bucket
+// (see also: RestoreSnapshot, DeleteSnapshot, and ListSnapshots).
+func SnapshotBucket(baseParams BaseParams, bck cmn.Bck, snap string) (xactID string, err error) {
+	return snapAction(baseParams, bck, apc.ActSnapshotBck, snap)
+}
+
+// RestoreSnapshot rolls back the content of a given bucket to a given snapshot.
+func RestoreSnapshot(baseParams BaseParams, bck cmn.Bck, snap string) (xactID string, err error) {
+	return snapAction(baseParams, bck, apc.ActRestoreSnap, snap)
+}
+
+func DeleteSnapshot(baseParams BaseParams, bck cmn.Bck, snap string) error {
+	_, err := snapAction(baseParams, bck, apc.ActDeleteSnap, snap)
+	return err
+}
+
+func snapAction(baseParams BaseParams, bck cmn.Bck, action, snap string) (xactID string, err error) {
+	baseParams.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActionMsg{Action: action, Name: snap})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(nil)
+	}
+	err = reqParams.DoHTTPReqResp(&xactID)
+	FreeRp(reqParams)
+	return
+}
+
+// ListSnapshots returns existing snapshots of a given bucket (in the order of creation).
+func ListSnapshots(baseParams BaseParams, bck cmn.Bck) ([]cmn.BckSnap, error) {
+	props, err := HeadBucket(baseParams, bck)
+	if err != nil {
+		return nil, err
+	}
+	return props.Snapshots, nil
+}
+
 func NewProgressContext(cb ProgressCallback, after time.Duration) *ProgressContext {
 	ctx := &ProgressContext{
 		info:      ProgressInfo{Count: -1, Total: -1, Percent: -1.0},
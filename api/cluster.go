@@ -5,9 +5,11 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
@@ -34,6 +36,21 @@ func GetProxyReadiness(params BaseParams) error {
 	return err
 }
 
+// readiness as per `config.Keepalive.Readiness` criteria (503 if any of the listed
+// components is not "ok"); with no criteria configured, same as GetProxyReadiness
+func GetNodeReady(params BaseParams) error {
+	params.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = params
+		reqParams.Path = apc.URLPathHealth.S
+		reqParams.Query = url.Values{apc.QparamHealthReady: []string{"true"}}
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 func Health(baseParams BaseParams, readyToRebalance ...bool) error {
 	var q url.Values
 	baseParams.Method = http.MethodGet
@@ -51,6 +68,43 @@ func Health(baseParams BaseParams, readyToRebalance ...bool) error {
 	return err
 }
 
+// GetNodeHealth returns detailed health (component status) of the node at `baseParams.URL`
+func GetNodeHealth(baseParams BaseParams, backends bool) (nh *cmn.NodeHealth, err error) {
+	baseParams.Method = http.MethodGet
+	q := url.Values{apc.QparamHealthDetails: []string{"true"}}
+	if backends {
+		q.Set(apc.QparamHealthBackends, "true")
+	}
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathHealth.S
+		reqParams.Query = q
+	}
+	err = reqParams.DoHTTPReqResp(&nh)
+	FreeRp(reqParams)
+	return
+}
+
+// GetClusterHealth returns detailed health of all nodes in the cluster, including
+// metasync lag relative to the primary; `backends` to also check remote backends
+func GetClusterHealth(baseParams BaseParams, backends bool) (health cmn.ClusterHealth, err error) {
+	baseParams.Method = http.MethodGet
+	q := url.Values{apc.QparamWhat: []string{apc.GetWhatHealth}}
+	if backends {
+		q.Set(apc.QparamHealthBackends, "true")
+	}
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = q
+	}
+	err = reqParams.DoHTTPReqResp(&health)
+	FreeRp(reqParams)
+	return
+}
+
 // GetClusterMap retrieves AIStore cluster map.
 func GetClusterMap(baseParams BaseParams) (smap *cluster.Smap, err error) {
 	baseParams.Method = http.MethodGet
@@ -121,6 +175,22 @@ func GetClusterStats(baseParams BaseParams) (clusterStats stats.ClusterStats, er
 	return
 }
 
+// GetClusterEvents returns cluster (control-plane) events that match the specified
+// query, in chronological order. The events are served by the proxy from its
+// own (replicated) copy of the cluster event log.
+func GetClusterEvents(baseParams BaseParams, q *cmn.EventQuery) (events []cmn.Event, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = q.ToQuery(url.Values{apc.QparamWhat: []string{apc.GetWhatEvents}})
+	}
+	err = reqParams.DoHTTPReqResp(&events)
+	FreeRp(reqParams)
+	return
+}
+
 func GetTargetDiskStats(baseParams BaseParams, targetID string) (diskStats ios.AllDiskStats, err error) {
 	baseParams.Method = http.MethodGet
 	reqParams := AllocRp()
@@ -308,6 +378,125 @@ func DetachRemoteAIS(baseParams BaseParams, alias string) error {
 	return err
 }
 
+// Scheduled jobs API
+//
+
+// AddSchedJob adds a new or updates an existing (same name) scheduled job that
+// the primary will be periodically starting as per the job's cron expression
+func AddSchedJob(baseParams BaseParams, job *cmn.SchedJob) error {
+	return schedJobAction(baseParams, apc.ActionMsg{Action: apc.ActSchedAdd, Value: job})
+}
+
+func RemoveSchedJob(baseParams BaseParams, name string) error {
+	return schedJobAction(baseParams, apc.ActionMsg{Action: apc.ActSchedRemove, Name: name})
+}
+
+func schedJobAction(baseParams BaseParams, msg apc.ActionMsg) error {
+	baseParams.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// GetSchedJobs returns all scheduled jobs, including their most recent runs
+func GetSchedJobs(baseParams BaseParams) (md *cmn.SchedMD, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.GetWhatSched}}
+	}
+	md = &cmn.SchedMD{}
+	err = reqParams.DoHTTPReqResp(md)
+	FreeRp(reqParams)
+	return
+}
+
+// RunPipeline submits a pipeline (DAG of jobs) to run and returns its ID
+func RunPipeline(baseParams BaseParams, pl *cmn.Pipeline) (id string, err error) {
+	baseParams.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(apc.ActionMsg{Action: apc.ActPipelineRun, Value: pl})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	err = reqParams.DoHTTPReqResp(&id)
+	FreeRp(reqParams)
+	return
+}
+
+// StopPipeline aborts running steps and skips the remaining steps of a given pipeline
+func StopPipeline(baseParams BaseParams, id string) error {
+	baseParams.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(apc.ActionMsg{Action: apc.ActPipelineStop, Name: id})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// GetPipelineStatus returns the status of a given pipeline and each of its steps
+func GetPipelineStatus(baseParams BaseParams, id string) (status *cmn.PipelineStatus, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.GetWhatPipeline}, apc.QparamUUID: []string{id}}
+	}
+	status = &cmn.PipelineStatus{}
+	err = reqParams.DoHTTPReqResp(status)
+	FreeRp(reqParams)
+	return
+}
+
+// ListPipelines returns the status of all running and recently finished pipelines
+func ListPipelines(baseParams BaseParams) (list []*cmn.PipelineStatus, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.GetWhatPipeline}}
+	}
+	err = reqParams.DoHTTPReqResp(&list)
+	FreeRp(reqParams)
+	return
+}
+
+// WaitForPipeline waits for a given pipeline to finish (timeout <= 0: wait indefinitely)
+func WaitForPipeline(baseParams BaseParams, id string, timeout time.Duration) (status *cmn.PipelineStatus, err error) {
+	var (
+		sleep    = xactMinPollTime
+		deadline = time.Now().Add(timeout)
+	)
+	for {
+		if status, err = GetPipelineStatus(baseParams, id); err != nil || status.Finished() {
+			return
+		}
+		if timeout > 0 && time.Now().After(deadline) {
+			return status, fmt.Errorf("timed out waiting for pipeline %q (%v)", id, timeout)
+		}
+		time.Sleep(sleep)
+		sleep = backoffPoll(sleep)
+	}
+}
+
 // Maintenance API
 //
 func StartMaintenance(baseParams BaseParams, actValue *apc.ActValRmNode) (id string, err error) {
@@ -67,6 +67,19 @@ func DownloadMulti(baseParams BaseParams, description string, bck cmn.Bck, msg i
 	return DownloadWithParam(baseParams, downloader.DlTypeMulti, dlBody)
 }
 
+// DownloadManifest downloads the links listed in the manifest and verifies each
+// downloaded object against its expected size and checksum(s), if specified.
+func DownloadManifest(baseParams BaseParams, description string, bck cmn.Bck, manifest []downloader.DlManifestEntry,
+	intervals ...time.Duration) (string, error) {
+	dlBody := downloader.DlMultiBody{Manifest: manifest}
+	if len(intervals) > 0 {
+		dlBody.ProgressInterval = intervals[0].String()
+	}
+	dlBody.Bck = bck
+	dlBody.Description = description
+	return DownloadWithParam(baseParams, downloader.DlTypeMulti, dlBody)
+}
+
 func DownloadBackend(baseParams BaseParams, description string, bck cmn.Bck, prefix, suffix string,
 	intervals ...time.Duration) (string, error) {
 	dlBody := downloader.DlBackendBody{Prefix: prefix, Suffix: suffix}
@@ -143,6 +156,21 @@ func RemoveDownload(baseParams BaseParams, id string) error {
 	return err
 }
 
+func RetryDownload(baseParams BaseParams, id string) error {
+	dlBody := downloader.DlAdminBody{ID: id}
+	baseParams.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathDownloadRetry.S
+		reqParams.Body = cos.MustMarshal(dlBody)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	_, err := reqParams.doDlDownloadRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 func (reqParams *ReqParams) doDlDownloadRequest() (string, error) {
 	var resp downloader.DlPostResp
 	err := reqParams.DoHTTPReqResp(&resp)
@@ -6,7 +6,9 @@ package api
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -67,6 +69,44 @@ func EvictRange(baseParams BaseParams, bck cmn.Bck, rng string) (string, error)
 	return doListRangeRequest(baseParams, bck, apc.ActEvictObjects, evictMsg)
 }
 
+// GetBatch gets multiple objects and/or archived files (possibly, from multiple buckets)
+// as a single archive formatted as per `msg.Mime` (default: TAR), and writes the latter
+// into the provided writer. Returns the number of bytes written.
+// Objects that don't specify bucket default to `bck`.
+// See also: `cmn.GetBatchMsg`, `GetBatchReader`
+func GetBatch(baseParams BaseParams, bck cmn.Bck, msg *cmn.GetBatchMsg, w io.Writer) (n int64, err error) {
+	var resp *wrappedResp
+	reqParams := getBatchParams(baseParams, bck, msg)
+	resp, err = reqParams.doResp(w)
+	FreeRp(reqParams)
+	if err != nil {
+		return 0, err
+	}
+	return resp.n, nil
+}
+
+// GetBatchReader is the same as `GetBatch` except that it returns the resulting (streamed)
+// archive for reading; the caller is responsible for closing the reader.
+func GetBatchReader(baseParams BaseParams, bck cmn.Bck, msg *cmn.GetBatchMsg) (io.ReadCloser, error) {
+	reqParams := getBatchParams(baseParams, bck, msg)
+	r, err := reqParams.doReader()
+	FreeRp(reqParams)
+	return r, err
+}
+
+func getBatchParams(baseParams BaseParams, bck cmn.Bck, msg *cmn.GetBatchMsg) (reqParams *ReqParams) {
+	baseParams.Method = http.MethodGet
+	reqParams = AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(url.Values{})
+		reqParams.Body = cos.MustMarshal(apc.ActionMsg{Action: apc.ActGetBatch, Value: msg})
+	}
+	return
+}
+
 // Handles multi-object (delete, prefetch, evict) operations
 // as well as (archive, copy and ETL) transactions
 func doListRangeRequest(baseParams BaseParams, bck cmn.Bck, action string, msg interface{}) (xactID string, err error) {
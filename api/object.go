@@ -57,6 +57,7 @@ type (
 	AppendToArchArgs struct {
 		PutObjectArgs
 		ArchPath string
+		Replace  bool // replace archived file if exists (otherwise, fail - or, in case of TAR, append as is)
 	}
 	PromoteArgs struct {
 		BaseParams BaseParams
@@ -157,7 +158,28 @@ func HeadObject(baseParams BaseParams, bck cmn.Bck, object string, checkExists .
 	} else {
 		q = bck.AddToQuery(nil)
 	}
+	return headObject(baseParams, bck, object, q, checkIsCached)
+}
+
+// HeadObjectVersion returns properties of a given (current or previous) version
+// of the object - see cmn.VersionConf.History
+func HeadObjectVersion(baseParams BaseParams, bck cmn.Bck, object, version string) (*cmn.ObjectProps, error) {
+	baseParams.Method = http.MethodHead
+	q := bck.AddToQuery(nil)
+	q.Set(apc.QparamVersionID, version)
+	return headObject(baseParams, bck, object, q, false)
+}
 
+// HeadObjectSnapshot returns properties of the object in a given bucket snapshot
+// (to GET the object from the snapshot, use GetObjectInput.Query with apc.QparamSnapshot)
+func HeadObjectSnapshot(baseParams BaseParams, bck cmn.Bck, object, snap string) (*cmn.ObjectProps, error) {
+	baseParams.Method = http.MethodHead
+	q := bck.AddToQuery(nil)
+	q.Set(apc.QparamSnapshot, snap)
+	return headObject(baseParams, bck, object, q, false)
+}
+
+func headObject(baseParams BaseParams, bck cmn.Bck, object string, q url.Values, checkIsCached bool) (*cmn.ObjectProps, error) {
 	reqParams := AllocRp()
 	defer FreeRp(reqParams)
 	{
@@ -237,6 +259,23 @@ func DeleteObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	return err
 }
 
+// DeleteObjectVersion permanently removes a given (current or previous) version of the object,
+// or a delete marker. Removing the current version makes the most recent previous version current.
+func DeleteObjectVersion(baseParams BaseParams, bck cmn.Bck, object, version string) error {
+	baseParams.Method = http.MethodDelete
+	q := bck.AddToQuery(nil)
+	q.Set(apc.QparamVersionID, version)
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, object)
+		reqParams.Query = q
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // EvictObject evicts an object specified by bucket/object.
 func EvictObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	baseParams.Method = http.MethodDelete
@@ -413,6 +452,7 @@ func PutObject(args PutObjectArgs) (err error) {
 // object formatted as one of the supported archives.
 // In other words, append to an existing archive.
 // For supported archival (mime) types, see cmn/cos/archive.go.
+// With `args.Replace` set, replace the archived file in place (or add it if doesn't exist).
 // NOTE: compare with:
 //   - `api.CreateArchMultiObj`
 //   - `api.AppendObject`
@@ -425,6 +465,9 @@ func AppendToArch(args AppendToArchArgs) (err error) {
 	q = args.Bck.AddToQuery(q)
 	q.Set(apc.QparamArchpath, args.ArchPath)
 	q.Set(apc.QparamArchmime, m)
+	if args.Replace {
+		q.Set(apc.QparamArchmode, apc.ArchReplace)
+	}
 	reqArgs := cmn.AllocHra()
 	{
 		reqArgs.Method = http.MethodPut
@@ -514,6 +557,40 @@ func RenameObject(baseParams BaseParams, bck cmn.Bck, oldName, newName string) e
 	return err
 }
 
+// DeleteFromArch removes a given file from an existing archive (shard).
+func DeleteFromArch(baseParams BaseParams, bck cmn.Bck, object, archPath string) error {
+	baseParams.Method = http.MethodDelete
+	q := bck.AddToQuery(nil)
+	q.Set(apc.QparamArchpath, archPath)
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, object)
+		reqParams.Query = q
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// ExtractArch stores archived files as individual objects named `msg.Prefix` + filename
+// in the destination bucket (`msg.ToBck`, or the archive's own bucket if empty).
+// Returns the number of extracted files.
+func ExtractArch(baseParams BaseParams, bck cmn.Bck, object string, msg *cmn.ExtractArchMsg) (cnt int64, err error) {
+	baseParams.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, object)
+		reqParams.Body = cos.MustMarshal(apc.ActionMsg{Action: apc.ActExtractArch, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(nil)
+	}
+	err = reqParams.DoHTTPReqResp(&cnt)
+	FreeRp(reqParams)
+	return
+}
+
 // promote files and directories to ais objects
 func Promote(args *PromoteArgs) (xactID string, err error) {
 	actMsg := apc.ActionMsg{Action: apc.ActPromote, Name: args.SrcFQN}
@@ -142,12 +142,22 @@ func QueryXactionSnaps(baseParams BaseParams, args XactReqArgs) (xs NodesXactMul
 
 // GetXactionStatus retrieves the status of the xact.
 func GetXactionStatus(baseParams BaseParams, args XactReqArgs) (status *nl.NotifStatus, err error) {
+	var ext *xact.StatusExt
+	if ext, err = GetXactionProgress(baseParams, args); err == nil {
+		status = &ext.NotifStatus
+	}
+	return
+}
+
+// GetXactionProgress retrieves the status of the xact along with its (cluster-wide)
+// progress: totals, percent complete, throughput and ETA, when available.
+func GetXactionProgress(baseParams BaseParams, args XactReqArgs) (status *xact.StatusExt, err error) {
 	baseParams.Method = http.MethodGet
 	msg := xact.QueryMsg{ID: args.ID, Kind: args.Kind, Bck: args.Bck}
 	if args.OnlyRunning {
 		msg.OnlyRunning = Bool(true)
 	}
-	status = &nl.NotifStatus{}
+	status = &xact.StatusExt{}
 	reqParams := AllocRp()
 	{
 		reqParams.BaseParams = baseParams
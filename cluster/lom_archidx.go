@@ -0,0 +1,143 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn/archidx"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Archive index sidecars: <bucket>/%ai/<object name> (fs.ArchIndexType) on the archive's
+// mountpath - see cmn/archidx. An index is used only when it matches the archive's current
+// size and mtime; obsolete indices get removed by space cleanup.
+
+// max total number of (archived file) entries in the in-memory cache of recently used indices
+const maxCachedArchEntries = 1024 * 1024
+
+type (
+	cachedArchIdx struct {
+		idx   *archidx.Index
+		atime int64
+	}
+	archIdxCache struct {
+		m   map[string]*cachedArchIdx // index FQN => index
+		num int                       // total number of entries
+		mu  sync.Mutex
+	}
+)
+
+var aidxCache = archIdxCache{m: make(map[string]*cachedArchIdx, 64)}
+
+func (lom *LOM) ArchIndexFQN() string {
+	return lom.mpathInfo.MakePathFQN(lom.Bucket(), fs.ArchIndexType, lom.ObjName)
+}
+
+// LoadArchIndex returns the archive's index if the latter exists and is up to date,
+// or nil otherwise. The caller provides the archive's (current) file info.
+func (lom *LOM) LoadArchIndex(finfo os.FileInfo) *archidx.Index {
+	ifqn := lom.ArchIndexFQN()
+	if idx := aidxCache.get(ifqn); idx != nil && idx.Valid(finfo) {
+		return idx
+	}
+	idx := &archidx.Index{}
+	if _, err := jsp.LoadMeta(ifqn, idx); err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to load archive index: %v", lom, err)
+		}
+		return nil
+	}
+	if !idx.Valid(finfo) {
+		return nil
+	}
+	aidxCache.put(ifqn, idx)
+	return idx
+}
+
+// BuildArchIndex (re)builds and stores the archive's index; the archive's type is
+// determined by its extension (see archidx.Indexable).
+func (lom *LOM) BuildArchIndex() (*archidx.Index, error) {
+	mime, err := cos.Mime("", lom.ObjName)
+	if err != nil {
+		return nil, err
+	}
+	fh, err := os.Open(lom.FQN)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := archidx.Build(fh, mime)
+	cos.Close(fh)
+	if err != nil {
+		return nil, err
+	}
+	ifqn := lom.ArchIndexFQN()
+	if err = cos.CreateDir(filepath.Dir(ifqn)); err != nil {
+		return nil, err
+	}
+	if err = jsp.SaveMeta(ifqn, idx, nil); err != nil {
+		return nil, err
+	}
+	aidxCache.put(ifqn, idx)
+	return idx, nil
+}
+
+// ArchIndexObsolete returns true if a given index (as per its parsed FQN) does not match
+// its archive, or the archive does not exist.
+func ArchIndexObsolete(ifqn string, parsedFQN *fs.ParsedFQN) bool {
+	finfo, err := os.Stat(parsedFQN.MpathInfo.MakePathFQN(&parsedFQN.Bck, fs.ObjectType, parsedFQN.ObjName))
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	idx := &archidx.Index{}
+	if _, err := jsp.LoadMeta(ifqn, idx); err != nil {
+		return true
+	}
+	return !idx.Valid(finfo)
+}
+
+//////////////////
+// archIdxCache //
+//////////////////
+
+func (c *archIdxCache) get(ifqn string) (idx *archidx.Index) {
+	c.mu.Lock()
+	if e, ok := c.m[ifqn]; ok {
+		e.atime = mono.NanoTime()
+		idx = e.idx
+	}
+	c.mu.Unlock()
+	return
+}
+
+func (c *archIdxCache) put(ifqn string, idx *archidx.Index) {
+	c.mu.Lock()
+	if e, ok := c.m[ifqn]; ok {
+		c.num -= len(e.idx.Entries)
+	}
+	c.m[ifqn] = &cachedArchIdx{idx: idx, atime: mono.NanoTime()}
+	c.num += len(idx.Entries)
+	// evict least recently used
+	for c.num > maxCachedArchEntries && len(c.m) > 1 {
+		var (
+			lru   string
+			atime int64
+		)
+		for k, e := range c.m {
+			if k != ifqn && (lru == "" || e.atime < atime) {
+				lru, atime = k, e.atime
+			}
+		}
+		c.num -= len(c.m[lru].idx.Entries)
+		delete(c.m, lru)
+	}
+	c.mu.Unlock()
+}
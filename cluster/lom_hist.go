@@ -0,0 +1,331 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Object version history (ais:// buckets only; see cmn.VersionConf.History):
+// - when an object gets overwritten or deleted, its current replica is linked
+//   (xattr-stored metadata and all) into fs.ObjVersionType content on the same mountpath,
+//   one directory per object;
+// - delete adds an (empty) delete marker that takes the next version number;
+// - the history is trimmed by count upon every change and by age (retention)
+//   by the space cleanup.
+// Version IDs are the (numeric) ais versions - see LOM.IncVersion.
+
+type ObjVersion struct {
+	FQN       string
+	Ver       string
+	Mtime     int64 // when the version became non-current
+	DelMarker bool
+}
+
+func (lom *LOM) HistoryEnabled() bool {
+	if !lom.Bck().IsAIS() {
+		return false
+	}
+	conf := lom.VersionConf()
+	return conf.HistoryEnabled()
+}
+
+func (lom *LOM) versionFQN(ver string, delMarker bool) string {
+	return fs.CSM.Gen(lom, fs.ObjVersionType, fs.ObjVersionTag(ver, delMarker))
+}
+
+// ListVersions returns previous versions and delete markers - newest first.
+func (lom *LOM) ListVersions() (vers []*ObjVersion, err error) {
+	var (
+		dir      = filepath.Dir(lom.versionFQN(lomInitialVersion, false))
+		dentries []os.DirEntry
+	)
+	if dentries, err = os.ReadDir(dir); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	for _, de := range dentries {
+		if de.IsDir() {
+			continue
+		}
+		ver, delMarker, ok := fs.ParseObjVersionTag(de.Name())
+		if !ok {
+			continue
+		}
+		finfo, errV := de.Info()
+		if errV != nil {
+			continue // removed in the meantime
+		}
+		ov := &ObjVersion{
+			FQN:       filepath.Join(dir, de.Name()),
+			Ver:       ver,
+			Mtime:     finfo.ModTime().UnixNano(),
+			DelMarker: delMarker,
+		}
+		vers = append(vers, ov)
+	}
+	sort.Slice(vers, func(i, j int) bool { return cmn.VersionLess(vers[j].Ver, vers[i].Ver) })
+	return
+}
+
+// PreserveVersion links the current (on-disk) object into version history and returns
+// the most recent known version for the caller to increment, along with the preserved
+// replica (empty if there's none). The current object stays intact: once it gets
+// overwritten the caller calls CommitHistory; otherwise, it removes the replica.
+// Must be called under exclusive lock.
+func (lom *LOM) PreserveVersion() (latest, hfqn string, err error) {
+	debug.AssertFunc(func() bool { _, exclusive := lom.IsLocked(); return exclusive })
+	// NOTE: versionless objects (written before versioning was enabled) are not preserved
+	if md, errMD := lom.lmfs(false); errMD == nil && md.Ver != "" {
+		fqn := lom.versionFQN(md.Ver, false)
+		if err = linkVersion(lom.FQN, fqn); err == nil {
+			hfqn = fqn
+		} else if !os.IsExist(err) {
+			err = cmn.NewErrFailedTo(T, "preserve version of", lom, err)
+			return
+		}
+		err = nil
+		latest = md.Ver
+	}
+	var vers []*ObjVersion
+	if vers, err = lom.ListVersions(); err != nil {
+		if hfqn != "" {
+			if errRm := cos.RemoveFile(hfqn); errRm != nil {
+				glog.Errorf("nested error: %v --> %v", err, errRm)
+			}
+		}
+		return
+	}
+	if len(vers) > 0 && (latest == "" || cmn.VersionLess(latest, vers[0].Ver)) {
+		latest = vers[0].Ver
+	}
+	return
+}
+
+func linkVersion(fqn, hfqn string) (err error) {
+	if err = os.Link(fqn, hfqn); err == nil || !os.IsNotExist(err) {
+		return
+	}
+	if err = cos.CreateDir(filepath.Dir(hfqn)); err != nil {
+		return
+	}
+	return os.Link(fqn, hfqn)
+}
+
+// CommitHistory timestamps the version preserved by PreserveVersion (if any) with the
+// time it became non-current and trims the history. Exclusive lock is required.
+func (lom *LOM) CommitHistory(hfqn string) {
+	now := time.Now()
+	if hfqn != "" {
+		if err := os.Chtimes(hfqn, now, now); err != nil {
+			glog.Errorf("%s: %v", lom, err)
+		}
+	}
+	vers, err := lom.ListVersions()
+	if err != nil {
+		glog.Errorf("%s: failed to list versions: %v", lom, err)
+		return
+	}
+	lom.trimHistory(vers, now)
+}
+
+// RemoveToHistory removes the object (and its copies, if any) while keeping it
+// in version history, followed by a delete marker. Exclusive lock is required.
+func (lom *LOM) RemoveToHistory() (err error) {
+	latest, hfqn, err := lom.PreserveVersion()
+	if err != nil {
+		return
+	}
+	next := lomInitialVersion
+	if latest != "" {
+		n, errV := strconv.ParseInt(latest, 10, 64)
+		if errV != nil {
+			err = cmn.NewErrFailedTo(T, "parse version of", lom, errV)
+			lom.abortHistory(hfqn, "", err)
+			return
+		}
+		next = strconv.FormatInt(n+1, 10)
+	}
+	fqn := lom.versionFQN(next, true)
+	fh, err := cos.CreateFile(fqn)
+	if err != nil {
+		err = cmn.NewErrFailedTo(T, "create delete marker for", lom, err)
+		lom.abortHistory(hfqn, "", err)
+		return
+	}
+	cos.Close(fh)
+	if err = lom.Remove(); err != nil {
+		lom.abortHistory(hfqn, fqn, err)
+		return
+	}
+	lom.CommitHistory(hfqn)
+	return
+}
+
+func (lom *LOM) abortHistory(hfqn, markerFQN string, err error) {
+	for _, fqn := range []string{hfqn, markerFQN} {
+		if fqn == "" {
+			continue
+		}
+		if errRm := cos.RemoveFile(fqn); errRm != nil {
+			glog.Errorf("nested error: %v --> %v", err, errRm)
+		}
+	}
+}
+
+// keep at most `History` (newest) versions; retention is also enforced by the space cleanup
+func (lom *LOM) trimHistory(vers []*ObjVersion, now time.Time) {
+	var (
+		conf    = lom.VersionConf()
+		removed bool
+	)
+	for i, ov := range vers {
+		if i < conf.History && !VersionExpired(&conf, ov.Mtime, now.UnixNano()) {
+			continue
+		}
+		if err := cos.RemoveFile(ov.FQN); err != nil {
+			glog.Errorf("%s: failed to remove version %s: %v", lom, ov.Ver, err)
+		} else {
+			removed = true
+		}
+	}
+	if removed {
+		fs.RemoveObjVersionDir(vers[0].FQN)
+	}
+}
+
+func VersionExpired(conf *cmn.VersionConf, mtime, now int64) bool {
+	return conf.Retention > 0 && mtime+int64(conf.Retention) < now
+}
+
+// LoadVersion returns the given previous version. For a delete marker the returned LOM is nil.
+// Otherwise, the LOM points to the previous version's replica (`FQN`) and must be freed.
+func (lom *LOM) LoadVersion(ver string) (hlom *LOM, ov *ObjVersion, err error) {
+	if ov, err = lom.findVersion(ver); err != nil || ov.DelMarker {
+		return
+	}
+	hlom, err = lom.LoadVersionMD(ov)
+	return
+}
+
+// LoadVersionMD loads metadata of a given previous version (that is not a delete marker)
+func (lom *LOM) LoadVersionMD(ov *ObjVersion) (hlom *LOM, err error) {
+	debug.Assert(!ov.DelMarker)
+	hlom = lom.CloneMD(ov.FQN)
+	hlom.md = lmeta{uname: lom.md.uname}
+	if _, err = hlom.lmfs(true); err == nil {
+		hlom.md.copies = nil // (history is never mirrored)
+		hlom.md.Atime = ov.Mtime
+		hlom.md.bckID = lom.Bprops().BID
+		return
+	}
+	FreeLOM(hlom)
+	hlom = nil
+	return
+}
+
+func (lom *LOM) findVersion(ver string) (*ObjVersion, error) {
+	vers, err := lom.ListVersions()
+	if err != nil {
+		return nil, err
+	}
+	for _, ov := range vers {
+		if ov.Ver == ver {
+			return ov, nil
+		}
+	}
+	return nil, cmn.NewErrNotFound("%s: version %q of %s", T.Snode(), ver, lom.FullName())
+}
+
+// DeleteVersion permanently removes the given version. Removing the current version,
+// or the delete marker that "hides" a deleted object, makes the most recent remaining
+// version current. Exclusive lock is required.
+func (lom *LOM) DeleteVersion(ver string) error {
+	debug.AssertFunc(func() bool { _, exclusive := lom.IsLocked(); return exclusive })
+	lom.CowSnap()
+	if errLoad := lom.Load(false /*cache it*/, true /*locked*/); errLoad == nil {
+		if lom.Version() != ver {
+			ov, err := lom.findVersion(ver)
+			if err != nil {
+				return err
+			}
+			return lom.removeVersion(ov)
+		}
+		if err := lom.Remove(); err != nil {
+			return err
+		}
+	} else {
+		if !cmn.IsObjNotExist(errLoad) {
+			return errLoad
+		}
+		vers, err := lom.ListVersions()
+		if err != nil {
+			return err
+		}
+		idx := -1
+		for i, ov := range vers {
+			if ov.Ver == ver {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return cmn.NewErrNotFound("%s: version %q of %s", T.Snode(), ver, lom.FullName())
+		}
+		if err := lom.removeVersion(vers[idx]); err != nil {
+			return err
+		}
+		if idx > 0 {
+			return nil
+		}
+	}
+	return lom.promoteLatest()
+}
+
+func (lom *LOM) removeVersion(ov *ObjVersion) error {
+	if err := cos.RemoveFile(ov.FQN); err != nil {
+		return err
+	}
+	fs.RemoveObjVersionDir(ov.FQN)
+	return nil
+}
+
+func (lom *LOM) promoteLatest() error {
+	vers, err := lom.ListVersions()
+	if err != nil || len(vers) == 0 || vers[0].DelMarker {
+		return err
+	}
+	if err := cos.Rename(vers[0].FQN, lom.FQN); err != nil {
+		return cmn.NewErrFailedTo(T, "restore version of", lom, err)
+	}
+	fs.RemoveObjVersionDir(vers[0].FQN)
+	lom.md = lmeta{uname: lom.md.uname}
+	if _, err := lom.lmfs(true); err != nil {
+		return err
+	}
+	if len(lom.md.copies) > 0 {
+		// the copies of the (then) current version have been removed since
+		lom.md.copies = nil
+		buf, mm := lom.marshal()
+		err = fs.SetXattr(lom.FQN, XattrLOM, buf)
+		mm.Free(buf)
+		if err != nil {
+			return err
+		}
+	}
+	return lom.Load(true /*cache it*/, true /*locked*/)
+}
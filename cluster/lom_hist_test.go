@@ -0,0 +1,277 @@
+// Package cluster_test provides tests for cluster package
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LOM version history", func() {
+	const (
+		tmpDir  = "/tmp/lom_hist_test"
+		objName = "dir/obj"
+
+		bucketHist      = "HIST_TEST_History"
+		bucketRetention = "HIST_TEST_Retention"
+	)
+
+	var (
+		mpath = filepath.Join(tmpDir, "mpath")
+
+		histBck      = cmn.Bck{Name: bucketHist, Provider: apc.ProviderAIS, Ns: cmn.NsGlobal}
+		retentionBck = cmn.Bck{Name: bucketRetention, Provider: apc.ProviderAIS, Ns: cmn.NsGlobal}
+	)
+
+	bmd := mock.NewBaseBownerMock(
+		cluster.NewBck(
+			bucketHist, apc.ProviderAIS, cmn.NsGlobal,
+			&cmn.BucketProps{
+				Cksum:      cmn.CksumConf{Type: cos.ChecksumXXHash},
+				Versioning: cmn.VersionConf{Enabled: true, History: 2},
+				BID:        11,
+			},
+		),
+		cluster.NewBck(
+			bucketRetention, apc.ProviderAIS, cmn.NsGlobal,
+			&cmn.BucketProps{
+				Cksum:      cmn.CksumConf{Type: cos.ChecksumXXHash},
+				Versioning: cmn.VersionConf{Enabled: true, History: 10, Retention: cos.Duration(time.Hour)},
+				BID:        12,
+			},
+		),
+	)
+
+	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.Reg(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
+
+	BeforeEach(func() {
+		_ = cos.CreateDir(mpath)
+		fs.TestDisableValidation()
+		_, _ = fs.Add(mpath, "daeID")
+		_ = mock.NewTarget(bmd)
+	})
+
+	AfterEach(func() {
+		// (mountpaths of other test suites may be still registered)
+		for _, mi := range fs.GetAvail() {
+			_ = os.RemoveAll(mi.MakePathBck(&histBck))
+			_ = os.RemoveAll(mi.MakePathBck(&retentionBck))
+		}
+		_, _ = fs.Remove(mpath)
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	newLOM := func(bck cmn.Bck) *cluster.LOM {
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(&bck)).NotTo(HaveOccurred())
+		return lom
+	}
+
+	// same as PUT (see ais/tgtobj.go) minus the networking
+	put := func(lom *cluster.LOM, size int) {
+		lom.Lock(true)
+		defer lom.Unlock(true)
+		latest, hfqn, err := lom.PreserveVersion()
+		Expect(err).NotTo(HaveOccurred())
+		workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfilePut)
+		createTestFile(workFQN, size)
+		lom.SetVersion(latest)
+		Expect(lom.IncVersion()).NotTo(HaveOccurred())
+		Expect(cos.Rename(workFQN, lom.FQN)).NotTo(HaveOccurred())
+		lom.CommitHistory(hfqn)
+		lom.SetSize(int64(size))
+		Expect(persist(lom)).NotTo(HaveOccurred())
+	}
+
+	del := func(lom *cluster.LOM) {
+		lom.Lock(true)
+		defer lom.Unlock(true)
+		Expect(lom.Load(false, true)).NotTo(HaveOccurred())
+		Expect(lom.RemoveToHistory()).NotTo(HaveOccurred())
+	}
+
+	versions := func(lom *cluster.LOM) (vers []string) {
+		list, err := lom.ListVersions()
+		Expect(err).NotTo(HaveOccurred())
+		for _, ov := range list {
+			ver := ov.Ver
+			if ov.DelMarker {
+				ver += "(d)"
+			}
+			vers = append(vers, ver)
+		}
+		return
+	}
+
+	loadCurrent := func(lom *cluster.LOM) error {
+		lom.Uncache(true)
+		return lom.Load(false, false)
+	}
+
+	It("should preserve overwritten versions", func() {
+		lom := newLOM(histBck)
+		put(lom, 10)
+		Expect(versions(lom)).To(BeEmpty())
+
+		put(lom, 20)
+		Expect(lom.Version()).To(Equal("2"))
+		Expect(versions(lom)).To(Equal([]string{"1"}))
+
+		Expect(loadCurrent(lom)).NotTo(HaveOccurred())
+		Expect(lom.SizeBytes()).To(BeEquivalentTo(20))
+		Expect(lom.Version()).To(Equal("2"))
+	})
+
+	It("should add delete marker and continue numbering after it", func() {
+		lom := newLOM(histBck)
+		put(lom, 10)
+		del(lom)
+		Expect(versions(lom)).To(Equal([]string{"2(d)", "1"}))
+		Expect(cmn.IsObjNotExist(loadCurrent(lom))).To(BeTrue())
+
+		put(lom, 30)
+		Expect(lom.Version()).To(Equal("3"))
+		Expect(versions(lom)).To(Equal([]string{"2(d)", "1"}))
+	})
+
+	It("should trim history by count", func() {
+		lom := newLOM(histBck)
+		for i := 0; i < 5; i++ {
+			put(lom, 10+i)
+		}
+		Expect(lom.Version()).To(Equal("5"))
+		Expect(versions(lom)).To(Equal([]string{"4", "3"}))
+	})
+
+	It("should trim history by retention", func() {
+		lom := newLOM(retentionBck)
+		put(lom, 10)
+		put(lom, 20)
+		put(lom, 30)
+		Expect(versions(lom)).To(Equal([]string{"2", "1"}))
+
+		list, err := lom.ListVersions()
+		Expect(err).NotTo(HaveOccurred())
+		old := time.Now().Add(-2 * time.Hour)
+		Expect(os.Chtimes(list[1].FQN, old, old)).NotTo(HaveOccurred())
+
+		put(lom, 40)
+		Expect(versions(lom)).To(Equal([]string{"3", "2"}))
+	})
+
+	It("should keep a separate history for each object", func() {
+		lom := newLOM(histBck)
+		put(lom, 10)
+		put(lom, 20)
+
+		other := &cluster.LOM{ObjName: objName + ".other"}
+		Expect(other.InitBck(&histBck)).NotTo(HaveOccurred())
+		put(other, 10)
+		put(other, 20)
+		put(other, 30)
+
+		Expect(versions(lom)).To(Equal([]string{"1"}))
+		Expect(versions(other)).To(Equal([]string{"2", "1"}))
+	})
+
+	It("should make the latest remaining version current when deleting the current one", func() {
+		lom := newLOM(histBck)
+		put(lom, 10)
+		put(lom, 20)
+		put(lom, 30)
+
+		lom.Lock(true)
+		Expect(lom.DeleteVersion("3")).NotTo(HaveOccurred())
+		lom.Unlock(true)
+
+		Expect(loadCurrent(lom)).NotTo(HaveOccurred())
+		Expect(lom.Version()).To(Equal("2"))
+		Expect(lom.SizeBytes()).To(BeEquivalentTo(20))
+		Expect(versions(lom)).To(Equal([]string{"1"}))
+	})
+
+	It("should restore the object when deleting its delete marker", func() {
+		lom := newLOM(histBck)
+		put(lom, 10)
+		del(lom)
+
+		lom.Lock(true)
+		Expect(lom.DeleteVersion("2")).NotTo(HaveOccurred())
+		lom.Unlock(true)
+
+		Expect(loadCurrent(lom)).NotTo(HaveOccurred())
+		Expect(lom.Version()).To(Equal("1"))
+		Expect(versions(lom)).To(BeEmpty())
+	})
+
+	It("should delete a previous version", func() {
+		lom := newLOM(histBck)
+		put(lom, 10)
+		put(lom, 20)
+
+		lom.Lock(true)
+		Expect(lom.DeleteVersion("1")).NotTo(HaveOccurred())
+		err := lom.DeleteVersion("1")
+		lom.Unlock(true)
+		Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+
+		Expect(versions(lom)).To(BeEmpty())
+		Expect(loadCurrent(lom)).NotTo(HaveOccurred())
+		Expect(lom.Version()).To(Equal("2"))
+	})
+
+	It("should load previous versions and delete markers", func() {
+		lom := newLOM(histBck)
+		put(lom, 10)
+		put(lom, 20)
+		del(lom)
+
+		hlom, ov, err := lom.LoadVersion("2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ov.DelMarker).To(BeFalse())
+		Expect(hlom.Version()).To(Equal("2"))
+		Expect(hlom.SizeBytes()).To(BeEquivalentTo(20))
+		Expect(hlom.FQN).To(Equal(ov.FQN))
+		cluster.FreeLOM(hlom)
+
+		hlom, ov, err = lom.LoadVersion("3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hlom).To(BeNil())
+		Expect(ov.DelMarker).To(BeTrue())
+
+		_, _, err = lom.LoadVersion("1") // trimmed
+		Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+	})
+
+	It("should keep the object intact when the preserved version is discarded", func() {
+		lom := newLOM(histBck)
+		put(lom, 10)
+
+		lom.Lock(true)
+		latest, hfqn, err := lom.PreserveVersion()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(latest).To(Equal("1"))
+		Expect(hfqn).NotTo(BeEmpty())
+		Expect(cos.RemoveFile(hfqn)).NotTo(HaveOccurred())
+		lom.Unlock(true)
+
+		Expect(versions(lom)).To(BeEmpty())
+		Expect(loadCurrent(lom)).NotTo(HaveOccurred())
+		Expect(lom.Version()).To(Equal("1"))
+		Expect(lom.SizeBytes()).To(BeEquivalentTo(10))
+	})
+})
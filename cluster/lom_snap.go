@@ -0,0 +1,214 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Bucket snapshots (ais:// buckets only; see cmn.BckSnap):
+// - snapshot is a set of hard links to the bucket's objects, each on the object's own
+//   mountpath: <bucket>/%sn/<snapshot name>/<object name> (fs.SnapshotType);
+// - objects are never updated in place (PUT writes a new file and renames it over the
+//   existing one), which makes hard links copy-on-write; the (few) in-place updates
+//   call CowInPlace first;
+// - while a snapshot is being taken, objects that get overwritten or deleted are linked
+//   prior to being updated (CowSnap), while objects that are newer than the snapshot
+//   are skipped - the snapshot's point in time is when it started on a given target.
+
+type snapInProgress struct {
+	name    string
+	started int64
+}
+
+var snapsInProgress sync.Map // bucket ID => *snapInProgress
+
+// SnapBegin marks the start of a snapshot and returns its (local) point in time.
+func SnapBegin(bck *Bck, snap string) (started int64) {
+	started = time.Now().UnixNano()
+	snapsInProgress.Store(bck.Props.BID, &snapInProgress{name: snap, started: started})
+	return
+}
+
+func SnapEnd(bck *Bck) { snapsInProgress.Delete(bck.Props.BID) }
+
+func (lom *LOM) snapFQN(mi *fs.MountpathInfo, snap string) string {
+	return mi.MakePathFQN(lom.Bucket(), fs.SnapshotType, snap+"/"+lom.ObjName)
+}
+
+// LinkSnap adds the object to a given snapshot unless the object is newer than the snapshot
+// or is already present there. Must be called under lock.
+func (lom *LOM) LinkSnap(snap string, started int64) (linked bool, err error) {
+	var finfo os.FileInfo
+	if finfo, err = os.Stat(lom.FQN); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	if finfo.ModTime().UnixNano() > started {
+		return // written after the snapshot
+	}
+	sfqn := lom.snapFQN(lom.mpathInfo, snap)
+	if err = cos.CreateDir(filepath.Dir(sfqn)); err != nil {
+		return
+	}
+	if err = os.Link(lom.FQN, sfqn); err == nil {
+		linked = true
+	} else if os.IsExist(err) {
+		err = nil
+	}
+	return
+}
+
+// CowSnap preserves the object in the snapshot that is currently being taken, if any.
+// Must be called under exclusive lock prior to overwriting or removing the object.
+func (lom *LOM) CowSnap() {
+	v, ok := snapsInProgress.Load(lom.Bprops().BID)
+	if !ok {
+		return
+	}
+	sip := v.(*snapInProgress)
+	if _, err := lom.LinkSnap(sip.name, sip.started); err != nil {
+		glog.Errorf("%s: failed to preserve in snapshot %q: %v", lom, sip.name, err)
+	}
+}
+
+// CowInPlace must be called (under exclusive lock) prior to updating the object in place
+// (e.g., appending to TAR): an object that shares its file with a snapshot gets copied first.
+func (lom *LOM) CowInPlace(buf []byte) error {
+	lom.CowSnap()
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return err
+	}
+	if st, ok := finfo.Sys().(*syscall.Stat_t); !ok || st.Nlink < 2 {
+		return nil
+	}
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileCow)
+	if err = copyWithMD(lom.FQN, workFQN, buf); err == nil {
+		if err = cos.Rename(workFQN, lom.FQN); err == nil {
+			return nil
+		}
+	}
+	if errRm := cos.RemoveFile(workFQN); errRm != nil {
+		glog.Errorf("nested error: %v --> %v", err, errRm)
+	}
+	return cmn.NewErrFailedTo(T, "copy-on-write", lom, err)
+}
+
+func copyWithMD(src, dst string, buf []byte) error {
+	if _, _, err := cos.CopyFile(src, dst, buf, cos.ChecksumNone); err != nil {
+		return err
+	}
+	md, err := fs.GetXattr(src, XattrLOM)
+	if err != nil {
+		return err
+	}
+	return fs.SetXattr(dst, XattrLOM, md)
+}
+
+// FindSnap returns the object's location in a given snapshot: the object's (HRW) mountpath
+// or any other available mountpath (e.g., upon resilvering).
+func (lom *LOM) FindSnap(snap string) (sfqn string, mi *fs.MountpathInfo, err error) {
+	sfqn = lom.snapFQN(lom.mpathInfo, snap)
+	if err = cos.Stat(sfqn); err == nil {
+		return sfqn, lom.mpathInfo, nil
+	}
+	for _, mi = range fs.GetAvail() {
+		if mi.Path == lom.mpathInfo.Path {
+			continue
+		}
+		sfqn = lom.snapFQN(mi, snap)
+		if err = cos.Stat(sfqn); err == nil {
+			return
+		}
+	}
+	err = cmn.NewErrNotFound("%s: %s in snapshot %q", T.Snode(), lom.FullName(), snap)
+	return
+}
+
+// LoadSnapMD loads metadata of the object's snapshot replica (as returned by FindSnap).
+// The returned LOM must be freed.
+func (lom *LOM) LoadSnapMD(sfqn string, mi *fs.MountpathInfo) (slom *LOM, err error) {
+	slom = lom.CloneMD(sfqn)
+	slom.mpathInfo = mi
+	slom.md = lmeta{uname: lom.md.uname}
+	if _, err = slom.lmfs(true); err == nil {
+		slom.md.copies = nil // (copies are not snapshotted)
+		slom.md.bckID = lom.Bprops().BID
+		return
+	}
+	FreeLOM(slom)
+	slom = nil
+	return
+}
+
+// RestoreSnap replaces the object with its snapshot replica (as returned by FindSnap).
+// Existing copies, if any, get removed. Exclusive lock is required.
+func (lom *LOM) RestoreSnap(sfqn string, mi *fs.MountpathInfo, buf []byte) (restored bool, err error) {
+	if finfo, errS := os.Stat(lom.FQN); errS == nil {
+		if sinfo, errS := os.Stat(sfqn); errS == nil && os.SameFile(finfo, sinfo) {
+			return
+		}
+		if errLoad := lom.Load(false /*cache it*/, true /*locked*/); errLoad == nil && lom.HasCopies() {
+			if err = lom.DelAllCopies(); err != nil {
+				return
+			}
+		}
+	}
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileRestoreSnap)
+	if err = cos.CreateDir(filepath.Dir(workFQN)); err != nil {
+		return
+	}
+	if mi.Path == lom.mpathInfo.Path {
+		err = os.Link(sfqn, workFQN)
+	} else {
+		err = copyWithMD(sfqn, workFQN, buf)
+	}
+	if err == nil {
+		err = cos.Rename(workFQN, lom.FQN)
+	}
+	if err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf("nested error: %v --> %v", err, errRm)
+		}
+		err = cmn.NewErrFailedTo(T, "restore", lom, err)
+		return
+	}
+	restored = true
+	lom.Uncache(true /*delDirty*/)
+	lom.md = lmeta{uname: lom.md.uname}
+	if _, err = lom.lmfs(true); err != nil || len(lom.md.copies) == 0 {
+		return
+	}
+	// the copies (if any) at the time of the snapshot have been removed or overwritten since
+	lom.md.copies = nil
+	mdbuf, mm := lom.marshal()
+	err = fs.SetXattr(lom.FQN, XattrLOM, mdbuf)
+	mm.Free(mdbuf)
+	return
+}
+
+// RemoveSnap removes a given snapshot of a given bucket from all local mountpaths.
+func RemoveSnap(bck *cmn.Bck, snap string) (err error) {
+	for _, mi := range fs.GetAvail() {
+		dir := mi.MakePathFQN(bck, fs.SnapshotType, snap)
+		if errRm := mi.MoveToDeleted(dir); errRm != nil {
+			glog.Errorf("%s: failed to remove snapshot %q of %s: %v", mi, snap, bck, errRm)
+			err = errRm
+		}
+	}
+	return
+}
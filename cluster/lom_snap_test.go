@@ -0,0 +1,245 @@
+// Package cluster_test provides tests for cluster package
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LOM snapshots", func() {
+	const (
+		tmpDir  = "/tmp/lom_snap_test"
+		objName = "dir/obj"
+		snap    = "snap1"
+
+		bucketSnap = "SNAP_TEST_Snapshots"
+	)
+
+	var (
+		mpath   = filepath.Join(tmpDir, "mpath")
+		snapBck = cmn.Bck{Name: bucketSnap, Provider: apc.ProviderAIS, Ns: cmn.NsGlobal}
+	)
+
+	bmd := mock.NewBaseBownerMock(
+		cluster.NewBck(
+			bucketSnap, apc.ProviderAIS, cmn.NsGlobal,
+			&cmn.BucketProps{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}, BID: 21},
+		),
+	)
+
+	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.Reg(fs.SnapshotType, &fs.SnapshotContentResolver{})
+
+	BeforeEach(func() {
+		_ = cos.CreateDir(mpath)
+		fs.TestDisableValidation()
+		_, _ = fs.Add(mpath, "daeID")
+		_ = mock.NewTarget(bmd)
+	})
+
+	AfterEach(func() {
+		for _, mi := range fs.GetAvail() {
+			_ = os.RemoveAll(mi.MakePathBck(&snapBck))
+		}
+		_, _ = fs.Remove(mpath)
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	newLOM := func() *cluster.LOM {
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(&snapBck)).NotTo(HaveOccurred())
+		return lom
+	}
+
+	// (new file renamed over the existing one - same as PUT)
+	put := func(lom *cluster.LOM, size int) {
+		workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfilePut)
+		createTestFile(workFQN, size)
+		Expect(cos.Rename(workFQN, lom.FQN)).NotTo(HaveOccurred())
+		lom.SetSize(int64(size))
+		Expect(persist(lom)).NotTo(HaveOccurred())
+	}
+
+	snapFQN := func(lom *cluster.LOM) string {
+		sfqn, mi, err := lom.FindSnap(snap)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mi.Path).To(Equal(lom.MpathInfo().Path)) // (the object's own)
+		return sfqn
+	}
+
+	sameFile := func(a, b string) bool {
+		fa, err := os.Stat(a)
+		Expect(err).NotTo(HaveOccurred())
+		fb, err := os.Stat(b)
+		Expect(err).NotTo(HaveOccurred())
+		return os.SameFile(fa, fb)
+	}
+
+	Describe("LinkSnap", func() {
+		It("should link objects that are older than the snapshot", func() {
+			lom := newLOM()
+			put(lom, 10)
+			started := time.Now().UnixNano()
+
+			linked, err := lom.LinkSnap(snap, started)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(linked).To(BeTrue())
+			Expect(sameFile(lom.FQN, snapFQN(lom))).To(BeTrue())
+
+			// already there
+			linked, err = lom.LinkSnap(snap, started)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(linked).To(BeFalse())
+		})
+
+		It("should skip objects that are newer than the snapshot", func() {
+			lom := newLOM()
+			started := time.Now().Add(-time.Minute).UnixNano()
+			put(lom, 10)
+
+			linked, err := lom.LinkSnap(snap, started)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(linked).To(BeFalse())
+			_, _, err = lom.FindSnap(snap)
+			Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+		})
+
+		It("should ignore objects removed in the meantime", func() {
+			lom := newLOM()
+			linked, err := lom.LinkSnap(snap, time.Now().UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(linked).To(BeFalse())
+		})
+	})
+
+	Describe("CowSnap", func() {
+		It("should preserve the object being deleted while the snapshot is in progress", func() {
+			lom := newLOM()
+			put(lom, 10)
+			hash := getTestFileHash(lom.FQN)
+
+			cluster.SnapBegin(lom.Bck(), snap)
+			lom.CowSnap()
+			cluster.SnapEnd(lom.Bck())
+			Expect(lom.Remove()).NotTo(HaveOccurred())
+
+			Expect(getTestFileHash(snapFQN(lom))).To(Equal(hash))
+		})
+
+		It("should do nothing when no snapshot is in progress", func() {
+			lom := newLOM()
+			put(lom, 10)
+			lom.CowSnap()
+			_, _, err := lom.FindSnap(snap)
+			Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("CowInPlace", func() {
+		It("should copy the object that shares its file with a snapshot", func() {
+			lom := newLOM()
+			put(lom, 10)
+			hash := getTestFileHash(lom.FQN)
+			_, err := lom.LinkSnap(snap, time.Now().UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			sfqn := snapFQN(lom)
+
+			Expect(lom.CowInPlace(nil)).NotTo(HaveOccurred())
+			Expect(sameFile(lom.FQN, sfqn)).To(BeFalse())
+			Expect(getTestFileHash(lom.FQN)).To(Equal(hash))
+
+			// update in place does not affect the snapshot
+			fh, err := os.OpenFile(lom.FQN, os.O_APPEND|os.O_WRONLY, cos.PermRWR)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = fh.Write([]byte("appended"))
+			Expect(err).NotTo(HaveOccurred())
+			fh.Close()
+			Expect(getTestFileHash(sfqn)).To(Equal(hash))
+			Expect(getTestFileHash(lom.FQN)).NotTo(Equal(hash))
+
+			// (and metadata is preserved)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.SizeBytes()).To(BeEquivalentTo(10))
+		})
+
+		It("should not copy the object that is not in any snapshot", func() {
+			lom := newLOM()
+			put(lom, 10)
+			finfo, err := os.Stat(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lom.CowInPlace(nil)).NotTo(HaveOccurred())
+			finfo2, err := os.Stat(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.SameFile(finfo, finfo2)).To(BeTrue())
+		})
+	})
+
+	Describe("RestoreSnap", func() {
+		It("should restore overwritten object", func() {
+			lom := newLOM()
+			put(lom, 10)
+			hash := getTestFileHash(lom.FQN)
+			_, err := lom.LinkSnap(snap, time.Now().UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			sfqn := snapFQN(lom)
+
+			put(lom, 20)
+			Expect(getTestFileHash(sfqn)).To(Equal(hash))
+
+			restored, err := lom.RestoreSnap(sfqn, lom.MpathInfo(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(BeTrue())
+			Expect(sameFile(lom.FQN, sfqn)).To(BeTrue())
+
+			restoredLOM := newLOM()
+			Expect(restoredLOM.Load(false, false)).NotTo(HaveOccurred())
+			Expect(restoredLOM.SizeBytes()).To(BeEquivalentTo(10))
+			Expect(getTestFileHash(restoredLOM.FQN)).To(Equal(hash))
+
+			// nothing to do
+			restored, err = lom.RestoreSnap(sfqn, lom.MpathInfo(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(BeFalse())
+		})
+
+		It("should restore deleted object", func() {
+			lom := newLOM()
+			put(lom, 10)
+			hash := getTestFileHash(lom.FQN)
+			_, err := lom.LinkSnap(snap, time.Now().UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			sfqn := snapFQN(lom)
+			Expect(lom.Remove()).NotTo(HaveOccurred())
+
+			restored, err := lom.RestoreSnap(sfqn, lom.MpathInfo(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(BeTrue())
+			Expect(getTestFileHash(lom.FQN)).To(Equal(hash))
+		})
+
+		It("should remove the snapshot", func() {
+			lom := newLOM()
+			put(lom, 10)
+			_, err := lom.LinkSnap(snap, time.Now().UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.RemoveSnap(&snapBck, snap)).NotTo(HaveOccurred())
+			_, _, err = lom.FindSnap(snap)
+			Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+			Expect(lom.FQN).To(BeAnExistingFile())
+		})
+	})
+})
@@ -30,11 +30,11 @@ type (
 		MaxPageSize() uint
 		CreateBucket(bck *Bck) (errCode int, err error)
 		ListObjects(bck *Bck, msg *apc.ListObjsMsg) (bckList *cmn.BucketList, errCode int, err error)
-		ListBuckets(qbck cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error)
 		PutObj(r io.ReadCloser, lom *LOM) (errCode int, err error)
 		DeleteObj(lom *LOM) (errCode int, err error)
 
 		// with context
+		ListBuckets(ctx context.Context, qbck cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error)
 		HeadBucket(ctx context.Context, bck *Bck) (bckProps cos.SimpleKVs, errCode int, err error)
 		HeadObj(ctx context.Context, lom *LOM) (objAttrs *cmn.ObjAttrs, errCode int, err error)
 		GetObj(ctx context.Context, lom *LOM, owt cmn.OWT) (errCode int, err error)
@@ -71,6 +71,7 @@ type (
 		DM        DataMover
 		DP        DP // Data Provider (optional)
 		Xact      Xact
+		Sync      bool // skip if the destination is identical (see apc.CopyBckMsg.Sync)
 	}
 	// common part that's used in `api.PromoteArgs` and `PromoteParams`(server side), both
 	PromoteArgs struct {
@@ -125,7 +126,7 @@ type Target interface {
 	CopyObject(lom *LOM, params *CopyObjectParams, dryRun bool) (int64, error)
 	GetCold(ctx context.Context, lom *LOM, owt cmn.OWT) (errCode int, err error)
 	Promote(params PromoteParams) (errCode int, err error)
-	HeadObjT2T(lom *LOM, si *Snode) bool
+	HeadObjT2T(lom *LOM, si *Snode) (ok bool, errCode int)
 
 	// File-system related functions.
 	FSHC(err error, path string)
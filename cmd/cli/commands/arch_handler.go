@@ -6,6 +6,12 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/urfave/cli"
 )
 
@@ -22,16 +28,34 @@ var (
 		},
 		subcmdAppend: {
 			archpathFlag,
+			replaceArchFlag,
+		},
+		commandRemove: {
+			archpathFlag,
+		},
+		subcmdExtract: {
+			archPrefixFlag,
+			archMatchFlag,
+			continueOnErrorFlag,
 		},
 		subcmdList: {
 			objPropsFlag,
 			allPropsFlag,
 		},
+		subcmdGetBatch: {
+			templateFlag,
+			listFlag,
+			archpathFlag,
+			archMimeFlag,
+			unorderedFlag,
+			onlyObjNameFlag,
+			continueOnErrorFlag,
+		},
 	}
 
 	archCmd = cli.Command{
 		Name:  commandArch,
-		Usage: "Create archive and append files to archive",
+		Usage: "Create archive, add, replace, remove, and extract archived files",
 		Subcommands: []cli.Command{
 			{
 				Name:         commandCreate,
@@ -43,12 +67,28 @@ var (
 			},
 			{
 				Name:         subcmdAppend,
-				Usage:        "create an archive",
+				Usage:        "add file to an existing archive (or, with --replace, replace archived file)",
 				ArgsUsage:    "FILE_NAME OBJECT_NAME",
 				Flags:        archCmdsFlags[subcmdAppend],
 				Action:       putRegularObjHandler,
 				BashComplete: putPromoteObjectCompletions,
 			},
+			{
+				Name:         commandRemove,
+				Usage:        "remove file from an existing archive",
+				ArgsUsage:    "OBJECT_NAME",
+				Flags:        archCmdsFlags[commandRemove],
+				Action:       rmFromArchHandler,
+				BashComplete: bucketCompletions(bckCompletionsOpts{withProviders: true}),
+			},
+			{
+				Name:         subcmdExtract,
+				Usage:        "extract archived files as individual objects",
+				ArgsUsage:    "OBJECT_NAME [DST_BUCKET]",
+				Flags:        archCmdsFlags[subcmdExtract],
+				Action:       extractArchHandler,
+				BashComplete: bucketCompletions(bckCompletionsOpts{withProviders: true}),
+			},
 			{
 				Name:         subcmdList,
 				Usage:        "list archive content",
@@ -57,6 +97,14 @@ var (
 				Action:       listArchHandler,
 				BashComplete: bucketCompletions(bckCompletionsOpts{withProviders: true}),
 			},
+			{
+				Name:         subcmdGetBatch,
+				Usage:        "get multiple objects (or archived files) as a single archive",
+				ArgsUsage:    "BUCKET OUT_FILE",
+				Flags:        archCmdsFlags[subcmdGetBatch],
+				Action:       getBatchHandler,
+				BashComplete: bucketCompletions(bckCompletionsOpts{withProviders: true}),
+			},
 		},
 	}
 )
@@ -68,3 +116,114 @@ func listArchHandler(c *cli.Context) (err error) {
 	}
 	return _doListObj(c, bck, objName, true /*list arch*/)
 }
+
+func rmFromArchHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "object name")
+	}
+	archPath := parseStrFlag(c, archpathFlag)
+	if archPath == "" {
+		return missingArgumentsError(c, "archpath flag")
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().First())
+	if err != nil {
+		return err
+	}
+	if err := api.DeleteFromArch(defaultAPIParams, bck, objName, archPath); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "removed %q from archive \"%s/%s\"\n", archPath, bck, objName)
+	return nil
+}
+
+func extractArchHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "object name")
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().First())
+	if err != nil {
+		return err
+	}
+	msg := &cmn.ExtractArchMsg{
+		Prefix:          parseStrFlag(c, archPrefixFlag),
+		Match:           parseStrFlag(c, archMatchFlag),
+		ContinueOnError: flagIsSet(c, continueOnErrorFlag),
+	}
+	bckTo := bck
+	if c.NArg() > 1 {
+		if bckTo, err = parseBckURI(c, c.Args().Get(1)); err != nil {
+			return err
+		}
+		msg.ToBck = bckTo
+	}
+	cnt, err := api.ExtractArch(defaultAPIParams, bck, objName, msg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "extracted %d file%s from \"%s/%s\" => %s/%s\n",
+		cnt, cos.Plural(int(cnt)), bck, objName, bckTo, msg.Prefix)
+	return nil
+}
+
+func getBatchHandler(c *cli.Context) (err error) {
+	var (
+		objNames []string
+		template = parseStrFlag(c, templateFlag)
+		list     = parseStrFlag(c, listFlag)
+		outFile  = c.Args().Get(1)
+		w        = os.Stdout
+	)
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "bucket", "output file")
+	}
+	if template == "" && list == "" {
+		return missingArgumentsError(c, "either object list or template flag")
+	}
+	if template != "" && list != "" {
+		return incorrectUsageMsg(c, "list and template options are mutually exclusive")
+	}
+	bck, err := parseBckURI(c, c.Args().First())
+	if err != nil {
+		return err
+	}
+	if list != "" {
+		objNames = makeList(list)
+	} else {
+		pt, err := cos.NewParsedTemplate(template)
+		if err != nil {
+			return err
+		}
+		objNames = pt.ToSlice()
+	}
+	msg := &cmn.GetBatchMsg{
+		In:              make([]cmn.GetBatchIn, 0, len(objNames)),
+		Mime:            parseStrFlag(c, archMimeFlag),
+		OnlyObjName:     flagIsSet(c, onlyObjNameFlag),
+		Unordered:       flagIsSet(c, unorderedFlag),
+		ContinueOnError: flagIsSet(c, continueOnErrorFlag),
+	}
+	archPath := parseStrFlag(c, archpathFlag)
+	for _, objName := range objNames {
+		msg.In = append(msg.In, cmn.GetBatchIn{ObjName: objName, ArchPath: archPath})
+	}
+	if outFile != fileStdIO {
+		if w, err = os.Create(outFile); err != nil {
+			return
+		}
+		defer func() {
+			w.Close()
+			if err != nil {
+				os.Remove(outFile)
+			}
+		}()
+	}
+	n, err := api.GetBatch(defaultAPIParams, bck, msg, w)
+	if err != nil {
+		return
+	}
+	if outFile != fileStdIO {
+		fmt.Fprintf(c.App.Writer, "GET %d object%s from %s as %q [%s]\n",
+			len(msg.In), cos.Plural(len(msg.In)), bck, outFile, cos.B2S(n, 2))
+	}
+	return
+}
@@ -210,7 +210,23 @@ func _doListObj(c *cli.Context, bck cmn.Bck, prefix string, listArch bool) error
 	if flagIsSet(c, allItemsFlag) {
 		msg.SetFlag(apc.LsMisplaced)
 	}
+	if flagIsSet(c, listVersionsFlag) {
+		if listArch {
+			return incorrectUsageMsg(c, "flags %q and %q cannot be used together", listVersionsFlag.Name, listArchFlag.Name)
+		}
+		msg.SetFlag(apc.LsVersions)
+	}
+	if flagIsSet(c, snapshotFlag) {
+		if listArch || flagIsSet(c, listVersionsFlag) {
+			return incorrectUsageMsg(c, "flag %q cannot be used with %q or %q",
+				snapshotFlag.Name, listArchFlag.Name, listVersionsFlag.Name)
+		}
+		msg.Snapshot = parseStrFlag(c, snapshotFlag)
+	}
 	props := strings.Split(parseStrFlag(c, objPropsFlag), ",")
+	if flagIsSet(c, listVersionsFlag) && !cos.StringInSlice("all", props) {
+		props = append(props, apc.GetPropsVersion)
+	}
 	if flagIsSet(c, nameOnlyFlag) {
 		msg.SetFlag(apc.LsNameOnly)
 		msg.Props = apc.GetPropsName
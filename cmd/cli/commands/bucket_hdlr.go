@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
@@ -30,6 +32,8 @@ var (
 			yesFlag,
 		},
 		commandRename: {waitFlag},
+		subcmdSnapshot: {waitFlag},
+		subcmdRestore:  {waitFlag, yesFlag},
 		commandCreate: {
 			ignoreErrorFlag,
 			bucketPropsFlag,
@@ -38,6 +42,9 @@ var (
 		commandCopy: {
 			cpBckDryRunFlag,
 			cpBckPrefixFlag,
+			cpBckSyncFlag,
+			cpBckPruneFlag,
+			cpBckSinceFlag,
 			templateFlag,
 			listFlag,
 			waitFlag,
@@ -71,6 +78,8 @@ var (
 			listAnonymousFlag,
 			listArchFlag,
 			nameOnlyFlag,
+			listVersionsFlag,
+			snapshotFlag,
 		},
 		subcmdSummary: {
 			listCachedFlag,
@@ -166,6 +175,42 @@ var (
 				Action:       evictHandler,
 				BashComplete: bucketCompletions(bckCompletionsOpts{multiple: true}),
 			},
+			{
+				Name:  subcmdSnapshot,
+				Usage: "take, list, restore, and remove point-in-time snapshots of ais buckets",
+				Subcommands: []cli.Command{
+					{
+						Name:         commandCreate,
+						Usage:        "take a named snapshot of a bucket",
+						ArgsUsage:    bucketSnapshotArgument,
+						Flags:        bucketCmdsFlags[subcmdSnapshot],
+						Action:       createSnapHandler,
+						BashComplete: bucketCompletions(bckCompletionsOpts{provider: apc.ProviderAIS}),
+					},
+					{
+						Name:         commandList,
+						Usage:        "list snapshots of a bucket",
+						ArgsUsage:    bucketArgument,
+						Action:       listSnapsHandler,
+						BashComplete: bucketCompletions(bckCompletionsOpts{provider: apc.ProviderAIS}),
+					},
+					{
+						Name:         subcmdRestore,
+						Usage:        "roll back bucket's content to a given snapshot",
+						ArgsUsage:    bucketSnapshotArgument,
+						Flags:        bucketCmdsFlags[subcmdRestore],
+						Action:       restoreSnapHandler,
+						BashComplete: bucketCompletions(bckCompletionsOpts{provider: apc.ProviderAIS}),
+					},
+					{
+						Name:         commandRemove,
+						Usage:        "remove a snapshot of a bucket",
+						ArgsUsage:    bucketSnapshotArgument,
+						Action:       removeSnapHandler,
+						BashComplete: bucketCompletions(bckCompletionsOpts{provider: apc.ProviderAIS}),
+					},
+				},
+			},
 			{
 				Name:   subcmdProps,
 				Usage:  "show, update or reset bucket properties",
@@ -336,6 +381,12 @@ func fullBckCopy(c *cli.Context, bckFrom, bckTo cmn.Bck) (err error) {
 		Prefix: parseStrFlag(c, cpBckPrefixFlag),
 		DryRun: flagIsSet(c, cpBckDryRunFlag),
 		Force:  flagIsSet(c, forceFlag),
+		Sync:   flagIsSet(c, cpBckSyncFlag),
+		Prune:  flagIsSet(c, cpBckPruneFlag),
+		Since:  parseStrFlag(c, cpBckSinceFlag),
+	}
+	if err = msg.ValidateSync(); err != nil {
+		return
 	}
 
 	return copyBucket(c, bckFrom, bckTo, msg)
@@ -418,6 +469,10 @@ func copyBucketHandler(c *cli.Context) (err error) {
 	}
 
 	// Copy matching objects
+	if flagIsSet(c, cpBckSyncFlag) {
+		return fmt.Errorf("--%s applies to the entire bucket (cannot be used with --%s or --%s)",
+			cpBckSyncFlag.Name, listFlag.Name, templateFlag.Name)
+	}
 	if listObjs != "" && tmplObjs != "" {
 		return incorrectUsageMsg(c, errFmtExclusive, listFlag.Name, templateFlag.Name)
 	}
@@ -624,3 +679,99 @@ func listAnyHandler(c *cli.Context) error {
 	}
 	return listObjects(c, bck)
 }
+
+//
+// bucket snapshots
+//
+
+func parseBckSnap(c *cli.Context) (bck cmn.Bck, snap string, err error) {
+	if c.NArg() == 0 {
+		err = missingArgumentsError(c, "bucket name", "snapshot name")
+		return
+	}
+	if c.NArg() == 1 {
+		err = missingArgumentsError(c, "snapshot name")
+		return
+	}
+	if bck, err = parseBckURI(c, c.Args().Get(0)); err != nil {
+		return
+	}
+	snap = c.Args().Get(1)
+	return
+}
+
+func createSnapHandler(c *cli.Context) error {
+	bck, snap, err := parseBckSnap(c)
+	if err != nil {
+		return err
+	}
+	xactID, err := api.SnapshotBucket(defaultAPIParams, bck, snap)
+	if err != nil {
+		return err
+	}
+	return waitSnapXact(c, xactID, fmt.Sprintf("Taking snapshot %q of %s", snap, bck))
+}
+
+func restoreSnapHandler(c *cli.Context) error {
+	bck, snap, err := parseBckSnap(c)
+	if err != nil {
+		return err
+	}
+	if !flagIsSet(c, yesFlag) {
+		prompt := fmt.Sprintf("Restore %s to snapshot %q (objects written after the snapshot will be lost)?", bck, snap)
+		if ok := confirm(c, prompt); !ok {
+			return nil
+		}
+	}
+	xactID, err := api.RestoreSnapshot(defaultAPIParams, bck, snap)
+	if err != nil {
+		return err
+	}
+	return waitSnapXact(c, xactID, fmt.Sprintf("Restoring %s to snapshot %q", bck, snap))
+}
+
+func waitSnapXact(c *cli.Context, xactID, what string) error {
+	if !flagIsSet(c, waitFlag) {
+		fmt.Fprintln(c.App.Writer, what+",", xactProgressMsg(xactID))
+		return nil
+	}
+	fmt.Fprintln(c.App.Writer, what, "...")
+	if err := waitForXactionCompletion(defaultAPIParams, api.XactReqArgs{ID: xactID}); err != nil {
+		return err
+	}
+	fmt.Fprint(c.App.Writer, fmtXactSucceeded)
+	return nil
+}
+
+func removeSnapHandler(c *cli.Context) error {
+	bck, snap, err := parseBckSnap(c)
+	if err != nil {
+		return err
+	}
+	if err := api.DeleteSnapshot(defaultAPIParams, bck, snap); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "Snapshot %q of %s removed\n", snap, bck)
+	return nil
+}
+
+func listSnapsHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "bucket name")
+	}
+	bck, err := parseBckURI(c, c.Args().First())
+	if err != nil {
+		return err
+	}
+	snaps, err := api.ListSnapshots(defaultAPIParams, bck)
+	if err != nil {
+		return err
+	}
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tCREATED")
+	for _, snap := range snaps {
+		fmt.Fprintf(tw, "%s\t%s\n", snap.Name, time.Unix(0, snap.Created).Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
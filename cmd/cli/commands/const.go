@@ -50,6 +50,7 @@ const (
 	commandStart     = apc.ActXactStart
 	commandStop      = apc.ActXactStop
 	commandWait      = "wait"
+	commandRetry     = "retry"
 	commandAlias     = "alias"
 	commandStorage   = "storage"
 	commandArch      = "archive"
@@ -64,6 +65,7 @@ const (
 	subcmdMpath      = apc.GetWhatDiskStats
 	subcmdConfig     = apc.GetWhatConfig
 	subcmdLog        = apc.GetWhatLog
+	subcmdEvents     = apc.GetWhatEvents
 	subcmdRebalance  = apc.ActRebalance
 	subcmdBucket     = "bucket"
 	subcmdObject     = "object"
@@ -84,6 +86,8 @@ const (
 	subcmdAttach     = "attach"
 	subcmdDetach     = "detach"
 	subcmdRmSmap     = "remove-from-smap"
+	subcmdSnapshot   = "snapshot"
+	subcmdRestore    = "restore"
 
 	// Cluster subcommands
 	subcmdCluAttach = "remote-" + subcmdAttach
@@ -114,9 +118,11 @@ const (
 	subcmdShowBucket       = subcmdBucket
 	subcmdShowConfig       = subcmdConfig
 	subcmdShowLog          = subcmdLog
+	subcmdShowEvents       = subcmdEvents
 	subcmdShowRemoteAIS    = "remote-cluster"
 	subcmdShowCluster      = subcmdCluster
 	subcmdShowClusterStats = "stats"
+	subcmdShowHealth       = apc.GetWhatHealth
 
 	subcmdShowStorage  = commandStorage
 	subcmdShowMpath    = subcmdMountpath
@@ -140,6 +146,9 @@ const (
 	subcmdStopDsort    = subcmdDsort
 	subcmdStopDownload = subcmdDownload
 
+	// Retry subcommands
+	subcmdRetryDownload = subcmdDownload
+
 	// Bucket subcommands
 	subcmdSummary = "summary"
 
@@ -148,12 +157,27 @@ const (
 	subcmdResetProps = "reset"
 
 	// Archive subcommands
-	subcmdAppend = "append"
+	subcmdAppend   = "append"
+	subcmdGetBatch = "get-batch"
+	subcmdExtract  = "extract"
+
+	// Job schedule subcommands
+	subcmdSchedule    = "schedule"
+	subcmdSchedAdd    = "add"
+	subcmdSchedRemove = commandRemove
+	subcmdSchedShow   = commandShow
+
+	// Pipeline subcommands
+	subcmdPipeline     = "pipeline"
+	subcmdPipelineRun  = "run"
+	subcmdPipelineStop = commandStop
+	subcmdPipelineShow = commandShow
 
 	// Wait subcommands
 	subcmdWaitXaction  = subcmdXaction
 	subcmdWaitDownload = subcmdDownload
 	subcmdWaitDSort    = subcmdDsort
+	subcmdWaitPipeline = subcmdPipeline
 
 	// AuthN subcommands
 	subcmdAuthAdd     = "add"
@@ -222,6 +246,7 @@ const (
 
 	// Buckets
 	bucketArgument         = "BUCKET"
+	bucketSnapshotArgument = "BUCKET SNAPSHOT"
 	optionalBucketArgument = "[BUCKET]"
 	bucketsArgument        = "BUCKET [BUCKET...]"
 	bucketPropsArgument    = bucketArgument + " " + jsonSpecArgument + "|" + keyValuePairsArgument
@@ -252,6 +277,16 @@ const (
 	// Xactions
 	xactionArgument = "XACTION_NAME"
 
+	// Scheduled jobs
+	schedJobArgument             = "NAME CRON KIND [BUCKET [DST_BUCKET]]"
+	schedJobNameArgument         = "NAME"
+	optionalSchedJobNameArgument = "[NAME]"
+
+	// Pipelines
+	pipelineSpecArgument       = "[JSON_SPECIFICATION|YAML_SPECIFICATION]"
+	pipelineIDArgument         = "PIPELINE_ID"
+	optionalPipelineIDArgument = "[PIPELINE_ID]"
+
 	// List command
 	listCommandArgument = "[PROVIDER://][BUCKET_NAME]"
 
@@ -357,6 +392,28 @@ var (
 	// Log severity (cmn.LogInfo, ....) enum
 	logSevFlag = cli.StringFlag{Name: "severity", Usage: "show the specified log, one of: 'i[nfo]','w[arning]','e[rror]'"}
 
+	// Cluster health
+	healthBackendsFlag = cli.BoolFlag{Name: "backends", Usage: "check remote backends reachability (may take time)"}
+
+	// Cluster event log
+	evSinceFlag = cli.DurationFlag{Name: "since", Usage: "show events that happened within the specified time, e.g. '1h'"}
+	evKindFlag  = cli.StringFlag{
+		Name:  "kind",
+		Usage: "comma-separated list of event kinds, e.g.: 'smap,bmd,bucket,config,node,auth,xaction'",
+	}
+	evActorFlag = cli.StringFlag{Name: "actor", Usage: "show events initiated by the specified user (or client address)"}
+	evLimitFlag = cli.IntFlag{Name: "limit", Usage: "show at most this number of (the most recent) events"}
+
+	// Scheduled jobs
+	schedValueFlag = cli.StringFlag{
+		Name:  "value",
+		Usage: "job parameters in JSON (same as when starting the job by hand), e.g.: '2' (copies), '{\"template\": \"shard-{0..9}\"}'",
+	}
+	schedDisabledFlag = cli.BoolFlag{Name: "disabled", Usage: "add job in disabled state (use to pause an existing job)"}
+
+	// Pipelines
+	pipelineSpecFileFlag = cli.StringFlag{Name: "file,f", Value: "", Usage: "path to file with pipeline specification"}
+
 	// Daeclu
 	countFlag = cli.IntFlag{Name: "count", Usage: "total number of generated reports", Value: countDefault}
 
@@ -375,6 +432,65 @@ var (
 		Name:  "object-list,from",
 		Usage: "path to file containing JSON array of strings with object names to download",
 	}
+	dlManifestFlag = cli.StringFlag{
+		Name: "manifest",
+		Usage: "path to download manifest (.jsonl or .csv) listing links with expected sizes and checksums\n" +
+			"\t (link, name, size, md5, sha256, crc32c); when specified, SOURCE is omitted",
+	}
+	dlCredsFlag = cli.StringFlag{
+		Name: "creds",
+		Usage: "path to JSON file with credentials to access the source (s3://, gs://, ftp://, sftp://);\n" +
+			"\t with credentials s3:// and gs:// sources are downloaded via the respective APIs (rather than public links)",
+	}
+	dlMaxAttemptsFlag = cli.IntFlag{
+		Name:  "max-attempts",
+		Usage: "maximum number of attempts to download each object (default: 10)",
+	}
+	dlBackoffFlag = cli.StringFlag{
+		Name:  "backoff",
+		Usage: "delay before the first retry, doubled with each subsequent one (e.g. '1s')",
+	}
+	dlMaxBackoffFlag = cli.StringFlag{
+		Name:  "max-backoff",
+		Usage: "maximum delay between retries (default: '1m')",
+	}
+	dlRetryCodesFlag = cli.StringFlag{
+		Name:  "retry-codes",
+		Usage: "comma-separated HTTP status codes to retry upon, e.g. '429,500,503' (default: all except 401-407 and 410)",
+	}
+	dlCrawlFlag = cli.BoolFlag{
+		Name:  "crawl",
+		Usage: "crawl SOURCE - directory listing (autoindex) or sitemap (*.xml) - and download all discovered links",
+	}
+	dlMaxDepthFlag = cli.IntFlag{
+		Name:  "max-depth",
+		Usage: "(crawl) number of directory (or sitemap) levels to follow, including SOURCE (default: unlimited)",
+	}
+	dlIncludeFlag = cli.StringFlag{
+		Name:  "include",
+		Usage: "(crawl) regex: download only matching paths (relative to SOURCE)",
+	}
+	dlExcludeFlag = cli.StringFlag{
+		Name:  "exclude",
+		Usage: "(crawl) regex: skip matching paths and directories (relative to SOURCE)",
+	}
+	dlAnyHostFlag = cli.BoolFlag{
+		Name:  "any-host",
+		Usage: "(crawl) follow sitemap links on any host (default: SOURCE host only)",
+	}
+	dlContinuousFlag = cli.StringFlag{
+		Name: "continuous",
+		Usage: "keep re-running the job at the specified interval (e.g. '6h') to download new and changed objects;\n" +
+			"\t the job runs until aborted (or --max-runs)",
+	}
+	dlMaxRunsFlag = cli.IntFlag{
+		Name:  "max-runs",
+		Usage: "(continuous) maximum number of runs (default: unlimited)",
+	}
+	dlDeleteFlag = cli.BoolFlag{
+		Name:  "delete",
+		Usage: "(continuous) delete objects downloaded by the previous run that are no longer present at the source",
+	}
 	syncFlag             = cli.BoolFlag{Name: "sync", Usage: "sync bucket with cloud"}
 	progressIntervalFlag = cli.StringFlag{
 		Name:  "progress-interval",
@@ -463,8 +579,23 @@ var (
 		Name:  "cont-on-err",
 		Usage: "keep running archiving xaction in presence of errors in a any given multi-object transaction",
 	}
+	// get-batch
+	archMimeFlag    = cli.StringFlag{Name: "mime", Usage: "output format: .tar (default), .tgz, .zip, or .msgpack"}
+	unorderedFlag   = cli.BoolFlag{Name: "unordered", Usage: "output objects in the order of arrival (faster) rather than in the specified order"}
+	onlyObjNameFlag = cli.BoolFlag{Name: "only-obj-name", Usage: "name output files by object name only (default: bucket/object)"}
+	// append, extract
+	replaceArchFlag = cli.BoolFlag{Name: "replace", Usage: "replace archived file if exists (default: fail or, in case of TAR, append as is)"}
+	archPrefixFlag  = cli.StringFlag{Name: "prefix", Usage: "name prefix for extracted objects (e.g., virtual directory \"abc/\")"}
+	archMatchFlag   = cli.StringFlag{Name: "match", Usage: "extract only the archived files that match this shell pattern (e.g., \"*.jpg\")"}
 	// end archive
 
+	// object version history (ais:// buckets, see versioning.history)
+	listVersionsFlag = cli.BoolFlag{Name: "versions", Usage: "list previous versions and delete markers of each object"}
+	objVersionFlag   = cli.StringFlag{Name: "version", Usage: "object version ID"}
+
+	// bucket snapshots (ais:// buckets)
+	snapshotFlag = cli.StringFlag{Name: "snapshot", Usage: "read-only access to a given bucket snapshot"}
+
 	sourceBckFlag = cli.StringFlag{Name: "source-bck", Usage: "source bucket"}
 
 	// AuthN
@@ -481,6 +612,15 @@ var (
 		Usage: "show total size of new objects without really creating them",
 	}
 	cpBckPrefixFlag = cli.StringFlag{Name: "prefix", Usage: "prefix added to every new object's name"}
+	cpBckSyncFlag   = cli.BoolFlag{
+		Name:  "sync",
+		Usage: "copy only new and changed objects (missing at the destination or differing in size, checksum, or version)",
+	}
+	cpBckPruneFlag = cli.BoolFlag{Name: "prune", Usage: "remove destination objects that do not exist in the source (requires --sync)"}
+	cpBckSinceFlag = cli.StringFlag{
+		Name:  "since",
+		Usage: "copy only objects modified after: RFC3339 time or \"" + apc.SyncSinceLast + "\" (last successful sync) (requires --sync)",
+	}
 
 	// ETL
 	etlExtFlag = cli.StringFlag{Name: "ext", Usage: "mapping from old to new extensions of transformed objects' names"}
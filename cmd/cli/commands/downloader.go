@@ -348,6 +348,18 @@ func printDownloadStatus(w io.Writer, d downloader.DlStatusResp, verbose bool) {
 		return
 	}
 
+	if d.Run > 0 {
+		fmt.Fprintf(w, "Continuous download, run %d\n", d.Run)
+		if verbose && len(d.Runs) > 0 {
+			fmt.Fprintln(w, "Completed runs:")
+			for _, r := range d.Runs {
+				fmt.Fprintf(w, "\t%d (%s): %d file%s downloaded (skipped: %d), %d error%s, %d deleted\n",
+					r.Run, r.FinishedTime.Format(time.RFC3339), r.FinishedCnt, cos.NounEnding(r.FinishedCnt),
+					r.SkippedCnt, r.ErrorCnt, cos.NounEnding(r.ErrorCnt), r.DeletedCnt)
+			}
+		}
+	}
+
 	if d.JobFinished() {
 		if d.SkippedCnt > 0 {
 			fmt.Fprintf(w, "Done: %d file%s downloaded (skipped: %d), %d error%s\n",
@@ -26,6 +26,9 @@ var (
 		jobStopSubcmds,
 		jobWaitSubcmds,
 		jobRemoveSubcmds,
+		jobRetrySubcmds,
+		jobScheduleSubcmds,
+		jobPipelineSubcmds,
 		makeAlias(showCmdJob, "", true, commandShow), // alias for `ais show`
 	}
 )
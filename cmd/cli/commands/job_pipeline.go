@@ -0,0 +1,164 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles commands that run and control pipelines (DAGs of jobs).
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	pipelineCmdsFlags = map[string][]cli.Flag{
+		subcmdPipelineRun: {
+			pipelineSpecFileFlag,
+			waitFlag,
+			timeoutFlag,
+		},
+		subcmdPipelineShow: {
+			jsonFlag,
+		},
+	}
+
+	jobPipelineSubcmds = cli.Command{
+		Name:  subcmdPipeline,
+		Usage: "run and manage pipelines: DAGs of download, dsort, ETL, archive, and copy jobs",
+		Subcommands: []cli.Command{
+			{
+				Name: subcmdPipelineRun,
+				Usage: "run pipeline, e.g.:\n" +
+					"\t  ais job pipeline run --file pipeline.yaml --wait\n" +
+					"\t  supported steps: " + strings.Join(cmn.PipelineActs, ", "),
+				ArgsUsage: pipelineSpecArgument,
+				Flags:     pipelineCmdsFlags[subcmdPipelineRun],
+				Action:    runPipelineHandler,
+			},
+			{
+				Name:      subcmdPipelineStop,
+				Usage:     "stop pipeline: abort running steps and skip the pending ones",
+				ArgsUsage: pipelineIDArgument,
+				Action:    stopPipelineHandler,
+			},
+			{
+				Name:      subcmdPipelineShow,
+				Usage:     "show all pipelines or the steps of a given pipeline",
+				ArgsUsage: optionalPipelineIDArgument,
+				Flags:     pipelineCmdsFlags[subcmdPipelineShow],
+				Action:    showPipelinesHandler,
+			},
+		},
+	}
+)
+
+func runPipelineHandler(c *cli.Context) (err error) {
+	var (
+		specBytes []byte
+		pl        cmn.Pipeline
+		id        string
+	)
+	if specBytes, err = readJobSpec(c, parseStrFlag(c, pipelineSpecFileFlag)); err != nil {
+		return
+	}
+	if err = parsePipelineSpec(specBytes, &pl); err != nil {
+		return
+	}
+	if err = pl.Validate(); err != nil {
+		return
+	}
+	if id, err = api.RunPipeline(defaultAPIParams, &pl); err != nil {
+		return
+	}
+	if !flagIsSet(c, waitFlag) {
+		fmt.Fprintln(c.App.Writer, id)
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "pipeline %q (%s) is running...\n", pl.Name, id)
+	return waitPipeline(c, id)
+}
+
+// JSON or YAML; in the latter case, step values (e.g. dsort.RequestSpec) are
+// decoded as map[interface{}]interface{} and must be converted to JSON-friendly
+// maps before they can be sent to the cluster
+func parsePipelineSpec(specBytes []byte, pl *cmn.Pipeline) error {
+	errj := jsoniter.Unmarshal(specBytes, pl)
+	if errj == nil {
+		return nil
+	}
+	var v interface{}
+	if erry := yaml.Unmarshal(specBytes, &v); erry != nil {
+		return fmt.Errorf("failed to parse pipeline specification, errs: (%v, %v)", errj, erry)
+	}
+	b, err := jsoniter.Marshal(yamlToJSON(v))
+	if err != nil {
+		return err
+	}
+	return jsoniter.Unmarshal(b, pl)
+}
+
+func yamlToJSON(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, val := range x {
+			m[fmt.Sprint(k)] = yamlToJSON(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range x {
+			x[i] = yamlToJSON(val)
+		}
+	}
+	return v
+}
+
+func stopPipelineHandler(c *cli.Context) (err error) {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, "pipeline id")
+	}
+	id := c.Args().First()
+	if err = api.StopPipeline(defaultAPIParams, id); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "stopped pipeline %q\n", id)
+	return
+}
+
+func showPipelinesHandler(c *cli.Context) error {
+	useJSON := flagIsSet(c, jsonFlag)
+	if c.NArg() == 0 {
+		list, err := api.ListPipelines(defaultAPIParams)
+		if err != nil {
+			return err
+		}
+		return templates.DisplayOutput(list, c.App.Writer, templates.PipelinesTmpl, useJSON)
+	}
+	status, err := api.GetPipelineStatus(defaultAPIParams, c.Args().First())
+	if err != nil {
+		return err
+	}
+	return templates.DisplayOutput(status, c.App.Writer, templates.PipelineStepsTmpl, useJSON)
+}
+
+func waitPipeline(c *cli.Context, id string) error {
+	status, err := api.WaitForPipeline(defaultAPIParams, id, parseDurationFlag(c, timeoutFlag))
+	if err != nil {
+		return err
+	}
+	if status.State != cmn.PipeStateSucceeded {
+		if err := templates.DisplayOutput(status, c.App.Writer, templates.PipelineStepsTmpl, false); err != nil {
+			return err
+		}
+		return fmt.Errorf("pipeline %q (%s) %s", status.Name, id, status.State)
+	}
+	fmt.Fprintf(c.App.Writer, "pipeline %q (%s) succeeded in %v\n", status.Name, id, status.Elapsed())
+	return nil
+}
@@ -0,0 +1,47 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file provides commands that retry (the failed parts of) finished jobs.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/urfave/cli"
+)
+
+var (
+	retryCmdsFlags = map[string][]cli.Flag{
+		subcmdRetryDownload: {},
+	}
+
+	jobRetrySubcmds = cli.Command{
+		Name:  commandRetry,
+		Usage: "retry failed parts of finished jobs",
+		Subcommands: []cli.Command{
+			{
+				Name:         subcmdRetryDownload,
+				Usage:        "retry failed objects of a finished (or aborted) download job, resuming partially downloaded content",
+				ArgsUsage:    jobIDArgument,
+				Flags:        retryCmdsFlags[subcmdRetryDownload],
+				Action:       retryDownloadHandler,
+				BashComplete: downloadIDFinishedCompletions,
+			},
+		},
+	}
+)
+
+func retryDownloadHandler(c *cli.Context) (err error) {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, "download job ID")
+	}
+	id := c.Args().First()
+	if err = api.RetryDownload(defaultAPIParams, id); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "retrying failed objects of download job %q\n", id)
+	fmt.Fprintf(c.App.Writer, dlProgressFmt, id)
+	return
+}
@@ -0,0 +1,128 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles commands that schedule recurring jobs.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/urfave/cli"
+)
+
+var (
+	schedCmdsFlags = map[string][]cli.Flag{
+		subcmdSchedAdd: {
+			schedValueFlag,
+			schedDisabledFlag,
+		},
+		subcmdSchedShow: {
+			jsonFlag,
+		},
+	}
+
+	jobScheduleSubcmds = cli.Command{
+		Name:  subcmdSchedule,
+		Usage: "schedule recurring jobs that the cluster starts on a cron schedule",
+		Subcommands: []cli.Command{
+			{
+				Name: subcmdSchedAdd,
+				Usage: "add (or update) scheduled job, e.g.:\n" +
+					"\t  ais job schedule add nightly-lru \"0 3 * * *\" lru\n" +
+					"\t  ais job schedule add backup @daily copy-bck ais://src ais://dst\n" +
+					"\t  ais job schedule add mirror \"*/30 * * * *\" make-n-copies ais://abc --value 2\n" +
+					"\t  supported job kinds: " + strings.Join(cmn.SchedKinds, ", "),
+				ArgsUsage: schedJobArgument,
+				Flags:     schedCmdsFlags[subcmdSchedAdd],
+				Action:    addSchedJobHandler,
+			},
+			{
+				Name:      subcmdSchedRemove,
+				Usage:     "remove scheduled job",
+				ArgsUsage: schedJobNameArgument,
+				Action:    removeSchedJobHandler,
+			},
+			{
+				Name:      subcmdSchedShow,
+				Usage:     "show scheduled jobs or the run history of a given job",
+				ArgsUsage: optionalSchedJobNameArgument,
+				Flags:     schedCmdsFlags[subcmdSchedShow],
+				Action:    showSchedJobsHandler,
+			},
+		},
+	}
+)
+
+func addSchedJobHandler(c *cli.Context) (err error) {
+	if c.NArg() < 3 {
+		return missingArgumentsError(c, "job name", "cron expression", "job kind")
+	}
+	job := &cmn.SchedJob{
+		Name:     c.Args().Get(0),
+		Cron:     c.Args().Get(1),
+		Kind:     c.Args().Get(2),
+		Disabled: flagIsSet(c, schedDisabledFlag),
+	}
+	if c.NArg() > 3 {
+		if job.Bck, err = parseBckURI(c, c.Args().Get(3)); err != nil {
+			return
+		}
+	}
+	if c.NArg() > 4 {
+		if job.BckTo, err = parseBckURI(c, c.Args().Get(4)); err != nil {
+			return
+		}
+	}
+	if flagIsSet(c, schedValueFlag) {
+		value := parseStrFlag(c, schedValueFlag)
+		if err = jsoniter.UnmarshalFromString(value, &job.Value); err != nil {
+			return fmt.Errorf("invalid --%s %q: %v", schedValueFlag.Name, value, err)
+		}
+	}
+	if err = job.Validate(); err != nil {
+		return
+	}
+	if err = api.AddSchedJob(defaultAPIParams, job); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "scheduled job %q (%s)\n", job.Name, job.Cron)
+	return
+}
+
+func removeSchedJobHandler(c *cli.Context) (err error) {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, "job name")
+	}
+	name := c.Args().First()
+	if err = api.RemoveSchedJob(defaultAPIParams, name); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "removed scheduled job %q\n", name)
+	return
+}
+
+func showSchedJobsHandler(c *cli.Context) error {
+	md, err := api.GetSchedJobs(defaultAPIParams)
+	if err != nil {
+		return err
+	}
+	useJSON := flagIsSet(c, jsonFlag)
+	if c.NArg() == 0 {
+		return templates.DisplayOutput(md.List(), c.App.Writer, templates.SchedJobsTmpl, useJSON)
+	}
+	name := c.Args().First()
+	job, ok := md.Jobs[name]
+	if !ok {
+		return fmt.Errorf("scheduled job %q does not exist", name)
+	}
+	if useJSON {
+		return templates.DisplayOutput(job, c.App.Writer, "", true)
+	}
+	return templates.DisplayOutput(job.Runs, c.App.Writer, templates.SchedRunsTmpl, false)
+}
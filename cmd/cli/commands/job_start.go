@@ -10,7 +10,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,11 +44,25 @@ var (
 			descJobFlag,
 			limitConnectionsFlag,
 			objectsListFlag,
+			dlManifestFlag,
 			progressIntervalFlag,
 			progressBarFlag,
 			waitFlag,
 			limitBytesPerHourFlag,
 			syncFlag,
+			dlCredsFlag,
+			dlMaxAttemptsFlag,
+			dlBackoffFlag,
+			dlMaxBackoffFlag,
+			dlRetryCodesFlag,
+			dlCrawlFlag,
+			dlMaxDepthFlag,
+			dlIncludeFlag,
+			dlExcludeFlag,
+			dlAnyHostFlag,
+			dlContinuousFlag,
+			dlMaxRunsFlag,
+			dlDeleteFlag,
 		},
 		subcmdStartDsort: {
 			specFileFlag,
@@ -184,31 +201,46 @@ func startDownloadHandler(c *cli.Context) error {
 		description      = parseStrFlag(c, descJobFlag)
 		timeout          = parseStrFlag(c, timeoutFlag)
 		objectsListPath  = parseStrFlag(c, objectsListFlag)
+		manifestPath     = parseStrFlag(c, dlManifestFlag)
 		progressInterval = parseStrFlag(c, progressIntervalFlag)
 		id               string
 	)
 
-	if c.NArg() == 0 {
-		return missingArgumentsError(c, "source", "destination")
-	}
-	if c.NArg() == 1 {
-		return missingArgumentsError(c, "destination")
-	}
-	if c.NArg() > 2 {
-		const q = "For range download, enclose source in quotation marks, e.g.: \"gs://imagenet/train-{00..99}.tgz\""
-		s := fmt.Sprintf("too many arguments - expected 2, got %d.\n%s", len(c.Args()), q)
-		return &errUsage{
-			context:      c,
-			message:      s,
-			helpData:     c.Command,
-			helpTemplate: cli.CommandHelpTemplate,
+	var (
+		src, dst string
+		source   dlSource
+		err      error
+	)
+	if manifestPath != "" {
+		// links come from the manifest - destination only
+		if c.NArg() == 0 {
+			return missingArgumentsError(c, "destination")
+		}
+		if c.NArg() > 1 {
+			return incorrectUsageMsg(c, "too many arguments - with %s expecting destination only", "--"+dlManifestFlag.Name)
+		}
+		dst = c.Args().Get(0)
+	} else {
+		if c.NArg() == 0 {
+			return missingArgumentsError(c, "source", "destination")
+		}
+		if c.NArg() == 1 {
+			return missingArgumentsError(c, "destination")
+		}
+		if c.NArg() > 2 {
+			const q = "For range download, enclose source in quotation marks, e.g.: \"gs://imagenet/train-{00..99}.tgz\""
+			s := fmt.Sprintf("too many arguments - expected 2, got %d.\n%s", len(c.Args()), q)
+			return &errUsage{
+				context:      c,
+				message:      s,
+				helpData:     c.Command,
+				helpTemplate: cli.CommandHelpTemplate,
+			}
+		}
+		src, dst = c.Args().Get(0), c.Args().Get(1)
+		if source, err = parseSource(src); err != nil {
+			return err
 		}
-	}
-
-	src, dst := c.Args().Get(0), c.Args().Get(1)
-	source, err := parseSource(src)
-	if err != nil {
-		return err
 	}
 	bck, pathSuffix, err := parseDest(c, dst)
 	if err != nil {
@@ -235,6 +267,31 @@ func startDownloadHandler(c *cli.Context) error {
 		},
 	}
 
+	if basePayload.Retry, err = parseDlRetryFlags(c); err != nil {
+		return err
+	}
+	if basePayload.Continuous, err = parseDlContinuousFlags(c); err != nil {
+		return err
+	}
+
+	if credsPath := parseStrFlag(c, dlCredsFlag); credsPath != "" {
+		creds := &downloader.DlCreds{}
+		file, err := os.Open(credsPath)
+		if err != nil {
+			return err
+		}
+		err = jsoniter.NewDecoder(file).Decode(creds)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("failed to parse credentials file %q: %v", credsPath, err)
+		}
+		basePayload.Creds = creds
+		// keep s3:// and gs:// links as they are - to be accessed with the credentials
+		if u, err := url.Parse(src); err == nil && (u.Scheme == apc.S3Scheme || u.Scheme == apc.GSScheme) {
+			source.link = src
+		}
+	}
+
 	if basePayload.Bck.Props, err = api.HeadBucket(defaultAPIParams, basePayload.Bck); err != nil {
 		if !cmn.IsStatusNotFound(err) {
 			return err
@@ -244,8 +301,10 @@ func startDownloadHandler(c *cli.Context) error {
 
 	// Heuristics to determine the download type.
 	var dlType downloader.DlType
-	if objectsListPath != "" {
+	if objectsListPath != "" || manifestPath != "" {
 		dlType = downloader.DlTypeMulti
+	} else if flagIsSet(c, dlCrawlFlag) {
+		dlType = downloader.DlTypeCrawl
 	} else if strings.Contains(source.link, "{") && strings.Contains(source.link, "}") {
 		dlType = downloader.DlTypeRange
 	} else if source.backend.bck.IsEmpty() {
@@ -299,6 +358,14 @@ func startDownloadHandler(c *cli.Context) error {
 		}
 		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
 	case downloader.DlTypeMulti:
+		if manifestPath != "" {
+			manifest, err := readDlManifest(manifestPath, pathSuffix)
+			if err != nil {
+				return err
+			}
+			id, err = api.DownloadWithParam(defaultAPIParams, dlType, downloader.DlMultiBody{DlBase: basePayload, Manifest: manifest})
+			break
+		}
 		var objects []string
 		{
 			file, err := os.Open(objectsListPath)
@@ -324,6 +391,17 @@ func startDownloadHandler(c *cli.Context) error {
 			Template: source.link,
 		}
 		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
+	case downloader.DlTypeCrawl:
+		payload := downloader.DlCrawlBody{
+			DlBase:   basePayload,
+			URL:      src, // as is (e.g., with trailing slash)
+			Subdir:   pathSuffix,
+			MaxDepth: parseIntFlag(c, dlMaxDepthFlag),
+			Include:  parseStrFlag(c, dlIncludeFlag),
+			Exclude:  parseStrFlag(c, dlExcludeFlag),
+			AnyHost:  flagIsSet(c, dlAnyHostFlag),
+		}
+		id, err = api.DownloadWithParam(defaultAPIParams, dlType, payload)
 	case downloader.DlTypeBackend:
 		payload := downloader.DlBackendBody{
 			DlBase: basePayload,
@@ -352,6 +430,72 @@ func startDownloadHandler(c *cli.Context) error {
 	return bgDownload(c, id)
 }
 
+// nil if none of the retry flags is set
+// parse download manifest; object names are prefixed with the destination's path (if any)
+func readDlManifest(manifestPath, pathSuffix string) ([]downloader.DlManifestEntry, error) {
+	format, err := downloader.ManifestFormat(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := downloader.ParseManifest(file, format)
+	file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %q: %v", manifestPath, err)
+	}
+	if pathSuffix != "" {
+		for i := range manifest {
+			manifest[i].Name = path.Join(pathSuffix, manifest[i].Name)
+		}
+	}
+	return manifest, nil
+}
+
+func parseDlRetryFlags(c *cli.Context) (*downloader.DlRetry, error) {
+	if !flagIsSet(c, dlMaxAttemptsFlag) && !flagIsSet(c, dlBackoffFlag) &&
+		!flagIsSet(c, dlMaxBackoffFlag) && !flagIsSet(c, dlRetryCodesFlag) {
+		return nil, nil
+	}
+	retry := &downloader.DlRetry{
+		MaxAttempts: parseIntFlag(c, dlMaxAttemptsFlag),
+		Backoff:     parseStrFlag(c, dlBackoffFlag),
+		MaxBackoff:  parseStrFlag(c, dlMaxBackoffFlag),
+	}
+	if codes := parseStrFlag(c, dlRetryCodesFlag); codes != "" {
+		for _, s := range strings.Split(codes, ",") {
+			code, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return nil, fmt.Errorf("invalid --%s %q: %v", dlRetryCodesFlag.Name, codes, err)
+			}
+			retry.RetryCodes = append(retry.RetryCodes, code)
+		}
+	}
+	return retry, retry.Validate()
+}
+
+func parseDlContinuousFlags(c *cli.Context) (*downloader.DlContinuous, error) {
+	if !flagIsSet(c, dlContinuousFlag) {
+		if flagIsSet(c, dlMaxRunsFlag) || flagIsSet(c, dlDeleteFlag) {
+			return nil, incorrectUsageMsg(c, "%s and %s require %s",
+				"--"+dlMaxRunsFlag.Name, "--"+dlDeleteFlag.Name, "--"+dlContinuousFlag.Name)
+		}
+		return nil, nil
+	}
+	cont := &downloader.DlContinuous{
+		Interval: parseStrFlag(c, dlContinuousFlag),
+		MaxRuns:  parseIntFlag(c, dlMaxRunsFlag),
+		Delete:   flagIsSet(c, dlDeleteFlag),
+	}
+	if cont.MaxRuns == 0 && (flagIsSet(c, progressBarFlag) || flagIsSet(c, waitFlag)) {
+		return nil, incorrectUsageMsg(c, "continuous job without %s never finishes - cannot %s or %s",
+			"--"+dlMaxRunsFlag.Name, "--"+progressBarFlag.Name, "--"+waitFlag.Name)
+	}
+	return cont, cont.Validate()
+}
+
 func pbDownload(c *cli.Context, id string) (err error) {
 	refreshRate := calcRefreshRate(c)
 	downloadingResult, err := newDownloaderPB(defaultAPIParams, id, refreshRate).run()
@@ -438,46 +582,11 @@ func waitDownload(c *cli.Context, id string) (err error) {
 
 func startDsortHandler(c *cli.Context) (err error) {
 	var (
-		id       string
-		specPath = parseStrFlag(c, specFileFlag)
+		id        string
+		specBytes []byte
 	)
-	if c.NArg() == 0 && specPath == "" {
-		return missingArgumentsError(c, "job specification")
-	} else if c.NArg() > 0 && specPath != "" {
-		return &errUsage{
-			context:      c,
-			message:      "multiple job specifications provided, expected one",
-			helpData:     c.Command,
-			helpTemplate: cli.CommandHelpTemplate,
-		}
-	}
-
-	var specBytes []byte
-	if specPath == "" {
-		// Specification provided as an argument.
-		specBytes = []byte(c.Args().First())
-	} else {
-		// Specification provided as path to the file (flag).
-		var r io.Reader
-		if specPath == fileStdIO {
-			r = os.Stdin
-		} else {
-			f, err := os.Open(specPath)
-			if err != nil {
-				return err
-			}
-			defer f.Close()
-			r = f
-		}
-
-		var b bytes.Buffer
-		// Read at most 1MB so we don't blow up when reading a malicious file.
-		if _, err := io.CopyN(&b, r, cos.MiB); err == nil {
-			return errors.New("file too big")
-		} else if err != io.EOF {
-			return err
-		}
-		specBytes = b.Bytes()
+	if specBytes, err = readJobSpec(c, parseStrFlag(c, specFileFlag)); err != nil {
+		return
 	}
 
 	var rs dsort.RequestSpec
@@ -498,6 +607,46 @@ func startDsortHandler(c *cli.Context) (err error) {
 	return
 }
 
+// job specification: either the (first) argument or the file (or stdin)
+func readJobSpec(c *cli.Context, specPath string) ([]byte, error) {
+	if c.NArg() == 0 && specPath == "" {
+		return nil, missingArgumentsError(c, "job specification")
+	} else if c.NArg() > 0 && specPath != "" {
+		return nil, &errUsage{
+			context:      c,
+			message:      "multiple job specifications provided, expected one",
+			helpData:     c.Command,
+			helpTemplate: cli.CommandHelpTemplate,
+		}
+	}
+
+	if specPath == "" {
+		// Specification provided as an argument.
+		return []byte(c.Args().First()), nil
+	}
+	// Specification provided as path to the file (flag).
+	var r io.Reader
+	if specPath == fileStdIO {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(specPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var b bytes.Buffer
+	// Read at most 1MB so we don't blow up when reading a malicious file.
+	if _, err := io.CopyN(&b, r, cos.MiB); err == nil {
+		return nil, errors.New("file too big")
+	} else if err != io.EOF {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
 func startLRUHandler(c *cli.Context) (err error) {
 	if !flagIsSet(c, listBucketsFlag) {
 		return startXactionHandler(c)
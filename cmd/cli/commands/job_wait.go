@@ -11,6 +11,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/dsort"
+	"github.com/NVIDIA/aistore/nl"
 	"github.com/urfave/cli"
 )
 
@@ -18,6 +19,8 @@ var (
 	waitCmdsFlags = map[string][]cli.Flag{
 		subcmdWaitXaction: {
 			timeoutFlag,
+			refreshFlag,
+			progressBarFlag,
 		},
 		subcmdWaitDownload: {
 			refreshFlag,
@@ -27,6 +30,9 @@ var (
 			refreshFlag,
 			progressBarFlag,
 		},
+		subcmdWaitPipeline: {
+			timeoutFlag,
+		},
 	}
 
 	jobWaitSubcmds = cli.Command{
@@ -57,6 +63,13 @@ var (
 				Action:       waitDSortHandler,
 				BashComplete: dsortIDRunningCompletions,
 			},
+			{
+				Name:      subcmdWaitPipeline,
+				Usage:     "wait for a pipeline to finish",
+				ArgsUsage: pipelineIDArgument,
+				Flags:     waitCmdsFlags[subcmdWaitPipeline],
+				Action:    waitPipelineHandler,
+			},
 		},
 	}
 )
@@ -71,8 +84,15 @@ func waitXactionHandler(c *cli.Context) error {
 		return err
 	}
 
-	xactArgs := api.XactReqArgs{ID: xactID, Kind: xactKind, Bck: bck, Timeout: parseDurationFlag(c, timeoutFlag)}
-	status, err := api.WaitForXactionIC(defaultAPIParams, xactArgs)
+	var (
+		status   *nl.NotifStatus
+		xactArgs = api.XactReqArgs{ID: xactID, Kind: xactKind, Bck: bck, Timeout: parseDurationFlag(c, timeoutFlag)}
+	)
+	if flagIsSet(c, progressBarFlag) {
+		status, err = waitXactionProgress(c, xactArgs)
+	} else {
+		status, err = api.WaitForXactionIC(defaultAPIParams, xactArgs)
+	}
 	if err != nil {
 		return err
 	}
@@ -88,6 +108,33 @@ func waitXactionHandler(c *cli.Context) error {
 	return nil
 }
 
+// poll cluster-wide xaction status and show its progress (percentage, throughput, and ETA)
+func waitXactionProgress(c *cli.Context, xactArgs api.XactReqArgs) (*nl.NotifStatus, error) {
+	var (
+		refreshRate = calcRefreshRate(c)
+		deadline    time.Time
+	)
+	if xactArgs.Timeout > 0 {
+		deadline = time.Now().Add(xactArgs.Timeout)
+	}
+	for {
+		status, err := api.GetXactionProgress(defaultAPIParams, xactArgs)
+		if err != nil {
+			return nil, err
+		}
+		if status.Progress != nil {
+			fmt.Fprintln(c.App.Writer, status.Progress.String())
+		}
+		if status.Finished() {
+			return &status.NotifStatus, nil
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for xaction (%v)", xactArgs.Timeout)
+		}
+		time.Sleep(refreshRate)
+	}
+}
+
 func waitDownloadHandler(c *cli.Context) (err error) {
 	if c.NArg() == 0 {
 		return missingArgumentsError(c, "job id")
@@ -170,3 +217,10 @@ func waitDSortHandler(c *cli.Context) (err error) {
 	}
 	return nil
 }
+
+func waitPipelineHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "pipeline id")
+	}
+	return waitPipeline(c, c.Args().First())
+}
@@ -118,6 +118,18 @@ func getObject(c *cli.Context, outFile string, silent bool) (err error) {
 		objArgs.Query = make(url.Values, 2)
 		objArgs.Query.Set(apc.QparamOrigURL, uri)
 	}
+	if flagIsSet(c, objVersionFlag) {
+		if objArgs.Query == nil {
+			objArgs.Query = make(url.Values, 1)
+		}
+		objArgs.Query.Set(apc.QparamVersionID, parseStrFlag(c, objVersionFlag))
+	}
+	if flagIsSet(c, snapshotFlag) {
+		if objArgs.Query == nil {
+			objArgs.Query = make(url.Values, 1)
+		}
+		objArgs.Query.Set(apc.QparamSnapshot, parseStrFlag(c, snapshotFlag))
+	}
 	// TODO: validate
 	if archPath != "" {
 		if objArgs.Query == nil {
@@ -282,6 +294,7 @@ func putSingleObject(c *cli.Context, bck cmn.Bck, objName, path string) (err err
 		appendArchArgs := api.AppendToArchArgs{
 			PutObjectArgs: putArgs,
 			ArchPath:      archPath,
+			Replace:       flagIsSet(c, replaceArchFlag),
 		}
 		err = api.AppendToArch(appendArchArgs)
 		if flagIsSet(c, progressBarFlag) {
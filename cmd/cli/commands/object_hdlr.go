@@ -23,6 +23,7 @@ var (
 			rmRfFlag,
 			verboseFlag,
 			yesFlag,
+			objVersionFlag,
 		),
 		commandRename: {},
 		commandGet: {
@@ -31,6 +32,8 @@ var (
 			archpathFlag,
 			cksumFlag,
 			checkCachedFlag,
+			objVersionFlag,
+			snapshotFlag,
 		},
 		commandPut: append(
 			supportedCksumFlags,
@@ -235,10 +238,21 @@ func removeObjectHandler(c *cli.Context) (err error) {
 			return incorrectUsageMsg(c, "%q or %q flag not set with a single bucket argument",
 				listFlag.Name, templateFlag.Name)
 		}
+		if flagIsSet(c, objVersionFlag) {
+			ver := parseStrFlag(c, objVersionFlag)
+			if err := api.DeleteObjectVersion(defaultAPIParams, bck, objName, ver); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.App.Writer, "deleted version %s of %q from %s\n", ver, objName, bck)
+			return nil
+		}
 
 		// ais rm BUCKET/OBJECT_NAME - pass, multiObjOp will handle it
 	}
 
+	if flagIsSet(c, objVersionFlag) {
+		return incorrectUsageMsg(c, "flag %q requires a single object argument", objVersionFlag.Name)
+	}
 	// List and range flags are invalid with object argument(s).
 	if flagIsSet(c, listFlag) || flagIsSet(c, templateFlag) {
 		return incorrectUsageMsg(c, "flags %q, %q cannot be used together with object name arguments",
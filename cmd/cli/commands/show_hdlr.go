@@ -19,7 +19,9 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/dsort"
+	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
@@ -106,11 +108,23 @@ var (
 		subcmdShowLog: {
 			logSevFlag,
 		},
+		subcmdShowEvents: {
+			evSinceFlag,
+			evKindFlag,
+			evActorFlag,
+			evLimitFlag,
+			jsonFlag,
+		},
 		subcmdShowClusterStats: {
 			jsonFlag,
 			rawFlag,
 			refreshFlag,
 		},
+		subcmdShowHealth: {
+			healthBackendsFlag,
+			verboseFlag,
+			jsonFlag,
+		},
 	}
 
 	showCmd = cli.Command{
@@ -128,6 +142,7 @@ var (
 			showCmdStorage,
 			showCmdJob,
 			showCmdLog,
+			showCmdEvents,
 		},
 	}
 
@@ -201,6 +216,14 @@ var (
 				Action:       showClusterStatsHandler,
 				BashComplete: daemonCompletions(completeAllDaemons),
 			},
+			{
+				Name:         subcmdShowHealth,
+				Usage:        "show detailed health and readiness of all (or selected) nodes",
+				ArgsUsage:    optionalDaemonIDArgument,
+				Flags:        showCmdsFlags[subcmdShowHealth],
+				Action:       showClusterHealthHandler,
+				BashComplete: daemonCompletions(completeAllDaemons),
+			},
 		},
 	}
 	showCmdRebalance = cli.Command{
@@ -244,6 +267,14 @@ var (
 		BashComplete: daemonCompletions(completeAllDaemons),
 	}
 
+	showCmdEvents = cli.Command{
+		Name:      subcmdShowEvents,
+		Usage:     "show cluster events: Smap and BMD changes, config updates, node maintenance, and more",
+		ArgsUsage: noArguments,
+		Flags:     showCmdsFlags[subcmdShowEvents],
+		Action:    showEventsHandler,
+	}
+
 	showCmdJob = cli.Command{
 		Name:  subcmdShowJob,
 		Usage: "show running and completed jobs (xactions)",
@@ -516,6 +547,25 @@ func showSmapHandler(c *cli.Context) (err error) {
 	return clusterSmap(c, primarySmap, daemonID, flagIsSet(c, jsonFlag))
 }
 
+func showClusterHealthHandler(c *cli.Context) error {
+	health, err := api.GetClusterHealth(defaultAPIParams, flagIsSet(c, healthBackendsFlag))
+	if err != nil {
+		return err
+	}
+	if daemonID := argDaemonID(c); daemonID != "" {
+		nh, ok := health[daemonID]
+		if !ok {
+			return fmt.Errorf("node %q does not exist", daemonID)
+		}
+		health = cmn.ClusterHealth{daemonID: nh}
+	}
+	tmpl := templates.ClusterHealthTmpl
+	if flagIsSet(c, verboseFlag) {
+		tmpl = templates.ClusterHealthVerboseTmpl
+	}
+	return templates.DisplayOutput(health, c.App.Writer, tmpl, flagIsSet(c, jsonFlag))
+}
+
 func showBMDHandler(c *cli.Context) (err error) {
 	return getBMD(c)
 }
@@ -649,6 +699,24 @@ func showDaemonLogHandler(c *cli.Context) (err error) {
 	return api.GetDaemonLog(defaultAPIParams, node, args)
 }
 
+func showEventsHandler(c *cli.Context) error {
+	q := &cmn.EventQuery{
+		Actor: parseStrFlag(c, evActorFlag),
+		Limit: parseIntFlag(c, evLimitFlag),
+	}
+	if flagIsSet(c, evSinceFlag) {
+		q.Since = time.Now().Add(-parseDurationFlag(c, evSinceFlag)).UnixNano()
+	}
+	if kinds := parseStrFlag(c, evKindFlag); kinds != "" {
+		q.Kinds = strings.Split(kinds, ",")
+	}
+	events, err := api.GetClusterEvents(defaultAPIParams, q)
+	if err != nil {
+		return err
+	}
+	return templates.DisplayOutput(events, c.App.Writer, templates.EventsTmpl, flagIsSet(c, jsonFlag))
+}
+
 func showRemoteAISHandler(c *cli.Context) (err error) {
 	aisCloudInfo, err := api.GetRemoteAIS(defaultAPIParams)
 	if err != nil {
@@ -822,7 +890,12 @@ func showClusterTotalStats(c *cli.Context) (err error) {
 				continue
 			}
 			if totalVal, ok := tgtStats[k]; ok {
-				v.Value += totalVal
+				if stats.IsPercentile(k) {
+					// latency percentiles: the cluster-wide value is the worst target's
+					v.Value = cos.MaxI64(v.Value, totalVal)
+				} else {
+					v.Value += totalVal
+				}
 			}
 			tgtStats[k] = v.Value
 		}
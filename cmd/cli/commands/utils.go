@@ -1009,7 +1009,7 @@ func formatStatHuman(name string, value int64) string {
 		return "0"
 	}
 	switch {
-	case strings.HasSuffix(name, ".ns"):
+	case strings.HasSuffix(name, ".ns"), stats.IsPercentile(name):
 		dur := time.Duration(value)
 		return dur.String()
 	case strings.HasSuffix(name, ".time"):
@@ -1092,6 +1092,9 @@ func parseSource(rawURL string) (source dlSource, err error) {
 	case "":
 		scheme = apc.DefaultScheme
 	case "https", "http":
+	case "ftp", "sftp", "file":
+		// downloaded as is (see `downloader.DlCreds` for the respective credentials)
+		return dlSource{link: rawURL}, nil
 	default:
 		err = fmt.Errorf("invalid scheme: %s", scheme)
 		return
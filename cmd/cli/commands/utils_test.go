@@ -172,6 +172,14 @@ func TestParseSourceValidURIs(t *testing.T) {
 				},
 			},
 		},
+		{
+			input:    "sftp://user@10.0.0.1:2222/data/shard-{0..9}.tar",
+			expected: dlSource{link: "sftp://user@10.0.0.1:2222/data/shard-{0..9}.tar"},
+		},
+		{
+			input:    "file:///mnt/nfs/images/img001.jpg",
+			expected: dlSource{link: "file:///mnt/nfs/images/img001.jpg"},
+		},
 		{
 			input:    "ais://172.10.10.10/bucket",
 			expected: dlSource{link: "http://172.10.10.10:8080/v1/objects/bucket"},
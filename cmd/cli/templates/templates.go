@@ -294,6 +294,44 @@ const (
 		"\t\t{{ $mp }}\n" +
 		"{{end}}{{end}}" +
 		"{{end}}{{end}}"
+
+	// Command `show events`
+	ClusterHealthTmpl = "NODE\t TYPE\t STATUS\t READY\t SMAP\t BMD\t ISSUES\n" +
+		"{{range $nh := . }}" +
+		"{{$nh.Node}}\t {{$nh.Role}}\t {{$nh.Status}}\t {{FormatBool $nh.Ready}}\t v{{$nh.SmapVer}}\t v{{$nh.BMDVer}}\t " +
+		"{{FormatHealthIssues $nh}}\n" +
+		"{{end}}"
+	ClusterHealthVerboseTmpl = "NODE\t TYPE\t COMPONENT\t STATUS\t DETAILS\n" +
+		"{{range $nh := . }}{{range $c := $nh.Components}}" +
+		"{{$nh.Node}}\t {{$nh.Role}}\t {{$c.Name}}\t {{$c.Status}}\t {{if $c.Msg}}{{$c.Msg}}{{else}}-{{end}}\n" +
+		"{{end}}{{end}}"
+
+	EventsTmpl = "ID\t TIME\t KIND\t ACTION\t ACTOR\t NODE\t DETAILS\n" +
+		"{{range $ev := . }}" +
+		"{{$ev.ID}}\t {{FormatUnixNano $ev.Time}}\t {{$ev.Kind}}\t {{$ev.Action}}\t " +
+		"{{if $ev.Actor}}{{$ev.Actor}}{{else}}-{{end}}\t {{$ev.Node}}\t {{FormatEvent $ev}}\n" +
+		"{{end}}"
+
+	SchedJobsTmpl = "NAME\t CRON\t KIND\t BUCKET\t ENABLED\t RUNS\t LAST RUN\n" +
+		"{{range $j := . }}" +
+		"{{$j.Name}}\t {{$j.Cron}}\t {{$j.Kind}}\t {{FormatSchedBck $j}}\t {{FormatBool (not $j.Disabled)}}\t " +
+		"{{len $j.Runs}}\t {{FormatSchedRun $j.LastRun}}\n" +
+		"{{end}}"
+	SchedRunsTmpl = "TIME\t XACTION\t STATUS\n" +
+		"{{range $r := . }}" +
+		"{{FormatUnixNano $r.Time}}\t {{if $r.XactID}}{{$r.XactID}}{{else}}-{{end}}\t {{FormatSchedStatus $r}}\n" +
+		"{{end}}"
+
+	PipelinesTmpl = "ID\t NAME\t STATE\t STEPS\t STARTED\t ELAPSED\n" +
+		"{{range $pl := . }}" +
+		"{{$pl.ID}}\t {{$pl.Name}}\t {{$pl.State}}\t {{FormatPipelineSteps $pl}}\t " +
+		"{{FormatUnixNano $pl.Started}}\t {{FormatDur $pl.Elapsed.Nanoseconds}}\n" +
+		"{{end}}"
+	PipelineStepsTmpl = "STEP\t ACTION\t STATE\t JOB\t BUCKET\t DETAILS\n" +
+		"{{range $ss := .Steps}}" +
+		"{{$ss.Name}}\t {{$ss.Action}}\t {{$ss.State}}\t {{if $ss.JobID}}{{$ss.JobID}}{{else}}-{{end}}\t " +
+		"{{if $ss.Bck.IsEmpty}}-{{else}}{{$ss.Bck}}{{end}}\t {{if $ss.Err}}{{$ss.Err}}{{else}}-{{end}}\n" +
+		"{{end}}"
 )
 
 var (
@@ -324,28 +362,34 @@ var (
 	}
 
 	funcMap = template.FuncMap{
-		"FormatBytesSig":    cos.B2S,
-		"FormatBytesUns":    cos.UnsignedB2S,
-		"FormatMAM":         func(u int64) string { return fmt.Sprintf("%-10s", cos.B2S(u, 2)) },
-		"IsUnsetTime":       isUnsetTime,
-		"FormatTime":        fmtTime,
-		"FormatUnixNano":    func(t int64) string { return cos.FormatUnixNano(t, "") },
-		"FormatEC":          FmtEC,
-		"FormatDur":         fmtDuration,
-		"FormatObjStatus":   fmtObjStatus,
-		"FormatObjIsCached": fmtObjIsCached,
-		"FormatDaemonID":    fmtDaemonID,
-		"FormatSmapVersion": fmtSmapVer,
-		"FormatFloat":       func(f float64) string { return fmt.Sprintf("%.2f", f) },
-		"FormatBool":        FmtBool,
-		"FormatMilli":       fmtMilli,
-		"JoinList":          fmtStringList,
-		"JoinListNL":        func(lst []string) string { return fmtStringListGeneric(lst, "\n") },
-		"FormatACL":         fmtACL,
-		"ExtECGetStats":     extECGetStats,
-		"ExtECPutStats":     extECPutStats,
-		"FormatNameArch":    fmtNameArch,
-		"FormatXactState":   fmtXactStatus,
+		"FormatBytesSig":      cos.B2S,
+		"FormatBytesUns":      cos.UnsignedB2S,
+		"FormatMAM":           func(u int64) string { return fmt.Sprintf("%-10s", cos.B2S(u, 2)) },
+		"IsUnsetTime":         isUnsetTime,
+		"FormatTime":          fmtTime,
+		"FormatUnixNano":      func(t int64) string { return cos.FormatUnixNano(t, "") },
+		"FormatEC":            FmtEC,
+		"FormatDur":           fmtDuration,
+		"FormatObjStatus":     fmtObjStatus,
+		"FormatObjIsCached":   fmtObjIsCached,
+		"FormatDaemonID":      fmtDaemonID,
+		"FormatSmapVersion":   fmtSmapVer,
+		"FormatFloat":         func(f float64) string { return fmt.Sprintf("%.2f", f) },
+		"FormatBool":          FmtBool,
+		"FormatMilli":         fmtMilli,
+		"JoinList":            fmtStringList,
+		"JoinListNL":          func(lst []string) string { return fmtStringListGeneric(lst, "\n") },
+		"FormatACL":           fmtACL,
+		"ExtECGetStats":       extECGetStats,
+		"ExtECPutStats":       extECPutStats,
+		"FormatNameArch":      fmtNameArch,
+		"FormatXactState":     fmtXactStatus,
+		"FormatEvent":         fmtEvent,
+		"FormatHealthIssues":  fmtHealthIssues,
+		"FormatSchedBck":      fmtSchedBck,
+		"FormatSchedRun":      fmtSchedRun,
+		"FormatSchedStatus":   fmtSchedStatus,
+		"FormatPipelineSteps": fmtPipelineSteps,
 		// for all stats.DaemonStatus structs in `h`: select specific field
 		// and make a slice, and then a string out of it
 		"OnlineStatus": func(h DaemonStatusTemplateHelper) string { return toString(h.onlineStatus()) },
@@ -649,6 +693,92 @@ func fmtRebStatus(rebSnap *stats.RebalanceSnap) string {
 	return unknownVal
 }
 
+func fmtEvent(ev cmn.Event) string {
+	details := make([]string, 0, 4)
+	if ev.Bck != "" {
+		details = append(details, ev.Bck)
+	}
+	if ev.Ver != 0 {
+		details = append(details, fmt.Sprintf("v%d", ev.Ver))
+	}
+	if ev.XactID != "" {
+		details = append(details, ev.XactID)
+	}
+	if ev.Msg != "" {
+		details = append(details, ev.Msg)
+	}
+	if ev.Err != "" {
+		details = append(details, "err: "+ev.Err)
+	}
+	if len(details) == 0 {
+		return unknownVal
+	}
+	return strings.Join(details, ", ")
+}
+
+func fmtSchedBck(j *cmn.SchedJob) string {
+	switch {
+	case j.Bck.IsEmpty():
+		return NotSetVal
+	case j.BckTo.IsEmpty():
+		return j.Bck.String()
+	default:
+		return j.Bck.String() + " => " + j.BckTo.String()
+	}
+}
+
+func fmtSchedRun(r *cmn.SchedRun) string {
+	if r == nil {
+		return NotSetVal
+	}
+	s := cos.FormatUnixNano(r.Time, "")
+	if r.XactID != "" {
+		s += " " + r.XactID
+	}
+	return s + " (" + fmtSchedStatus(r) + ")"
+}
+
+func fmtSchedStatus(r *cmn.SchedRun) string {
+	switch {
+	case r.Err != "":
+		return "failed: " + r.Err
+	case r.Skipped:
+		return "skipped (previous run in progress)"
+	default:
+		return "started"
+	}
+}
+
+// number of succeeded steps out of total
+func fmtPipelineSteps(ps *cmn.PipelineStatus) string {
+	var cnt int
+	for i := range ps.Steps {
+		if ps.Steps[i].State == cmn.PipeStateSucceeded {
+			cnt++
+		}
+	}
+	return fmt.Sprintf("%d/%d", cnt, len(ps.Steps))
+}
+
+// components that are not "ok"
+func fmtHealthIssues(nh *cmn.NodeHealth) string {
+	issues := make([]string, 0, 2)
+	for _, c := range nh.Components {
+		if c.Status == cmn.HealthOK {
+			continue
+		}
+		s := c.Name + ": " + c.Status
+		if c.Msg != "" {
+			s += " (" + c.Msg + ")"
+		}
+		issues = append(issues, s)
+	}
+	if len(issues) == 0 {
+		return unknownVal
+	}
+	return strings.Join(issues, "; ")
+}
+
 func fmtXactStatus(xctn *xact.SnapExt) string {
 	if xctn.AbortedX {
 		return xactStateAborted
@@ -66,6 +66,9 @@ type (
 		// Bucket creation time
 		Created int64 `json:"created,string" list:"readonly"`
 
+		// Bucket snapshots, if any (ais:// buckets only - see BckSnap)
+		Snapshots []BckSnap `json:"snapshots,omitempty" list:"omit"`
+
 		// Non-empty when the bucket has been renamed.
 		// TODO: Could be used for delayed deletion.
 		Renamed string `list:"omit"`
@@ -212,12 +215,18 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 		}
 	}
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Versioning} {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
 		} else if pv == &bp.Extra {
 			err = bp.Extra.ValidateAsProps(bp.Provider)
+		} else if pv == &bp.Versioning {
+			provider := bp.Provider
+			if !bp.BackendBck.IsEmpty() {
+				provider = bp.BackendBck.Provider
+			}
+			err = bp.Versioning.ValidateAsProps(provider)
 		} else {
 			err = pv.ValidateAsProps()
 		}
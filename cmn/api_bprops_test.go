@@ -0,0 +1,41 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestVersionConfValidateAsProps(t *testing.T) {
+	tests := []struct {
+		conf     VersionConf
+		provider string
+		valid    bool
+	}{
+		// non-versioned bucket inheriting cluster-wide validate_warm_get
+		{VersionConf{ValidateWarmGet: true}, apc.ProviderAmazon, true},
+		{VersionConf{Enabled: true, ValidateWarmGet: true}, apc.ProviderAmazon, true},
+		{VersionConf{Enabled: true, History: 3}, apc.ProviderAIS, true},
+		{VersionConf{Enabled: true, History: 3, Retention: cos.Duration(time.Hour)}, apc.ProviderAIS, true},
+		{VersionConf{Enabled: true, History: 3}, apc.ProviderAmazon, false},
+		{VersionConf{History: 3}, apc.ProviderAIS, false},
+		{VersionConf{Enabled: true, History: -1}, apc.ProviderAIS, false},
+		{VersionConf{Enabled: true, Retention: cos.Duration(time.Hour)}, apc.ProviderAIS, false},
+	}
+	for _, test := range tests {
+		err := test.conf.ValidateAsProps(test.provider)
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v (%s): expected valid=%t, got %v",
+			test.conf, test.provider, test.valid, err)
+	}
+	// (cluster config, though, is still validated as before)
+	conf := VersionConf{ValidateWarmGet: true}
+	tassert.Errorf(t, conf.Validate() != nil, "expected validate_warm_get to require versioning")
+}
@@ -0,0 +1,144 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Cluster event log: control-plane events recorded by the primary proxy and
+// replicated to all other proxies (see ais/evlog.go)
+
+// event kinds
+const (
+	EvKindSmap    = "smap"    // cluster map changes
+	EvKindBMD     = "bmd"     // bucket metadata updates
+	EvKindBucket  = "bucket"  // bucket creation, destruction
+	EvKindConfig  = "config"  // cluster configuration changes
+	EvKindNode    = "node"    // node maintenance, decommission, shutdown
+	EvKindAuth    = "auth"    // AuthN logins
+	EvKindXaction = "xaction" // xaction start, abort
+)
+
+// event actions other than apc.Act*
+const (
+	EvActLogin = "login"
+	EvActAbort = "abort"
+)
+
+const DfltEventsMax = 10000 // default number of events to keep (see `LogConf.EventsMax`)
+
+type (
+	Event struct {
+		ID     int64  `json:"id,string"`       // sequence number (assigned by primary)
+		Time   int64  `json:"time,string"`     // Unix time (nanoseconds)
+		Kind   string `json:"kind"`            // enum { EvKindSmap, ... }
+		Action string `json:"action"`          // apc.ActCreateBck, apc.ActSetConfig, etc.
+		Actor  string `json:"actor,omitempty"` // AuthN user or client address (when known)
+		Node   string `json:"node"`            // ID of the node that originated the event
+		Msg    string `json:"msg,omitempty"`   // details
+		Ver    int64  `json:"ver,omitempty"`   // Smap or BMD version (when applicable)
+		XactID string `json:"xid,omitempty"`   // xaction ID (when applicable)
+		Bck    string `json:"bck,omitempty"`   // bucket (when applicable)
+		Err    string `json:"err,omitempty"`   // error, if any
+		// AuthN token fingerprint (login events only) - to record a given login once;
+		// internal: not sent to the webhook
+		TokenFP string `json:"token_fp,omitempty"`
+	}
+	// selection criteria; zero values match all
+	EventQuery struct {
+		Since int64    `json:"since,string"` // Unix time (nanoseconds)
+		Until int64    `json:"until,string"` // ditto
+		Kinds []string `json:"kinds"`
+		Actor string   `json:"actor"`
+		Limit int      `json:"limit"` // return (at most) this number of the most recent matching events
+	}
+)
+
+func (ev *Event) String() string {
+	var sb strings.Builder
+	sb.WriteString("ev[")
+	sb.WriteString(strconv.FormatInt(ev.ID, 10))
+	sb.WriteString(", ")
+	sb.WriteString(ev.Kind)
+	sb.WriteString(", ")
+	sb.WriteString(ev.Action)
+	if ev.Actor != "" {
+		sb.WriteString(", actor=")
+		sb.WriteString(ev.Actor)
+	}
+	if ev.Msg != "" {
+		sb.WriteString(", ")
+		sb.WriteString(ev.Msg)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+////////////////
+// EventQuery //
+////////////////
+
+func (q *EventQuery) Match(ev *Event) bool {
+	if q.Since != 0 && ev.Time < q.Since {
+		return false
+	}
+	if q.Until != 0 && ev.Time > q.Until {
+		return false
+	}
+	if len(q.Kinds) > 0 && !cos.StringInSlice(ev.Kind, q.Kinds) {
+		return false
+	}
+	return q.Actor == "" || q.Actor == ev.Actor
+}
+
+func (q *EventQuery) ToQuery(query url.Values) url.Values {
+	if query == nil {
+		query = make(url.Values, 4)
+	}
+	if q.Since != 0 {
+		query.Set(apc.QparamEvSince, strconv.FormatInt(q.Since, 10))
+	}
+	if q.Until != 0 {
+		query.Set(apc.QparamEvUntil, strconv.FormatInt(q.Until, 10))
+	}
+	if len(q.Kinds) > 0 {
+		query.Set(apc.QparamEvKind, strings.Join(q.Kinds, ","))
+	}
+	if q.Actor != "" {
+		query.Set(apc.QparamEvActor, q.Actor)
+	}
+	if q.Limit != 0 {
+		query.Set(apc.QparamEvLimit, strconv.Itoa(q.Limit))
+	}
+	return query
+}
+
+func (q *EventQuery) FromQuery(query url.Values) (err error) {
+	if s := query.Get(apc.QparamEvSince); s != "" {
+		if q.Since, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return
+		}
+	}
+	if s := query.Get(apc.QparamEvUntil); s != "" {
+		if q.Until, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return
+		}
+	}
+	if s := query.Get(apc.QparamEvKind); s != "" {
+		q.Kinds = strings.Split(s, ",")
+	}
+	q.Actor = query.Get(apc.QparamEvActor)
+	if s := query.Get(apc.QparamEvLimit); s != "" {
+		q.Limit, err = strconv.Atoi(s)
+	}
+	return
+}
@@ -0,0 +1,58 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestEventQueryRoundTrip(t *testing.T) {
+	q := EventQuery{Since: 100, Until: 200, Kinds: []string{EvKindBucket, EvKindAuth}, Actor: "alice", Limit: 5}
+	var out EventQuery
+	tassert.CheckFatal(t, out.FromQuery(q.ToQuery(nil)))
+	tassert.Errorf(t, reflect.DeepEqual(q, out), "expected %+v, got %+v", q, out)
+
+	// zero values are not encoded (and match all)
+	tassert.Errorf(t, len((&EventQuery{}).ToQuery(nil)) == 0, "expected empty query")
+	out = EventQuery{}
+	tassert.CheckFatal(t, out.FromQuery(url.Values{}))
+	tassert.Errorf(t, reflect.DeepEqual(out, EventQuery{}), "expected zero query, got %+v", out)
+}
+
+func TestEventQueryFromQueryInvalid(t *testing.T) {
+	for _, qparam := range []string{apc.QparamEvSince, apc.QparamEvUntil, apc.QparamEvLimit} {
+		var q EventQuery
+		err := q.FromQuery(url.Values{qparam: []string{"abc"}})
+		tassert.Errorf(t, err != nil, "%s: expected error", qparam)
+	}
+}
+
+func TestEventQueryMatch(t *testing.T) {
+	ev := &Event{Time: 150, Kind: EvKindBucket, Actor: "alice"}
+	tests := []struct {
+		q     EventQuery
+		match bool
+	}{
+		{EventQuery{}, true},
+		{EventQuery{Since: 100, Until: 200}, true},
+		{EventQuery{Since: 150, Until: 150}, true},
+		{EventQuery{Since: 151}, false},
+		{EventQuery{Until: 149}, false},
+		{EventQuery{Kinds: []string{EvKindSmap, EvKindBucket}}, true},
+		{EventQuery{Kinds: []string{EvKindSmap}}, false},
+		{EventQuery{Actor: "alice"}, true},
+		{EventQuery{Actor: "bob"}, false},
+		{EventQuery{Kinds: []string{EvKindBucket}, Actor: "bob"}, false},
+	}
+	for _, test := range tests {
+		tassert.Errorf(t, test.q.Match(ev) == test.match, "%+v: expected match=%t", test.q, test.match)
+	}
+}
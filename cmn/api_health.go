@@ -0,0 +1,127 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Detailed node health: GET /v1/health?details=true (see also `KeepaliveConf.Readiness`)
+
+// health components
+const (
+	HealthSmap       = "smap"       // Smap validity and node's membership
+	HealthMetasync   = "metasync"   // Smap and BMD version lag (relative to the caller or primary)
+	HealthMountpaths = "mountpaths" // (target) FSHC-disabled mountpaths
+	HealthCapacity   = "capacity"   // (target) used capacity vs `SpaceConf` watermarks
+	HealthRebalance  = "rebalance"  // (target) running or interrupted rebalance
+	HealthResilver   = "resilver"   // (target) running or interrupted resilver
+	HealthBackend    = "backend"    // (target) remote backend reachability (on demand)
+	HealthAdmission  = "admission"  // (target) overloaded: rejecting PUTs and xactions
+)
+
+// health status, in the increasing order of severity
+const (
+	HealthOK       = "ok"
+	HealthStarting = "starting"
+	HealthDegraded = "degraded"
+	HealthFailed   = "failed"
+)
+
+var HealthComponents = []string{
+	HealthSmap, HealthMetasync, HealthMountpaths, HealthCapacity, HealthRebalance, HealthResilver, HealthBackend,
+	HealthAdmission,
+}
+
+type (
+	HealthComponent struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+		Msg    string `json:"msg,omitempty"`
+	}
+	NodeHealth struct {
+		Node       string            `json:"node"`
+		Role       string            `json:"role"`
+		Status     string            `json:"status"` // the worst of the component statuses
+		Ready      bool              `json:"ready"`  // all readiness criteria are met
+		SmapVer    int64             `json:"smap_version,string"`
+		BMDVer     int64             `json:"bmd_version,string"`
+		Components []HealthComponent `json:"components"`
+	}
+	ClusterHealth map[string]*NodeHealth // by node ID
+)
+
+func healthSeverity(status string) int {
+	switch status {
+	case HealthOK:
+		return 0
+	case HealthStarting:
+		return 1
+	case HealthDegraded:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func ValidateReadiness(criteria string) error {
+	for _, name := range ParseReadiness(criteria) {
+		if !cos.StringInSlice(name, HealthComponents) {
+			return fmt.Errorf("invalid readiness criterion %q (expecting one of %v)", name, HealthComponents)
+		}
+	}
+	return nil
+}
+
+// comma-separated list of components that must be healthy for the node to report ready
+func ParseReadiness(criteria string) (names []string) {
+	for _, name := range strings.Split(criteria, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return
+}
+
+////////////////
+// NodeHealth //
+////////////////
+
+func (nh *NodeHealth) Add(name, status, msg string) {
+	nh.Components = append(nh.Components, HealthComponent{Name: name, Status: status, Msg: msg})
+}
+
+func (nh *NodeHealth) Get(name string) *HealthComponent {
+	for i := range nh.Components {
+		if nh.Components[i].Name == name {
+			return &nh.Components[i]
+		}
+	}
+	return nil
+}
+
+// compute the overall status and readiness; a node that is still starting up
+// is never ready; missing (not applicable) components do not count
+func (nh *NodeHealth) Eval(criteria []string) {
+	nh.Status, nh.Ready = HealthOK, true
+	for i := range nh.Components {
+		status := nh.Components[i].Status
+		if healthSeverity(status) > healthSeverity(nh.Status) {
+			nh.Status = status
+		}
+		if status == HealthStarting {
+			nh.Ready = false
+		}
+	}
+	for _, name := range criteria {
+		if c := nh.Get(name); c != nil && c.Status != HealthOK {
+			nh.Ready = false
+		}
+	}
+}
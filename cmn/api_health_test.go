@@ -0,0 +1,43 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestNodeHealthEval(t *testing.T) {
+	nh := &NodeHealth{}
+	nh.Add(HealthSmap, HealthOK, "")
+	nh.Add(HealthMetasync, HealthOK, "")
+	nh.Add(HealthRebalance, HealthDegraded, "running")
+
+	nh.Eval(nil)
+	tassert.Errorf(t, nh.Status == HealthDegraded, "expected %q, got %q", HealthDegraded, nh.Status)
+	tassert.Errorf(t, nh.Ready, "expected ready with no criteria")
+
+	nh.Eval(ParseReadiness("smap, metasync"))
+	tassert.Errorf(t, nh.Ready, "expected ready: rebalance is not a criterion")
+
+	nh.Eval(ParseReadiness("smap,rebalance"))
+	tassert.Errorf(t, !nh.Ready, "expected not ready while rebalancing")
+
+	// not applicable (e.g., proxy) components don't count
+	nh.Eval(ParseReadiness("smap,capacity"))
+	tassert.Errorf(t, nh.Ready, "expected ready: capacity n/a")
+
+	nh.Components[0].Status = HealthStarting
+	nh.Eval(nil)
+	tassert.Errorf(t, !nh.Ready && nh.Status == HealthDegraded, "expected not ready while starting (status %q)", nh.Status)
+}
+
+func TestValidateReadiness(t *testing.T) {
+	tassert.CheckError(t, ValidateReadiness(""))
+	tassert.CheckError(t, ValidateReadiness("smap,mountpaths,capacity"))
+	tassert.Errorf(t, ValidateReadiness("smap,foo") != nil, "expected error on invalid criterion")
+}
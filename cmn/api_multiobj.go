@@ -6,9 +6,14 @@
 package cmn
 
 import (
+	"errors"
+	"fmt"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
 )
 
 // used in multi-object (list|range) operations
@@ -44,6 +49,39 @@ type (
 		// flags
 		ContinueOnError bool `json:"coer"` // keep running in presence of errors in a any given multi-object transaction
 	}
+
+	// GetBatchMsg is used to GET multiple objects and/or archived files in one shot: the result
+	// is a single archive (one of the cos.ArchExtensions types) streamed back to the client.
+	// Objects may reside in different buckets and on different targets.
+	GetBatchMsg struct {
+		In   []GetBatchIn `json:"in"`
+		Mime string       `json:"mime"` // output format (default: cos.ExtTar)
+		// flags
+		OnlyObjName     bool `json:"onob"`      // name output files by object name only (default: bucket/object)
+		Unordered       bool `json:"unordered"` // output in the order of arrival (default: in the order of `In`)
+		ContinueOnError bool `json:"coer"`      // include missing (or failed) objects as empty files under `GetBatchMissingDir`
+	}
+	GetBatchIn struct {
+		Bck      Bck    `json:"bck"` // defaults to the bucket of the request
+		ObjName  string `json:"objname"`
+		ArchPath string `json:"archpath,omitempty"` // extract this file from the (archived) object
+	}
+
+	// ExtractArchMsg is used to extract files from an existing archive (shard) and store
+	// them as individual objects named `Prefix` + archived filename in the destination bucket
+	ExtractArchMsg struct {
+		ToBck  Bck    `json:"tobck"`  // defaults to the bucket of the archive
+		Prefix string `json:"prefix"` // destination name prefix (e.g., virtual directory "abc/")
+		Mime   string `json:"mime"`   // user-specified mime type takes precedence if defined
+		Match  string `json:"match"`  // extract only the files that match this shell pattern (default: all)
+		// flags
+		ContinueOnError bool `json:"coer"` // skip (and log) files that fail to extract
+	}
+)
+
+const (
+	GetBatchMissingDir = "__404__"
+	GetBatchMaxIn      = 64 * 1024
 )
 
 // NOTE: empty SelectObjsMsg{} corresponds to (range = entire bucket)
@@ -55,3 +93,89 @@ func (lrm *SelectObjsMsg) HasTemplate() bool { return lrm.Template != "" }
 // ArchiveMsg //
 ////////////////
 func (msg *ArchiveMsg) FullName() string { return filepath.Join(msg.ToBck.Name, msg.ArchName) }
+
+/////////////////
+// GetBatchMsg //
+/////////////////
+
+func (msg *GetBatchMsg) Validate() (err error) {
+	switch {
+	case len(msg.In) == 0:
+		return errors.New("get-batch: empty list of objects")
+	case len(msg.In) > GetBatchMaxIn:
+		return fmt.Errorf("get-batch: too many objects (%d > %d)", len(msg.In), GetBatchMaxIn)
+	}
+	if msg.Mime == "" {
+		msg.Mime = cos.ExtTar
+	} else if msg.Mime, err = cos.Mime(msg.Mime, ""); err != nil {
+		return
+	}
+	for i := range msg.In {
+		if msg.In[i].ObjName == "" {
+			return fmt.Errorf("get-batch: missing object name (entry %d)", i)
+		}
+	}
+	return
+}
+
+// FullName returns the name of a given (input) object or archived file in the output archive.
+func (msg *GetBatchMsg) FullName(in *GetBatchIn) (name string) {
+	name = in.ObjName
+	if !msg.OnlyObjName {
+		name = filepath.Join(in.Bck.Name, name)
+	}
+	if in.ArchPath != "" {
+		name = filepath.Join(name, in.ArchPath)
+	}
+	return
+}
+
+////////////////////
+// ExtractArchMsg //
+////////////////////
+
+func (msg *ExtractArchMsg) Validate() (err error) {
+	if msg.Mime != "" {
+		if msg.Mime, err = cos.Mime(msg.Mime, ""); err != nil {
+			return
+		}
+	}
+	if msg.Match != "" {
+		if _, err = filepath.Match(msg.Match, ""); err != nil {
+			return fmt.Errorf("extract-arch: invalid pattern %q: %v", msg.Match, err)
+		}
+	}
+	if strings.HasPrefix(msg.Prefix, "/") || hasDotDot(msg.Prefix) {
+		return fmt.Errorf("extract-arch: invalid prefix %q", msg.Prefix)
+	}
+	return
+}
+
+// Selected returns true if a given archived file is to be extracted.
+func (msg *ExtractArchMsg) Selected(filename string) bool {
+	if msg.Match == "" {
+		return true
+	}
+	ok, _ := filepath.Match(msg.Match, strings.TrimPrefix(filename, "/"))
+	return ok
+}
+
+// ObjName returns the destination object name of a given archived file. Archived names
+// are untrusted: absolute paths and names that climb up (e.g., "a/../../b") are rejected -
+// otherwise, they'd resolve outside the destination bucket.
+func (msg *ExtractArchMsg) ObjName(filename string) (string, error) {
+	name := path.Clean(filename)
+	if path.IsAbs(filename) || hasDotDot(name) {
+		return "", fmt.Errorf("extract-arch: invalid archived filename %q (absolute or outside the bucket)", filename)
+	}
+	return msg.Prefix + name, nil
+}
+
+func hasDotDot(name string) bool {
+	for _, seg := range strings.Split(name, "/") {
+		if seg == ".." {
+			return true
+		}
+	}
+	return false
+}
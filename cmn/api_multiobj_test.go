@@ -0,0 +1,64 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestGetBatchMsg(t *testing.T) {
+	msg := &GetBatchMsg{}
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (empty)")
+
+	msg.In = []GetBatchIn{{ObjName: "a"}, {}}
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (missing object name)")
+
+	msg.In = []GetBatchIn{{Bck: Bck{Name: "b1"}, ObjName: "a"}, {Bck: Bck{Name: "b2"}, ObjName: "s.tar", ArchPath: "x/y"}}
+	tassert.CheckFatal(t, msg.Validate())
+	tassert.Errorf(t, msg.Mime == cos.ExtTar, "expected default %s, got %q", cos.ExtTar, msg.Mime)
+	tassert.Errorf(t, msg.FullName(&msg.In[0]) == "b1/a", "got %q", msg.FullName(&msg.In[0]))
+	tassert.Errorf(t, msg.FullName(&msg.In[1]) == "b2/s.tar/x/y", "got %q", msg.FullName(&msg.In[1]))
+	msg.OnlyObjName = true
+	tassert.Errorf(t, msg.FullName(&msg.In[0]) == "a", "got %q", msg.FullName(&msg.In[0]))
+
+	msg.Mime = "application/zip"
+	tassert.CheckFatal(t, msg.Validate())
+	tassert.Errorf(t, msg.Mime == cos.ExtZip, "expected %s, got %q", cos.ExtZip, msg.Mime)
+	msg.Mime = "rar"
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (unsupported format)")
+}
+
+func TestExtractArchMsg(t *testing.T) {
+	msg := &ExtractArchMsg{Prefix: "dir/", Mime: "application/x-tar"}
+	tassert.CheckFatal(t, msg.Validate())
+	tassert.Errorf(t, msg.Mime == cos.ExtTar, "expected %s, got %q", cos.ExtTar, msg.Mime)
+	for filename, expected := range map[string]string{"a/b.jpg": "dir/a/b.jpg", "./a/./b.jpg": "dir/a/b.jpg",
+		"a/../b.jpg": "dir/b.jpg"} {
+		objName, err := msg.ObjName(filename)
+		tassert.Errorf(t, err == nil && objName == expected, "%q: expected %q, got %q (%v)", filename, expected, objName, err)
+	}
+	for _, filename := range []string{"/a/b.jpg", "../b.jpg", "a/../../b.jpg", ".."} {
+		objName, err := msg.ObjName(filename)
+		tassert.Errorf(t, err != nil, "%q: expected error, got %q", filename, objName)
+	}
+	tassert.Errorf(t, msg.Selected("any"), "expected all files selected")
+
+	msg.Match = "*/*.jpg"
+	tassert.CheckFatal(t, msg.Validate())
+	tassert.Errorf(t, msg.Selected("a/b.jpg") && msg.Selected("/a/b.jpg"), "expected match")
+	tassert.Errorf(t, !msg.Selected("a/b.cls") && !msg.Selected("b.jpg"), "expected no match")
+
+	msg.Match = "[a-"
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (bad pattern)")
+	msg.Match, msg.Prefix = "", "../dir/"
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (bad prefix)")
+	msg.Prefix = "dir/"
+	msg.Match, msg.Mime = "", "rar"
+	tassert.Errorf(t, msg.Validate() != nil, "expected error (unsupported format)")
+}
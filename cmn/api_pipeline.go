@@ -0,0 +1,226 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Pipelines: DAGs of (existing) asynchronous actions - download, dSort, ETL and
+// copy bucket, archive - that the primary proxy runs step by step, starting each
+// step when all its predecessors succeed. Steps can be wired via buckets: the
+// output bucket of one step becomes the input bucket of another - see ais/prxpipe.go
+
+// actions that can be pipelined
+var PipelineActs = []string{
+	apc.ActDownload,
+	apc.ActDsort,
+	apc.ActETLBck,
+	apc.ActArchive,
+	apc.ActCopyBck,
+}
+
+// pipeline and step states
+const (
+	PipeStatePending   = "pending"
+	PipeStateStarting  = "starting" // step: being started by the primary
+	PipeStateRunning   = "running"
+	PipeStateSucceeded = "succeeded"
+	PipeStateFailed    = "failed"
+	PipeStateAborted   = "aborted"
+	PipeStateSkipped   = "skipped" // step: not started because upstream failed or was aborted
+)
+
+type (
+	PipelineStep struct {
+		Name   string `json:"name"`   // unique within pipeline
+		Action string `json:"action"` // one of the PipelineActs
+		// input bucket (download: destination); must be empty when `Input` is defined
+		Bck Bck `json:"bck"`
+		// output bucket (copy-bck, etl-bck, dsort, and archive - if different from `Bck`)
+		BckTo Bck `json:"bck_to"`
+		// name of the step whose output bucket is this step's input (implies `After`)
+		Input string   `json:"input,omitempty"`
+		After []string `json:"after,omitempty"` // names of the (other) predecessors
+		// action-specific parameters: the same `apc.ActionMsg.Value` that one would use
+		// to run the step by hand, e.g.: downloader.DlBody (download), dsort.RequestSpec,
+		// apc.TCBMsg (etl-bck), apc.CopyBckMsg (copy-bck), or ArchiveMsg (archive)
+		Value interface{} `json:"value,omitempty"`
+	}
+	Pipeline struct {
+		Name  string          `json:"name"`
+		Steps []*PipelineStep `json:"steps"`
+	}
+
+	PipelineStepStatus struct {
+		Name    string `json:"name"`
+		Action  string `json:"action"`
+		State   string `json:"state"`
+		JobID   string `json:"job_id,omitempty"` // ID of the started xaction, download, or dSort job
+		Bck     Bck    `json:"bck"`              // resolved input bucket
+		Err     string `json:"err,omitempty"`
+		Started int64  `json:"started,string,omitempty"`
+		Ended   int64  `json:"ended,string,omitempty"`
+	}
+	PipelineStatus struct {
+		ID      string               `json:"id"`
+		Name    string               `json:"name"`
+		State   string               `json:"state"`
+		Err     string               `json:"err,omitempty"`
+		Started int64                `json:"started,string"`
+		Ended   int64                `json:"ended,string,omitempty"`
+		Steps   []PipelineStepStatus `json:"steps"`
+	}
+)
+
+//////////////
+// Pipeline //
+//////////////
+
+func (pl *Pipeline) Step(name string) *PipelineStep {
+	for _, step := range pl.Steps {
+		if step.Name == name {
+			return step
+		}
+	}
+	return nil
+}
+
+// all predecessors, including the input step (if any)
+func (step *PipelineStep) Deps() (deps []string) {
+	deps = make([]string, 0, len(step.After)+1)
+	if step.Input != "" {
+		deps = append(deps, step.Input)
+	}
+	for _, name := range step.After {
+		if !cos.StringInSlice(name, deps) {
+			deps = append(deps, name)
+		}
+	}
+	return
+}
+
+// output bucket: the destination of copy/transform/dsort/archive, or the bucket itself
+func (step *PipelineStep) OutBck(inBck *Bck) Bck {
+	if !step.BckTo.IsEmpty() {
+		return step.BckTo
+	}
+	return *inBck
+}
+
+func (pl *Pipeline) Validate() error {
+	if pl.Name == "" {
+		return errors.New("pipeline: missing name")
+	}
+	if len(pl.Steps) == 0 {
+		return fmt.Errorf("pipeline %q: no steps", pl.Name)
+	}
+	names := make(cos.StringSet, len(pl.Steps))
+	for _, step := range pl.Steps {
+		if step.Name == "" {
+			return fmt.Errorf("pipeline %q: step with no name", pl.Name)
+		}
+		if names.Contains(step.Name) {
+			return fmt.Errorf("pipeline %q: duplicate step %q", pl.Name, step.Name)
+		}
+		names.Add(step.Name)
+	}
+	for _, step := range pl.Steps {
+		if err := pl.validateStep(step); err != nil {
+			return fmt.Errorf("pipeline %q, step %q: %v", pl.Name, step.Name, err)
+		}
+	}
+	_, err := pl.TopoSort()
+	return err
+}
+
+func (pl *Pipeline) validateStep(step *PipelineStep) error {
+	if !cos.StringInSlice(step.Action, PipelineActs) {
+		return fmt.Errorf("%q cannot be pipelined (expecting one of %v)", step.Action, PipelineActs)
+	}
+	for _, name := range step.Deps() {
+		if name == step.Name {
+			return errors.New("depends on itself")
+		}
+		if pl.Step(name) == nil {
+			return fmt.Errorf("unknown predecessor %q", name)
+		}
+	}
+	switch {
+	case step.Input != "" && !step.Bck.IsEmpty():
+		return fmt.Errorf("input bucket %s is defined by the %q step", step.Bck, step.Input)
+	case step.Input != "" && step.Action == apc.ActDownload:
+		return errors.New("download has no input")
+	case step.Input == "" && step.Bck.IsEmpty():
+		return errors.New("missing bucket")
+	}
+	if (step.Action == apc.ActCopyBck || step.Action == apc.ActETLBck) && step.BckTo.IsEmpty() {
+		return errors.New("missing destination bucket")
+	}
+	if step.Action != apc.ActCopyBck && step.Value == nil {
+		return fmt.Errorf("%q requires parameters (value)", step.Action)
+	}
+	return nil
+}
+
+// TopoSort returns steps in the order of execution (each step after all its
+// predecessors), or error if the pipeline is not a DAG
+func (pl *Pipeline) TopoSort() (sorted []*PipelineStep, err error) {
+	var (
+		done  = make(cos.StringSet, len(pl.Steps))
+		ready = func(step *PipelineStep) bool {
+			for _, name := range step.Deps() {
+				if !done.Contains(name) {
+					return false
+				}
+			}
+			return true
+		}
+	)
+	sorted = make([]*PipelineStep, 0, len(pl.Steps))
+	for len(sorted) < len(pl.Steps) {
+		var progress bool
+		for _, step := range pl.Steps {
+			if done.Contains(step.Name) || !ready(step) {
+				continue
+			}
+			sorted = append(sorted, step)
+			done.Add(step.Name)
+			progress = true
+		}
+		if !progress {
+			return nil, fmt.Errorf("pipeline %q: cycle detected", pl.Name)
+		}
+	}
+	return
+}
+
+////////////////////
+// PipelineStatus //
+////////////////////
+
+func (ps *PipelineStatus) Finished() bool { return ps.Ended != 0 }
+
+func (ps *PipelineStatus) Step(name string) *PipelineStepStatus {
+	for i := range ps.Steps {
+		if ps.Steps[i].Name == name {
+			return &ps.Steps[i]
+		}
+	}
+	return nil
+}
+
+func (ps *PipelineStatus) Elapsed() time.Duration {
+	if ps.Ended != 0 {
+		return time.Duration(ps.Ended - ps.Started)
+	}
+	return time.Duration(time.Now().UnixNano() - ps.Started)
+}
@@ -0,0 +1,68 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func newTestPipeline() *Pipeline {
+	var (
+		src = Bck{Name: "src", Provider: apc.ProviderAIS}
+		dst = Bck{Name: "dst", Provider: apc.ProviderAIS}
+		rem = Bck{Name: "rem", Provider: apc.ProviderAmazon}
+	)
+	return &Pipeline{
+		Name: "test",
+		Steps: []*PipelineStep{
+			{Name: "copy", Action: apc.ActCopyBck, Input: "sort", BckTo: rem},
+			{Name: "dl", Action: apc.ActDownload, Bck: src, Value: "..."},
+			{Name: "sort", Action: apc.ActDsort, Input: "dl", BckTo: dst, Value: "..."},
+		},
+	}
+}
+
+func TestPipelineTopoSort(t *testing.T) {
+	pl := newTestPipeline()
+	tassert.CheckFatal(t, pl.Validate())
+	sorted, err := pl.TopoSort()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(sorted) == 3, "expected 3 steps, got %d", len(sorted))
+	for i, name := range []string{"dl", "sort", "copy"} {
+		tassert.Errorf(t, sorted[i].Name == name, "step %d: expected %q, got %q", i, name, sorted[i].Name)
+	}
+	out := pl.Step("sort").OutBck(&pl.Step("dl").Bck)
+	tassert.Errorf(t, out.Name == "dst", "expected dsort output %q, got %s", "dst", out)
+}
+
+func TestPipelineValidate(t *testing.T) {
+	pl := newTestPipeline()
+	pl.Step("dl").After = []string{"copy"}
+	tassert.Errorf(t, pl.Validate() != nil, "expected cycle error")
+
+	pl = newTestPipeline()
+	pl.Step("copy").Input = "nonexistent"
+	tassert.Errorf(t, pl.Validate() != nil, "expected unknown predecessor error")
+
+	pl = newTestPipeline()
+	pl.Step("sort").Bck = Bck{Name: "src", Provider: apc.ProviderAIS}
+	tassert.Errorf(t, pl.Validate() != nil, "expected error: both input step and bucket")
+
+	pl = newTestPipeline()
+	pl.Step("copy").BckTo = Bck{}
+	tassert.Errorf(t, pl.Validate() != nil, "expected error: missing destination")
+
+	pl = newTestPipeline()
+	pl.Steps = append(pl.Steps, &PipelineStep{Name: "dl", Action: apc.ActDownload, Bck: Bck{Name: "x"}, Value: "..."})
+	tassert.Errorf(t, pl.Validate() != nil, "expected duplicate step error")
+
+	pl = newTestPipeline()
+	pl.Step("copy").Action = apc.ActLRU
+	tassert.Errorf(t, pl.Validate() != nil, "expected unsupported action error")
+}
@@ -0,0 +1,109 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Scheduled (recurring) jobs: the primary proxy starts xactions on a cron schedule.
+// The schedule is stored in the replicated (versioned and metasync-ed) proxy
+// metadata - see ais/schmeta.go; the runs are recorded in the cluster event log.
+
+const SchedRunsMax = 16 // run history (per job) to show
+
+// xaction kinds that can be scheduled
+var SchedKinds = []string{
+	apc.ActLRU,
+	apc.ActStoreCleanup,
+	apc.ActPrefetchObjects,
+	apc.ActCopyBck,
+	apc.ActECEncode,
+	apc.ActMakeNCopies,
+	apc.ActDownload,
+}
+
+type (
+	SchedJob struct {
+		Name  string `json:"name"`
+		Cron  string `json:"cron"`   // e.g. "0 3 * * *" or "@daily" (see cos.ParseCron)
+		Kind  string `json:"kind"`   // one of the SchedKinds
+		Bck   Bck    `json:"bck"`    // bucket (when applicable)
+		BckTo Bck    `json:"bck_to"` // destination bucket (apc.ActCopyBck only)
+		// action-specific parameters: the same `apc.ActionMsg.Value` that one
+		// would use to start the job by hand (e.g., apc.CopyBckMsg for apc.ActCopyBck)
+		Value    interface{} `json:"value,omitempty"`
+		Disabled bool        `json:"disabled,omitempty"`
+		Created  int64       `json:"created,string"`
+		Runs     []SchedRun  `json:"runs,omitempty"` // most recent last (when queried; not stored)
+	}
+	SchedRun struct {
+		Time    int64  `json:"time,string"`       // Unix time (nanoseconds)
+		XactID  string `json:"xid,omitempty"`     // ID of the started xaction
+		Skipped bool   `json:"skipped,omitempty"` // previous run still in progress
+		Err     string `json:"err,omitempty"`     // failed to start
+	}
+	SchedMD struct {
+		Jobs    map[string]*SchedJob `json:"jobs"`
+		Version int64                `json:"version,string"`
+	}
+)
+
+func (j *SchedJob) Validate() error {
+	if j.Name == "" {
+		return errors.New("scheduled job: missing name")
+	}
+	if _, err := cos.ParseCron(j.Cron); err != nil {
+		return fmt.Errorf("scheduled job %q: %v", j.Name, err)
+	}
+	if !cos.StringInSlice(j.Kind, SchedKinds) {
+		return fmt.Errorf("scheduled job %q: %q cannot be scheduled (expecting one of %v)", j.Name, j.Kind, SchedKinds)
+	}
+	switch j.Kind {
+	case apc.ActLRU, apc.ActStoreCleanup:
+		return nil
+	case apc.ActDownload:
+		if j.Value == nil {
+			return fmt.Errorf("scheduled job %q: missing download request (value)", j.Name)
+		}
+		return nil
+	}
+	if j.Bck.IsEmpty() {
+		return fmt.Errorf("scheduled job %q: %q requires bucket", j.Name, j.Kind)
+	}
+	if j.Kind == apc.ActCopyBck {
+		if j.BckTo.IsEmpty() {
+			return fmt.Errorf("scheduled job %q: %q requires destination bucket", j.Name, j.Kind)
+		}
+		return nil
+	}
+	if j.Value == nil {
+		return fmt.Errorf("scheduled job %q: %q requires parameters (value)", j.Name, j.Kind)
+	}
+	return nil
+}
+
+func (j *SchedJob) LastRun() *SchedRun {
+	if len(j.Runs) == 0 {
+		return nil
+	}
+	return &j.Runs[len(j.Runs)-1]
+}
+
+// sorted by name
+func (md *SchedMD) List() (jobs []*SchedJob) {
+	jobs = make([]*SchedJob, 0, len(md.Jobs))
+	for _, j := range md.Jobs {
+		jobs = append(jobs, j)
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].Name < jobs[k].Name })
+	return
+}
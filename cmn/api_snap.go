@@ -0,0 +1,42 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// BckSnap is a point-in-time, read-only, copy-on-write snapshot of an ais:// bucket.
+// Snapshots are recorded in the bucket's properties (BMD); the content is stored by
+// targets as hard links to the respective objects (see cluster/lom_snap.go).
+type BckSnap struct {
+	Name    string `json:"name"`
+	Created int64  `json:"created,string"` // (as per primary)
+	// (active) targets at the time of the snapshot - to restore, the objects must be
+	// exactly where they were (see ais/prxsnap.go)
+	Targets string `json:"targets"`
+}
+
+const maxSnapNameLen = 64
+
+func ValidateSnapName(name string) error {
+	if name == "" || len(name) > maxSnapNameLen || !cos.IsAlphaPlus(name, false /*with period*/) {
+		return fmt.Errorf("invalid snapshot name %q (expecting up to %d alphanumeric characters, dashes, and underscores)",
+			name, maxSnapNameLen)
+	}
+	return nil
+}
+
+func (bp *BucketProps) Snap(name string) (*BckSnap, bool) {
+	for i := range bp.Snapshots {
+		if bp.Snapshots[i].Name == name {
+			return &bp.Snapshots[i], true
+		}
+	}
+	return nil, false
+}
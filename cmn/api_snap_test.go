@@ -0,0 +1,30 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestValidateSnapName(t *testing.T) {
+	for _, name := range []string{"snap1", "daily-2022_06_01", strings.Repeat("s", maxSnapNameLen)} {
+		tassert.Errorf(t, ValidateSnapName(name) == nil, "expected %q to be valid", name)
+	}
+	for _, name := range []string{"", "a/b", "v1.0", "a b", strings.Repeat("s", maxSnapNameLen+1)} {
+		tassert.Errorf(t, ValidateSnapName(name) != nil, "expected %q to be invalid", name)
+	}
+}
+
+func TestBpropsSnap(t *testing.T) {
+	bp := &BucketProps{Snapshots: []BckSnap{{Name: "a", Created: 1}, {Name: "b", Created: 2}}}
+	s, ok := bp.Snap("b")
+	tassert.Fatalf(t, ok && s.Created == 2, "expected snapshot \"b\"")
+	_, ok = bp.Snap("c")
+	tassert.Errorf(t, !ok, "unexpected snapshot \"c\"")
+}
@@ -0,0 +1,315 @@
+// Package archidx provides random-access indices for tar, tar.gz, and zip archives.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package archidx
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+)
+
+// Index maps archived files to their locations within the archive, to read any
+// given file without scanning (and decompressing) the archive sequentially:
+// - tar:    offset of the file's data;
+// - tar.gz: offset of the file's data in the uncompressed stream, plus seek points -
+//           compressed offsets of the gzip members (streams) that make up the archive,
+//           so that reading starts at the nearest preceding member (note that AIS writes
+//           tar.gz as a sequence of gzip members - see xs/archive.go);
+// - zip:    offset of the file's (compressed) data and the compression method.
+// The index also records the archive's size and mtime - to detect changes.
+
+const Metaver = 1 // (jsp formatting version)
+
+type (
+	Entry struct {
+		Name   string `json:"n"`
+		Off    int64  `json:"o"`           // (see above)
+		Size   int64  `json:"s"`           // uncompressed size
+		CSize  int64  `json:"c,omitempty"` // zip: compressed size
+		Method uint16 `json:"m,omitempty"` // zip: compression method
+	}
+	SeekPoint struct {
+		COff int64 `json:"c"` // compressed offset of a gzip member
+		UOff int64 `json:"u"` // the corresponding offset in the uncompressed stream
+	}
+	Index struct {
+		Mime    string      `json:"mime"` // one of cos.ArchExtensions (with .tgz => .tar.gz)
+		Size    int64       `json:"size"`
+		Mtime   int64       `json:"mtime,string"`
+		Entries []Entry     `json:"entries"` // sorted by name (stable - in archive order otherwise)
+		Seek    []SeekPoint `json:"seek,omitempty"`
+	}
+
+	reader struct {
+		io.Reader
+		closer io.Closer
+		size   int64
+	}
+	// counts compressed bytes consumed by gzip (and flate) - see buildTgz
+	countingReader struct {
+		br *bufio.Reader
+		n  int64
+	}
+	// reads gzip members one at a time while recording seek points
+	memberReader struct {
+		cr   *countingReader
+		gzr  *gzip.Reader
+		idx  *Index
+		uoff int64
+	}
+)
+
+// interface guard
+var _ jsp.Opts = (*Index)(nil)
+
+func (*Index) JspOpts() jsp.Options { return jsp.CCSign(Metaver) }
+
+func canonMime(mime string) string {
+	if mime == cos.ExtTgz {
+		return cos.ExtTarTgz
+	}
+	return mime
+}
+
+// Indexable returns true if archives of a given type can be indexed.
+func Indexable(mime string) bool {
+	switch canonMime(mime) {
+	case cos.ExtTar, cos.ExtTarTgz, cos.ExtZip:
+		return true
+	}
+	return false
+}
+
+// Valid returns true if the index is up to date with a given archive.
+func (idx *Index) Valid(finfo os.FileInfo) bool {
+	return idx.Size == finfo.Size() && idx.Mtime == finfo.ModTime().UnixNano()
+}
+
+func (idx *Index) Matches(mime string) bool { return idx.Mime == canonMime(mime) }
+
+// Find returns the first (in archive order) file with a given name, or nil if not found.
+func (idx *Index) Find(name string) *Entry {
+	if e := idx.find(name); e != nil {
+		return e
+	}
+	// in re `--absolute-names`
+	if strings.HasPrefix(name, "/") {
+		return idx.find(name[1:])
+	}
+	return idx.find("/" + name)
+}
+
+func (idx *Index) find(name string) *Entry {
+	i := sort.Search(len(idx.Entries), func(i int) bool { return idx.Entries[i].Name >= name })
+	if i < len(idx.Entries) && idx.Entries[i].Name == name {
+		return &idx.Entries[i]
+	}
+	return nil
+}
+
+///////////
+// build //
+///////////
+
+// Build indexes a given archive.
+func Build(fh *os.File, mime string) (idx *Index, err error) {
+	var finfo os.FileInfo
+	if finfo, err = fh.Stat(); err != nil {
+		return
+	}
+	if _, err = fh.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	idx = &Index{Mime: canonMime(mime), Size: finfo.Size(), Mtime: finfo.ModTime().UnixNano()}
+	switch idx.Mime {
+	case cos.ExtTar:
+		err = idx.buildTar(tar.NewReader(fh), func() (int64, error) { return fh.Seek(0, io.SeekCurrent) })
+	case cos.ExtTarTgz:
+		err = idx.buildTgz(fh)
+	case cos.ExtZip:
+		err = idx.buildZip(fh)
+	default:
+		err = fmt.Errorf("cannot index %q archives", mime)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(idx.Entries, func(i, j int) bool { return idx.Entries[i].Name < idx.Entries[j].Name })
+	return
+}
+
+// `pos` returns the current position in the (uncompressed) tar stream which, since
+// tar.Reader does not buffer, is where the data of the current file starts
+func (idx *Index) buildTar(tr *tar.Reader, pos func() (int64, error)) error {
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if hdr.FileInfo().IsDir() {
+			continue
+		}
+		if isSparse(hdr) {
+			return fmt.Errorf("cannot index sparse file %q", hdr.Name)
+		}
+		off, err := pos()
+		if err != nil {
+			return err
+		}
+		idx.Entries = append(idx.Entries, Entry{Name: hdr.Name, Off: off, Size: hdr.Size})
+	}
+}
+
+func isSparse(hdr *tar.Header) bool {
+	if hdr.Typeflag == tar.TypeGNUSparse {
+		return true
+	}
+	for k := range hdr.PAXRecords {
+		if strings.HasPrefix(k, "GNU.sparse.") {
+			return true
+		}
+	}
+	return false
+}
+
+func (idx *Index) buildTgz(fh *os.File) error {
+	cr := &countingReader{br: bufio.NewReaderSize(fh, cos.KiB*64)}
+	gzr, err := gzip.NewReader(cr)
+	if err != nil {
+		return err
+	}
+	gzr.Multistream(false)
+	mr := &memberReader{cr: cr, gzr: gzr, idx: idx}
+	idx.Seek = append(idx.Seek, SeekPoint{})
+	err = idx.buildTar(tar.NewReader(mr), func() (int64, error) { return mr.uoff, nil })
+	gzr.Close()
+	return err
+}
+
+func (idx *Index) buildZip(fh *os.File) error {
+	zr, err := zip.NewReader(fh, idx.Size)
+	if err != nil {
+		return err
+	}
+	idx.Entries = make([]Entry, 0, len(zr.File))
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		off, err := f.DataOffset()
+		if err != nil {
+			return err
+		}
+		idx.Entries = append(idx.Entries, Entry{
+			Name:   f.Name,
+			Off:    off,
+			Size:   int64(f.UncompressedSize64),
+			CSize:  int64(f.CompressedSize64),
+			Method: f.Method,
+		})
+	}
+	return nil
+}
+
+// NOTE: implementing io.ByteReader makes gzip (and flate) consume exactly
+// as many bytes as needed, which is what makes the counter precise
+func (cr *countingReader) Read(b []byte) (n int, err error) {
+	n, err = cr.br.Read(b)
+	cr.n += int64(n)
+	return
+}
+
+func (cr *countingReader) ReadByte() (c byte, err error) {
+	if c, err = cr.br.ReadByte(); err == nil {
+		cr.n++
+	}
+	return
+}
+
+func (mr *memberReader) Read(b []byte) (n int, err error) {
+	for {
+		n, err = mr.gzr.Read(b)
+		mr.uoff += int64(n)
+		if err != io.EOF {
+			return
+		}
+		// end of gzip member: next one (if any) is a seek point
+		coff := mr.cr.n
+		if err = mr.gzr.Reset(mr.cr); err != nil {
+			return // (io.EOF when there are no more members)
+		}
+		mr.gzr.Multistream(false)
+		mr.idx.Seek = append(mr.idx.Seek, SeekPoint{COff: coff, UOff: mr.uoff})
+		if n > 0 {
+			return
+		}
+	}
+}
+
+//////////
+// read //
+//////////
+
+// Open returns a reader of a given archived file. Closing the reader does not close the archive.
+func (idx *Index) Open(fh *os.File, e *Entry) (cos.ReadCloseSizer, error) {
+	switch idx.Mime {
+	case cos.ExtTar:
+		return &reader{Reader: io.NewSectionReader(fh, e.Off, e.Size), size: e.Size}, nil
+	case cos.ExtTarTgz:
+		return idx.openTgz(fh, e)
+	case cos.ExtZip:
+		sr := io.NewSectionReader(fh, e.Off, e.CSize)
+		switch e.Method {
+		case zip.Store:
+			return &reader{Reader: sr, size: e.Size}, nil
+		case zip.Deflate:
+			rc := flate.NewReader(sr)
+			return &reader{Reader: rc, closer: rc, size: e.Size}, nil
+		default:
+			return nil, fmt.Errorf("unsupported zip compression method %d (%q)", e.Method, e.Name)
+		}
+	default:
+		return nil, fmt.Errorf("cannot read %q archives by index", idx.Mime)
+	}
+}
+
+func (idx *Index) openTgz(fh *os.File, e *Entry) (cos.ReadCloseSizer, error) {
+	i := sort.Search(len(idx.Seek), func(i int) bool { return idx.Seek[i].UOff > e.Off }) - 1
+	if i < 0 {
+		return nil, fmt.Errorf("invalid index: no seek point for %q at %d", e.Name, e.Off)
+	}
+	sp := idx.Seek[i]
+	gzr, err := gzip.NewReader(bufio.NewReader(io.NewSectionReader(fh, sp.COff, idx.Size-sp.COff)))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.CopyN(io.Discard, gzr, e.Off-sp.UOff); err != nil {
+		gzr.Close()
+		return nil, err
+	}
+	return &reader{Reader: io.LimitReader(gzr, e.Size), closer: gzr, size: e.Size}, nil
+}
+
+func (r *reader) Size() int64 { return r.size }
+
+func (r *reader) Close() error {
+	if r.closer != nil {
+		return r.closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Package archidx provides random-access indices for tar, tar.gz, and zip archives.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package archidx
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func genFiles(n int) map[string][]byte {
+	files := make(map[string][]byte, n)
+	for i := 0; i < n; i++ {
+		b := make([]byte, rand.Intn(64*cos.KiB))
+		rand.Read(b)
+		files[fmt.Sprintf("dir%d/file-%03d.bin", i%3, i)] = b
+	}
+	return files
+}
+
+// writes tar into `w`, calling `flush` after every `every` files
+func writeTar(t *testing.T, w io.Writer, files map[string][]byte, every int, flush func(tw *tar.Writer)) {
+	tw := tar.NewWriter(w)
+	var i int
+	for name, b := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(b)), Mode: 0o644, Typeflag: tar.TypeReg})
+		tassert.CheckFatal(t, err)
+		_, err = tw.Write(b)
+		tassert.CheckFatal(t, err)
+		if i++; flush != nil && every > 0 && i%every == 0 {
+			flush(tw)
+		}
+	}
+	tassert.CheckFatal(t, tw.Close())
+}
+
+func verify(t *testing.T, fqn, mime string, files map[string][]byte) *Index {
+	fh, err := os.Open(fqn)
+	tassert.CheckFatal(t, err)
+	defer fh.Close()
+	idx, err := Build(fh, mime)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(idx.Entries) == len(files), "expected %d entries, got %d", len(files), len(idx.Entries))
+	finfo, err := fh.Stat()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, idx.Valid(finfo), "expected valid index")
+
+	for name, b := range files {
+		e := idx.Find(name)
+		tassert.Fatalf(t, e != nil, "%s: not found", name)
+		r, err := idx.Open(fh, e)
+		tassert.CheckFatal(t, err)
+		got, err := io.ReadAll(r)
+		tassert.CheckFatal(t, err)
+		r.Close()
+		tassert.Fatalf(t, r.Size() == int64(len(b)) && bytes.Equal(got, b), "%s: content mismatch", name)
+	}
+	tassert.Errorf(t, idx.Find("/"+"dir0/file-000.bin") != nil, "expected to find absolute name")
+	tassert.Errorf(t, idx.Find("nonexistent") == nil, "unexpected entry")
+	return idx
+}
+
+func TestIndexTar(t *testing.T) {
+	var (
+		files = genFiles(50)
+		fqn   = filepath.Join(t.TempDir(), "a.tar")
+		buf   bytes.Buffer
+	)
+	writeTar(t, &buf, files, 0, nil)
+	tassert.CheckFatal(t, os.WriteFile(fqn, buf.Bytes(), 0o644))
+	verify(t, fqn, cos.ExtTar, files)
+}
+
+func TestIndexTgz(t *testing.T) {
+	files := genFiles(50)
+	for _, every := range []int{0, 1, 7} {
+		var (
+			fqn = filepath.Join(t.TempDir(), "a.tgz")
+			buf bytes.Buffer
+			gzw = gzip.NewWriter(&buf)
+		)
+		// multiple gzip members (streams)
+		writeTar(t, gzw, files, every, func(tw *tar.Writer) {
+			tassert.CheckFatal(t, tw.Flush())
+			tassert.CheckFatal(t, gzw.Close())
+			gzw.Reset(&buf)
+		})
+		tassert.CheckFatal(t, gzw.Close())
+		tassert.CheckFatal(t, os.WriteFile(fqn, buf.Bytes(), 0o644))
+
+		idx := verify(t, fqn, cos.ExtTgz, files)
+		expected := 1
+		if every > 0 {
+			expected += len(files) / every
+		}
+		tassert.Errorf(t, len(idx.Seek) == expected, "every %d: expected %d seek points, got %d",
+			every, expected, len(idx.Seek))
+	}
+}
+
+func TestIndexZip(t *testing.T) {
+	var (
+		files = genFiles(50)
+		fqn   = filepath.Join(t.TempDir(), "a.zip")
+		buf   bytes.Buffer
+		zw    = zip.NewWriter(&buf)
+		i     int
+	)
+	for name, b := range files {
+		method := zip.Deflate
+		if i++; i%2 == 0 {
+			method = zip.Store
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: method})
+		tassert.CheckFatal(t, err)
+		_, err = w.Write(b)
+		tassert.CheckFatal(t, err)
+	}
+	tassert.CheckFatal(t, zw.Close())
+	tassert.CheckFatal(t, os.WriteFile(fqn, buf.Bytes(), 0o644))
+	verify(t, fqn, cos.ExtZip, files)
+}
@@ -36,6 +36,8 @@ func (be *BucketEntry) SetExists()         { be.Flags |= apc.EntryIsCached }
 func (be *BucketEntry) IsStatusOK() bool   { return be.Status() == 0 }
 func (be *BucketEntry) Status() uint16     { return be.Flags & apc.EntryStatusMask }
 func (be *BucketEntry) IsInsideArch() bool { return be.Flags&apc.EntryInArch != 0 }
+func (be *BucketEntry) IsPrevVer() bool    { return be.Flags&apc.EntryIsPrevVer != 0 }
+func (be *BucketEntry) IsDelMarker() bool  { return be.Flags&apc.EntryIsDelMarker != 0 }
 func (be *BucketEntry) String() string     { return "{" + be.Name + "}" }
 
 func (be *BucketEntry) CopyWithProps(propsSet cos.StringSet) (ne *BucketEntry) {
@@ -75,6 +75,7 @@ type (
 		DSort       DSortConf       `json:"distributed_sort"`
 		Transport   TransportConf   `json:"transport"`
 		Memsys      MemsysConf      `json:"memsys"`
+		Admission   AdmissionConf   `json:"admission"`
 		TCB         TCBConf         `json:"tcb"`                             // transform/copy bucket
 		WritePolicy WritePolicyConf `json:"write_policy"`                    // write {immediate, delayed, never}
 		Features    feat.Flags      `json:"features,string" allow:"cluster"` // (to flip assorted defaults)
@@ -109,6 +110,7 @@ type (
 		DSort       *DSortConfToUpdate       `json:"distributed_sort,omitempty"`
 		Transport   *TransportConfToUpdate   `json:"transport,omitempty"`
 		Memsys      *MemsysConfToUpdate      `json:"memsys,omitempty"`
+		Admission   *AdmissionConfToUpdate   `json:"admission,omitempty"`
 		TCB         *TCBConfToUpdate         `json:"tcb,omitempty"`
 		WritePolicy *WritePolicyConfToUpdate `json:"write_policy,omitempty"`
 		Proxy       *ProxyConfToUpdate       `json:"proxy,omitempty"`
@@ -199,13 +201,18 @@ type (
 		MaxTotal  cos.Size     `json:"max_total"`  // (sum individual log sizes); exceeding this number triggers cleanup
 		FlushTime cos.Duration `json:"flush_time"` // log flush interval
 		StatsTime cos.Duration `json:"stats_time"` // log stats interval (must be a multiple of `PeriodConf.StatsTime`)
+		// cluster event log (see cmn.Event)
+		EventsMax     int    `json:"events_max,omitempty"`     // max number of events to keep (0 - use default)
+		EventsWebhook string `json:"events_webhook,omitempty"` // if defined, POST each event (JSON) to this URL
 	}
 	LogConfToUpdate struct {
-		Level     *string       `json:"level,omitempty"`
-		MaxSize   *cos.Size     `json:"max_size,omitempty"`
-		MaxTotal  *cos.Size     `json:"max_total,omitempty"`
-		FlushTime *cos.Duration `json:"flush_time,omitempty"`
-		StatsTime *cos.Duration `json:"stats_time,omitempty"`
+		Level         *string       `json:"level,omitempty"`
+		MaxSize       *cos.Size     `json:"max_size,omitempty"`
+		MaxTotal      *cos.Size     `json:"max_total,omitempty"`
+		FlushTime     *cos.Duration `json:"flush_time,omitempty"`
+		StatsTime     *cos.Duration `json:"stats_time,omitempty"`
+		EventsMax     *int          `json:"events_max,omitempty"`
+		EventsWebhook *string       `json:"events_webhook,omitempty"`
 	}
 
 	// NOTE: StatsTime is a one important timer
@@ -213,6 +220,9 @@ type (
 		StatsTime     cos.Duration `json:"stats_time"`      // collect and publish stats; other house-keeping
 		RetrySyncTime cos.Duration `json:"retry_sync_time"` // metasync retry
 		NotifTime     cos.Duration `json:"notif_time"`      // (IC notifications)
+		// upper bounds of the latency histogram buckets (empty - use defaults);
+		// readonly - change requires restart
+		LatencyBuckets []cos.Duration `json:"latency_buckets,omitempty"`
 	}
 	PeriodConfToUpdate struct {
 		StatsTime     *cos.Duration `json:"stats_time,omitempty"`
@@ -375,10 +385,20 @@ type (
 
 		// Validate object version upon warm GET.
 		ValidateWarmGet bool `json:"validate_warm_get"`
+
+		// (ais:// buckets only) number of previous versions to keep when an object
+		// gets overwritten or deleted; zero disables version history.
+		History int `json:"history"`
+
+		// (ais:// buckets only) remove previous versions older than this;
+		// zero means keeping them for as long as `History` allows.
+		Retention cos.Duration `json:"retention"`
 	}
 	VersionConfToUpdate struct {
-		Enabled         *bool `json:"enabled,omitempty"`
-		ValidateWarmGet *bool `json:"validate_warm_get,omitempty"`
+		Enabled         *bool         `json:"enabled,omitempty"`
+		ValidateWarmGet *bool         `json:"validate_warm_get,omitempty"`
+		History         *int          `json:"history,omitempty"`
+		Retention       *cos.Duration `json:"retention,omitempty"`
 	}
 
 	TestFSPConf struct {
@@ -457,18 +477,27 @@ type (
 		Proxy       KeepaliveTrackerConf `json:"proxy"`  // how proxy tracks target keepalives
 		Target      KeepaliveTrackerConf `json:"target"` // how target tracks primary proxies keepalives
 		RetryFactor uint8                `json:"retry_factor"`
+		// comma-separated health components (enum cmn.HealthComponents) that must be "ok"
+		// for the node to pass readiness probes (GET /v1/health?ready=true);
+		// empty (default) - ready as soon as the node is up and running
+		Readiness string `json:"readiness,omitempty"`
 	}
 	KeepaliveConfToUpdate struct {
 		Proxy       *KeepaliveTrackerConfToUpdate `json:"proxy,omitempty"`
 		Target      *KeepaliveTrackerConfToUpdate `json:"target,omitempty"`
 		RetryFactor *uint8                        `json:"retry_factor,omitempty"`
+		Readiness   *string                       `json:"readiness,omitempty"`
 	}
 
 	DownloaderConf struct {
 		Timeout cos.Duration `json:"timeout"`
+		// local (e.g., NFS-mounted) directories that file:// download links are allowed
+		// to refer to; empty - downloading local files is disabled
+		LocalDirs []string `json:"local_dirs,omitempty"`
 	}
 	DownloaderConfToUpdate struct {
-		Timeout *cos.Duration `json:"timeout,omitempty"`
+		Timeout   *cos.Duration `json:"timeout,omitempty"`
+		LocalDirs *[]string     `json:"local_dirs,omitempty"`
 	}
 
 	DSortConf struct {
@@ -538,6 +567,27 @@ type (
 		MinPctFree     *int          `json:"min_pct_free,omitempty" list:"readonly"`
 	}
 
+	// target admission control: when overloaded, reject new PUTs (503 with Retry-After)
+	// and refuse to start massive and background xactions (see admit/admit.go)
+	AdmissionConf struct {
+		// memory pressure (enum: "moderate", "high", "extreme") at or above which the target is overloaded
+		MemPressure string `json:"mem_pressure"`
+		// max disk utilization (%) across mountpaths; zero - do not check
+		DiskUtil int64 `json:"disk_util"`
+		// max number of goroutines; zero - do not check
+		MaxGoroutines int `json:"max_goroutines"`
+		// Retry-After to suggest to the clients (rounded up to seconds)
+		RetryAfter cos.Duration `json:"retry_after"`
+		Enabled    bool         `json:"enabled"`
+	}
+	AdmissionConfToUpdate struct {
+		MemPressure   *string       `json:"mem_pressure,omitempty"`
+		DiskUtil      *int64        `json:"disk_util,omitempty"`
+		MaxGoroutines *int          `json:"max_goroutines,omitempty"`
+		RetryAfter    *cos.Duration `json:"retry_after,omitempty"`
+		Enabled       *bool         `json:"enabled,omitempty"`
+	}
+
 	TCBConf struct {
 		Compression string `json:"compression"`       // enum { CompressAlways, ... } in api/apc/compression.go
 		SbundleMult int    `json:"bundle_multiplier"` // stream-bundle multiplier: num streams to destination
@@ -620,6 +670,7 @@ var (
 	_ Validator = (*DSortConf)(nil)
 	_ Validator = (*TransportConf)(nil)
 	_ Validator = (*MemsysConf)(nil)
+	_ Validator = (*AdmissionConf)(nil)
 	_ Validator = (*TCBConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
 
@@ -628,6 +679,7 @@ var (
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
 	_ PropsValidator = (*WritePolicyConf)(nil)
+	_ PropsValidator = (*VersionConf)(nil)
 
 	_ json.Marshaler   = (*BackendConf)(nil)
 	_ json.Unmarshaler = (*BackendConf)(nil)
@@ -743,6 +795,12 @@ func (c *PeriodConf) Validate() error {
 		return fmt.Errorf("invalid periodic.notif_time=%s (expected range [1s, 1m])",
 			c.StatsTime)
 	}
+	for i, b := range c.LatencyBuckets {
+		if b <= 0 || (i > 0 && b <= c.LatencyBuckets[i-1]) {
+			return fmt.Errorf("invalid periodic.latency_buckets=%v (expecting positive and strictly increasing)",
+				c.LatencyBuckets)
+		}
+	}
 	return nil
 }
 
@@ -771,6 +829,14 @@ func (c *LogConf) Validate() error {
 	if c.StatsTime.D() > 10*time.Minute {
 		return fmt.Errorf("invalid log.stats_time=%s (expected range [log.stats_time, 10m])", c.StatsTime)
 	}
+	if c.EventsMax < 0 || c.EventsMax > 1000*DfltEventsMax {
+		return fmt.Errorf("invalid log.events_max=%d (expected range [0, %d])", c.EventsMax, 1000*DfltEventsMax)
+	}
+	if c.EventsWebhook != "" {
+		if _, err := url.ParseRequestURI(c.EventsWebhook); err != nil {
+			return fmt.Errorf("invalid log.events_webhook=%q: %v", c.EventsWebhook, err)
+		}
+	}
 	return nil
 }
 
@@ -1023,9 +1089,44 @@ func (c *VersionConf) Validate() error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	return c.validateHistory()
+}
+
+func (c *VersionConf) validateHistory() error {
+	if c.History < 0 {
+		return fmt.Errorf("invalid versioning.history=%d (expecting non-negative number)", c.History)
+	}
+	if c.Retention < 0 {
+		return fmt.Errorf("invalid versioning.retention=%v (expecting non-negative duration)", c.Retention)
+	}
+	if !c.Enabled && (c.History > 0 || c.Retention > 0) {
+		return errors.New("versioning.history and versioning.retention require versioning to be enabled")
+	}
+	return nil
+}
+
+// version history is supported only for ais:// buckets (with no remote backend);
+// NOTE: checking only history and retention - bucket props inherit validate_warm_get as is
+func (c *VersionConf) ValidateAsProps(arg ...interface{}) error {
+	if err := c.validateHistory(); err != nil {
+		return err
+	}
+	if c.History == 0 && c.Retention == 0 {
+		return nil
+	}
+	provider, ok := arg[0].(string)
+	debug.Assert(ok)
+	if provider != apc.ProviderAIS {
+		return fmt.Errorf("versioning.history is not supported for %q buckets", provider)
+	}
+	if c.History == 0 {
+		return errors.New("versioning.retention requires versioning.history to be set")
+	}
 	return nil
 }
 
+func (c *VersionConf) HistoryEnabled() bool { return c.Enabled && c.History > 0 }
+
 func (c *VersionConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
@@ -1037,6 +1138,12 @@ func (c *VersionConf) String() string {
 	} else {
 		text += "no"
 	}
+	if c.HistoryEnabled() {
+		text += fmt.Sprintf(" | History: %d", c.History)
+		if c.Retention > 0 {
+			text += fmt.Sprintf(" (retention %v)", c.Retention)
+		}
+	}
 
 	return text
 }
@@ -1175,7 +1282,7 @@ func (c *KeepaliveConf) Validate() (err error) {
 	if !validKeepaliveType(c.Target.Name) {
 		return fmt.Errorf("invalid keepalivetracker.target.name %s", c.Target.Name)
 	}
-	return nil
+	return ValidateReadiness(c.Readiness)
 }
 
 func KeepaliveRetryDuration(cs ...*Config) time.Duration {
@@ -1510,6 +1617,29 @@ func (c *TCBConf) Validate() error {
 	return nil
 }
 
+///////////////////
+// AdmissionConf //
+///////////////////
+
+// memory pressure enum (compare with memsys.Pressure*)
+var SupportedMemPressure = []string{"moderate", "high", "extreme"}
+
+func (c *AdmissionConf) Validate() error {
+	if c.MemPressure != "" && !cos.StringInSlice(c.MemPressure, SupportedMemPressure) {
+		return fmt.Errorf("invalid admission.mem_pressure: %q (expecting one of: %v)", c.MemPressure, SupportedMemPressure)
+	}
+	if c.DiskUtil < 0 || c.DiskUtil > 100 {
+		return fmt.Errorf("invalid admission.disk_util: %d (expected range [0, 100])", c.DiskUtil)
+	}
+	if c.MaxGoroutines < 0 {
+		return fmt.Errorf("invalid admission.max_goroutines: %d", c.MaxGoroutines)
+	}
+	if c.RetryAfter < 0 || c.RetryAfter.D() > time.Hour {
+		return fmt.Errorf("invalid admission.retry_after: %v (expected range [0, 1h])", c.RetryAfter)
+	}
+	return nil
+}
+
 /////////////////
 // TimeoutConf //
 /////////////////
@@ -1555,6 +1685,11 @@ func (c *DownloaderConf) Validate() error {
 	if j := c.Timeout.D(); j < time.Second || j > time.Hour {
 		return fmt.Errorf("invalid downloader.timeout=%s (expected range [1s, 1h])", j)
 	}
+	for _, dir := range c.LocalDirs {
+		if !filepath.IsAbs(dir) {
+			return fmt.Errorf("invalid downloader.local_dirs: %q is not an absolute path", dir)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,196 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed standard (5-field) cron expression:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field is "*", a value, a range "a-b", a step "*/n" or "a-b/n", or a
+// comma-separated list of the above. Months and weekdays can be named
+// ("jan", "mon"); Sunday is both 0 and 7. As in the classic cron, when both
+// day-of-month and day-of-week are restricted the time matches either one.
+// Predefined schedules: @yearly (@annually), @monthly, @weekly, @daily (@midnight), @hourly.
+
+const cronMaxYears = 5 // Next() gives up after that many years
+
+type (
+	Cron struct {
+		expr                       string
+		min, hour, dom, month, dow uint64 // bitmasks
+		domStar, dowStar           bool
+	}
+	cronField struct {
+		name     string
+		min, max int
+		names    []string // optional, indexed from `min`
+	}
+)
+
+var (
+	cronMonths   = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	cronWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+	cronFields = [5]cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day-of-month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: cronMonths},
+		{name: "day-of-week", min: 0, max: 7, names: cronWeekdays},
+	}
+	cronPredefined = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+func ParseCron(expr string) (*Cron, error) {
+	var (
+		c    = &Cron{expr: expr}
+		spec = strings.TrimSpace(expr)
+	)
+	if s, ok := cronPredefined[strings.ToLower(spec)]; ok {
+		spec = s
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expecting %d fields, got %d", expr, len(cronFields), len(fields))
+	}
+	masks := [5]*uint64{&c.min, &c.hour, &c.dom, &c.month, &c.dow}
+	for i, f := range fields {
+		mask, err := cronFields[i].parse(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+		}
+		*masks[i] = mask
+	}
+	// Sunday: 7 => 0
+	if c.dow&(1<<7) != 0 {
+		c.dow = (c.dow | 1) &^ (1 << 7)
+	}
+	c.domStar, c.dowStar = fields[2] == "*" || fields[2] == "?", fields[4] == "*" || fields[4] == "?"
+	return c, nil
+}
+
+func (c *Cron) String() string { return c.expr }
+
+// Next returns the earliest matching time (with minute precision) strictly after `t`,
+// or zero time if there's none within the next few years (e.g., "0 0 30 2 *")
+func (c *Cron) Next(t time.Time) time.Time {
+	var (
+		loc   = t.Location()
+		limit = t.AddDate(cronMaxYears, 0, 0)
+	)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.min&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *Cron) matchDay(t time.Time) bool {
+	var (
+		dom = c.dom&(1<<uint(t.Day())) != 0
+		dow = c.dow&(1<<uint(t.Weekday())) != 0
+	)
+	switch {
+	case c.domStar && c.dowStar:
+		return true
+	case c.domStar:
+		return dow
+	case c.dowStar:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+///////////////
+// cronField //
+///////////////
+
+func (cf *cronField) parse(s string) (mask uint64, err error) {
+	for _, part := range strings.Split(s, ",") {
+		var (
+			lo, hi = cf.min, cf.max
+			step   = 1
+			rng    = part
+		)
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			rng = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s: invalid step in %q", cf.name, part)
+			}
+		}
+		switch {
+		case rng == "*" || rng == "?":
+		case strings.IndexByte(rng, '-') > 0:
+			i := strings.IndexByte(rng, '-')
+			if lo, err = cf.value(rng[:i]); err != nil {
+				return
+			}
+			if hi, err = cf.value(rng[i+1:]); err != nil {
+				return
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s: invalid range %q", cf.name, rng)
+			}
+		default:
+			if lo, err = cf.value(rng); err != nil {
+				return
+			}
+			if rng != part { // "a/n" means "a-max/n"
+				hi = cf.max
+			} else {
+				hi = lo
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return
+}
+
+func (cf *cronField) value(s string) (int, error) {
+	for i, name := range cf.names {
+		if strings.EqualFold(s, name) {
+			return cf.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < cf.min || v > cf.max {
+		return 0, fmt.Errorf("%s: invalid value %q (expecting %d to %d)", cf.name, s, cf.min, cf.max)
+	}
+	return v, nil
+}
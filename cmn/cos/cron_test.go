@@ -0,0 +1,52 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	// Wednesday
+	from := time.Date(2022, time.June, 15, 10, 30, 0, 0, time.UTC)
+
+	DescribeTable("next scheduled time",
+		func(expr string, expected time.Time) {
+			c, err := cos.ParseCron(expr)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(c.Next(from)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2022, time.June, 15, 10, 31, 0, 0, time.UTC)),
+		Entry("every 15 minutes", "*/15 * * * *", time.Date(2022, time.June, 15, 10, 45, 0, 0, time.UTC)),
+		Entry("daily at 03:00", "0 3 * * *", time.Date(2022, time.June, 16, 3, 0, 0, 0, time.UTC)),
+		Entry("@daily", "@daily", time.Date(2022, time.June, 16, 0, 0, 0, 0, time.UTC)),
+		Entry("@hourly", "@hourly", time.Date(2022, time.June, 15, 11, 0, 0, 0, time.UTC)),
+		Entry("list and range", "5,10 8-9,22 * * *", time.Date(2022, time.June, 15, 22, 5, 0, 0, time.UTC)),
+		Entry("named weekday", "0 1 * * sat", time.Date(2022, time.June, 18, 1, 0, 0, 0, time.UTC)),
+		Entry("Sunday as 7", "0 0 * * 7", time.Date(2022, time.June, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("named month", "0 0 1 jan *", time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("day-of-month or day-of-week", "0 0 20 * mon", time.Date(2022, time.June, 20, 0, 0, 0, 0, time.UTC)),
+		Entry("leap day", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("never", "0 0 30 2 *", time.Time{}),
+	)
+
+	DescribeTable("invalid expressions",
+		func(expr string) {
+			_, err := cos.ParseCron(expr)
+			Expect(err).Should(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("too few fields", "* * * *"),
+		Entry("out of range", "60 * * * *"),
+		Entry("invalid range", "* 10-5 * * *"),
+		Entry("invalid step", "*/0 * * * *"),
+		Entry("invalid name", "* * * foo *"),
+	)
+})
@@ -18,6 +18,7 @@ const (
 	HdrLocation              = "Location"
 	HdrETag                  = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Hdrs/ETag
 	HdrError                 = "Hdr-Error"
+	HdrRetryAfter            = "Retry-After" // Ref: https://www.rfc-editor.org/rfc/rfc7231#section-7.1.3
 )
 
 // Ref: https://www.iana.org/assignments/media-types/media-types.xhtml
@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -73,6 +74,11 @@ type (
 		usedPct        int32
 		oos            bool
 	}
+	ErrOverloaded struct {
+		node       string
+		reason     string
+		retryAfter time.Duration
+	}
 	ErrBucketAccessDenied struct{ errAccessDenied }
 	ErrObjectAccessDenied struct{ errAccessDenied }
 	errAccessDenied       struct {
@@ -349,6 +355,26 @@ func IsErrCapacityExceeded(err error) bool {
 	return ok
 }
 
+// ErrOverloaded
+
+func NewErrOverloaded(node, reason string, retryAfter time.Duration) *ErrOverloaded {
+	return &ErrOverloaded{node: node, reason: reason, retryAfter: retryAfter}
+}
+
+func (e *ErrOverloaded) Error() string {
+	return fmt.Sprintf("%s is overloaded (%s), retry after %v", e.node, e.reason, e.retryAfter)
+}
+
+// Retry-After header value (seconds)
+func (e *ErrOverloaded) RetryAfter() string {
+	return strconv.FormatInt(int64((e.retryAfter+time.Second-1)/time.Second), 10)
+}
+
+func IsErrOverloaded(err error) bool {
+	_, ok := err.(*ErrOverloaded)
+	return ok
+}
+
 // ErrInvalidCksum
 
 func (e *ErrInvalidCksum) Error() string {
@@ -918,6 +944,9 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, opts ...int /*[
 		status = opts[0]
 	} else if errf, ok := err.(*ErrFailedTo); ok {
 		status = errf.status
+	} else if erro, ok := err.(*ErrOverloaded); ok {
+		status = http.StatusServiceUnavailable
+		w.Header().Set(cos.HdrRetryAfter, erro.RetryAfter())
 	}
 	httpErr.init(r, err.Error(), status)
 	httpErr.write(w, r, l > 1)
@@ -15,6 +15,7 @@ const (
 	NoHeadRemB                      // see also api/apc/lsmsg.go, and in particular `LsNoHeadRemB`
 	SkipVC                          // skip loading existing object's metadata, Version and Checksum in particular
 	DontAutoDetectFshare            // when promoting NFS shares to AIS
+	IndexArchivesOnPut              // build archive index (cmn/archidx) when putting tar, tar.gz, or zip
 )
 
 var all = []struct {
@@ -25,6 +26,7 @@ var all = []struct {
 	{name: "NoHeadRemB", value: NoHeadRemB},
 	{name: "SkipVC", value: SkipVC},
 	{name: "DontAutoDetectFshare", value: DontAutoDetectFshare},
+	{name: "IndexArchivesOnPut", value: IndexArchivesOnPut},
 }
 
 func (cflags Flags) IsSet(flag Flags) bool { return cflags&flag == flag }
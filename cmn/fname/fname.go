@@ -31,6 +31,12 @@ const (
 	Vmd         = ".ais.vmd"    // vmd persistent file basename
 	Emd         = ".ais.emd"    // emd persistent file basename
 
+	// cluster event log (proxy only)
+	Events = ".ais.events" // JSONL, one cmn.Event per line
+
+	// scheduled jobs (proxy only)
+	Schmd = ".ais.schmd"
+
 	// CLI config
 	CliConfig = "cli.json" // see jsp/app.go
 
@@ -46,4 +52,10 @@ const (
 	ResilverMarker      = "resilver"
 	RebalanceMarker     = "rebalance"
 	NodeRestartedMarker = "node_restarted"
+
+	// Checkpoints of resumable xactions: per mountpath, one file per xaction ID
+	XactCkptDir = ".ais.xacts"
+
+	// Markers of incremental bucket copies (sync): per mountpath, one file per pair of buckets
+	SyncMarkersDir = ".ais.sync"
 )
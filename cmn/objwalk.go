@@ -16,6 +16,9 @@ import (
 func SortBckEntries(bckEntries []*BucketEntry) {
 	entryLess := func(i, j int) bool {
 		if bckEntries[i].Name == bckEntries[j].Name {
+			if li, lj := bckEntries[i].IsPrevVer(), bckEntries[j].IsPrevVer(); li != lj || li {
+				return versionEntryLess(bckEntries[i], bckEntries[j])
+			}
 			return bckEntries[i].Flags&apc.EntryStatusMask < bckEntries[j].Flags&apc.EntryStatusMask
 		}
 		return bckEntries[i].Name < bckEntries[j].Name
@@ -23,19 +26,43 @@ func SortBckEntries(bckEntries []*BucketEntry) {
 	sort.Slice(bckEntries, entryLess)
 }
 
+// (apc.LsVersions) current version first, followed by previous versions - newest first
+func versionEntryLess(a, b *BucketEntry) bool {
+	if !a.IsPrevVer() {
+		return true
+	}
+	if !b.IsPrevVer() {
+		return false
+	}
+	return VersionLess(b.Version, a.Version)
+}
+
+// GroupVersions extends page boundary `cnt` (if need be) so that all versions
+// of the same object end up on the same page (apc.LsVersions)
+func GroupVersions(entries []*BucketEntry, cnt int) int {
+	for cnt > 0 && cnt < len(entries) && entries[cnt].IsPrevVer() && entries[cnt].Name == entries[cnt-1].Name {
+		cnt++
+	}
+	return cnt
+}
+
 func deduplicateBckEntries(bckEntries []*BucketEntry, maxSize uint) ([]*BucketEntry, string) {
 	objCount := uint(len(bckEntries))
 
 	j := 0
 	token := ""
-	for _, obj := range bckEntries {
+	for i, obj := range bckEntries {
 		if j > 0 && bckEntries[j-1].Name == obj.Name {
-			continue
+			if !obj.IsPrevVer() || bckEntries[j-1].Version == obj.Version {
+				continue
+			}
 		}
 		bckEntries[j] = obj
 		j++
 
-		if maxSize > 0 && j == int(maxSize) {
+		// NOTE: not splitting versions of the same object between pages
+		if maxSize > 0 && j >= int(maxSize) && (i+1 == len(bckEntries) ||
+			!bckEntries[i+1].IsPrevVer() || bckEntries[i+1].Name != obj.Name) {
 			break
 		}
 	}
@@ -162,3 +189,11 @@ func DirNameContainsPrefix(dirPath, prefix string) bool {
 func ObjNameContainsPrefix(objName, prefix string) bool {
 	return prefix == "" || strings.HasPrefix(objName, prefix)
 }
+
+// VersionLess compares numeric (ais) object versions - see also `apc.EntryIsPrevVer`
+func VersionLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
@@ -0,0 +1,47 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestVersionLess(t *testing.T) {
+	tassert.Errorf(t, VersionLess("2", "10"), "expected 2 < 10")
+	tassert.Errorf(t, VersionLess("9", "11"), "expected 9 < 11")
+	tassert.Errorf(t, !VersionLess("11", "11"), "expected !(11 < 11)")
+	tassert.Errorf(t, !VersionLess("100", "99"), "expected !(100 < 99)")
+}
+
+func TestSortGroupVersions(t *testing.T) {
+	var (
+		prev    = uint16(apc.EntryIsPrevVer)
+		entries = []*BucketEntry{
+			{Name: "b", Version: "3", Flags: prev},
+			{Name: "a", Version: "2", Flags: prev},
+			{Name: "b", Version: "12"},
+			{Name: "a", Version: "10", Flags: prev},
+			{Name: "b", Version: "11", Flags: prev | apc.EntryIsDelMarker},
+			{Name: "c", Version: "1"},
+		}
+		expected = []string{"a/10", "a/2", "b/12", "b/11", "b/3", "c/1"}
+	)
+	SortBckEntries(entries)
+	for i, e := range entries {
+		tassert.Errorf(t, e.Name+"/"+e.Version == expected[i], "entry %d: expected %s, got %s/%s",
+			i, expected[i], e.Name, e.Version)
+	}
+	// page boundaries never split versions of the same object
+	tassert.Errorf(t, GroupVersions(entries, 1) == 2, "expected 2, got %d", GroupVersions(entries, 1))
+	tassert.Errorf(t, GroupVersions(entries, 3) == 5, "expected 5, got %d", GroupVersions(entries, 3))
+	tassert.Errorf(t, GroupVersions(entries, 5) == 5, "expected 5, got %d", GroupVersions(entries, 5))
+
+	page, _ := deduplicateBckEntries(entries, 3)
+	tassert.Errorf(t, len(page) == 5, "expected 5 entries in the page, got %d", len(page))
+}
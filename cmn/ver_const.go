@@ -36,6 +36,7 @@ const (
 	MetaverRMD   = 1 // Rebalance MD (jsp)
 	MetaverVMD   = 1 // Volume MD (jsp)
 	MetaverEtlMD = 1 // ETL MD (jsp)
+	MetaverSchMD = 1 // Scheduled jobs MD (jsp)
 
 	MetaverLOM = 1 // LOM
 
@@ -111,7 +111,9 @@ cat > $AIS_CONF_FILE <<EOL
 	},
 	"versioning": {
 		"enabled":           true,
-		"validate_warm_get": false
+		"validate_warm_get": false,
+		"history":           0,
+		"retention":         "0s"
 	},
 	"net": {
 		"l4": {
@@ -164,6 +166,13 @@ cat > $AIS_CONF_FILE <<EOL
 		"compression":           "${AIS_DSORT_COMPRESSION:-never}",
 		"bundle_multiplier":	${AIS_DSORT_BUNDLE_MULTIPLIER:-4}
 	},
+	"admission": {
+		"mem_pressure":		"high",
+		"disk_util":		0,
+		"max_goroutines":	0,
+		"retry_after":		"5s",
+		"enabled":		false
+	},
 	"tcb": {
 		"compression":		"never",
 		"bundle_multiplier":	2
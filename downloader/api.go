@@ -8,8 +8,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,8 +28,12 @@ const (
 	DlTypeRange   DlType = "range"
 	DlTypeMulti   DlType = "multi"
 	DlTypeBackend DlType = "backend"
+	DlTypeCrawl   DlType = "crawl"
 
 	DownloadProgressInterval = 10 * time.Second
+
+	// continuous jobs: minimum interval between consecutive runs
+	MinContinuousInterval = time.Minute
 )
 
 type (
@@ -56,21 +63,36 @@ type (
 		Aborted       bool      `json:"aborted"`
 		StartedTime   time.Time `json:"started_time"`
 		FinishedTime  time.Time `json:"finished_time"`
+		Run           int       `json:"run,omitempty"` // continuous job: current (or last) run, starting from 1
 	}
 
 	DlJobInfos []*DlJobInfo
 
+	// Summary of a single (completed) run of a continuous job
+	DlRunInfo struct {
+		Run          int       `json:"run"`
+		StartedTime  time.Time `json:"started_time"`
+		FinishedTime time.Time `json:"finished_time"`
+		ScheduledCnt int       `json:"scheduled_cnt"`
+		FinishedCnt  int       `json:"finished_cnt"` // including skipped (unchanged at the source)
+		SkippedCnt   int       `json:"skipped_cnt"`
+		ErrorCnt     int       `json:"error_cnt"`
+		DeletedCnt   int       `json:"deleted_cnt"` // objects removed upon disappearing at the source
+		Aborted      bool      `json:"aborted,omitempty"`
+	}
+
 	DlStatusResp struct {
 		DlJobInfo
 		CurrentTasks  []TaskDlInfo  `json:"current_tasks,omitempty"`
 		FinishedTasks []TaskDlInfo  `json:"finished_tasks,omitempty"`
 		Errs          []TaskErrInfo `json:"download_errors,omitempty"`
+		Runs          []DlRunInfo   `json:"runs,omitempty"` // continuous job: history of completed runs
 	}
 )
 
 func IsType(a string) bool {
 	b := DlType(a)
-	return b == DlTypeMulti || b == DlTypeBackend || b == DlTypeSingle || b == DlTypeRange
+	return b == DlTypeMulti || b == DlTypeBackend || b == DlTypeSingle || b == DlTypeRange || b == DlTypeCrawl
 }
 
 func (j *DlJobInfo) Aggregate(rhs *DlJobInfo) {
@@ -92,6 +114,25 @@ func (j *DlJobInfo) Aggregate(rhs *DlJobInfo) {
 			j.FinishedTime = rhs.FinishedTime
 		}
 	}
+	if j.Run < rhs.Run {
+		j.Run = rhs.Run
+	}
+}
+
+// NOTE: targets run (and number) the runs independently of each other
+func (r *DlRunInfo) Aggregate(rhs *DlRunInfo) {
+	r.ScheduledCnt += rhs.ScheduledCnt
+	r.FinishedCnt += rhs.FinishedCnt
+	r.SkippedCnt += rhs.SkippedCnt
+	r.ErrorCnt += rhs.ErrorCnt
+	r.DeletedCnt += rhs.DeletedCnt
+	r.Aborted = r.Aborted || rhs.Aborted
+	if r.StartedTime.After(rhs.StartedTime) {
+		r.StartedTime = rhs.StartedTime
+	}
+	if r.FinishedTime.Before(rhs.FinishedTime) {
+		r.FinishedTime = rhs.FinishedTime
+	}
 }
 
 func (db DlBody) MarshalJSON() ([]byte, error) {
@@ -192,20 +233,84 @@ func (d *DlStatusResp) Aggregate(rhs DlStatusResp) *DlStatusResp {
 	d.CurrentTasks = append(d.CurrentTasks, rhs.CurrentTasks...)
 	d.FinishedTasks = append(d.FinishedTasks, rhs.FinishedTasks...)
 	d.Errs = append(d.Errs, rhs.Errs...)
+	d.Runs = aggregateRuns(d.Runs, rhs.Runs)
 	return d
 }
 
+func aggregateRuns(runs, rhs []DlRunInfo) []DlRunInfo {
+outer:
+	for i := range rhs {
+		for j := range runs {
+			if runs[j].Run == rhs[i].Run {
+				runs[j].Aggregate(&rhs[i])
+				continue outer
+			}
+		}
+		runs = append(runs, rhs[i])
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Run < runs[j].Run })
+	return runs
+}
+
 type DlLimits struct {
 	Connections  int `json:"connections"`
 	BytesPerHour int `json:"bytes_per_hour"`
 }
 
+// Credentials to access download sources other than plain HTTP(S) - see downloader/source.go.
+// All fields are optional; the downloader keeps the credentials in memory (with the job) and does not store them.
+type DlCreds struct {
+	// s3://bucket/object (anonymous if the keys are not specified; requires `aws` build tag)
+	S3AccessKeyID     string `json:"s3_access_key_id,omitempty"`
+	S3SecretAccessKey string `json:"s3_secret_access_key,omitempty"`
+	S3SessionToken    string `json:"s3_session_token,omitempty"`
+	S3Region          string `json:"s3_region,omitempty"`   // default: us-east-1
+	S3Endpoint        string `json:"s3_endpoint,omitempty"` // S3-compatible storage (path-style addressing)
+
+	// gs://bucket/object (anonymous if the service account key is not specified; requires `gcp` build tag)
+	GCSCredentials string `json:"gcs_credentials,omitempty"` // service account key (JSON)
+	GCSEndpoint    string `json:"gcs_endpoint,omitempty"`
+
+	// ftp:// and sftp:// (take precedence over user:password, if any, in the link itself)
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	SSHPrivateKey string `json:"ssh_private_key,omitempty"` // PEM-encoded
+	SSHHostKey    string `json:"ssh_host_key,omitempty"`    // public key in authorized_keys format
+	SSHInsecure   bool   `json:"ssh_insecure,omitempty"`    // skip host key verification (testing only)
+}
+
+// Per-task (i.e., per-object) retry policy. Attempts that fail with a retryable error
+// are repeated after an exponentially growing delay (starting at `backoff` and capped
+// at `max_backoff`); partially downloaded content is resumed when the source allows
+// (e.g., HTTP Range requests) - see downloader/partial.go.
+type DlRetry struct {
+	MaxAttempts int    `json:"max_attempts,omitempty"` // including the first one (default: 10)
+	Backoff     string `json:"backoff,omitempty"`      // delay before the first retry (default: none)
+	MaxBackoff  string `json:"max_backoff,omitempty"`  // (default: 1m)
+	// HTTP status codes to retry upon; if empty, all codes except 401, 402, 403, 404, 405, 406, 407, and 410
+	RetryCodes []int `json:"retry_codes,omitempty"`
+}
+
 type DlBase struct {
-	Description      string   `json:"description"`
-	Bck              cmn.Bck  `json:"bucket"`
-	Timeout          string   `json:"timeout"`
-	ProgressInterval string   `json:"progress_interval"`
-	Limits           DlLimits `json:"limits"`
+	Description      string        `json:"description"`
+	Bck              cmn.Bck       `json:"bucket"`
+	Timeout          string        `json:"timeout"`
+	ProgressInterval string        `json:"progress_interval"`
+	Limits           DlLimits      `json:"limits"`
+	Creds            *DlCreds      `json:"creds,omitempty"`
+	Retry            *DlRetry      `json:"retry,omitempty"`
+	Continuous       *DlContinuous `json:"continuous,omitempty"`
+}
+
+// Continuous job: when done, the job re-runs after the specified interval to download
+// objects that are new or have changed at the source (unchanged objects are skipped),
+// optionally deleting objects that have disappeared. The job keeps running until
+// aborted (or until it completes `max_runs`), thus maintaining a mirror of the source.
+type DlContinuous struct {
+	Interval string `json:"interval"`           // between the end of a run and the start of the next one
+	MaxRuns  int    `json:"max_runs,omitempty"` // 0 - unlimited
+	// delete objects that were downloaded by a previous run but are no longer present at the source
+	Delete bool `json:"delete,omitempty"`
 }
 
 func (b *DlBase) Validate() error {
@@ -223,6 +328,76 @@ func (b *DlBase) Validate() error {
 	if b.Limits.BytesPerHour < 0 {
 		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", b.Limits.BytesPerHour)
 	}
+	if b.Retry != nil {
+		if err := b.Retry.Validate(); err != nil {
+			return err
+		}
+	}
+	if b.Continuous != nil {
+		if err := b.Continuous.Validate(); err != nil {
+			return err
+		}
+	}
+	if b.Creds != nil {
+		return b.Creds.Validate()
+	}
+	return nil
+}
+
+func (c *DlContinuous) Validate() error {
+	interval, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return fmt.Errorf("invalid 'continuous.interval': %v", err)
+	}
+	if interval < MinContinuousInterval {
+		return fmt.Errorf("'continuous.interval' must be at least %v (got: %v)", MinContinuousInterval, interval)
+	}
+	if c.MaxRuns < 0 {
+		return fmt.Errorf("'continuous.max_runs' must be non-negative (got: %d)", c.MaxRuns)
+	}
+	return nil
+}
+
+func (r *DlRetry) Validate() error {
+	if r.MaxAttempts < 0 {
+		return fmt.Errorf("'retry.max_attempts' must be non-negative (got: %d)", r.MaxAttempts)
+	}
+	if _, err := parseBackoff(r.Backoff, 0); err != nil {
+		return fmt.Errorf("invalid 'retry.backoff': %v", err)
+	}
+	if _, err := parseBackoff(r.MaxBackoff, 0); err != nil {
+		return fmt.Errorf("invalid 'retry.max_backoff': %v", err)
+	}
+	for _, code := range r.RetryCodes {
+		if code < http.StatusBadRequest || code > 599 {
+			return fmt.Errorf("invalid 'retry.retry_codes': %d is not an HTTP error status", code)
+		}
+	}
+	return nil
+}
+
+func (c *DlCreds) Validate() error {
+	if (c.S3AccessKeyID == "") != (c.S3SecretAccessKey == "") {
+		return errors.New("'creds.s3_access_key_id' and 'creds.s3_secret_access_key' must be specified together")
+	}
+	for _, ep := range []string{c.S3Endpoint, c.GCSEndpoint} {
+		if ep != "" && !cos.IsHTTP(ep) && !cos.IsHTTPS(ep) {
+			return fmt.Errorf("invalid endpoint %q (expecting http(s)://host[:port])", ep)
+		}
+	}
+	if c.GCSCredentials != "" {
+		// (the part of) service account key - the rest is up to the SDK
+		var key struct {
+			ClientEmail string `json:"client_email"`
+			PrivateKey  string `json:"private_key"`
+		}
+		if err := jsoniter.Unmarshal([]byte(c.GCSCredentials), &key); err != nil {
+			return fmt.Errorf("invalid 'creds.gcs_credentials': %v", err)
+		}
+		if key.ClientEmail == "" || key.PrivateKey == "" {
+			return errors.New("invalid 'creds.gcs_credentials': expecting service account key (JSON)")
+		}
+	}
 	return nil
 }
 
@@ -295,6 +470,9 @@ func (t TaskErrByName) Less(i, j int) bool { return t[i].Name < t[j].Name }
 type TaskErrInfo struct {
 	Name string `json:"name"`
 	Err  string `json:"error"`
+	Link string `json:"link,omitempty"` // empty when downloading from remote bucket
+	// expected size and checksum(s) from the download manifest, if any
+	Expected *DlExpected `json:"expected,omitempty"`
 }
 
 // Single request
@@ -355,15 +533,74 @@ func (b *DlRangeBody) String() string {
 	return fmt.Sprintf("bucket: %q, template: %q", b.Bck, b.Template)
 }
 
-// Multi request
+// Crawl request: follow directory listings (Apache/nginx autoindex) or sitemap(s)
+// starting from the root URL and download all discovered links (see downloader/crawl.go)
+type DlCrawlBody struct {
+	DlBase
+	URL      string `json:"url"`                 // root directory (autoindex) or sitemap (*.xml)
+	Subdir   string `json:"subdir"`              // destination virtual directory
+	MaxDepth int    `json:"max_depth,omitempty"` // directory (sitemap) levels to follow, including root; 0 - unlimited
+	Include  string `json:"include,omitempty"`   // regex: download only matching (relative) paths
+	Exclude  string `json:"exclude,omitempty"`   // regex: skip matching paths and directories
+	AnyHost  bool   `json:"any_host,omitempty"`  // sitemap: follow <loc>s on any host (default: root URL's host only)
+}
+
+func (b *DlCrawlBody) Validate() error {
+	if err := b.DlBase.Validate(); err != nil {
+		return err
+	}
+	if b.URL == "" {
+		return errors.New("missing 'url' in the request body")
+	}
+	u, err := url.Parse(b.URL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid crawl URL %q (expecting http(s)://)", b.URL)
+	}
+	if b.MaxDepth < 0 {
+		return fmt.Errorf("'max_depth' must be non-negative (got: %d)", b.MaxDepth)
+	}
+	if _, err := regexp.Compile(b.Include); err != nil {
+		return fmt.Errorf("invalid 'include' regex: %v", err)
+	}
+	if _, err := regexp.Compile(b.Exclude); err != nil {
+		return fmt.Errorf("invalid 'exclude' regex: %v", err)
+	}
+	return nil
+}
+
+func (b *DlCrawlBody) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	return fmt.Sprintf("crawl %s -> %s", b.URL, b.Bck)
+}
+
+func (b *DlCrawlBody) String() string {
+	return fmt.Sprintf("bucket: %q, url: %q, max depth: %d", b.Bck, b.URL, b.MaxDepth)
+}
+
+// Multi request: either objects (list of links or map of object names to links)
+// or manifest (links with expected sizes and checksums, see downloader/manifest.go)
 type DlMultiBody struct {
 	DlBase
-	ObjectsPayload interface{} `json:"objects"`
+	ObjectsPayload interface{}       `json:"objects"`
+	Manifest       []DlManifestEntry `json:"manifest,omitempty"`
 }
 
 func (b *DlMultiBody) Validate() error {
-	if b.ObjectsPayload == nil {
+	switch {
+	case b.ObjectsPayload == nil && len(b.Manifest) == 0:
 		return errors.New("body should not be empty")
+	case b.ObjectsPayload != nil && len(b.Manifest) > 0:
+		return errors.New("objects and manifest are mutually exclusive")
+	}
+	for i := range b.Manifest {
+		if err := b.Manifest[i].Validate(); err != nil {
+			return fmt.Errorf("manifest entry %d: %v", i+1, err)
+		}
 	}
 	return b.DlBase.Validate()
 }
@@ -0,0 +1,366 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Crawl download: starting from the root URL, follow directory listings - HTML pages
+// generated by Apache/nginx autoindex and similar - or sitemaps (https://www.sitemaps.org)
+// and download all discovered links. Object names are the links' paths relative to the
+// root directory (optionally, prefixed with `subdir`).
+// Links are untrusted: names that'd resolve outside the bucket (e.g., "..%2F..%2Fetc") are
+// skipped, and sitemap links on other hosts are followed only upon request (`AnyHost`).
+// Same as range download, each target crawls on its own and only downloads the links
+// that map (HRW) to itself. Listing pages are fetched subject to the job's limits.
+
+const (
+	maxCrawlDepth    = 64           // when not limited by the request
+	maxCrawlPageSize = 64 * cos.MiB // (uncompressed sitemap is limited to 50MiB)
+)
+
+type (
+	crawlDlJob struct {
+		baseDlJob
+		t        cluster.Target
+		root     *url.URL
+		rootDir  string // escaped path of the root directory (with trailing '/')
+		subdir   string
+		maxDepth int
+		anyHost  bool
+		include  *regexp.Regexp // nil: all
+		exclude  *regexp.Regexp // nil: none
+		queue    []crawlPage    // pages to visit (breadth-first)
+		seen     cos.StringSet  // links and pages (to visit each once)
+		objs     []dlObj        // objects' metas which are ready to be downloaded
+		skipped  int            // pages that failed to crawl
+		done     bool           // true when there's nothing left to crawl
+	}
+	crawlPage struct {
+		u       *url.URL
+		depth   int // root: 1
+		sitemap bool
+	}
+
+	// sitemap or sitemap index
+	sitemap struct {
+		URLs     []sitemapLoc `xml:"url"`
+		Sitemaps []sitemapLoc `xml:"sitemap"`
+	}
+	sitemapLoc struct {
+		Loc string `xml:"loc"`
+	}
+)
+
+var hrefRegex = regexp.MustCompile(`(?is)<a\s[^>]*?\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+
+func newCrawlDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlCrawlBody, dlXact *Downloader) (*crawlDlJob, error) {
+	root, err := url.Parse(payload.URL)
+	if err != nil {
+		return nil, err
+	}
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
+	job := &crawlDlJob{
+		baseDlJob: *base,
+		t:         t,
+		subdir:    payload.Subdir,
+		maxDepth:  payload.MaxDepth,
+		anyHost:   payload.AnyHost,
+		seen:      make(cos.StringSet, 64),
+	}
+	if job.maxDepth == 0 || job.maxDepth > maxCrawlDepth {
+		job.maxDepth = maxCrawlDepth
+	}
+	if payload.Include != "" {
+		if job.include, err = regexp.Compile(payload.Include); err != nil {
+			return nil, err
+		}
+	}
+	if payload.Exclude != "" {
+		if job.exclude, err = regexp.Compile(payload.Exclude); err != nil {
+			return nil, err
+		}
+	}
+	page := crawlPage{u: root, depth: 1, sitemap: isSitemap(root)}
+	if page.sitemap {
+		job.rootDir = path.Dir(root.EscapedPath())
+	} else {
+		// directory (listing) - relative links are resolved against its path with trailing '/'
+		if !strings.HasSuffix(root.Path, "/") {
+			root.Path += "/"
+			if root.RawPath != "" {
+				root.RawPath += "/"
+			}
+		}
+		job.rootDir = root.EscapedPath()
+	}
+	if !strings.HasSuffix(job.rootDir, "/") {
+		job.rootDir += "/"
+	}
+	job.root = root
+	job.seen.Add(root.String())
+	job.queue = append(job.queue, page)
+	return job, nil
+}
+
+func (j *crawlDlJob) rewind(dlXact *Downloader) {
+	j.baseDlJob.rewind(dlXact)
+	j.seen = make(cos.StringSet, len(j.seen))
+	j.seen.Add(j.root.String())
+	j.queue = append(j.queue[:0], crawlPage{u: j.root, depth: 1, sitemap: isSitemap(j.root)})
+	j.skipped = 0
+	j.done = false
+}
+
+// the links under the pages that failed to crawl are unknown (see DlContinuous.Delete)
+func (j *crawlDlJob) incomplete() bool { return j.skipped > 0 }
+
+func isSitemap(u *url.URL) bool { return strings.EqualFold(path.Ext(u.Path), ".xml") }
+
+func (*crawlDlJob) Len() int { return -1 }
+
+func (j *crawlDlJob) String() (s string) {
+	return fmt.Sprintf("crawl-%s-%s-%s", &j.baseDlJob, j.root, j.subdir)
+}
+
+func (j *crawlDlJob) genNext() ([]dlObj, bool, error) {
+	if j.done {
+		return nil, false, nil
+	}
+	if err := j.getNextObjs(); err != nil {
+		return nil, false, err
+	}
+	return j.objs, true, nil
+}
+
+// Visits the pages (breadth-first) until enough objects to download are found
+// or there's nothing left to crawl. Failure to fetch the root page fails the job;
+// any other page that cannot be fetched or parsed is skipped.
+func (j *crawlDlJob) getNextObjs() error {
+	j.objs = j.objs[:0]
+	for len(j.objs) < downloadBatchSize {
+		if len(j.queue) == 0 || j.aborted() {
+			j.done = true
+			break
+		}
+		page := j.queue[0]
+		j.queue = j.queue[1:]
+		if err := j.visit(page); err != nil {
+			if page.depth == 1 {
+				return err
+			}
+			glog.Warningf("%s: failed to crawl %s: %v - skipping", j, page.u, err)
+			j.skipped++
+		}
+	}
+	return nil
+}
+
+func (j *crawlDlJob) aborted() bool {
+	d := j.dlXact.dispatcher
+	return d.checkAborted() || d.checkAbortedJob(j)
+}
+
+func (j *crawlDlJob) visit(page crawlPage) error {
+	b, base, err := j.fetch(page.u)
+	if err != nil {
+		return err
+	}
+	if page.sitemap {
+		return j.visitSitemap(page, b)
+	}
+	for _, href := range parseHrefs(b) {
+		ref, err := url.Parse(href)
+		if err != nil {
+			continue
+		}
+		u := base.ResolveReference(ref)
+		u.Fragment = ""
+		// same host and scheme; no queries (e.g., "?C=N;O=D" to sort the listing)
+		if u.RawQuery != "" || u.Scheme != j.root.Scheme || u.Host != j.root.Host {
+			continue
+		}
+		esc := u.EscapedPath()
+		if !strings.HasPrefix(esc, j.rootDir) || esc == j.rootDir {
+			continue // outside of the root directory (e.g., parent)
+		}
+		rel := strings.TrimPrefix(esc, j.rootDir)
+		if !strings.HasSuffix(rel, "/") {
+			j.addLink(u, rel)
+			continue
+		}
+		// directory
+		depth := strings.Count(rel, "/") + 1
+		if depth > j.maxDepth || j.excluded(rel) || !j.see(u) {
+			continue
+		}
+		j.queue = append(j.queue, crawlPage{u: u, depth: depth})
+	}
+	return nil
+}
+
+func (j *crawlDlJob) visitSitemap(page crawlPage, b []byte) error {
+	var sm sitemap
+	if err := xml.Unmarshal(b, &sm); err != nil {
+		return fmt.Errorf("invalid sitemap: %v", err)
+	}
+	for _, loc := range sm.Sitemaps {
+		u, ok := j.parseLoc(loc.Loc)
+		if !ok || page.depth >= j.maxDepth || !j.see(u) {
+			continue
+		}
+		j.queue = append(j.queue, crawlPage{u: u, depth: page.depth + 1, sitemap: true})
+	}
+	for _, loc := range sm.URLs {
+		u, ok := j.parseLoc(loc.Loc)
+		if !ok {
+			continue
+		}
+		// relative to the root directory if possible, full path otherwise
+		esc := u.EscapedPath()
+		rel := strings.TrimPrefix(esc, "/")
+		if u.Host == j.root.Host && strings.HasPrefix(esc, j.rootDir) {
+			rel = strings.TrimPrefix(esc, j.rootDir)
+		}
+		if rel == "" || strings.HasSuffix(rel, "/") {
+			continue // (not a file)
+		}
+		j.addLink(u, rel)
+	}
+	return nil
+}
+
+func (j *crawlDlJob) parseLoc(loc string) (*url.URL, bool) {
+	u, err := url.Parse(strings.TrimSpace(loc))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, false
+	}
+	// (a third-party sitemap must not point targets at arbitrary, e.g. internal, addresses)
+	if u.Host != j.root.Host && !j.anyHost {
+		return nil, false
+	}
+	u.Fragment = ""
+	return u, true
+}
+
+func (j *crawlDlJob) addLink(u *url.URL, rel string) {
+	if j.excluded(rel) || (j.include != nil && !j.include.MatchString(unescape(rel))) || !j.see(u) {
+		return
+	}
+	var (
+		smap = j.t.Sowner().Get()
+		name = path.Join(j.subdir, rel)
+	)
+	if objName, err := NormalizeObjName(name); err != nil || !isLocalObjName(objName) {
+		glog.Warningf("%s: skipping %s: invalid object name %q", j, u, objName)
+		return
+	}
+	obj, err := makeDlObj(smap, j.t.SID(), j.bck, name, u.String())
+	if err != nil {
+		if err != errInvalidTarget {
+			glog.Warningf("%s: skipping %s: %v", j, u, err)
+		}
+		return
+	}
+	j.objs = append(j.objs, obj)
+}
+
+// (unescaped) object name that stays within the bucket: not absolute and no ".." segments
+func isLocalObjName(objName string) bool {
+	if objName == "" || strings.HasPrefix(objName, "/") {
+		return false
+	}
+	for _, seg := range strings.Split(objName, "/") {
+		if seg == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *crawlDlJob) excluded(rel string) bool {
+	return j.exclude != nil && j.exclude.MatchString(unescape(rel))
+}
+
+// returns false if already seen
+func (j *crawlDlJob) see(u *url.URL) bool {
+	link := u.String()
+	if j.seen.Contains(link) {
+		return false
+	}
+	j.seen.Add(link)
+	return true
+}
+
+// fetch listing page (via throttler, same as objects); returns the content
+// and the final URL (after redirects) to resolve relative links
+func (j *crawlDlJob) fetch(u *url.URL) ([]byte, *url.URL, error) {
+	select {
+	case <-j.throttler().tryAcquire():
+	case <-j.dlXact.dispatcher.jobAbortedCh(j.ID()).Listen():
+		return nil, nil, cmn.NewErrAborted(j.String(), "", nil)
+	}
+	defer j.throttler().release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), j.pageTimeout())
+	defer cancel()
+	resp, err := doHTTP(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cos.Close(resp.Body)
+	r := j.throttler().wrapReader(ctx, resp.Body)
+	b, err := io.ReadAll(io.LimitReader(r, maxCrawlPageSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(b) > maxCrawlPageSize {
+		return nil, nil, fmt.Errorf("page size exceeds %s", cos.B2S(maxCrawlPageSize, 0))
+	}
+	return b, resp.Request.URL, nil
+}
+
+func (j *crawlDlJob) pageTimeout() time.Duration {
+	if j.Timeout() != 0 {
+		return j.Timeout()
+	}
+	return cmn.GCO.Get().Downloader.Timeout.D()
+}
+
+// extracts (unescaped) hrefs of all anchors
+func parseHrefs(b []byte) (hrefs []string) {
+	for _, m := range hrefRegex.FindAllSubmatch(b, -1) {
+		for _, g := range m[1:] {
+			if len(g) > 0 {
+				hrefs = append(hrefs, html.UnescapeString(string(g)))
+				break
+			}
+		}
+	}
+	return
+}
+
+func unescape(rel string) string {
+	if s, err := url.PathUnescape(rel); err == nil {
+		return s
+	}
+	return rel
+}
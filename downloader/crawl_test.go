@@ -0,0 +1,274 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+type (
+	// single-target cluster: all links map to the target
+	crawlTarget struct {
+		*mock.TargetMock
+		smap *cluster.Smap
+	}
+)
+
+func (t *crawlTarget) Sowner() cluster.Sowner         { return t }
+func (t *crawlTarget) Get() *cluster.Smap             { return t.smap }
+func (*crawlTarget) Listeners() cluster.SmapListeners { return nil }
+
+// nginx-style autoindex
+const crawlIndex = `<html><head><title>Index of %s</title></head><body><h1>Index of %s</h1><hr><pre>
+<a href="../">../</a>
+<a href="?C=N;O=D">Name</a>
+%s</pre><hr></body></html>`
+
+func serveCrawl(files map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		if content, ok := files[p]; ok {
+			fmt.Fprint(w, content)
+			return
+		}
+		if !strings.HasSuffix(p, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		// directory: list immediate children
+		children := make(cos.StringSet)
+		for name := range files {
+			if !strings.HasPrefix(name, p) {
+				continue
+			}
+			rel := strings.TrimPrefix(name, p)
+			if i := strings.IndexByte(rel, '/'); i >= 0 {
+				rel = rel[:i+1]
+			}
+			children.Add(rel)
+		}
+		if len(children) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		var sb strings.Builder
+		for _, child := range children.ToSlice() {
+			fmt.Fprintf(&sb, "<a href=%q>%s</a>\n", (&url.URL{Path: child}).EscapedPath(), child)
+		}
+		fmt.Fprintf(w, crawlIndex, p, p, sb.String())
+	}))
+}
+
+func crawlAll(t *testing.T, payload *DlCrawlBody) (names []string) {
+	var (
+		bck  = cluster.NewBck("crawl", apc.ProviderAIS, cmn.NsGlobal)
+		smap = &cluster.Smap{Tmap: make(cluster.NodeMap, 1)}
+		tgt  = &crawlTarget{TargetMock: mock.NewTarget(mock.NewBaseBownerMock()), smap: smap}
+		id   = "crawl-job"
+		disp = &dispatcher{stopCh: cos.NewStopCh(), abortJob: map[string]*cos.StopCh{id: cos.NewStopCh()}}
+	)
+	smap.Tmap[tgt.SID()] = cluster.NewSnode(tgt.SID(), apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+	payload.Bck = bck.Clone()
+	payload.Timeout = "10s"
+	tassert.CheckFatal(t, payload.Validate())
+
+	job, err := newCrawlDlJob(tgt, id, bck, payload, &Downloader{dispatcher: disp})
+	tassert.CheckFatal(t, err)
+	defer job.throttler().stop()
+	names = crawlNames(t, job)
+
+	// continuous job: the next run must crawl all over again
+	job.rewind(job.dlXact)
+	again := crawlNames(t, job)
+	tassert.Errorf(t, strings.Join(names, ",") == strings.Join(again, ","), "rewind: expected %v, got %v", names, again)
+	return
+}
+
+func crawlNames(t *testing.T, job *crawlDlJob) (names []string) {
+	for {
+		objs, ok, err := job.genNext()
+		tassert.CheckFatal(t, err)
+		if !ok {
+			break
+		}
+		for _, obj := range objs {
+			tassert.Errorf(t, strings.HasPrefix(obj.link, "http://"), "unexpected link %q for %q", obj.link, obj.objName)
+			names = append(names, obj.objName)
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+func TestCrawlAutoindex(t *testing.T) {
+	srv := serveCrawl(map[string]string{
+		"/pub/data/a.tar":           "a",
+		"/pub/data/b.txt":           "b",
+		"/pub/data/sub/c.tar":       "c",
+		"/pub/data/sub/deep/d.tar":  "d",
+		"/pub/data/skip/e.tar":      "e",
+		"/pub/data/my file.tar":     "f",
+		"/pub/other/outside.tar":    "g",
+		"/pub/data/sub/deep/e/f.gz": "h",
+	})
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		payload  DlCrawlBody
+		expected []string
+	}{
+		{
+			name:    "all",
+			payload: DlCrawlBody{URL: srv.URL + "/pub/data"},
+			expected: []string{
+				"a.tar", "b.txt", "my file.tar", "skip/e.tar", "sub/c.tar", "sub/deep/d.tar", "sub/deep/e/f.gz",
+			},
+		},
+		{
+			name:     "depth",
+			payload:  DlCrawlBody{URL: srv.URL + "/pub/data/", MaxDepth: 2, Subdir: "dir"},
+			expected: []string{"dir/a.tar", "dir/b.txt", "dir/my file.tar", "dir/skip/e.tar", "dir/sub/c.tar"},
+		},
+		{
+			name:     "include-exclude",
+			payload:  DlCrawlBody{URL: srv.URL + "/pub/data/", Include: `\.tar$`, Exclude: `^skip/|deep/`},
+			expected: []string{"a.tar", "my file.tar", "sub/c.tar"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			names := crawlAll(t, &test.payload)
+			tassert.Errorf(t, strings.Join(names, ",") == strings.Join(test.expected, ","),
+				"expected %v, got %v", test.expected, names)
+		})
+	}
+}
+
+func TestCrawlSitemap(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/maps/index.xml":
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>%s/maps/one.xml</loc></sitemap>
+  <sitemap><loc>%s/maps/missing.xml</loc></sitemap>
+</sitemapindex>`, srv.URL, srv.URL)
+		case "/maps/one.xml":
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>%s/maps/shards/s-0.tar</loc></url>
+  <url><loc> %s/maps/shards/s-1.tar </loc></url>
+  <url><loc>%s/elsewhere/x.tar</loc></url>
+  <url><loc>%s/maps/shards/s-0.tar</loc></url>
+  <url><loc>ftp://host/y.tar</loc></url>
+</urlset>`, srv.URL, srv.URL, srv.URL, srv.URL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	names := crawlAll(t, &DlCrawlBody{URL: srv.URL + "/maps/index.xml"})
+	expected := []string{"elsewhere/x.tar", "shards/s-0.tar", "shards/s-1.tar"}
+	tassert.Errorf(t, strings.Join(names, ",") == strings.Join(expected, ","), "expected %v, got %v", expected, names)
+}
+
+// links that'd resolve outside the bucket
+func TestCrawlUnsafeLinks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pub/":
+			fmt.Fprintf(w, crawlIndex, r.URL.Path, r.URL.Path, `<a href="a.tar">a.tar</a>
+<a href="..%2F..%2F..%2Fetc%2Fx">x</a>
+<a href="%2Fabs.tar">abs.tar</a>
+<a href="sub%2F..%2F..%2Fy.tar">y.tar</a>
+<a href="sub%2F..%2Fb.tar">b.tar</a>`) // (any ".." is rejected)
+		case "/pub/sitemap.xml":
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>http://`+r.Host+`/pub/c.tar</loc></url>
+  <url><loc>http://`+r.Host+`/pub/..%2F..%2Fetc%2Fz</loc></url>
+</urlset>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	names := crawlAll(t, &DlCrawlBody{URL: srv.URL + "/pub/"})
+	expected := []string{"a.tar"}
+	tassert.Errorf(t, strings.Join(names, ",") == strings.Join(expected, ","), "expected %v, got %v", expected, names)
+
+	names = crawlAll(t, &DlCrawlBody{URL: srv.URL + "/pub/sitemap.xml"})
+	expected = []string{"c.tar"}
+	tassert.Errorf(t, strings.Join(names, ",") == strings.Join(expected, ","), "expected %v, got %v", expected, names)
+}
+
+// sitemap links on other hosts are followed only when requested
+func TestCrawlSitemapHosts(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>http://`+r.Host+`/secret/s.tar</loc></url>
+</urlset>`)
+	}))
+	defer other.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/maps/index.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>%s/internal.xml</loc></sitemap>
+</sitemapindex>`, other.URL)
+	}))
+	defer srv.Close()
+
+	names := crawlAll(t, &DlCrawlBody{URL: srv.URL + "/maps/index.xml"})
+	tassert.Errorf(t, len(names) == 0, "expected nothing, got %v", names)
+
+	names = crawlAll(t, &DlCrawlBody{URL: srv.URL + "/maps/index.xml", AnyHost: true})
+	expected := []string{"secret/s.tar"}
+	tassert.Errorf(t, strings.Join(names, ",") == strings.Join(expected, ","), "expected %v, got %v", expected, names)
+}
+
+func TestCrawlValidate(t *testing.T) {
+	base := DlBase{Bck: cmn.Bck{Name: "bucket"}}
+	tests := []struct {
+		body  DlCrawlBody
+		valid bool
+	}{
+		{DlCrawlBody{DlBase: base, URL: "https://host/pub/"}, true},
+		{DlCrawlBody{DlBase: base, URL: "https://host/sitemap.xml", MaxDepth: 2, Include: `\.tar$`}, true},
+		{DlCrawlBody{DlBase: base}, false},
+		{DlCrawlBody{DlBase: base, URL: "ftp://host/pub/"}, false},
+		{DlCrawlBody{DlBase: base, URL: "https://host/pub/", MaxDepth: -1}, false},
+		{DlCrawlBody{DlBase: base, URL: "https://host/pub/", Exclude: "("}, false},
+	}
+	for _, test := range tests {
+		err := test.body.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got %v", test.body, test.valid, err)
+	}
+}
@@ -16,6 +16,7 @@ import (
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderRuns       = "runs"
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -25,6 +26,9 @@ const (
 	// Number of tasks stored in memory. When the number of tasks exceeds
 	// this number, then all errors will be flushed to disk
 	taskInfoCacheSize = 1000
+
+	// Number of the most recent runs of a continuous job to keep in the history
+	maxRunHistory = 100
 )
 
 var errJobNotFound = errors.New("job not found")
@@ -66,11 +70,25 @@ func (db *downloaderDB) getErrors(id string) (errors []TaskErrInfo, err error) {
 	return db.errors(id)
 }
 
-func (db *downloaderDB) persistError(id, objName, errMsg string) {
+// returns and removes all errors of a given job
+func (db *downloaderDB) takeErrors(id string) (errors []TaskErrInfo, err error) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	if errors, err = db.errors(id); err != nil {
+		return
+	}
+	key := path.Join(downloaderErrors, id)
+	if err = db.driver.Delete(downloaderCollection, key); err != nil && !dbdriver.IsErrNotFound(err) {
+		return nil, err
+	}
+	db.errCache[id] = db.errCache[id][:0]
+	return errors, nil
+}
+
+func (db *downloaderDB) persistError(id string, errInfo TaskErrInfo) {
 	db.mtx.Lock()
 	defer db.mtx.Unlock()
 
-	errInfo := TaskErrInfo{Name: objName, Err: errMsg}
 	if len(db.errCache[id]) < errCacheSize { // if possible store error in cache
 		db.errCache[id] = append(db.errCache[id], errInfo)
 		return
@@ -174,11 +192,52 @@ func (db *downloaderDB) flush(id string) error {
 	return nil
 }
 
-func (db *downloaderDB) delete(id string) {
+func (db *downloaderDB) getRuns(id string) (runs []DlRunInfo, err error) {
+	db.mtx.RLock()
+	defer db.mtx.RUnlock()
+	key := path.Join(downloaderRuns, id)
+	if err := db.driver.Get(downloaderCollection, key, &runs); err != nil && !dbdriver.IsErrNotFound(err) {
+		glog.Error(err)
+		return nil, err
+	}
+	return runs, nil
+}
+
+func (db *downloaderDB) persistRun(id string, run DlRunInfo) {
+	runs, err := db.getRuns(id)
+	if err != nil {
+		return
+	}
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	runs = append(runs, run)
+	if len(runs) > maxRunHistory {
+		runs = runs[len(runs)-maxRunHistory:]
+	}
+	key := path.Join(downloaderRuns, id)
+	if err := db.driver.Set(downloaderCollection, key, runs); err != nil {
+		glog.Error(err)
+	}
+}
+
+// removes errors and tasks (but not the runs) of a given job
+func (db *downloaderDB) clear(id string) {
 	db.mtx.Lock()
 	key := path.Join(downloaderErrors, id)
 	db.driver.Delete(downloaderCollection, key)
 	key = path.Join(downloaderTasks, id)
 	db.driver.Delete(downloaderCollection, key)
+	db.errCache[id] = db.errCache[id][:0]
+	db.taskInfoCache[id] = db.taskInfoCache[id][:0]
+	db.mtx.Unlock()
+}
+
+func (db *downloaderDB) delete(id string) {
+	db.clear(id)
+	db.mtx.Lock()
+	key := path.Join(downloaderRuns, id)
+	db.driver.Delete(downloaderCollection, key)
+	delete(db.errCache, id)
+	delete(db.taskInfoCache, id)
 	db.mtx.Unlock()
 }
@@ -147,12 +147,20 @@ func (d *dispatcher) cleanupJob(jobID string) {
 
 // forward request to designated jogger
 func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
+	var (
+		complete bool          // all objects have been listed (and dispatched)
+		objs     cos.StringSet // continuous job: objects present at the source, see DlContinuous.Delete
+	)
 	defer func() {
 		debug.Infof("Waiting for job %q", job.ID())
 		d.waitFor(job.ID())
 		debug.Infof("Job %q finished waiting for all tasks", job.ID())
 		d.cleanupJob(job.ID())
 		debug.Infof("Job %q cleaned up", job.ID())
+		if job.dlBase().Continuous != nil && d.parent.endRun(job, complete) {
+			debug.Infof("Job %q: next run scheduled", job.ID())
+			return
+		}
 		job.cleanup()
 		debug.Infof("Job %q has finished", job.ID())
 	}()
@@ -161,6 +169,9 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 		return !aborted
 	}
 
+	if jInfo, err := dlStore.getJob(job.ID()); err == nil {
+		objs = jInfo.curObjs
+	}
 	diffResolver := NewDiffResolver(nil)
 
 	diffResolver.Start()
@@ -264,6 +275,9 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 					link:       dst.Link,
 					fromRemote: dst.Link == "",
 				}
+				if objs != nil {
+					objs.Add(dst.ObjName)
+				}
 			} else {
 				src := result.Src
 				cos.Assert(result.Action == DiffResolverDelete)
@@ -316,6 +330,7 @@ func (d *dispatcher) dispatchDownload(job DlJob) (ok bool) {
 			cos.Assert(job.Sync())
 		case DiffResolverEOF:
 			dlStore.setAllDispatched(job.ID(), true)
+			complete = !d.checkAbortedJob(job)
 			return true
 		}
 	}
@@ -452,6 +467,7 @@ func (d *dispatcher) handleStatus(req *request) {
 	var (
 		finishedTasks []TaskDlInfo
 		dlErrors      []TaskErrInfo
+		runs          []DlRunInfo
 	)
 
 	jInfo, err := d.parent.checkJob(req)
@@ -473,6 +489,13 @@ func (d *dispatcher) handleStatus(req *request) {
 			return
 		}
 		sort.Sort(TaskErrByName(dlErrors))
+
+		if jInfo.dlb != nil && jInfo.dlb.Continuous != nil {
+			if runs, err = dlStore.getRuns(req.id); err != nil {
+				req.writeErrResp(err, http.StatusInternalServerError)
+				return
+			}
+		}
 	}
 
 	req.writeResp(&DlStatusResp{
@@ -480,6 +503,7 @@ func (d *dispatcher) handleStatus(req *request) {
 		CurrentTasks:  currentTasks,
 		FinishedTasks: finishedTasks,
 		Errs:          dlErrors,
+		Runs:          runs,
 	})
 }
 
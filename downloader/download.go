@@ -6,6 +6,7 @@ package downloader
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"regexp"
@@ -42,6 +43,7 @@ import (
 //   * Download    - to download a new object from a URL
 //   * Abort       - to abort a previously requested download (currently queued or currently downloading)
 //   * Status      - to request the status of a previously requested download
+//   * RetryJob    - to re-run the failed tasks of a finished download job
 // The Download, Abort and Status requests are encapsulated into an internal
 // request object, added to a dispatcher's request queue and then are dispatched by dispatcher
 // to the correct jogger. The remaining operations are private to the Downloader and
@@ -79,6 +81,15 @@ import (
 // from queue (see: put, get). If the task is running, `cancel` function is
 // invoked to abort task's request.
 //
+// ====== Continuous jobs ======
+//
+// When a run of a continuous job completes, the job is not finished - instead, it
+// rewinds and waits for the configured interval to run again (see endRun, nextRun).
+// Each run goes through the same diff as the first one, and so downloads only
+// the objects that are new or have changed at the source. Each completed run
+// is recorded in the job's history (see DlRunInfo). Continuous job finishes only
+// when aborted or upon reaching the maximum number of runs, if specified.
+//
 // ====== Status Updates ======
 //
 // Status updates are made possible by progressReader that overwrites the
@@ -282,6 +293,137 @@ func (d *Downloader) Download(dJob DlJob) (resp interface{}, statusCode int, err
 	d.IncPending()
 	defer d.DecPending()
 	dlStore.setJob(dJob.ID(), dJob)
+	return d.dispatch(dJob)
+}
+
+// RetryJob re-runs (with the same ID) the tasks that have failed in the previous run
+// of a given job, resuming partially downloaded content when possible.
+// The job must not be running; with no failed tasks, it finishes right away.
+func (d *Downloader) RetryJob(id string, n cluster.Notif) (resp interface{}, statusCode int, err error) {
+	d.IncPending()
+	defer d.DecPending()
+	jInfo, err := dlStore.getJob(id)
+	if err != nil {
+		return nil, http.StatusNotFound, cmn.NewErrNotFound("%s: download job %q", d.t, id)
+	}
+	if jInfo.ToDlJobInfo().JobRunning() {
+		return nil, http.StatusBadRequest, fmt.Errorf("download job %q is still running", id)
+	}
+	if jInfo.dlb == nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("download job %q cannot be retried", id)
+	}
+	dJob, err := newRetryDlJob(d.t, jInfo, d)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	errs, err := dlStore.takeErrors(id)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	dJob.addFailed(errs)
+	dJob.AddNotif(n, dJob)
+	dlStore.resetJob(id, len(errs))
+	return d.dispatch(dJob)
+}
+
+// endRun is called upon completion of each run of a continuous job to (optionally)
+// delete the objects that have disappeared at the source, record the run, and
+// schedule the next one. Returns false when the job is done: aborted or max runs reached.
+func (d *Downloader) endRun(job DlJob, complete bool) bool {
+	var (
+		id         = job.ID()
+		cont       = job.dlBase().Continuous
+		deleted    int
+		jInfo, err = dlStore.getJob(id)
+	)
+	if err != nil {
+		return false
+	}
+	aborted := jInfo.Aborted.Load() || d.dispatcher.checkAborted()
+	if jInfo.curObjs != nil && complete && !aborted {
+		if ij, ok := job.(interface{ incomplete() bool }); !ok || !ij.incomplete() {
+			deleted = d.deleteDisappeared(job, jInfo)
+		}
+	}
+	run := dlStore.finishRun(id, deleted)
+	if aborted || (cont.MaxRuns > 0 && run.Run >= cont.MaxRuns) {
+		return false
+	}
+	dlStore.flush(id)
+	interval, err := time.ParseDuration(cont.Interval)
+	debug.AssertNoErr(err)
+	go d.nextRun(job, jInfo, interval)
+	return true
+}
+
+// deletes the objects downloaded (or skipped as unchanged) by the previous run but
+// not found at the source this time - same as user DELETE: from the remote backend as well,
+// and subject to version history and snapshots. Objects that fail to get deleted are kept
+// to be retried by the next run.
+func (d *Downloader) deleteDisappeared(job DlJob, jInfo *downloadJobInfo) (deleted int) {
+	for name := range jInfo.prevObjs {
+		if jInfo.curObjs.Contains(name) {
+			continue
+		}
+		lom := cluster.AllocLOM(name)
+		if err := lom.InitBck(job.Bck()); err != nil {
+			cluster.FreeLOM(lom)
+			glog.Errorf("%s: %v", job, err)
+			return // (keep prevObjs as is)
+		}
+		if errCode, err := d.t.DeleteObject(lom, false /*evict*/); err != nil {
+			if errCode != http.StatusNotFound && !cmn.IsObjNotExist(err) {
+				glog.Errorf("%s: failed to delete %s: %v", job, lom, err)
+				jInfo.curObjs.Add(name)
+			}
+		} else {
+			deleted++
+		}
+		cluster.FreeLOM(lom)
+	}
+	jInfo.prevObjs = jInfo.curObjs
+	return
+}
+
+// waits for the interval to expire (or the job to get aborted) and dispatches
+// the next run via the current (possibly, new) downloader xaction
+func (d *Downloader) nextRun(job DlJob, jInfo *downloadJobInfo, interval time.Duration) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-jInfo.stopWait.Listen():
+		}
+		if jInfo.Aborted.Load() {
+			break
+		}
+		rns := xreg.RenewDownloader(d.t, d.statsT)
+		if rns.Err != nil {
+			glog.Errorf("%s: failed to start the next run: %v", job, rns.Err)
+			break
+		}
+		xdl := rns.Entry.Get().(*Downloader)
+		job.rewind(xdl)
+		dlStore.newRun(job.ID())
+		_, statusCode, _ := xdl.startRun(job)
+		if statusCode == http.StatusOK {
+			return
+		}
+		jInfo.Run.Dec() // (didn't start)
+		glog.Warningf("%s: downloader job queue is full - retrying in %v", job, interval)
+		timer.Reset(interval)
+	}
+	job.cleanup()
+}
+
+func (d *Downloader) startRun(dJob DlJob) (resp interface{}, statusCode int, err error) {
+	d.IncPending()
+	defer d.DecPending()
+	return d.dispatch(dJob)
+}
+
+func (d *Downloader) dispatch(dJob DlJob) (resp interface{}, statusCode int, err error) {
 	select {
 	case d.dispatcher.downloadCh <- dJob:
 		return nil, http.StatusOK, nil
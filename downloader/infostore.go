@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/dbdriver"
 	"github.com/NVIDIA/aistore/hk"
@@ -77,6 +78,15 @@ func (is *infoStore) setJob(id string, job DlJob) {
 		Total:       job.Len(),
 		Description: job.Description(),
 		StartedTime: time.Now(),
+		dlb:         job.dlBase(),
+	}
+	if cont := job.dlBase().Continuous; cont != nil {
+		jInfo.Run.Store(1)
+		jInfo.runStarted.Store(jInfo.StartedTime)
+		jInfo.stopWait = cos.NewStopCh()
+		if cont.Delete {
+			jInfo.curObjs = make(cos.StringSet, 64)
+		}
 	}
 
 	is.Lock()
@@ -129,14 +139,65 @@ func (is *infoStore) setAborted(id string) {
 	jInfo, err := is.getJob(id)
 	debug.AssertNoErr(err)
 	jInfo.Aborted.Store(true)
+	if jInfo.stopWait != nil {
+		jInfo.stopWait.Close()
+	}
 	// NOTE: Don't set `FinishedTime` yet as we are not fully done.
 	//       The job now can be removed but there's no guarantee
 	//       that all tasks have been stopped and all resources were freed.
 }
 
+// continuous job: start the next run from scratch (the previous one is in the runs' history)
+func (is *infoStore) newRun(id string) {
+	jInfo, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	jInfo.FinishedCnt.Store(0)
+	jInfo.ScheduledCnt.Store(0)
+	jInfo.SkippedCnt.Store(0)
+	jInfo.ErrorCnt.Store(0)
+	jInfo.AllDispatched.Store(false)
+	jInfo.Run.Inc()
+	jInfo.runStarted.Store(time.Now())
+	if jInfo.curObjs != nil {
+		jInfo.curObjs = make(cos.StringSet, len(jInfo.prevObjs))
+	}
+	is.downloaderDB.clear(id)
+}
+
+// continuous job: record the completed run
+func (is *infoStore) finishRun(id string, deleted int) (run DlRunInfo) {
+	jInfo, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	run = DlRunInfo{
+		Run:          int(jInfo.Run.Load()),
+		StartedTime:  jInfo.runStarted.Load(),
+		FinishedTime: time.Now(),
+		ScheduledCnt: int(jInfo.ScheduledCnt.Load()),
+		FinishedCnt:  int(jInfo.FinishedCnt.Load()),
+		SkippedCnt:   int(jInfo.SkippedCnt.Load()),
+		ErrorCnt:     int(jInfo.ErrorCnt.Load()),
+		DeletedCnt:   deleted,
+		Aborted:      jInfo.Aborted.Load(),
+	}
+	is.persistRun(id, run)
+	return
+}
+
+// to retry `n` failed tasks (that are no longer counted as errors)
+func (is *infoStore) resetJob(id string, n int) {
+	jInfo, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	jInfo.ErrorCnt.Sub(int32(n))
+	jInfo.ScheduledCnt.Sub(int32(n))
+	jInfo.Aborted.Store(false)
+	jInfo.AllDispatched.Store(false)
+	jInfo.FinishedTime.Store(time.Time{})
+}
+
 func (is *infoStore) delJob(id string) {
 	delete(is.jobInfo, id)
 	is.downloaderDB.delete(id)
+	dlParts.delJob(id)
 }
 
 func (is *infoStore) housekeep() time.Duration {
@@ -144,7 +205,8 @@ func (is *infoStore) housekeep() time.Duration {
 
 	is.Lock()
 	for id, jInfo := range is.jobInfo {
-		if time.Since(jInfo.FinishedTime.Load()) > interval {
+		finished := jInfo.FinishedTime.Load()
+		if !cos.IsTimeZero(finished) && time.Since(finished) > interval {
 			is.delJob(id)
 		}
 	}
@@ -31,12 +31,14 @@ var (
 	_ DlJob = (*sliceDlJob)(nil)
 	_ DlJob = (*backendDlJob)(nil)
 	_ DlJob = (*rangeDlJob)(nil)
+	_ DlJob = (*crawlDlJob)(nil)
 )
 
 type (
 	dlObj struct {
 		objName    string
 		link       string
+		expect     *DlExpected // (manifest) expected size and checksum(s)
 		fromRemote bool
 	}
 
@@ -66,6 +68,18 @@ type (
 		// via tryAcquire and release
 		throttler() *throttler
 
+		// credentials to access link sources (nil if not specified)
+		creds() *DlCreds
+
+		// per-task retries
+		retryPolicy() *retryPolicy
+
+		// as requested (see also: RetryJob)
+		dlBase() *DlBase
+
+		// continuous job: prepare for the next run (by the current downloader xaction)
+		rewind(dlXact *Downloader)
+
 		// job cleanup
 		cleanup()
 	}
@@ -77,6 +91,8 @@ type (
 		description string
 		t           *throttler
 		dlXact      *Downloader
+		dlb         *DlBase
+		retry       *retryPolicy
 
 		// notif
 		notif *NotifDownload
@@ -130,6 +146,15 @@ type (
 
 		StartedTime  time.Time   `json:"started_time"`
 		FinishedTime atomic.Time `json:"finished_time"`
+
+		dlb *DlBase // to retry failed tasks
+
+		// continuous job
+		Run        atomic.Int32 `json:"run"`
+		runStarted atomic.Time
+		stopWait   *cos.StopCh   // closed upon abort - to stop waiting for the next run
+		prevObjs   cos.StringSet // (delete) objects downloaded or skipped by the previous complete run
+		curObjs    cos.StringSet // ... by the current run
 	}
 )
 
@@ -137,14 +162,15 @@ type (
 // baseDlJob //
 ///////////////
 
-func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, timeout, desc string, limits DlLimits, dlXact *Downloader) *baseDlJob {
+func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, base *DlBase, desc string, dlXact *Downloader) *baseDlJob {
+	limits := base.Limits
 	// TODO: this might be inaccurate if we download 1 or 2 objects because then
 	//  other targets will have limits but will not use them.
 	if limits.BytesPerHour > 0 {
 		limits.BytesPerHour /= t.Sowner().Get().CountActiveTargets()
 	}
 
-	td, _ := time.ParseDuration(timeout)
+	td, _ := time.ParseDuration(base.Timeout)
 	return &baseDlJob{
 		id:          id,
 		bck:         bck,
@@ -152,14 +178,19 @@ func newBaseDlJob(t cluster.Target, id string, bck *cluster.Bck, timeout, desc s
 		description: desc,
 		t:           newThrottler(limits),
 		dlXact:      dlXact,
+		dlb:         base,
+		retry:       newRetryPolicy(base.Retry),
 	}
 }
 
-func (j *baseDlJob) ID() string             { return j.id }
-func (j *baseDlJob) Bck() *cmn.Bck          { return j.bck.Bucket() }
-func (j *baseDlJob) Timeout() time.Duration { return j.timeout }
-func (j *baseDlJob) Description() string    { return j.description }
-func (*baseDlJob) Sync() bool               { return false }
+func (j *baseDlJob) ID() string                { return j.id }
+func (j *baseDlJob) Bck() *cmn.Bck             { return j.bck.Bucket() }
+func (j *baseDlJob) Timeout() time.Duration    { return j.timeout }
+func (j *baseDlJob) Description() string       { return j.description }
+func (j *baseDlJob) creds() *DlCreds           { return j.dlb.Creds }
+func (j *baseDlJob) retryPolicy() *retryPolicy { return j.retry }
+func (j *baseDlJob) dlBase() *DlBase           { return j.dlb }
+func (*baseDlJob) Sync() bool                  { return false }
 
 func (j *baseDlJob) String() (s string) {
 	s = fmt.Sprintf("dl-job[%s]-%s", j.ID(), j.Bck())
@@ -194,6 +225,8 @@ func (j *baseDlJob) ActiveStats() (*DlStatusResp, error) {
 func (*baseDlJob) checkObj(string) bool    { debug.Assert(false); return false }
 func (j *baseDlJob) throttler() *throttler { return j.t }
 
+func (j *baseDlJob) rewind(dlXact *Downloader) { j.dlXact = dlXact }
+
 func (j *baseDlJob) cleanup() {
 	j.throttler().stop()
 	err := dlStore.markFinished(j.ID())
@@ -221,6 +254,11 @@ func newSliceDlJob(t cluster.Target, bck *cluster.Bck, base *baseDlJob, objects
 
 func (j *sliceDlJob) Len() int { return len(j.objs) }
 
+func (j *sliceDlJob) rewind(dlXact *Downloader) {
+	j.baseDlJob.rewind(dlXact)
+	j.current = 0
+}
+
 func (j *sliceDlJob) genNext() (objs []dlObj, ok bool, err error) {
 	if j.current == len(j.objs) {
 		return nil, false, nil
@@ -241,7 +279,14 @@ func newMultiDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlMul
 		objs cos.SimpleKVs
 		err  error
 	)
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
+	if len(payload.Manifest) > 0 {
+		dlObjs, err := buildManifestObjs(t, bck, payload.Manifest)
+		if err != nil {
+			return nil, err
+		}
+		return &multiDlJob{&sliceDlJob{baseDlJob: *base, objs: dlObjs}}, nil
+	}
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
@@ -261,7 +306,7 @@ func newSingleDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlSi
 		objs cos.SimpleKVs
 		err  error
 	)
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
 	}
@@ -276,6 +321,24 @@ func (j *singleDlJob) String() (s string) {
 	return "single-" + j.baseDlJob.String()
 }
 
+// (same ID) the job's failed tasks, see RetryJob
+func newRetryDlJob(t cluster.Target, jInfo *downloadJobInfo, dlXact *Downloader) (*sliceDlJob, error) {
+	bck := cluster.CloneBck(&jInfo.dlb.Bck)
+	if err := bck.Init(t.Bowner()); err != nil {
+		return nil, err
+	}
+	dlb := *jInfo.dlb
+	dlb.Continuous = nil // (retry is a one-off)
+	base := newBaseDlJob(t, jInfo.ID, bck, &dlb, jInfo.Description, dlXact)
+	return &sliceDlJob{baseDlJob: *base}, nil
+}
+
+func (j *sliceDlJob) addFailed(errs []TaskErrInfo) {
+	for _, e := range errs {
+		j.objs = append(j.objs, dlObj{objName: e.Name, link: e.Link, expect: e.Expected, fromRemote: e.Link == ""})
+	}
+}
+
 ////////////////
 // rangeDlJob //
 ////////////////
@@ -287,7 +350,7 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlRan
 		return
 	}
 	// TODO: job.Init instead, to avoid copying baseDlJob = *base
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
 	job.count, err = countObjects(t, job.pt, payload.Subdir, base.bck)
 	if err != nil {
 		return
@@ -312,6 +375,12 @@ func (j *rangeDlJob) genNext() ([]dlObj, bool, error) {
 	return j.objs, true, nil
 }
 
+func (j *rangeDlJob) rewind(dlXact *Downloader) {
+	j.baseDlJob.rewind(dlXact)
+	j.pt.InitIter()
+	j.done = false
+}
+
 func (j *rangeDlJob) String() (s string) {
 	return fmt.Sprintf("range-%s-%d-%s", &j.baseDlJob, j.count, j.dir)
 }
@@ -351,7 +420,7 @@ func newBackendDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *DlB
 	} else if bck.IsHTTP() {
 		return nil, errors.New("bucket download does not support HTTP buckets")
 	}
-	base := newBaseDlJob(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, dlXact)
+	base := newBaseDlJob(t, id, bck, &payload.DlBase, payload.Describe(), dlXact)
 	job := &backendDlJob{
 		baseDlJob: *base,
 		t:         t,
@@ -369,6 +438,12 @@ func (j *backendDlJob) String() (s string) {
 	return fmt.Sprintf("backend-%s-%s-%s", &j.baseDlJob, j.prefix, j.suffix)
 }
 
+func (j *backendDlJob) rewind(dlXact *Downloader) {
+	j.baseDlJob.rewind(dlXact)
+	j.continuationToken = ""
+	j.done = false
+}
+
 func (j *backendDlJob) checkObj(objName string) bool {
 	return strings.HasPrefix(objName, j.prefix) && strings.HasSuffix(objName, j.suffix)
 }
@@ -442,6 +517,7 @@ func (d *downloadJobInfo) ToDlJobInfo() DlJobInfo {
 		Aborted:       d.Aborted.Load(),
 		StartedTime:   d.StartedTime,
 		FinishedTime:  d.FinishedTime.Load(),
+		Run:           int(d.Run.Load()),
 	}
 }
 
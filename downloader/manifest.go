@@ -0,0 +1,266 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Download manifest: a list of links, each with an (optional) object name and the expected
+// size and checksum(s) of the content; every downloaded object is verified against its entry.
+// Manifests are accepted in two formats:
+//   * JSONL - one JSON object per line, e.g.:
+//     {"link": "https://host/a.tar", "name": "a.tar", "size": 1024, "sha256": "9f86d0..."}
+//   * CSV - with a header that names the columns (any order, all but `link` optional), e.g.:
+//     link,name,size,md5
+//     https://host/a.tar,a.tar,1024,d41d8c...
+// Checksums are hex-encoded; `sha256` is the standard SHA-256 (FIPS 180-4).
+
+const (
+	ManifestJSONL = "jsonl"
+	ManifestCSV   = "csv"
+
+	// custom metadata key of the verified SHA-256 (MD5 and CRC32C are stored
+	// under cmn.MD5ObjMD and cmn.CRC32CObjMD, respectively)
+	SHA256ObjMD = "sha256"
+)
+
+type (
+	DlManifestEntry struct {
+		Link string `json:"link"`
+		Name string `json:"name,omitempty"` // default: the last element of the link's path
+		DlExpected
+	}
+	// expected size (0 - unspecified) and checksums of the downloaded content
+	DlExpected struct {
+		Size   int64  `json:"size,omitempty"`
+		MD5    string `json:"md5,omitempty"`
+		SHA256 string `json:"sha256,omitempty"`
+		CRC32C string `json:"crc32c,omitempty"`
+	}
+
+	// computes all expected checksums in a single pass
+	verifier struct {
+		exp *DlExpected
+		md5 *cos.CksumHash
+		crc *cos.CksumHash
+		sha hash.Hash
+		ws  []io.Writer
+	}
+)
+
+// ManifestFormat returns manifest format by file name extension.
+func ManifestFormat(fname string) (string, error) {
+	switch ext := strings.ToLower(path.Ext(fname)); ext {
+	case ".jsonl", ".ndjson", ".json":
+		return ManifestJSONL, nil
+	case ".csv":
+		return ManifestCSV, nil
+	default:
+		return "", fmt.Errorf("unknown manifest format %q (expecting .jsonl or .csv)", ext)
+	}
+}
+
+// ParseManifest reads and validates manifest entries.
+func ParseManifest(r io.Reader, format string) (entries []DlManifestEntry, err error) {
+	switch format {
+	case ManifestJSONL:
+		entries, err = parseJSONL(r)
+	case ManifestCSV:
+		entries, err = parseCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown manifest format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("manifest is empty")
+	}
+	for i := range entries {
+		if err := entries[i].Validate(); err != nil {
+			return nil, fmt.Errorf("manifest entry %d: %v", i+1, err)
+		}
+	}
+	return entries, nil
+}
+
+func parseJSONL(r io.Reader) (entries []DlManifestEntry, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*cos.KiB), cos.MiB)
+	for ln := 1; scanner.Scan(); ln++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry DlManifestEntry
+		if err := jsoniter.UnmarshalFromString(line, &entry); err != nil {
+			return nil, fmt.Errorf("manifest line %d: %v", ln, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+func parseCSV(r io.Reader) ([]DlManifestEntry, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest header: %v", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, col := range header {
+		col = strings.ToLower(strings.TrimSpace(col))
+		switch col {
+		case "link", "name", "size", cmn.MD5ObjMD, SHA256ObjMD, cmn.CRC32CObjMD:
+			cols[col] = i
+		default:
+			return nil, fmt.Errorf("manifest header: unknown column %q", col)
+		}
+	}
+	if _, ok := cols["link"]; !ok {
+		return nil, errors.New("manifest header: missing 'link' column")
+	}
+	get := func(record []string, col string) string {
+		if i, ok := cols[col]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var entries []DlManifestEntry
+	for ln := 2; ; ln++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entry := DlManifestEntry{Link: get(record, "link"), Name: get(record, "name")}
+		if size := get(record, "size"); size != "" {
+			if entry.Size, err = strconv.ParseInt(size, 10, 64); err != nil {
+				return nil, fmt.Errorf("manifest line %d: invalid size %q", ln, size)
+			}
+		}
+		entry.MD5, entry.SHA256, entry.CRC32C = get(record, cmn.MD5ObjMD), get(record, SHA256ObjMD), get(record, cmn.CRC32CObjMD)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+/////////////////////
+// DlManifestEntry //
+/////////////////////
+
+func (e *DlManifestEntry) Validate() error {
+	if e.Link == "" {
+		return errors.New("missing link")
+	}
+	if e.Name == "" {
+		e.Name = path.Base(e.Link)
+		if e.Name == "." || e.Name == "/" {
+			return fmt.Errorf("can not extract a valid object name from the link %q", e.Link)
+		}
+	}
+	return e.DlExpected.Validate()
+}
+
+////////////////
+// DlExpected //
+////////////////
+
+func (e *DlExpected) Validate() error {
+	if e.Size < 0 {
+		return fmt.Errorf("invalid size %d", e.Size)
+	}
+	for _, c := range []struct {
+		ty, value string
+		l         int
+	}{
+		{cmn.MD5ObjMD, e.MD5, 2 * 16},
+		{SHA256ObjMD, e.SHA256, 2 * sha256.Size},
+		{cmn.CRC32CObjMD, e.CRC32C, 2 * 4},
+	} {
+		if c.value == "" {
+			continue
+		}
+		if _, err := hex.DecodeString(c.value); err != nil || len(c.value) != c.l {
+			return fmt.Errorf("invalid %s %q (expecting %d hex digits)", c.ty, c.value, c.l)
+		}
+	}
+	return nil
+}
+
+func (e *DlExpected) hasCksum() bool { return e.MD5 != "" || e.SHA256 != "" || e.CRC32C != "" }
+
+//////////////
+// verifier //
+//////////////
+
+func newVerifier(exp *DlExpected) *verifier {
+	v := &verifier{exp: exp}
+	if exp.MD5 != "" {
+		v.md5 = cos.NewCksumHash(cos.ChecksumMD5)
+		v.ws = append(v.ws, v.md5.H)
+	}
+	if exp.CRC32C != "" {
+		v.crc = cos.NewCksumHash(cos.ChecksumCRC32C)
+		v.ws = append(v.ws, v.crc.H)
+	}
+	if exp.SHA256 != "" {
+		v.sha = sha256.New()
+		v.ws = append(v.ws, v.sha)
+	}
+	return v
+}
+
+// returns verified checksums (as custom metadata) or error on mismatch
+func (v *verifier) verify() (md cos.SimpleKVs, err error) {
+	md = make(cos.SimpleKVs, 3)
+	if v.md5 != nil {
+		v.md5.Finalize()
+		if err = v.check(cmn.MD5ObjMD, v.exp.MD5, v.md5.Value()); err != nil {
+			return
+		}
+		md[cmn.MD5ObjMD] = v.md5.Value()
+	}
+	if v.crc != nil {
+		v.crc.Finalize()
+		if err = v.check(cmn.CRC32CObjMD, v.exp.CRC32C, v.crc.Value()); err != nil {
+			return
+		}
+		md[cmn.CRC32CObjMD] = v.crc.Value()
+	}
+	if v.sha != nil {
+		value := hex.EncodeToString(v.sha.Sum(nil))
+		if err = v.check(SHA256ObjMD, v.exp.SHA256, value); err != nil {
+			return
+		}
+		md[SHA256ObjMD] = value
+	}
+	return
+}
+
+func (*verifier) check(ty, expected, actual string) error {
+	if strings.EqualFold(expected, actual) {
+		return nil
+	}
+	return fmt.Errorf("%s checksum mismatch: expected %s, got %s", ty, strings.ToLower(expected), actual)
+}
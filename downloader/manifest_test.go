@@ -0,0 +1,105 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/downloader"
+)
+
+const (
+	testMD5    = "d41d8cd98f00b204e9800998ecf8427e"
+	testSHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		name, format, manifest string
+		expected               []downloader.DlManifestEntry
+	}{
+		{
+			name:   "jsonl",
+			format: downloader.ManifestJSONL,
+			manifest: `{"link": "https://host/dir/a.tar", "size": 10, "md5": "` + testMD5 + `"}
+
+{"link": "https://host/b.tar", "name": "x/b.tar", "sha256": "` + strings.ToUpper(testSHA256) + `", "crc32c": "00000000"}
+`,
+			expected: []downloader.DlManifestEntry{
+				{Link: "https://host/dir/a.tar", Name: "a.tar", DlExpected: downloader.DlExpected{Size: 10, MD5: testMD5}},
+				{Link: "https://host/b.tar", Name: "x/b.tar", DlExpected: downloader.DlExpected{
+					SHA256: strings.ToUpper(testSHA256), CRC32C: "00000000",
+				}},
+			},
+		},
+		{
+			name:   "csv",
+			format: downloader.ManifestCSV,
+			manifest: "Link, size, md5\n" +
+				"https://host/dir/a.tar, 10, " + testMD5 + "\n" +
+				"https://host/b.tar,,\n",
+			expected: []downloader.DlManifestEntry{
+				{Link: "https://host/dir/a.tar", Name: "a.tar", DlExpected: downloader.DlExpected{Size: 10, MD5: testMD5}},
+				{Link: "https://host/b.tar", Name: "b.tar"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries, err := downloader.ParseManifest(strings.NewReader(test.manifest), test.format)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, len(entries) == len(test.expected), "expected %d entries, got %d", len(test.expected), len(entries))
+			for i := range entries {
+				tassert.Errorf(t, entries[i] == test.expected[i], "entry %d: expected %+v, got %+v", i, test.expected[i], entries[i])
+			}
+		})
+	}
+}
+
+func TestParseManifestInvalid(t *testing.T) {
+	tests := []struct {
+		format, manifest string
+	}{
+		{downloader.ManifestJSONL, ""},
+		{downloader.ManifestJSONL, `{"link": "https://host/a.tar"`},
+		{downloader.ManifestJSONL, `{"name": "a.tar"}`},
+		{downloader.ManifestJSONL, `{"link": "https://host/a.tar", "size": -1}`},
+		{downloader.ManifestJSONL, `{"link": "https://host/a.tar", "md5": "xyz"}`},
+		{downloader.ManifestJSONL, `{"link": "https://host/a.tar", "sha256": "` + testMD5 + `"}`},
+		{downloader.ManifestCSV, "name,size\na.tar,10\n"},
+		{downloader.ManifestCSV, "link,etag\nhttps://host/a.tar,123\n"},
+		{downloader.ManifestCSV, "link,size\nhttps://host/a.tar,ten\n"},
+		{downloader.ManifestCSV, "link,size\nhttps://host/a.tar\n"},
+		{"yaml", "link: https://host/a.tar"},
+	}
+	for _, test := range tests {
+		_, err := downloader.ParseManifest(strings.NewReader(test.manifest), test.format)
+		tassert.Errorf(t, err != nil, "%s %q: expected error", test.format, test.manifest)
+	}
+}
+
+func TestDlMultiBodyValidate(t *testing.T) {
+	var (
+		base     = downloader.DlBase{}
+		manifest = []downloader.DlManifestEntry{{Link: "https://host/a.tar", DlExpected: downloader.DlExpected{MD5: testMD5}}}
+	)
+	base.Bck.Name = "bucket"
+	tests := []struct {
+		body  downloader.DlMultiBody
+		valid bool
+	}{
+		{downloader.DlMultiBody{DlBase: base}, false},
+		{downloader.DlMultiBody{DlBase: base, ObjectsPayload: []interface{}{"https://host/a.tar"}}, true},
+		{downloader.DlMultiBody{DlBase: base, Manifest: manifest}, true},
+		{downloader.DlMultiBody{DlBase: base, ObjectsPayload: []interface{}{"https://host/a.tar"}, Manifest: manifest}, false},
+		{downloader.DlMultiBody{DlBase: base, Manifest: []downloader.DlManifestEntry{{Name: "a.tar"}}}, false},
+	}
+	for i, test := range tests {
+		err := test.body.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%d: expected valid=%t, got %v", i, test.valid, err)
+	}
+}
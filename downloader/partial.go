@@ -0,0 +1,151 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Partially downloaded content: each task (object) of a job downloads into its own
+// workfile that outlives failed attempts - and the task itself, if it ultimately fails -
+// so that the next attempt (or "retry failed tasks" action) resumes from where the
+// previous one has stopped, provided the source supports it (see Resumable).
+//
+// The partials are kept in memory and are removed when the task succeeds or the job
+// gets removed; workfiles left behind by a previous run of the target are removed
+// by the space cleanup (as any other old workfiles).
+
+type (
+	partial struct {
+		fqn   string
+		size  int64         // written so far
+		total int64         // as reported by the source (-1 if unknown)
+		md    cos.SimpleKVs // source's custom metadata (version, checksum) to validate resumption
+	}
+	partials struct {
+		m map[string]*partial // (job ID, object name) => partial
+		sync.Mutex
+	}
+)
+
+var dlParts = &partials{m: make(map[string]*partial, 16)}
+
+func partialKey(jobID, objName string) string { return jobID + "|" + objName }
+
+// returns existing partial or a new (empty) one
+func (ps *partials) get(jobID string, lom *cluster.LOM) *partial {
+	key := partialKey(jobID, lom.ObjName)
+	ps.Lock()
+	defer ps.Unlock()
+	if p, ok := ps.m[key]; ok {
+		if finfo, err := os.Stat(p.fqn); err == nil && finfo.Size() >= p.size {
+			return p
+		}
+		delete(ps.m, key) // (e.g., removed by space cleanup)
+	}
+	p := &partial{fqn: fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileDownload), total: -1}
+	ps.m[key] = p
+	return p
+}
+
+// forget the partial; if `rm` is true remove the workfile as well
+func (ps *partials) del(jobID, objName string, rm bool) {
+	key := partialKey(jobID, objName)
+	ps.Lock()
+	p, ok := ps.m[key]
+	delete(ps.m, key)
+	ps.Unlock()
+	if ok && rm {
+		p.remove()
+	}
+}
+
+// remove the partial that has nothing to resume
+func (ps *partials) delEmpty(jobID, objName string) {
+	key := partialKey(jobID, objName)
+	ps.Lock()
+	p, ok := ps.m[key]
+	if ok && p.size == 0 {
+		delete(ps.m, key)
+	} else {
+		ok = false
+	}
+	ps.Unlock()
+	if ok {
+		p.remove()
+	}
+}
+
+func (ps *partials) delJob(jobID string) {
+	prefix := jobID + "|"
+	ps.Lock()
+	for key, p := range ps.m {
+		if strings.HasPrefix(key, prefix) {
+			p.remove()
+			delete(ps.m, key)
+		}
+	}
+	ps.Unlock()
+}
+
+/////////////
+// partial //
+/////////////
+
+// start from scratch
+func (p *partial) reset() {
+	p.size, p.total, p.md = 0, -1, nil
+}
+
+func (p *partial) remove() {
+	if err := cos.RemoveFile(p.fqn); err != nil {
+		glog.Errorf("failed to remove partially downloaded %q: %v", p.fqn, err)
+	}
+}
+
+// open the workfile for writing: truncate when starting from scratch, append otherwise
+func (p *partial) open() (*os.File, error) {
+	if p.size == 0 {
+		return cos.CreateFile(p.fqn)
+	}
+	fh, err := os.OpenFile(p.fqn, os.O_WRONLY, cos.PermRWR)
+	if err != nil {
+		return nil, err
+	}
+	// discard whatever may have been written past the last accounted-for offset
+	if err = fh.Truncate(p.size); err == nil {
+		_, err = fh.Seek(p.size, 0)
+	}
+	if err != nil {
+		fh.Close()
+		return nil, err
+	}
+	return fh, nil
+}
+
+// the source must not have changed since the first attempt
+func (p *partial) matches(oa *cmn.ObjAttrs, total int64) bool {
+	if p.total >= 0 && total >= 0 && p.total != total {
+		return false
+	}
+	for _, key := range []string{cmn.SourceObjMD, cmn.VersionObjMD, cmn.MD5ObjMD, cmn.CRC32CObjMD} {
+		prev, ok := p.md[key]
+		if !ok {
+			continue
+		}
+		if cur, ok := oa.GetCustomKey(key); ok && cur != prev {
+			return false
+		}
+	}
+	return true
+}
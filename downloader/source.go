@@ -0,0 +1,334 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Download sources: each supported link scheme (http(s), s3, gs, ftp, sftp, file) maps to
+// a Source that opens (GET) and inspects (HEAD) the links - with its own credentials
+// (see DlCreds) and its own way to extract object attributes (source, version, checksum).
+
+const fileObjMD = "file"
+
+type (
+	Source interface {
+		// Open returns a reader and the content size (-1 if unknown) and sets
+		// custom metadata of the object-to-be (e.g., remote version and checksum)
+		Open(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (io.ReadCloser, int64, error)
+		// Head is Open without the content (see CompareObjects)
+		Head(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (int64, error)
+	}
+	// Resumable is implemented by sources that can read starting from a given offset
+	// (to resume partially downloaded content, see downloader/partial.go)
+	Resumable interface {
+		// OpenAt returns a reader positioned at the offset and the total content size
+		// (-1 if unknown); ErrNoResume if the link cannot be resumed
+		OpenAt(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder, offset int64) (io.ReadCloser, int64, error)
+	}
+
+	httpSource struct{}
+	fileSource struct{}
+)
+
+// ErrNoResume is returned when (the source of) a given link does not support partial reads.
+var ErrNoResume = errors.New("cannot resume download")
+
+var (
+	srcMu   sync.RWMutex
+	sources = make(map[string]Source, 8)
+)
+
+// interface guard
+var (
+	_ Source    = (*httpSource)(nil)
+	_ Source    = (*fileSource)(nil)
+	_ Resumable = (*httpSource)(nil)
+	_ Resumable = (*fileSource)(nil)
+)
+
+func init() {
+	RegisterSource(&httpSource{}, "http", "https")
+	RegisterSource(&s3Source{}, "s3")
+	RegisterSource(&gsSource{}, "gs")
+	RegisterSource(&ftpSource{}, "ftp")
+	RegisterSource(&sftpSource{}, "sftp")
+	RegisterSource(&fileSource{}, "file")
+}
+
+// RegisterSource adds (or overrides) download source for the given link scheme(s).
+func RegisterSource(src Source, schemes ...string) {
+	srcMu.Lock()
+	for _, scheme := range schemes {
+		sources[strings.ToLower(scheme)] = src
+	}
+	srcMu.Unlock()
+}
+
+func sourceFor(link string) (Source, *url.URL, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, nil, err
+	}
+	srcMu.RLock()
+	src, ok := sources[strings.ToLower(u.Scheme)]
+	srcMu.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported download link %q (scheme %q)", link, u.Scheme)
+	}
+	return src, u, nil
+}
+
+// OpenLink opens a given download link for reading (see Source.Open).
+func OpenLink(ctx context.Context, link string, creds *DlCreds, oah cmn.ObjAttrsHolder) (io.ReadCloser, int64, error) {
+	src, u, err := sourceFor(link)
+	if err != nil {
+		return nil, 0, err
+	}
+	return src.Open(ctx, u, creds, oah)
+}
+
+// OpenLinkAt opens a given download link for reading starting from the offset (see Resumable).
+func OpenLinkAt(ctx context.Context, link string, creds *DlCreds, oah cmn.ObjAttrsHolder,
+	offset int64) (io.ReadCloser, int64, error) {
+	src, u, err := sourceFor(link)
+	if err != nil {
+		return nil, 0, err
+	}
+	rsrc, ok := src.(Resumable)
+	if !ok {
+		return nil, 0, ErrNoResume
+	}
+	return rsrc.OpenAt(ctx, u, creds, oah, offset)
+}
+
+// HeadLink returns the size (-1 if unknown) and sets custom metadata of a given download link.
+func HeadLink(ctx context.Context, link string, creds *DlCreds, oah cmn.ObjAttrsHolder) (int64, error) {
+	src, u, err := sourceFor(link)
+	if err != nil {
+		return 0, err
+	}
+	return src.Head(ctx, u, creds, oah)
+}
+
+// (all sources) status code to indicate a (terminal) failure, see `terminalStatuses`
+func errNotFound(link string, err error) error {
+	return cmn.NewErrHTTP(nil, fmt.Sprintf("%s: %v", link, err), http.StatusNotFound)
+}
+
+func errForbidden(link string, err error) error {
+	return cmn.NewErrHTTP(nil, fmt.Sprintf("%s: %v", link, err), http.StatusForbidden)
+}
+
+// s3:// and gs:// links are served by the respective SDKs (build tags `aws` and `gcp`)
+func errNotBuilt(link, provider string) error {
+	msg := fmt.Sprintf("%s: missing %s-supporting libraries in the build", link, provider)
+	return cmn.NewErrHTTP(nil, msg, http.StatusNotImplemented)
+}
+
+////////////////
+// httpSource //
+////////////////
+
+func (*httpSource) Open(ctx context.Context, u *url.URL, _ *DlCreds, oah cmn.ObjAttrsHolder) (io.ReadCloser, int64, error) {
+	resp, err := doHTTP(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Body, attrsFromLink(u.String(), resp, oah), nil
+}
+
+func (*httpSource) OpenAt(ctx context.Context, u *url.URL, _ *DlCreds, oah cmn.ObjAttrsHolder,
+	offset int64) (io.ReadCloser, int64, error) {
+	resp, total, err := doHTTPAt(ctx, u.String(), nil, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	attrsFromLink(u.String(), resp, oah)
+	return resp.Body, total, nil
+}
+
+func (*httpSource) Head(ctx context.Context, u *url.URL, _ *DlCreds, oah cmn.ObjAttrsHolder) (int64, error) {
+	resp, err := doHTTP(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	cos.Close(resp.Body)
+	return attrsFromLink(u.String(), resp, oah), nil
+}
+
+// (common for http(s), s3, and gs) executes request; returns error if the status is not OK
+func doHTTP(ctx context.Context, method, link string, prep func(req *http.Request) error) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	// Set "User-Agent" header when doing requests to Google Cloud Storage.
+	// This should increase the number of connections to GCS.
+	if cos.IsGoogleStorageURL(req.URL) {
+		req.Header.Add("User-Agent", gcsUA)
+	}
+	if prep != nil {
+		if err := prep(req); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := clientForURL(link).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		cos.DrainReader(resp.Body)
+		resp.Body.Close()
+		return nil, cmn.NewErrHTTP(req, "", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+// GET with "Range: bytes=offset-"; returns the response and the total size (-1 if unknown);
+// ErrNoResume if the server ignores the range (200) or cannot satisfy it (416)
+func doHTTPAt(ctx context.Context, link string, prep func(req *http.Request) error,
+	offset int64) (*http.Response, int64, error) {
+	rangePrep := func(req *http.Request) error {
+		if prep != nil {
+			if err := prep(req); err != nil {
+				return err
+			}
+		}
+		req.Header.Set(cos.HdrRange, fmt.Sprintf("bytes=%d-", offset))
+		return nil
+	}
+	resp, err := doHTTP(ctx, http.MethodGet, link, rangePrep)
+	if err != nil {
+		if herr := cmn.Err2HTTPErr(err); herr != nil && herr.Status == http.StatusRequestedRangeNotSatisfiable {
+			return nil, 0, ErrNoResume
+		}
+		return nil, 0, err
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close() // (not draining the entire content)
+		return nil, 0, ErrNoResume
+	}
+	// Content-Range: bytes <first>-<last>/<total|*>
+	var (
+		total       = int64(-1)
+		cr          = resp.Header.Get(cos.HdrContentRange)
+		rng, t, _   = strings.Cut(strings.TrimPrefix(cr, "bytes "), "/")
+		first, _, _ = strings.Cut(rng, "-")
+	)
+	if start, err := strconv.ParseInt(first, 10, 64); err != nil || start != offset {
+		cos.DrainReader(resp.Body)
+		resp.Body.Close()
+		return nil, 0, ErrNoResume
+	}
+	if n, err := strconv.ParseInt(t, 10, 64); err == nil {
+		total = n
+	}
+	return resp, total, nil
+}
+
+////////////////
+// fileSource //
+////////////////
+
+// file:///path/to/file - must be located in one of the configured `downloader.local_dirs`
+// (e.g., NFS-mounted on all targets)
+func (*fileSource) Open(_ context.Context, u *url.URL, _ *DlCreds, oah cmn.ObjAttrsHolder) (io.ReadCloser, int64, error) {
+	fqn, err := localPath(u)
+	if err != nil {
+		return nil, 0, err
+	}
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, 0, errLocal(u, err)
+	}
+	finfo, err := fh.Stat()
+	if err != nil {
+		fh.Close()
+		return nil, 0, err
+	}
+	if !finfo.Mode().IsRegular() {
+		fh.Close()
+		return nil, 0, errNotFound(u.String(), fmt.Errorf("not a regular file"))
+	}
+	oah.SetCustomKey(cmn.SourceObjMD, fileObjMD)
+	return fh, finfo.Size(), nil
+}
+
+func (src *fileSource) OpenAt(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder,
+	offset int64) (io.ReadCloser, int64, error) {
+	r, size, err := src.Open(ctx, u, creds, oah)
+	if err != nil {
+		return nil, 0, err
+	}
+	fh := r.(*os.File)
+	if offset > size {
+		fh.Close()
+		return nil, 0, ErrNoResume
+	}
+	if _, err := fh.Seek(offset, io.SeekStart); err != nil {
+		fh.Close()
+		return nil, 0, err
+	}
+	return fh, size, nil
+}
+
+func (*fileSource) Head(_ context.Context, u *url.URL, _ *DlCreds, oah cmn.ObjAttrsHolder) (int64, error) {
+	fqn, err := localPath(u)
+	if err != nil {
+		return 0, err
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return 0, errLocal(u, err)
+	}
+	oah.SetCustomKey(cmn.SourceObjMD, fileObjMD)
+	return finfo.Size(), nil
+}
+
+func localPath(u *url.URL) (string, error) {
+	if u.Host != "" && u.Host != "localhost" {
+		return "", fmt.Errorf("%s: expecting file:///absolute/path (local file)", u)
+	}
+	dirs := cmn.GCO.Get().Downloader.LocalDirs
+	if len(dirs) == 0 {
+		return "", errForbidden(u.String(), fmt.Errorf("local downloads are disabled (see downloader.local_dirs)"))
+	}
+	fqn := filepath.Clean(u.Path)
+	if resolved, err := filepath.EvalSymlinks(fqn); err == nil {
+		fqn = resolved
+	}
+	for _, dir := range dirs {
+		if rel, err := filepath.Rel(filepath.Clean(dir), fqn); err == nil && rel != ".." &&
+			!strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fqn, nil
+		}
+	}
+	return "", errForbidden(u.String(), fmt.Errorf("not in any of the downloader.local_dirs %v", dirs))
+}
+
+func errLocal(u *url.URL, err error) error {
+	switch {
+	case os.IsNotExist(err):
+		return errNotFound(u.String(), err)
+	case os.IsPermission(err):
+		return errForbidden(u.String(), err)
+	}
+	return err
+}
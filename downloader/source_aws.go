@@ -0,0 +1,176 @@
+//go:build aws
+
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// s3://bucket/object links (see DlCreds): anonymous unless the keys are provided;
+// can be pointed to S3-compatible storage (path-style addressing).
+
+const s3DefaultRegion = "us-east-1"
+
+type s3Source struct{}
+
+// interface guard
+var (
+	_ Source    = (*s3Source)(nil)
+	_ Resumable = (*s3Source)(nil)
+)
+
+var (
+	s3Mu      sync.Mutex
+	s3Clients = make(map[DlCreds]*s3.S3, 2) // by (the s3 part of) credentials
+)
+
+func (src *s3Source) Open(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (io.ReadCloser, int64, error) {
+	svc, input, err := src.get(u, creds)
+	if err != nil {
+		return nil, 0, err
+	}
+	obj, err := svc.GetObjectWithContext(ctx, input)
+	if err != nil {
+		return nil, 0, s3Err(u, err)
+	}
+	s3ObjAttrs(obj.VersionId, obj.ETag, oah)
+	return obj.Body, aws.Int64Value(obj.ContentLength), nil
+}
+
+func (src *s3Source) OpenAt(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder,
+	offset int64) (io.ReadCloser, int64, error) {
+	svc, input, err := src.get(u, creds)
+	if err != nil {
+		return nil, 0, err
+	}
+	input.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+	obj, err := svc.GetObjectWithContext(ctx, input)
+	if err != nil {
+		var reqErr awserr.RequestFailure
+		if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusRequestedRangeNotSatisfiable {
+			return nil, 0, ErrNoResume
+		}
+		return nil, 0, s3Err(u, err)
+	}
+	// Content-Range: bytes <first>-<last>/<total>
+	var (
+		total       = int64(-1)
+		rng, t, _   = strings.Cut(strings.TrimPrefix(aws.StringValue(obj.ContentRange), "bytes "), "/")
+		first, _, _ = strings.Cut(rng, "-")
+	)
+	if start, err := strconv.ParseInt(first, 10, 64); err != nil || start != offset {
+		obj.Body.Close()
+		return nil, 0, ErrNoResume
+	}
+	if n, err := strconv.ParseInt(t, 10, 64); err == nil {
+		total = n
+	}
+	s3ObjAttrs(obj.VersionId, obj.ETag, oah)
+	return obj.Body, total, nil
+}
+
+func (src *s3Source) Head(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (int64, error) {
+	svc, input, err := src.get(u, creds)
+	if err != nil {
+		return 0, err
+	}
+	obj, err := svc.HeadObjectWithContext(ctx, &s3.HeadObjectInput{Bucket: input.Bucket, Key: input.Key})
+	if err != nil {
+		return 0, s3Err(u, err)
+	}
+	s3ObjAttrs(obj.VersionId, obj.ETag, oah)
+	return aws.Int64Value(obj.ContentLength), nil
+}
+
+func (*s3Source) get(u *url.URL, creds *DlCreds) (*s3.S3, *s3.GetObjectInput, error) {
+	bucket, key := u.Host, strings.TrimPrefix(u.Path, "/")
+	if bucket == "" || key == "" {
+		return nil, nil, fmt.Errorf("invalid link %q (expecting s3://bucket/object)", u)
+	}
+	svc, err := s3Client(creds)
+	if err != nil {
+		return nil, nil, err
+	}
+	return svc, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)}, nil
+}
+
+// S3 clients are safe for concurrent use and get reused for the same credentials
+func s3Client(creds *DlCreds) (*s3.S3, error) {
+	var tag DlCreds
+	if creds != nil {
+		tag = DlCreds{
+			S3AccessKeyID:     creds.S3AccessKeyID,
+			S3SecretAccessKey: creds.S3SecretAccessKey,
+			S3SessionToken:    creds.S3SessionToken,
+			S3Region:          creds.S3Region,
+			S3Endpoint:        creds.S3Endpoint,
+		}
+	}
+	s3Mu.Lock()
+	defer s3Mu.Unlock()
+	if svc, ok := s3Clients[tag]; ok {
+		return svc, nil
+	}
+	conf := aws.NewConfig().
+		WithHTTPClient(cmn.NewClient(cmn.TransportArgs{})).
+		WithRegion(s3DefaultRegion)
+	if tag.S3Region != "" {
+		conf.WithRegion(tag.S3Region)
+	}
+	if tag.S3Endpoint != "" {
+		conf.WithEndpoint(tag.S3Endpoint).WithS3ForcePathStyle(true)
+	}
+	if tag.S3AccessKeyID != "" {
+		conf.WithCredentials(credentials.NewStaticCredentials(tag.S3AccessKeyID, tag.S3SecretAccessKey,
+			tag.S3SessionToken))
+	} else {
+		conf.WithCredentials(credentials.AnonymousCredentials)
+	}
+	sess, err := session.NewSession(conf)
+	if err != nil {
+		return nil, cmn.NewErrFailedTo(apc.ProviderAmazon, "create", "session", err)
+	}
+	svc := s3.New(sess)
+	s3Clients[tag] = svc
+	return svc, nil
+}
+
+func s3ObjAttrs(version, etag *string, oah cmn.ObjAttrsHolder) {
+	h := cmn.BackendHelpers.Amazon
+	oah.SetCustomKey(cmn.SourceObjMD, apc.ProviderAmazon)
+	if v, ok := h.EncodeVersion(version); ok {
+		oah.SetCustomKey(cmn.VersionObjMD, v)
+	}
+	if v, ok := h.EncodeCksum(etag); ok {
+		oah.SetCustomKey(cmn.MD5ObjMD, v)
+	}
+}
+
+// (see `terminalStatuses`)
+func s3Err(u *url.URL, err error) error {
+	var reqErr awserr.RequestFailure
+	if !errors.As(err, &reqErr) {
+		return err
+	}
+	return cmn.NewErrHTTP(nil, fmt.Sprintf("%s: %s", u, reqErr.Code()), reqErr.StatusCode())
+}
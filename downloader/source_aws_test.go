@@ -0,0 +1,61 @@
+//go:build aws
+
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/downloader"
+)
+
+func TestSourceS3(t *testing.T) {
+	var authHdr string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/bucket/dir/obj%20name" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		authHdr = r.Header.Get("Authorization")
+		w.Header().Set(cmn.S3VersionHeader, "v1")
+		w.Header().Set(cmn.S3CksumHeader, `"9e107d9d372bb6826bd81d3542a419d6"`)
+		w.Write([]byte(sourceContent))
+	}))
+	defer srv.Close()
+
+	creds := &downloader.DlCreds{
+		S3AccessKeyID:     "AKID",
+		S3SecretAccessKey: "SECRET",
+		S3Region:          "us-west-2",
+		S3Endpoint:        srv.URL,
+	}
+	tassert.CheckFatal(t, creds.Validate())
+
+	content, oa, err := readLink(t, "s3://bucket/dir/obj name", creds)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == sourceContent, "unexpected content %q", content)
+	tassert.Errorf(t, strings.HasPrefix(authHdr, "AWS4-HMAC-SHA256 Credential=AKID/"), "unexpected auth %q", authHdr)
+	tassert.Errorf(t, strings.Contains(authHdr, "/us-west-2/s3/aws4_request"), "unexpected auth %q", authHdr)
+	src, _ := oa.GetCustomKey(cmn.SourceObjMD)
+	tassert.Errorf(t, src == apc.ProviderAmazon, "unexpected source %q", src)
+	version, _ := oa.GetCustomKey(cmn.VersionObjMD)
+	tassert.Errorf(t, version == "v1", "unexpected version %q", version)
+
+	_, _, err = readLink(t, "s3://bucket/nonexistent", creds)
+	tassert.Errorf(t, cmn.IsStatusNotFound(err), "expected 404, got %v", err)
+
+	// anonymous
+	creds = &downloader.DlCreds{S3Endpoint: srv.URL}
+	_, _, err = readLink(t, "s3://bucket/dir/obj name", creds)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, authHdr == "", "expected no auth, got %q", authHdr)
+}
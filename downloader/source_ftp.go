@@ -0,0 +1,157 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/jlaffaye/ftp"
+)
+
+// ftp://[user[:password]@]host[:port]/path - passive mode, binary; one file per connection
+
+const (
+	ftpObjMD       = "ftp"
+	ftpDefaultPort = "21"
+	ftpAnonymous   = "anonymous"
+)
+
+const (
+	ftpCodeUnavailable = 550 // file unavailable (e.g., not found, no access)
+	ftpCodeNotLoggedIn = 530
+)
+
+type (
+	ftpSource struct{}
+
+	// data connection reader; Close completes the transfer and logs out
+	ftpReader struct {
+		resp *ftp.Response
+		c    *ftp.ServerConn
+	}
+)
+
+// interface guard
+var (
+	_ Source    = (*ftpSource)(nil)
+	_ Resumable = (*ftpSource)(nil)
+)
+
+func (src *ftpSource) Open(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (io.ReadCloser, int64, error) {
+	return src.OpenAt(ctx, u, creds, oah, 0)
+}
+
+// resuming via REST (RFC 3659) - requires SIZE as well, to validate the offset
+func (*ftpSource) OpenAt(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder,
+	offset int64) (io.ReadCloser, int64, error) {
+	c, err := ftpLogin(ctx, u, creds)
+	if err != nil {
+		return nil, 0, err
+	}
+	// best effort - not all servers support SIZE
+	size, err := c.FileSize(u.Path)
+	if err != nil {
+		size = -1
+	}
+	if offset > 0 && (size < 0 || offset > size) {
+		c.Quit()
+		return nil, 0, ErrNoResume
+	}
+	resp, err := c.RetrFrom(u.Path, uint64(offset))
+	if err != nil {
+		c.Quit()
+		var tpErr *textproto.Error
+		if offset > 0 && errors.As(err, &tpErr) && tpErr.Code != ftpCodeUnavailable {
+			return nil, 0, ErrNoResume // REST not supported (or refused)
+		}
+		return nil, 0, ftpErr(u, err)
+	}
+	oah.SetCustomKey(cmn.SourceObjMD, ftpObjMD)
+	return &ftpReader{resp: resp, c: c}, size, nil
+}
+
+func (*ftpSource) Head(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (int64, error) {
+	c, err := ftpLogin(ctx, u, creds)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Quit()
+	size, err := c.FileSize(u.Path)
+	if err != nil {
+		return 0, ftpErr(u, err)
+	}
+	oah.SetCustomKey(cmn.SourceObjMD, ftpObjMD)
+	return size, nil
+}
+
+func ftpLogin(ctx context.Context, u *url.URL, creds *DlCreds) (*ftp.ServerConn, error) {
+	if u.Path == "" || u.Path == "/" {
+		return nil, fmt.Errorf("invalid link %q (expecting ftp://host/path)", u.Redacted())
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), ftpDefaultPort)
+	}
+	// control connection only; the context deadline (if any) applies to the entire session
+	dial := func(network, address string) (net.Conn, error) {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+		return conn, nil
+	}
+	c, err := ftp.Dial(addr, ftp.DialWithContext(ctx), ftp.DialWithDialFunc(dial))
+	if err != nil {
+		return nil, err
+	}
+	user, pass := ftpAnonymous, ftpAnonymous
+	if creds != nil && creds.Username != "" {
+		user, pass = creds.Username, creds.Password
+	} else if u.User != nil {
+		user = u.User.Username()
+		pass, _ = u.User.Password()
+	}
+	if err := c.Login(user, pass); err != nil {
+		c.Quit()
+		return nil, ftpErr(u, fmt.Errorf("login failed: %w", err))
+	}
+	return c, nil
+}
+
+func ftpErr(u *url.URL, err error) error {
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) {
+		switch tpErr.Code {
+		case ftpCodeUnavailable:
+			return errNotFound(u.Redacted(), err)
+		case ftpCodeNotLoggedIn:
+			return errForbidden(u.Redacted(), err)
+		}
+	}
+	return err
+}
+
+///////////////
+// ftpReader //
+///////////////
+
+func (r *ftpReader) Read(p []byte) (int, error) { return r.resp.Read(p) }
+
+func (r *ftpReader) Close() (err error) {
+	err = r.resp.Close() // (226 Transfer complete)
+	r.c.Quit()
+	return
+}
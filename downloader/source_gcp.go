@@ -0,0 +1,151 @@
+//go:build gcp
+
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+// gs://bucket/object links (see DlCreds): anonymous unless the service account key
+// is provided; can be pointed to an alternative (e.g., local stand-in) endpoint.
+
+type gsSource struct{}
+
+// interface guard
+var (
+	_ Source    = (*gsSource)(nil)
+	_ Resumable = (*gsSource)(nil)
+)
+
+var (
+	gsMu      sync.Mutex
+	gsClients = make(map[DlCreds]*storage.Client, 2) // by (the gs part of) credentials
+)
+
+func (src *gsSource) Open(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (io.ReadCloser, int64, error) {
+	return src.OpenAt(ctx, u, creds, oah, 0)
+}
+
+func (src *gsSource) OpenAt(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder,
+	offset int64) (io.ReadCloser, int64, error) {
+	o, err := src.object(u, creds)
+	if err != nil {
+		return nil, 0, err
+	}
+	attrs, err := o.Attrs(ctx)
+	if err != nil {
+		return nil, 0, gsErr(u, err)
+	}
+	if offset > attrs.Size {
+		return nil, 0, ErrNoResume
+	}
+	// read the very generation that's been HEAD-ed
+	rc, err := o.Generation(attrs.Generation).NewRangeReader(ctx, offset, -1)
+	if err != nil {
+		return nil, 0, gsErr(u, err)
+	}
+	gsObjAttrs(attrs, oah)
+	if offset == 0 {
+		return rc, rc.Attrs.Size, nil
+	}
+	return rc, attrs.Size, nil
+}
+
+func (src *gsSource) Head(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (int64, error) {
+	o, err := src.object(u, creds)
+	if err != nil {
+		return 0, err
+	}
+	attrs, err := o.Attrs(ctx)
+	if err != nil {
+		return 0, gsErr(u, err)
+	}
+	gsObjAttrs(attrs, oah)
+	return attrs.Size, nil
+}
+
+func (*gsSource) object(u *url.URL, creds *DlCreds) (*storage.ObjectHandle, error) {
+	bucket, objName := u.Host, strings.TrimPrefix(u.Path, "/")
+	if bucket == "" || objName == "" {
+		return nil, fmt.Errorf("invalid link %q (expecting gs://bucket/object)", u)
+	}
+	client, err := gsClient(creds)
+	if err != nil {
+		return nil, err
+	}
+	return client.Bucket(bucket).Object(objName), nil
+}
+
+// storage clients are safe for concurrent use and get reused for the same credentials
+func gsClient(creds *DlCreds) (*storage.Client, error) {
+	var tag DlCreds
+	if creds != nil {
+		tag = DlCreds{GCSCredentials: creds.GCSCredentials, GCSEndpoint: creds.GCSEndpoint}
+	}
+	gsMu.Lock()
+	defer gsMu.Unlock()
+	if client, ok := gsClients[tag]; ok {
+		return client, nil
+	}
+	opts := []option.ClientOption{option.WithScopes(storage.ScopeReadOnly)}
+	if tag.GCSCredentials != "" {
+		opts = append(opts, option.WithCredentialsJSON([]byte(tag.GCSCredentials)))
+	} else {
+		opts = append(opts, option.WithoutAuthentication())
+	}
+	if tag.GCSEndpoint != "" {
+		opts = append(opts, option.WithEndpoint(strings.TrimSuffix(tag.GCSEndpoint, "/")+"/storage/v1/"))
+	}
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, cmn.NewErrFailedTo(apc.ProviderGoogle, "create", "client", err)
+	}
+	gsClients[tag] = client
+	return client, nil
+}
+
+func gsObjAttrs(attrs *storage.ObjectAttrs, oah cmn.ObjAttrsHolder) {
+	h := cmn.BackendHelpers.Google
+	oah.SetCustomKey(cmn.SourceObjMD, apc.ProviderGoogle)
+	if v, ok := h.EncodeVersion(attrs.Generation); ok {
+		oah.SetCustomKey(cmn.VersionObjMD, v)
+	}
+	if v, ok := h.EncodeCksum(attrs.MD5); ok {
+		oah.SetCustomKey(cmn.MD5ObjMD, v)
+	}
+	if v, ok := h.EncodeCksum(attrs.CRC32C); ok {
+		oah.SetCustomKey(cmn.CRC32CObjMD, v)
+	}
+}
+
+// (see `terminalStatuses`)
+func gsErr(u *url.URL, err error) error {
+	if errors.Is(err, storage.ErrObjectNotExist) || errors.Is(err, storage.ErrBucketNotExist) {
+		return errNotFound(u.String(), err)
+	}
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		if gerr.Code == http.StatusRequestedRangeNotSatisfiable {
+			return ErrNoResume
+		}
+		return cmn.NewErrHTTP(nil, fmt.Sprintf("%s: %v", u, gerr.Message), gerr.Code)
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+//go:build !gcp
+
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+type gsSource struct{}
+
+// interface guard
+var _ Source = (*gsSource)(nil)
+
+func (*gsSource) Open(_ context.Context, u *url.URL, _ *DlCreds, _ cmn.ObjAttrsHolder) (io.ReadCloser, int64, error) {
+	return nil, 0, errNotBuilt(u.String(), apc.ProviderGoogle)
+}
+
+func (*gsSource) Head(_ context.Context, u *url.URL, _ *DlCreds, _ cmn.ObjAttrsHolder) (int64, error) {
+	return 0, errNotBuilt(u.String(), apc.ProviderGoogle)
+}
@@ -0,0 +1,56 @@
+//go:build gcp
+
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/downloader"
+)
+
+func TestSourceGS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/storage/v1/b/bucket/o/obj": // JSON API: object metadata
+			w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+			fmt.Fprintf(w, `{"bucket": "bucket", "name": "obj", "size": "%d", "generation": "1234"}`, len(sourceContent))
+		case "/bucket/obj": // XML API: object content
+			tassert.Errorf(t, r.URL.Query().Get("generation") == "1234", "unexpected generation %q", r.URL.RawQuery)
+			w.Header().Set(cmn.GsVersionHeader, "1234")
+			http.ServeContent(w, r, "obj", time.Time{}, strings.NewReader(sourceContent))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	creds := &downloader.DlCreds{GCSEndpoint: srv.URL}
+	content, oa, err := readLink(t, "gs://bucket/obj", creds)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == sourceContent, "unexpected content %q", content)
+	src, _ := oa.GetCustomKey(cmn.SourceObjMD)
+	tassert.Errorf(t, src == apc.ProviderGoogle, "unexpected source %q", src)
+	version, _ := oa.GetCustomKey(cmn.VersionObjMD)
+	tassert.Errorf(t, version == "1234", "unexpected version %q", version)
+
+	content, total, err := readLinkAt(t, "gs://bucket/obj", creds, 4)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == sourceContent[4:], "unexpected content %q", content)
+	tassert.Errorf(t, total == int64(len(sourceContent)), "unexpected total %d", total)
+
+	_, _, err = readLink(t, "gs://bucket/nonexistent", creds)
+	tassert.Errorf(t, cmn.IsStatusNotFound(err), "expected 404, got %v", err)
+}
@@ -0,0 +1,198 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// sftp://[user[:password]@]host[:port]/path - SSH File Transfer Protocol, read-only
+
+const (
+	sftpObjMD       = "sftp"
+	sftpDefaultPort = "22"
+)
+
+type (
+	sftpSource struct{}
+
+	sftpClient struct {
+		client *ssh.Client
+		sc     *sftp.Client
+	}
+	// remote file reader; Close closes the file and the session
+	sftpReader struct {
+		f *sftp.File
+		c *sftpClient
+	}
+)
+
+// interface guard
+var (
+	_ Source    = (*sftpSource)(nil)
+	_ Resumable = (*sftpSource)(nil)
+)
+
+func (src *sftpSource) Open(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (io.ReadCloser, int64, error) {
+	return src.OpenAt(ctx, u, creds, oah, 0)
+}
+
+func (*sftpSource) OpenAt(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder,
+	offset int64) (io.ReadCloser, int64, error) {
+	c, err := sftpConnect(ctx, u, creds)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := c.sc.Open(u.Path)
+	if err != nil {
+		c.close()
+		return nil, 0, sftpErr(u, err)
+	}
+	finfo, err := f.Stat()
+	if err != nil {
+		f.Close()
+		c.close()
+		return nil, 0, sftpErr(u, err)
+	}
+	size := finfo.Size()
+	if offset > size {
+		f.Close()
+		c.close()
+		return nil, 0, ErrNoResume
+	}
+	if offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			f.Close()
+			c.close()
+			return nil, 0, ErrNoResume
+		}
+	}
+	oah.SetCustomKey(cmn.SourceObjMD, sftpObjMD)
+	return &sftpReader{f: f, c: c}, size, nil
+}
+
+func (*sftpSource) Head(ctx context.Context, u *url.URL, creds *DlCreds, oah cmn.ObjAttrsHolder) (int64, error) {
+	c, err := sftpConnect(ctx, u, creds)
+	if err != nil {
+		return 0, err
+	}
+	defer c.close()
+	finfo, err := c.sc.Stat(u.Path)
+	if err != nil {
+		return 0, sftpErr(u, err)
+	}
+	oah.SetCustomKey(cmn.SourceObjMD, sftpObjMD)
+	return finfo.Size(), nil
+}
+
+func sftpConfig(u *url.URL, creds *DlCreds) (*ssh.ClientConfig, error) {
+	if creds == nil {
+		creds = &DlCreds{}
+	}
+	config := &ssh.ClientConfig{}
+	password := creds.Password
+	if creds.Username != "" {
+		config.User = creds.Username
+	} else if u.User != nil {
+		config.User = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	if config.User == "" {
+		return nil, fmt.Errorf("%s: username required", u.Redacted())
+	}
+	if creds.SSHPrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(creds.SSHPrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'creds.ssh_private_key': %v", err)
+		}
+		config.Auth = append(config.Auth, ssh.PublicKeys(signer))
+	}
+	if password != "" {
+		config.Auth = append(config.Auth, ssh.Password(password))
+	}
+	switch {
+	case creds.SSHHostKey != "":
+		hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(creds.SSHHostKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'creds.ssh_host_key': %v", err)
+		}
+		config.HostKeyCallback = ssh.FixedHostKey(hostKey)
+	case creds.SSHInsecure:
+		config.HostKeyCallback = ssh.InsecureIgnoreHostKey() //nolint:gosec // explicitly requested
+	default:
+		return nil, errForbidden(u.Redacted(), errors.New("host key required ('creds.ssh_host_key')"))
+	}
+	return config, nil
+}
+
+func sftpConnect(ctx context.Context, u *url.URL, creds *DlCreds) (*sftpClient, error) {
+	if u.Path == "" || u.Path == "/" {
+		return nil, fmt.Errorf("invalid link %q (expecting sftp://host/path)", u.Redacted())
+	}
+	config, err := sftpConfig(u, creds)
+	if err != nil {
+		return nil, err
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), sftpDefaultPort)
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	sconn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		conn.Close()
+		return nil, errForbidden(u.Redacted(), err)
+	}
+	c := &sftpClient{client: ssh.NewClient(sconn, chans, reqs)}
+	if c.sc, err = sftp.NewClient(c.client); err != nil {
+		c.client.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *sftpClient) close() {
+	c.sc.Close()
+	c.client.Close()
+}
+
+func sftpErr(u *url.URL, err error) error {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return errNotFound(u.Redacted(), err)
+	case errors.Is(err, os.ErrPermission):
+		return errForbidden(u.Redacted(), err)
+	}
+	return err
+}
+
+////////////////
+// sftpReader //
+////////////////
+
+func (r *sftpReader) Read(p []byte) (int, error) { return r.f.Read(p) }
+
+func (r *sftpReader) Close() (err error) {
+	err = r.f.Close()
+	r.c.close()
+	return
+}
@@ -0,0 +1,280 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/downloader"
+)
+
+const sourceContent = "the quick brown fox jumps over the lazy dog"
+
+func readLink(t *testing.T, link string, creds *downloader.DlCreds) (string, *cmn.ObjAttrs, error) {
+	oa := &cmn.ObjAttrs{}
+	r, size, err := downloader.OpenLink(context.Background(), link, creds, oa)
+	if err != nil {
+		return "", oa, err
+	}
+	b, err := io.ReadAll(r)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, r.Close())
+	if size >= 0 {
+		tassert.Errorf(t, size == int64(len(b)), "expected size %d, got %d", len(b), size)
+	}
+	return string(b), oa, nil
+}
+
+func readLinkAt(t *testing.T, link string, creds *downloader.DlCreds, offset int64) (string, int64, error) {
+	r, total, err := downloader.OpenLinkAt(context.Background(), link, creds, &cmn.ObjAttrs{}, offset)
+	if err != nil {
+		return "", 0, err
+	}
+	b, err := io.ReadAll(r)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, r.Close())
+	return string(b), total, nil
+}
+
+func isForbidden(err error) bool {
+	herr := cmn.Err2HTTPErr(err)
+	return herr != nil && herr.Status == http.StatusForbidden
+}
+
+func setLocalDirs(dirs ...string) {
+	config := cmn.GCO.BeginUpdate()
+	config.Downloader.LocalDirs = dirs
+	cmn.GCO.CommitUpdate(config)
+}
+
+func TestSourceUnsupportedScheme(t *testing.T) {
+	_, _, err := downloader.OpenLink(context.Background(), "gopher://host/file", nil, &cmn.ObjAttrs{})
+	tassert.Errorf(t, err != nil, "expected error for unsupported scheme")
+}
+
+func TestSourceFile(t *testing.T) {
+	var (
+		allowed = t.TempDir()
+		other   = t.TempDir()
+		fqn     = filepath.Join(allowed, "dir", "obj")
+	)
+	tassert.CheckFatal(t, os.MkdirAll(filepath.Dir(fqn), 0o755))
+	tassert.CheckFatal(t, os.WriteFile(fqn, []byte(sourceContent), 0o644))
+	tassert.CheckFatal(t, os.WriteFile(filepath.Join(other, "obj"), []byte(sourceContent), 0o644))
+
+	defer setLocalDirs()
+
+	// disabled by default
+	setLocalDirs()
+	_, _, err := readLink(t, "file://"+fqn, nil)
+	tassert.Errorf(t, isForbidden(err), "expected 403, got %v", err)
+
+	setLocalDirs(allowed)
+	content, oa, err := readLink(t, "file://"+fqn, nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == sourceContent, "unexpected content %q", content)
+	src, _ := oa.GetCustomKey(cmn.SourceObjMD)
+	tassert.Errorf(t, src == "file", "unexpected source %q", src)
+
+	size, err := downloader.HeadLink(context.Background(), "file://"+fqn, nil, &cmn.ObjAttrs{})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, size == int64(len(sourceContent)), "unexpected size %d", size)
+
+	content, total, err := readLinkAt(t, "file://"+fqn, nil, 10)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == sourceContent[10:], "unexpected content %q", content)
+	tassert.Errorf(t, total == int64(len(sourceContent)), "unexpected total %d", total)
+
+	// outside of local_dirs, including via ".." and symlinks
+	for _, link := range []string{
+		"file://" + filepath.Join(other, "obj"),
+		"file://" + allowed + "/../" + filepath.Base(other) + "/obj",
+	} {
+		_, _, err = readLink(t, link, nil)
+		tassert.Errorf(t, isForbidden(err), "%s: expected 403, got %v", link, err)
+	}
+	tassert.CheckFatal(t, os.Symlink(filepath.Join(other, "obj"), filepath.Join(allowed, "link")))
+	_, _, err = readLink(t, "file://"+filepath.Join(allowed, "link"), nil)
+	tassert.Errorf(t, isForbidden(err), "symlink: expected 403, got %v", err)
+
+	_, _, err = readLink(t, "file://"+filepath.Join(allowed, "nonexistent"), nil)
+	tassert.Errorf(t, cmn.IsStatusNotFound(err), "expected 404, got %v", err)
+}
+
+func TestDlCredsValidate(t *testing.T) {
+	err := (&downloader.DlCreds{GCSCredentials: `{"client_email": "x"}`}).Validate()
+	tassert.Errorf(t, err != nil, "expected invalid service account key")
+	err = (&downloader.DlCreds{S3SecretAccessKey: "SECRET"}).Validate()
+	tassert.Errorf(t, err != nil, "expected error: secret key without access key")
+}
+
+// minimal single-session FTP server serving `sourceContent` as /data/obj
+func fakeFTPServer(t *testing.T) (addr string, stop func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	tassert.CheckFatal(t, err)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveFTP(conn)
+		}
+	}()
+	return ln.Addr().String(), func() { ln.Close() }
+}
+
+func serveFTP(conn net.Conn) {
+	defer conn.Close()
+	var (
+		r      = bufio.NewReader(conn)
+		dataLn net.Listener
+		offset int
+	)
+	reply := func(format string, a ...interface{}) { fmt.Fprintf(conn, format+"\r\n", a...) }
+	reply("220 ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch cmd {
+		case "USER":
+			reply("331 password required")
+		case "PASS":
+			if arg != "secret" {
+				reply("530 login incorrect")
+				continue
+			}
+			reply("230 logged in")
+		case "TYPE":
+			reply("200 ok")
+		case "SIZE":
+			if arg != "/data/obj" {
+				reply("550 no such file")
+				continue
+			}
+			reply("213 %d", len(sourceContent))
+		case "REST":
+			offset, _ = strconv.Atoi(arg)
+			reply("350 restarting at %d", offset)
+		case "EPSV":
+			dataLn, _ = net.Listen("tcp", "127.0.0.1:0")
+			reply("229 Entering Extended Passive Mode (|||%d|)", dataLn.Addr().(*net.TCPAddr).Port)
+		case "RETR":
+			if arg != "/data/obj" {
+				dataLn.Close()
+				reply("550 no such file")
+				continue
+			}
+			reply("150 opening data connection")
+			data, err := dataLn.Accept()
+			dataLn.Close()
+			if err != nil {
+				return
+			}
+			data.Write([]byte(sourceContent[offset:]))
+			data.Close()
+			offset = 0
+			reply("226 transfer complete")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func TestSourceFTP(t *testing.T) {
+	addr, stop := fakeFTPServer(t)
+	defer stop()
+
+	content, oa, err := readLink(t, "ftp://user:secret@"+addr+"/data/obj", nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == sourceContent, "unexpected content %q", content)
+	src, _ := oa.GetCustomKey(cmn.SourceObjMD)
+	tassert.Errorf(t, src == "ftp", "unexpected source %q", src)
+
+	// credentials take precedence over the link's
+	creds := &downloader.DlCreds{Username: "user", Password: "secret"}
+	size, err := downloader.HeadLink(context.Background(), "ftp://"+addr+"/data/obj", creds, &cmn.ObjAttrs{})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, size == int64(len(sourceContent)), "unexpected size %d", size)
+
+	content, total, err := readLinkAt(t, "ftp://user:secret@"+addr+"/data/obj", nil, 4)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == sourceContent[4:], "unexpected content %q", content)
+	tassert.Errorf(t, total == int64(len(sourceContent)), "unexpected total %d", total)
+
+	_, _, err = readLink(t, "ftp://user:secret@"+addr+"/data/nonexistent", nil)
+	tassert.Errorf(t, cmn.IsStatusNotFound(err), "expected 404, got %v", err)
+
+	_, _, err = readLink(t, "ftp://user:wrong@"+addr+"/data/obj", nil)
+	tassert.Errorf(t, isForbidden(err), "expected 403, got %v", err)
+}
+
+func TestSourceHTTPRange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ranged":
+			http.ServeContent(w, r, "obj", time.Time{}, strings.NewReader(sourceContent))
+		case "/plain": // ignores "Range"
+			w.Write([]byte(sourceContent))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	content, total, err := readLinkAt(t, srv.URL+"/ranged", nil, 16)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == sourceContent[16:], "unexpected content %q", content)
+	tassert.Errorf(t, total == int64(len(sourceContent)), "unexpected total %d", total)
+
+	// past the end (416)
+	_, _, err = readLinkAt(t, srv.URL+"/ranged", nil, int64(len(sourceContent)+1))
+	tassert.Errorf(t, errors.Is(err, downloader.ErrNoResume), "expected ErrNoResume, got %v", err)
+
+	_, _, err = readLinkAt(t, srv.URL+"/plain", nil, 16)
+	tassert.Errorf(t, errors.Is(err, downloader.ErrNoResume), "expected ErrNoResume, got %v", err)
+
+	_, _, err = readLinkAt(t, srv.URL+"/nonexistent", nil, 16)
+	tassert.Errorf(t, cmn.IsStatusNotFound(err), "expected 404, got %v", err)
+}
+
+func TestDlRetryValidate(t *testing.T) {
+	tests := []struct {
+		retry downloader.DlRetry
+		valid bool
+	}{
+		{downloader.DlRetry{}, true},
+		{downloader.DlRetry{MaxAttempts: 3, Backoff: "1s", MaxBackoff: "30s", RetryCodes: []int{429, 503}}, true},
+		{downloader.DlRetry{MaxAttempts: -1}, false},
+		{downloader.DlRetry{Backoff: "1"}, false},
+		{downloader.DlRetry{MaxBackoff: "-1s"}, false},
+		{downloader.DlRetry{RetryCodes: []int{200}}, false},
+	}
+	for _, test := range tests {
+		err := test.retry.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got %v", test.retry, test.valid, err)
+	}
+}
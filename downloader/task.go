@@ -27,9 +27,11 @@ const (
 )
 
 const (
-	retryCnt         = 10  // number of retries to external resource
+	retryCnt         = 10  // number of retries to external resource (default, see DlRetry)
 	reqTimeoutFactor = 1.2 // newTimeout = prevTimeout * reqTimeoutFactor
 	internalErrorMsg = "internal server error"
+
+	dfltMaxBackoff = time.Minute
 )
 
 // List of HTTP status codes on which we should
@@ -43,9 +45,18 @@ var terminalStatuses = map[int]struct{}{
 	http.StatusNotAcceptable:     {},
 	http.StatusProxyAuthRequired: {},
 	http.StatusGone:              {},
+	http.StatusNotImplemented:    {},
 }
 
 type (
+	// (parsed and validated DlRetry)
+	retryPolicy struct {
+		codes      map[int]struct{} // nil: retry all but `terminalStatuses`
+		attempts   int
+		backoff    time.Duration
+		maxBackoff time.Duration
+	}
+
 	singleObjectTask struct {
 		parent *Downloader
 		job    DlJob
@@ -112,43 +123,129 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 	ctx, cancel := context.WithTimeout(t.downloadCtx, timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.obj.link, http.NoBody)
+	var (
+		body io.ReadCloser
+		size int64
+		err  error
+		oa   = &cmn.ObjAttrs{}
+		part = dlParts.get(t.jobID(), lom)
+	)
+	if part.size > 0 {
+		body, size, err = OpenLinkAt(ctx, t.obj.link, t.job.creds(), oa, part.size)
+		switch {
+		case errors.Is(err, ErrNoResume):
+			glog.Warningf("%s: cannot resume at offset %d - restarting from scratch", t, part.size)
+			part.reset()
+		case err != nil:
+			return false, err
+		case !part.matches(oa, size):
+			cos.Close(body)
+			glog.Warningf("%s: source has changed - restarting from scratch", t)
+			part.reset()
+		default:
+			glog.Infof("%s: resuming at offset %d", t, part.size)
+			if part.total < 0 {
+				part.total = size
+			}
+		}
+	}
+	if part.size == 0 {
+		oa = &cmn.ObjAttrs{}
+		if body, size, err = OpenLink(ctx, t.obj.link, t.job.creds(), oa); err != nil {
+			return false, err
+		}
+		part.md, part.total = oa.GetCustomMD(), size
+	}
+	defer cos.Close(body)
+
+	t.currentSize.Store(part.size)
+	t.setTotalSize(part.total)
+
+	fh, err := part.open()
 	if err != nil {
 		return true, err
 	}
-
-	// Set "User-Agent" header when doing requests to Google Cloud Storage.
-	// This should increase the number of connections to GCS.
-	if cos.IsGoogleStorageURL(req.URL) {
-		req.Header.Add("User-Agent", gcsUA)
+	n, err := io.Copy(fh, t.wrapReader(ctx, body))
+	part.size += n
+	if errClose := fh.Close(); err == nil {
+		err = errClose
 	}
-
-	resp, err := clientForURL(t.obj.link).Do(req)
 	if err != nil {
 		return false, err
 	}
-	defer cos.Close(resp.Body)
-
-	if resp.StatusCode >= http.StatusBadRequest {
-		return false, cmn.NewErrHTTP(req, "", resp.StatusCode)
+	if part.total >= 0 && part.size != part.total {
+		err = fmt.Errorf("%s: %w (got %d, expected %d)", t, io.ErrUnexpectedEOF, part.size, part.total)
+		if part.size > part.total {
+			part.reset()
+		}
+		return false, err
 	}
+	return t.finalize(lom, part)
+}
 
-	r := t.wrapReader(ctx, resp.Body)
-	size := attrsFromLink(t.obj.link, resp, lom)
-	t.setTotalSize(size)
+// workfile => object
+func (t *singleObjectTask) finalize(lom *cluster.LOM, part *partial) (bool /*err is fatal*/, error) {
+	for k, v := range part.md {
+		lom.SetCustomKey(k, v)
+	}
+	lom.SetSize(part.size)
+	lom.SetAtimeUnix(t.started.Load().UnixNano())
 
-	params := cluster.AllocPutObjParams()
-	{
-		params.WorkTag = "dl"
-		params.Reader = r
-		params.OWT = cmn.OwtPut
-		params.Atime = t.started.Load()
-		params.Xact = t.parent
+	// compute bucket checksum and verify the expected ones (if any) - in one pass
+	var (
+		cksum     = cos.NewCksum(cos.ChecksumNone, "")
+		cksumHash *cos.CksumHash
+		v         *verifier
+		ws        []io.Writer
+	)
+	if exp := t.obj.expect; exp != nil {
+		if exp.Size > 0 && exp.Size != part.size {
+			dlParts.del(t.jobID(), lom.ObjName, true /*rm*/)
+			return true, fmt.Errorf("%s: size mismatch: expected %d, got %d", t, exp.Size, part.size)
+		}
+		if exp.hasCksum() {
+			v = newVerifier(exp)
+			ws = append(ws, v.ws...)
+		}
+	}
+	if ty := lom.CksumType(); ty != cos.ChecksumNone {
+		cksumHash = cos.NewCksumHash(ty)
+		ws = append(ws, cksumHash.H)
+	}
+	if len(ws) > 0 {
+		fh, err := os.Open(part.fqn)
+		if err != nil {
+			return true, err
+		}
+		_, err = io.Copy(cos.NewWriterMulti(ws...), fh)
+		cos.Close(fh)
+		if err != nil {
+			return true, err
+		}
+	}
+	if cksumHash != nil {
+		cksumHash.Finalize()
+		cksum = cksumHash.Clone()
+	}
+	lom.SetCksum(cksum)
+	if v != nil {
+		verified, err := v.verify()
+		if err != nil {
+			dlParts.del(t.jobID(), lom.ObjName, true /*rm*/)
+			return true, fmt.Errorf("%s: %v", t, err)
+		}
+		// (the one that matches bucket checksum type is already stored as the object's checksum)
+		for ty, value := range verified {
+			if ty != cksum.Type() {
+				lom.SetCustomKey(ty, value)
+			}
+		}
 	}
-	erp := t.parent.t.PutObject(lom, params)
-	cluster.FreePutObjParams(params)
-	if erp != nil {
-		return true, erp
+
+	_, err := t.parent.t.FinalizeObj(lom, part.fqn, t.parent)
+	dlParts.del(t.jobID(), lom.ObjName, false /*rm: renamed or, upon failure, removed*/)
+	if err != nil {
+		return true, err
 	}
 	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
 		return true, err
@@ -159,33 +256,44 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 func (t *singleObjectTask) downloadLocal(lom *cluster.LOM) (err error) {
 	var (
 		timeout = t.initialTimeout()
+		policy  = t.job.retryPolicy()
 		fatal   bool
 	)
-	for i := 0; i < retryCnt; i++ {
+	defer func() {
+		if err != nil {
+			dlParts.delEmpty(t.jobID(), lom.ObjName)
+		}
+	}()
+	for i := 0; i < policy.attempts; i++ {
+		if i > 0 {
+			if err := t.sleep(policy.delay(i)); err != nil {
+				return err
+			}
+		}
 		fatal, err = t.tryDownloadLocal(lom, timeout)
 		if err == nil || fatal {
 			return err
 		}
-		if errors.Is(err, context.Canceled) || errors.Is(err, errThrottlerStopped) {
+		if errors.Is(err, context.Canceled) || errors.Is(err, errThrottlerStopped) || t.downloadCtx.Err() != nil {
 			// Download was canceled or stopped, so just return.
 			return err
 		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			glog.Warningf("%s [retries: %d/%d]: timeout (%v) - increasing and retrying...",
-				t, i, retryCnt, timeout)
+				t, i, policy.attempts, timeout)
 			timeout = time.Duration(float64(timeout) * reqTimeoutFactor)
 		} else if httpErr := cmn.Err2HTTPErr(err); httpErr != nil {
-			glog.Warningf("%s [retries: %d/%d]: failed to perform request: %v (code: %d)", t, i, retryCnt, err,
+			glog.Warningf("%s [retries: %d/%d]: failed to perform request: %v (code: %d)", t, i, policy.attempts, err,
 				httpErr.Status)
-			if _, exists := terminalStatuses[httpErr.Status]; exists {
+			if !policy.retryable(httpErr.Status) {
 				// Nothing we can do...
 				return err
 			}
 			// Otherwise retry...
 		} else if cos.IsRetriableConnErr(err) {
-			glog.Warningf("%s [retries: %d/%d]: connection failed with (%v), retrying...", t, i, retryCnt, err)
+			glog.Warningf("%s [retries: %d/%d]: connection failed with (%v), retrying...", t, i, policy.attempts, err)
 		} else {
-			glog.Warningf("%s [retries: %d/%d]: unexpected error (%v), retrying...", t, i, retryCnt, err)
+			glog.Warningf("%s [retries: %d/%d]: unexpected error (%v), retrying...", t, i, policy.attempts, err)
 		}
 
 		t.reset()
@@ -193,6 +301,21 @@ func (t *singleObjectTask) downloadLocal(lom *cluster.LOM) (err error) {
 	return err
 }
 
+// wait before retrying, unless aborted
+func (t *singleObjectTask) sleep(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-t.downloadCtx.Done():
+		return t.downloadCtx.Err()
+	}
+}
+
 func (t *singleObjectTask) wrapReader(ctx context.Context, r io.ReadCloser) io.ReadCloser {
 	// Create a custom reader to monitor progress every time we read from response body stream.
 	r = &progressReader{
@@ -245,7 +368,7 @@ func (t *singleObjectTask) initialTimeout() time.Duration {
 func (t *singleObjectTask) markFailed(statusMsg string) {
 	t.parent.statsT.Add(stats.ErrDownloadCount, 1)
 
-	dlStore.persistError(t.jobID(), t.obj.objName, statusMsg)
+	dlStore.persistError(t.jobID(), TaskErrInfo{Name: t.obj.objName, Err: statusMsg, Link: t.obj.link, Expected: t.obj.expect})
 	dlStore.incErrorCnt(t.jobID())
 }
 
@@ -278,3 +401,59 @@ func (t *singleObjectTask) String() (str string) {
 		t.jobID(), t.obj.objName, t.obj.link, t.obj.fromRemote, t.job.Bck(),
 	)
 }
+
+/////////////////
+// retryPolicy //
+/////////////////
+
+func newRetryPolicy(r *DlRetry) *retryPolicy {
+	p := &retryPolicy{attempts: retryCnt, maxBackoff: dfltMaxBackoff}
+	if r == nil {
+		return p
+	}
+	if r.MaxAttempts > 0 {
+		p.attempts = r.MaxAttempts
+	}
+	// (validated, see DlRetry.Validate)
+	p.backoff, _ = parseBackoff(r.Backoff, 0)
+	p.maxBackoff, _ = parseBackoff(r.MaxBackoff, dfltMaxBackoff)
+	if len(r.RetryCodes) > 0 {
+		p.codes = make(map[int]struct{}, len(r.RetryCodes))
+		for _, code := range r.RetryCodes {
+			p.codes[code] = struct{}{}
+		}
+	}
+	return p
+}
+
+func parseBackoff(s string, dflt time.Duration) (time.Duration, error) {
+	if s == "" {
+		return dflt, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err == nil && d < 0 {
+		err = fmt.Errorf("negative duration %q", s)
+	}
+	return d, err
+}
+
+func (p *retryPolicy) retryable(status int) bool {
+	if p.codes == nil {
+		_, terminal := terminalStatuses[status]
+		return !terminal
+	}
+	_, ok := p.codes[status]
+	return ok
+}
+
+// exponential: backoff, 2*backoff, 4*backoff, ... (up to maxBackoff)
+func (p *retryPolicy) delay(retry int) time.Duration {
+	d := p.backoff
+	for i := 1; i < retry && d < p.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.maxBackoff {
+		d = p.maxBackoff
+	}
+	return d
+}
@@ -71,6 +71,31 @@ func buildDlObjs(t cluster.Target, bck *cluster.Bck, objects cos.SimpleKVs) ([]d
 	return objs, nil
 }
 
+// buildManifestObjs is buildDlObjs for the manifest entries (with expected sizes and checksums).
+func buildManifestObjs(t cluster.Target, bck *cluster.Bck, entries []DlManifestEntry) ([]dlObj, error) {
+	var (
+		smap = t.Sowner().Get()
+		sid  = t.SID()
+		objs = make([]dlObj, 0, len(entries))
+	)
+	for i := range entries {
+		entry := &entries[i]
+		obj, err := makeDlObj(smap, sid, bck, entry.Name, entry.Link)
+		if err != nil {
+			if err == errInvalidTarget {
+				continue
+			}
+			return nil, err
+		}
+		if entry.Size > 0 || entry.hasCksum() {
+			exp := entry.DlExpected
+			obj.expect = &exp
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
 func makeDlObj(smap *cluster.Smap, sid string, bck *cluster.Bck, objName, link string) (dlObj, error) {
 	objName, err := NormalizeObjName(objName)
 	if err != nil {
@@ -85,10 +110,16 @@ func makeDlObj(smap *cluster.Smap, sid string, bck *cluster.Bck, objName, link s
 		return dlObj{}, errInvalidTarget
 	}
 
+	// Make sure that link contains protocol (absence of protocol can result in errors).
+	link = cmn.PrependProtocol(link)
+	if link != "" {
+		if _, _, err := sourceFor(link); err != nil {
+			return dlObj{}, err
+		}
+	}
 	return dlObj{
-		objName: objName,
-		// Make sure that link contains protocol (absence of protocol can result in errors).
-		link:       cmn.PrependProtocol(link),
+		objName:    objName,
+		link:       link,
 		fromRemote: link == "",
 	}, nil
 }
@@ -150,8 +181,18 @@ func ParseStartDownloadRequest(t cluster.Target, bck *cluster.Bck, id string, dl
 			return nil, err
 		}
 		return newSingleDlJob(t, id, bck, dp, dlXact)
+	case DlTypeCrawl:
+		dp := &DlCrawlBody{}
+		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
+		if err != nil {
+			return nil, err
+		}
+		if err := dp.Validate(); err != nil {
+			return nil, err
+		}
+		return newCrawlDlJob(t, id, bck, dp, dlXact)
 	default:
-		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend)")
+		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend, crawl)")
 	}
 }
 
@@ -161,47 +202,59 @@ func attrsFromLink(link string, resp *http.Response, oah cmn.ObjAttrsHolder) (si
 	debug.AssertNoErr(err)
 	switch {
 	case cos.IsGoogleStorageURL(u) || cos.IsGoogleAPIURL(u):
-		h := cmn.BackendHelpers.Google
-		oah.SetCustomKey(cmn.SourceObjMD, apc.ProviderGoogle)
-		if v, ok := h.EncodeVersion(resp.Header.Get(cmn.GsVersionHeader)); ok {
-			oah.SetCustomKey(cmn.VersionObjMD, v)
-		}
-		if hdr := resp.Header[http.CanonicalHeaderKey(cmn.GsCksumHeader)]; len(hdr) > 0 {
-			for cksumType, cksumValue := range parseGoogleCksumHeader(hdr) {
-				switch cksumType {
-				case cos.ChecksumMD5:
-					oah.SetCustomKey(cmn.MD5ObjMD, cksumValue)
-				case cos.ChecksumCRC32C:
-					oah.SetCustomKey(cmn.CRC32CObjMD, cksumValue)
-				default:
-					glog.Errorf("unimplemented cksum type for custom metadata: %s", cksumType)
-				}
-			}
-		}
+		gsAttrs(resp.Header, oah)
 	case cos.IsS3URL(link):
-		h := cmn.BackendHelpers.Amazon
-		oah.SetCustomKey(cmn.SourceObjMD, apc.ProviderAmazon)
-		if v, ok := h.EncodeVersion(resp.Header.Get(cmn.S3VersionHeader)); ok {
-			oah.SetCustomKey(cmn.VersionObjMD, v)
-		}
-		if v, ok := h.EncodeCksum(resp.Header.Get(cmn.S3CksumHeader)); ok {
-			oah.SetCustomKey(cmn.MD5ObjMD, v)
-		}
+		s3Attrs(resp.Header, oah)
 	case cos.IsAzureURL(u):
-		h := cmn.BackendHelpers.Azure
-		oah.SetCustomKey(cmn.SourceObjMD, apc.ProviderAzure)
-		if v, ok := h.EncodeVersion(resp.Header.Get(cmn.AzVersionHeader)); ok {
-			oah.SetCustomKey(cmn.VersionObjMD, v)
-		}
-		if v, ok := h.EncodeCksum(resp.Header.Get(cmn.AzCksumHeader)); ok {
-			oah.SetCustomKey(cmn.MD5ObjMD, v)
-		}
+		azAttrs(resp.Header, oah)
 	default:
 		oah.SetCustomKey(cmn.SourceObjMD, cmn.WebObjMD)
 	}
 	return resp.ContentLength
 }
 
+func gsAttrs(hdr http.Header, oah cmn.ObjAttrsHolder) {
+	h := cmn.BackendHelpers.Google
+	oah.SetCustomKey(cmn.SourceObjMD, apc.ProviderGoogle)
+	if v, ok := h.EncodeVersion(hdr.Get(cmn.GsVersionHeader)); ok {
+		oah.SetCustomKey(cmn.VersionObjMD, v)
+	}
+	if vals := hdr[http.CanonicalHeaderKey(cmn.GsCksumHeader)]; len(vals) > 0 {
+		for cksumType, cksumValue := range parseGoogleCksumHeader(vals) {
+			switch cksumType {
+			case cos.ChecksumMD5:
+				oah.SetCustomKey(cmn.MD5ObjMD, cksumValue)
+			case cos.ChecksumCRC32C:
+				oah.SetCustomKey(cmn.CRC32CObjMD, cksumValue)
+			default:
+				glog.Errorf("unimplemented cksum type for custom metadata: %s", cksumType)
+			}
+		}
+	}
+}
+
+func s3Attrs(hdr http.Header, oah cmn.ObjAttrsHolder) {
+	h := cmn.BackendHelpers.Amazon
+	oah.SetCustomKey(cmn.SourceObjMD, apc.ProviderAmazon)
+	if v, ok := h.EncodeVersion(hdr.Get(cmn.S3VersionHeader)); ok {
+		oah.SetCustomKey(cmn.VersionObjMD, v)
+	}
+	if v, ok := h.EncodeCksum(hdr.Get(cmn.S3CksumHeader)); ok {
+		oah.SetCustomKey(cmn.MD5ObjMD, v)
+	}
+}
+
+func azAttrs(hdr http.Header, oah cmn.ObjAttrsHolder) {
+	h := cmn.BackendHelpers.Azure
+	oah.SetCustomKey(cmn.SourceObjMD, apc.ProviderAzure)
+	if v, ok := h.EncodeVersion(hdr.Get(cmn.AzVersionHeader)); ok {
+		oah.SetCustomKey(cmn.VersionObjMD, v)
+	}
+	if v, ok := h.EncodeCksum(hdr.Get(cmn.AzCksumHeader)); ok {
+		oah.SetCustomKey(cmn.MD5ObjMD, v)
+	}
+}
+
 func parseGoogleCksumHeader(hdr []string) cos.SimpleKVs {
 	var (
 		h      = cmn.BackendHelpers.Google
@@ -217,32 +270,17 @@ func parseGoogleCksumHeader(hdr []string) cos.SimpleKVs {
 	return cksums
 }
 
-func headLink(link string) (*http.Response, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), headReqTimeout)
-	defer cancel()
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, link, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := clientForURL(link).Do(req)
-	if err != nil {
-		return nil, err
-	}
-	cos.Close(resp.Body)
-	return resp, nil
-}
-
 // Use all available metadata including {size, version, ETag, MD5, CRC}
 // to compare local object with its remote counterpart.
 func CompareObjects(lom *cluster.LOM, dst *DstElement) (equal bool, err error) {
 	var oa *cmn.ObjAttrs
 	if dst.Link != "" {
-		resp, errHead := headLink(dst.Link)
-		if errHead != nil {
-			return false, errHead
-		}
+		ctx, cancel := context.WithTimeout(context.Background(), headReqTimeout)
+		defer cancel()
 		oa = &cmn.ObjAttrs{}
-		oa.Size = attrsFromLink(dst.Link, resp, oa)
+		if oa.Size, err = HeadLink(ctx, dst.Link, nil, oa); err != nil {
+			return false, err
+		}
 	} else {
 		ctx, cancel := context.WithTimeout(context.Background(), headReqTimeout)
 		defer cancel()
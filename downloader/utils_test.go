@@ -108,3 +108,49 @@ func prepareObject(t *testing.T) *cluster.LOM {
 	tassert.CheckFatal(t, err)
 	return lom
 }
+
+func TestDlContinuousValidate(t *testing.T) {
+	tests := []struct {
+		cont  downloader.DlContinuous
+		valid bool
+	}{
+		{downloader.DlContinuous{Interval: "1h"}, true},
+		{downloader.DlContinuous{Interval: "10m", MaxRuns: 3, Delete: true}, true},
+		{downloader.DlContinuous{}, false},
+		{downloader.DlContinuous{Interval: "ten minutes"}, false},
+		{downloader.DlContinuous{Interval: "10s"}, false},
+		{downloader.DlContinuous{Interval: "1h", MaxRuns: -1}, false},
+	}
+	for _, test := range tests {
+		base := downloader.DlBase{Bck: cmn.Bck{Name: "bucket"}, Continuous: &test.cont}
+		err := base.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got %v", test.cont, test.valid, err)
+	}
+}
+
+func TestDlStatusRespAggregateRuns(t *testing.T) {
+	var (
+		resp *downloader.DlStatusResp
+		tgt1 = downloader.DlStatusResp{
+			DlJobInfo: downloader.DlJobInfo{Run: 3},
+			Runs: []downloader.DlRunInfo{
+				{Run: 1, FinishedCnt: 10, DeletedCnt: 1},
+				{Run: 2, FinishedCnt: 5, ErrorCnt: 1},
+			},
+		}
+		tgt2 = downloader.DlStatusResp{
+			DlJobInfo: downloader.DlJobInfo{Run: 2},
+			Runs: []downloader.DlRunInfo{
+				{Run: 1, FinishedCnt: 7, DeletedCnt: 2},
+			},
+		}
+	)
+	resp = resp.Aggregate(tgt2)
+	resp = resp.Aggregate(tgt1)
+	tassert.Errorf(t, resp.Run == 3, "expected run 3, got %d", resp.Run)
+	tassert.Fatalf(t, len(resp.Runs) == 2, "expected 2 runs, got %d", len(resp.Runs))
+	tassert.Errorf(t, resp.Runs[0].Run == 1 && resp.Runs[0].FinishedCnt == 17 && resp.Runs[0].DeletedCnt == 3,
+		"unexpected first run: %+v", resp.Runs[0])
+	tassert.Errorf(t, resp.Runs[1].Run == 2 && resp.Runs[1].FinishedCnt == 5 && resp.Runs[1].ErrorCnt == 1,
+		"unexpected second run: %+v", resp.Runs[1])
+}
@@ -0,0 +1,457 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/dsort/extract"
+	"github.com/NVIDIA/aistore/dsort/filetype"
+	"github.com/OneOfOne/xxhash"
+	"github.com/pkg/errors"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Checkpoint of the restartable job (see: `RequestSpec.Restartable`) consists of:
+//  * metadata - persisted in the db and describing the state of the job,
+//  * records file - records extracted by the target, their contents are kept
+//    on the disk (or in the input shards) until the job successfully finishes,
+//  * created file - names (and digests) of the shards created by the target.
+//
+// When the job is restarted (see: `RequestSpec.RestartOf`) the new job takes
+// over the checkpoint: the extraction is skipped if the records were persisted
+// and the final target does not request creation of the shards which have
+// already been created.
+
+const (
+	checkpointsKey = "checkpoints"
+
+	checkpointRecordsSuffix = ".records"
+	checkpointCreatedSuffix = ".created"
+)
+
+type (
+	checkpointMeta struct {
+		// ID of the job which has created the checkpoint. It doesn't change
+		// when the job is restarted so the files can be reused by the new job.
+		ID           string    `json:"id"`
+		Bck          cmn.Bck   `json:"bck"`
+		Digest       uint64    `json:"digest,string"` // digest of the request spec (see: `specDigest`)
+		Targets      []string  `json:"targets"`       // active targets when the job has been started
+		Extracted    bool      `json:"extracted"`     // true when extracted records were persisted
+		Compressed   int64     `json:"compressed,string"`
+		Uncompressed int64     `json:"uncompressed,string"`
+		Updated      time.Time `json:"updated"`
+	}
+
+	checkpoint struct {
+		m        *Manager
+		meta     checkpointMeta
+		restored *checkpointMeta // checkpoint of the restarted job, nil if there is none
+		sizes    struct {
+			compressed   atomic.Int64 // compressed size of locally extracted shards
+			uncompressed atomic.Int64 // uncompressed size of locally extracted shards
+		}
+		created struct {
+			mu     sync.Mutex
+			shards map[string]uint64 // shard name => digest (see: `shardDigest`)
+			f      *os.File
+		}
+	}
+)
+
+func checkpointKey(managerUUID string) string { return path.Join(checkpointsKey, managerUUID) }
+
+func checkpointFQN(bck *cmn.Bck, id, suffix string) (string, error) {
+	fqn, _, err := cluster.HrwFQN(bck, filetype.DSortWorkfileType, id+suffix)
+	return fqn, err
+}
+
+// newCheckpoint creates checkpoint of the job. If the job restarts another one,
+// the checkpoint of the restarted job is taken over.
+func newCheckpoint(m *Manager) (*checkpoint, error) {
+	ck := &checkpoint{
+		m: m,
+		meta: checkpointMeta{
+			ID:      m.ManagerUUID,
+			Bck:     m.rs.Bck,
+			Digest:  specDigest(m.rs),
+			Targets: activeTargetIDs(m.smap),
+		},
+	}
+	ck.created.shards = make(map[string]uint64)
+	if m.rs.RestartOf != "" {
+		if err := ck.takeOver(m.rs.RestartOf); err != nil {
+			return nil, err
+		}
+	}
+
+	fqn, err := checkpointFQN(&ck.meta.Bck, ck.meta.ID, checkpointCreatedSuffix)
+	if err != nil {
+		return nil, err
+	}
+	if err := cos.CreateDir(path.Dir(fqn)); err != nil {
+		return nil, err
+	}
+	if ck.created.f, err = os.OpenFile(fqn, os.O_CREATE|os.O_APPEND|os.O_WRONLY, cos.PermRWR); err != nil {
+		return nil, err
+	}
+	if err := ck.persist(); err != nil {
+		ck.close()
+		return nil, err
+	}
+	return ck, nil
+}
+
+// takeOver loads the checkpoint of the restarted job. Since the files of the
+// checkpoint are reused, the checkpoint is removed from the restarted job.
+func (ck *checkpoint) takeOver(managerUUID string) error {
+	if prev, exists := ck.m.mg.Get(managerUUID); exists && prev.inProgress() {
+		return errors.Errorf("%s job %q is still in progress and cannot be restarted", DSortName, managerUUID)
+	}
+
+	var meta checkpointMeta
+	if err := ck.m.mg.db.Get(dsortCollection, checkpointKey(managerUUID), &meta); err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			return err
+		}
+		glog.Warningf("[dsort] %s: checkpoint of job %q not found, starting from scratch", ck.m.ManagerUUID, managerUUID)
+		return nil
+	}
+	if meta.Digest != ck.meta.Digest {
+		return errors.Errorf("request specification differs from the one of the restarted %s job %q",
+			DSortName, managerUUID)
+	}
+
+	fqn, err := checkpointFQN(&meta.Bck, meta.ID, checkpointCreatedSuffix)
+	if err != nil {
+		return err
+	}
+	if ck.created.shards, err = loadCreatedShards(fqn); err != nil {
+		return err
+	}
+
+	ck.meta.ID = meta.ID
+	ck.meta.Extracted = meta.Extracted
+	ck.meta.Compressed, ck.meta.Uncompressed = meta.Compressed, meta.Uncompressed
+	ck.restored = &meta
+	glog.Infof("[dsort] %s: restarting job %q (extracted: %t, created shards: %d)",
+		ck.m.ManagerUUID, managerUUID, meta.Extracted, len(ck.created.shards))
+	return ck.m.mg.db.Delete(dsortCollection, checkpointKey(managerUUID))
+}
+
+func (ck *checkpoint) persist() error {
+	ck.meta.Updated = time.Now()
+	return ck.m.mg.db.Set(dsortCollection, checkpointKey(ck.m.ManagerUUID), ck.meta)
+}
+
+func (ck *checkpoint) addSizes(compressed, uncompressed int64) {
+	ck.sizes.compressed.Add(compressed)
+	ck.sizes.uncompressed.Add(uncompressed)
+}
+
+// restoreRecords restores records extracted by the restarted job. It returns
+// false if the extraction must be repeated: either there are no records or
+// the cluster has changed and so the target may no longer own the same shards.
+func (ck *checkpoint) restoreRecords() (bool, error) {
+	if ck.restored == nil || !ck.restored.Extracted {
+		return false, nil
+	}
+
+	fqn, err := checkpointFQN(&ck.meta.Bck, ck.meta.ID, checkpointRecordsSuffix)
+	if err != nil {
+		return false, err
+	}
+	records, err := loadRecords(fqn)
+	if err != nil {
+		return false, err
+	}
+	ck.m.recManager.RestoreRecords(records)
+
+	if !cos.StrSlicesEqual(ck.restored.Targets, ck.meta.Targets) {
+		glog.Warningf("[dsort] %s: targets have changed since the records were extracted, extracting again",
+			ck.m.ManagerUUID)
+		ck.m.recManager.FilterRecords(func(*extract.Record) bool { return false })
+		ck.meta.Extracted = false
+		return false, nil
+	}
+
+	ck.m.addCompressionSizes(ck.meta.Compressed, ck.meta.Uncompressed)
+	ck.addSizes(ck.meta.Compressed, ck.meta.Uncompressed)
+	return true, nil
+}
+
+// saveRecords persists records extracted by the target. Before that, all the
+// contents kept in memory are moved to the disk so that they survive restart
+// of the target.
+func (ck *checkpoint) saveRecords() error {
+	buf, slab := mm.AllocSize(serializationBufSize)
+	defer slab.Free(buf)
+
+	if err := ck.m.recManager.SpillContents(buf); err != nil {
+		return err
+	}
+	fqn, err := checkpointFQN(&ck.meta.Bck, ck.meta.ID, checkpointRecordsSuffix)
+	if err != nil {
+		return err
+	}
+	if err := jsp.Save(fqn, nil, jsp.Plain(), &recordsWriter{ck.m.recManager.Records}); err != nil {
+		return err
+	}
+	ck.meta.Extracted = true
+	ck.meta.Compressed, ck.meta.Uncompressed = ck.sizes.compressed.Load(), ck.sizes.uncompressed.Load()
+	return ck.persist()
+}
+
+// addCreated records that the shard has been successfully created.
+func (ck *checkpoint) addCreated(s *extract.Shard) error {
+	digest := shardDigest(s)
+	ck.created.mu.Lock()
+	defer ck.created.mu.Unlock()
+	ck.created.shards[s.Name] = digest
+	_, err := fmt.Fprintf(ck.created.f, "%x %s\n", digest, s.Name)
+	return err
+}
+
+func (ck *checkpoint) createdShards() map[string]uint64 {
+	ck.created.mu.Lock()
+	defer ck.created.mu.Unlock()
+	shards := make(map[string]uint64, len(ck.created.shards))
+	for name, digest := range ck.created.shards {
+		shards[name] = digest
+	}
+	return shards
+}
+
+func (ck *checkpoint) close() {
+	ck.created.mu.Lock()
+	if ck.created.f != nil {
+		cos.Close(ck.created.f)
+		ck.created.f = nil
+	}
+	ck.created.mu.Unlock()
+}
+
+// remove removes the files and the metadata of the checkpoint. The extracted
+// contents are removed together with the records (see: `RecordManager.Cleanup`).
+func (ck *checkpoint) remove() {
+	ck.close()
+	removeCheckpointFiles(&ck.meta)
+	_ = ck.m.mg.db.Delete(dsortCollection, checkpointKey(ck.m.ManagerUUID))
+}
+
+// removeCheckpoint removes the checkpoint of the job which will not be
+// restarted, including the extracted contents which it references.
+func removeCheckpoint(db dbdriver.Driver, managerUUID string) {
+	var meta checkpointMeta
+	if err := db.Get(dsortCollection, checkpointKey(managerUUID), &meta); err != nil {
+		return
+	}
+	if meta.Extracted {
+		if err := removeExtractedContents(&meta); err != nil {
+			glog.Error(err)
+		}
+	}
+	removeCheckpointFiles(&meta)
+	_ = db.Delete(dsortCollection, checkpointKey(managerUUID))
+}
+
+func removeExtractedContents(meta *checkpointMeta) error {
+	fqn, err := checkpointFQN(&meta.Bck, meta.ID, checkpointRecordsSuffix)
+	if err != nil {
+		return err
+	}
+	records, err := loadRecords(fqn)
+	if err != nil {
+		return err
+	}
+	rm := extract.NewRecordManager(ctx.t, meta.Bck, "", nil, nil, nil)
+	rm.RestoreRecords(records)
+	rm.Cleanup()
+	return nil
+}
+
+func removeCheckpointFiles(meta *checkpointMeta) {
+	for _, suffix := range []string{checkpointRecordsSuffix, checkpointCreatedSuffix} {
+		fqn, err := checkpointFQN(&meta.Bck, meta.ID, suffix)
+		if err != nil {
+			glog.Error(err)
+			continue
+		}
+		if err := cos.RemoveFile(fqn); err != nil {
+			glog.Errorf("could not remove checkpoint file %q, err: %v", fqn, err)
+		}
+	}
+}
+
+// skipCreatedShards removes the shards which have been already created by the
+// restarted job. It returns the remaining shards and the number of record
+// objects, per target, which will not be requested because of that.
+func (m *Manager) skipCreatedShards(shards []*extract.Shard) ([]*extract.Shard, map[string]int64, error) {
+	var (
+		created   = make(map[string]uint64)
+		urlPath   = apc.URLPathdSortCreated.Join(m.ManagerUUID)
+		responses = broadcastTargets(http.MethodGet, urlPath, nil, nil, m.smap)
+	)
+	for _, resp := range responses {
+		if resp.err != nil || resp.statusCode != http.StatusOK {
+			return nil, nil, errors.Errorf("failed to get created shards from %s, status: %d, err: %v",
+				resp.si, resp.statusCode, resp.err)
+		}
+		var createdByTarget map[string]uint64
+		if err := js.Unmarshal(resp.res, &createdByTarget); err != nil {
+			return nil, nil, err
+		}
+		for name, digest := range createdByTarget {
+			created[name] = digest
+		}
+	}
+	if len(created) == 0 {
+		return shards, nil, nil
+	}
+
+	var (
+		remaining = shards[:0]
+		skipped   = make(map[string]int64, m.smap.CountActiveTargets())
+	)
+	for _, s := range shards {
+		if digest, ok := created[s.Name]; !ok || digest != shardDigest(s) {
+			remaining = append(remaining, s)
+			continue
+		}
+		for _, record := range s.Records.All() {
+			skipped[record.DaemonID] += int64(len(record.Objects))
+		}
+	}
+
+	metrics := m.Metrics.Creation
+	metrics.mu.Lock()
+	metrics.SkippedCnt += int64(len(shards) - len(remaining))
+	metrics.mu.Unlock()
+	glog.Infof("[dsort] %s skipping %d shards created by the restarted job", m.ManagerUUID, len(shards)-len(remaining))
+	return remaining, skipped, nil
+}
+
+// specDigest computes digest of the fields of the request spec which define
+// the result of the job.
+func specDigest(rs *ParsedRequestSpec) uint64 {
+	spec := *rs
+	spec.Description, spec.TargetOrderSalt = "", nil
+	spec.Restartable, spec.RestartOf = false, ""
+	spec.ExtractConcMaxLimit, spec.CreateConcMaxLimit, spec.StreamMultiplier = 0, 0, 0
+	spec.MaxMemUsage = cos.ParsedQuantity{}
+	spec.ExtendedMetrics = false
+	spec.DSorterType = ""
+	return xxhash.Checksum64S(cos.MustMarshal(&spec), cos.MLCG32)
+}
+
+// shardDigest computes digest of the shard content: records and their objects.
+func shardDigest(s *extract.Shard) uint64 {
+	h := xxhash.NewS64(cos.MLCG32)
+	h.WriteString(s.Split + "\x00" + s.Name + "\x00")
+	for _, record := range s.Records.All() {
+		h.WriteString(record.Name + "\x00")
+		for _, obj := range record.Objects {
+			h.WriteString(obj.Extension + "\x00" + strconv.FormatInt(obj.Size, 10) + "\x00")
+		}
+	}
+	return h.Sum64()
+}
+
+func activeTargetIDs(smap *cluster.Smap) []string {
+	ids := make([]string, 0, len(smap.Tmap))
+	for sid, si := range smap.Tmap {
+		if smap.PresentInMaint(si) {
+			continue
+		}
+		ids = append(ids, sid)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func loadRecords(fqn string) (*extract.Records, error) {
+	f, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(f)
+
+	buf, slab := mm.AllocSize(serializationBufSize)
+	defer slab.Free(buf)
+
+	records := extract.NewRecords(1000)
+	if err := records.DecodeMsg(msgp.NewReaderBuf(f, buf)); err != nil {
+		return nil, fmt.Errorf(cmn.FmtErrUnmarshal, DSortName, "checkpoint records", fqn, err)
+	}
+	return records, nil
+}
+
+// loadCreatedShards reads the file with the created shards. Each line has the
+// format: "<digest in hex> <shard name>". Incomplete last line is ignored.
+func loadCreatedShards(fqn string) (map[string]uint64, error) {
+	shards := make(map[string]uint64)
+	f, err := os.Open(fqn)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return shards, nil
+		}
+		return nil, err
+	}
+	defer cos.Close(f)
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			return shards, nil
+		} else if err != nil {
+			return nil, err
+		}
+		parts := strings.SplitN(strings.TrimSuffix(line, "\n"), " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		digest, err := strconv.ParseUint(parts[0], 16, 64)
+		if err != nil {
+			continue
+		}
+		shards[parts[1]] = digest
+	}
+}
+
+// recordsWriter encodes the records with msgp (see: `jsp.Save`).
+type recordsWriter struct {
+	records *extract.Records
+}
+
+func (rw *recordsWriter) WriteTo(w io.Writer) (int64, error) {
+	buf, slab := mm.AllocSize(serializationBufSize)
+	defer slab.Free(buf)
+
+	msgpw := msgp.NewWriterBuf(w, buf)
+	if err := rw.records.EncodeMsg(msgpw); err != nil {
+		return 0, err
+	}
+	return 0, msgpw.Flush()
+}
@@ -0,0 +1,226 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/dsort/extract"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	var (
+		mgrp *ManagerGroup
+		db   dbdriver.Driver
+	)
+
+	newRS := func() *ParsedRequestSpec {
+		return &ParsedRequestSpec{
+			Bck:         cmn.Bck{Name: "bck", Provider: apc.ProviderAIS},
+			Extension:   cos.ExtTar,
+			Algorithm:   &SortAlgorithm{Kind: SortKindNone},
+			MaxMemUsage: cos.ParsedQuantity{Type: cos.QuantityPercent, Value: 0},
+			DSorterType: DSorterGeneralType,
+			Restartable: true,
+		}
+	}
+
+	newShard := func(name string, recordNames ...string) *extract.Shard {
+		records := extract.NewRecords(len(recordNames))
+		for _, recordName := range recordNames {
+			records.Insert(&extract.Record{
+				Name:    recordName,
+				Objects: []*extract.RecordObj{{Extension: ".jpg", Size: 10}},
+			})
+		}
+		return &extract.Shard{Name: name, Records: records}
+	}
+
+	startJob := func(uuid string, rs *ParsedRequestSpec) (*Manager, error) {
+		m, err := mgrp.Add(uuid)
+		Expect(err).NotTo(HaveOccurred())
+		defer m.unlock()
+		return m, m.init(rs)
+	}
+
+	abortJob := func(m *Manager) {
+		m.setInProgressTo(false)
+		m.setAbortedTo(true)
+		m.checkpoint.close()
+	}
+
+	BeforeEach(func() {
+		Expect(cos.CreateDir(testingConfigDir)).NotTo(HaveOccurred())
+		config := cmn.GCO.BeginUpdate()
+		config.ConfigDir = testingConfigDir
+		cmn.GCO.CommitUpdate(config)
+
+		db = mock.NewDBDriver()
+		mgrp = NewManagerGroup(db, true /*skipHk*/)
+		mm = memsys.PageMM()
+
+		fs.TestNew(nil)
+		_, err := fs.Add(testingConfigDir, "daeID")
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx.smapOwner = newTestSmap("target")
+		ctx.node = ctx.smapOwner.Get().Tmap["target"]
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(testingConfigDir)).NotTo(HaveOccurred())
+	})
+
+	It("should take over the created shards of the restarted job", func() {
+		m, err := startJob("uuid1", newRS())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.checkpoint).NotTo(BeNil())
+		s1, s2 := newShard("shard-1", "a", "b"), newShard("shard-2", "c")
+		Expect(m.checkpoint.addCreated(s1)).NotTo(HaveOccurred())
+		Expect(m.checkpoint.addCreated(s2)).NotTo(HaveOccurred())
+		abortJob(m)
+
+		rs := newRS()
+		rs.RestartOf = "uuid1"
+		restarted, err := startJob("uuid2", rs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restarted.checkpoint.meta.ID).To(Equal("uuid1"))
+		Expect(restarted.checkpoint.createdShards()).To(Equal(map[string]uint64{
+			"shard-1": shardDigest(s1),
+			"shard-2": shardDigest(s2),
+		}))
+
+		var meta checkpointMeta
+		Expect(db.Get(dsortCollection, checkpointKey("uuid1"), &meta)).To(HaveOccurred())
+		Expect(db.Get(dsortCollection, checkpointKey("uuid2"), &meta)).NotTo(HaveOccurred())
+		Expect(meta.ID).To(Equal("uuid1"))
+		restarted.checkpoint.close()
+	})
+
+	It("should not restart the job with different request", func() {
+		m, err := startJob("uuid1", newRS())
+		Expect(err).NotTo(HaveOccurred())
+		abortJob(m)
+
+		rs := newRS()
+		rs.RestartOf = "uuid1"
+		rs.Extension = cos.ExtTgz
+		_, err = startJob("uuid2", rs)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not restart the job which is in progress", func() {
+		m, err := startJob("uuid1", newRS())
+		Expect(err).NotTo(HaveOccurred())
+		defer m.checkpoint.close()
+
+		rs := newRS()
+		rs.RestartOf = "uuid1"
+		_, err = startJob("uuid2", rs)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should persist and load the extracted records", func() {
+		m, err := startJob("uuid1", newRS())
+		Expect(err).NotTo(HaveOccurred())
+		m.recManager.Records.Insert(&extract.Record{
+			Key:  "a",
+			Name: "shard-1|a",
+			Objects: []*extract.RecordObj{{
+				ContentPath: "shard-1.tar", StoreType: extract.OffsetStoreType, Offset: 512, Size: 10, Extension: ".jpg",
+			}},
+		})
+		m.checkpoint.addSizes(100, 200)
+		Expect(m.checkpoint.saveRecords()).NotTo(HaveOccurred())
+		Expect(m.checkpoint.meta.Extracted).To(BeTrue())
+		abortJob(m)
+
+		fqn, err := checkpointFQN(&m.rs.Bck, "uuid1", checkpointRecordsSuffix)
+		Expect(err).NotTo(HaveOccurred())
+		records, err := loadRecords(fqn)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records.All()).To(HaveLen(1))
+		Expect(records.All()[0].Name).To(Equal("shard-1|a"))
+		Expect(records.All()[0].Objects[0].Offset).To(BeEquivalentTo(512))
+
+		rs := newRS()
+		rs.RestartOf = "uuid1"
+		restarted, err := startJob("uuid2", rs)
+		Expect(err).NotTo(HaveOccurred())
+		defer restarted.checkpoint.close()
+		restored, err := restarted.checkpoint.restoreRecords()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored).To(BeTrue())
+		Expect(restarted.recManager.Records.Len()).To(Equal(1))
+		Expect(restarted.checkpoint.sizes.uncompressed.Load()).To(BeEquivalentTo(200))
+	})
+
+	It("should remove expired checkpoints", func() {
+		m, err := startJob("uuid1", newRS())
+		Expect(err).NotTo(HaveOccurred())
+		abortJob(m)
+		mgrp.mtx.Lock()
+		delete(mgrp.managers, "uuid1")
+		mgrp.mtx.Unlock()
+
+		mgrp.mtx.Lock()
+		mgrp.housekeepCheckpoints(time.Hour)
+		mgrp.mtx.Unlock()
+		var meta checkpointMeta
+		Expect(db.Get(dsortCollection, checkpointKey("uuid1"), &meta)).NotTo(HaveOccurred())
+
+		mgrp.mtx.Lock()
+		mgrp.housekeepCheckpoints(0)
+		mgrp.mtx.Unlock()
+		Expect(db.Get(dsortCollection, checkpointKey("uuid1"), &meta)).To(HaveOccurred())
+		fqn, err := checkpointFQN(&m.rs.Bck, "uuid1", checkpointCreatedSuffix)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fqn).NotTo(BeAnExistingFile())
+	})
+
+	It("should compute digest of the shard", func() {
+		digest := shardDigest(newShard("shard-1", "a", "b"))
+		Expect(shardDigest(newShard("shard-1", "a", "b"))).To(Equal(digest))
+		Expect(shardDigest(newShard("shard-1", "b", "a"))).NotTo(Equal(digest))
+		Expect(shardDigest(newShard("shard-2", "a", "b"))).NotTo(Equal(digest))
+		Expect(shardDigest(newShard("shard-1", "a"))).NotTo(Equal(digest))
+	})
+
+	It("should compute digest of the request spec", func() {
+		rs := newRS()
+		digest := specDigest(rs)
+		rs.Description = "restart"
+		rs.TargetOrderSalt = []byte("salt")
+		rs.RestartOf = "uuid1"
+		rs.ExtractConcMaxLimit = 10
+		Expect(specDigest(rs)).To(Equal(digest))
+		rs.OutputShardSize = cos.MiB
+		Expect(specDigest(rs)).NotTo(Equal(digest))
+	})
+
+	It("should load created shards ignoring incomplete lines", func() {
+		fqn := filepath.Join(testingConfigDir, "created")
+		content := "1f shard-1\nff shard with spaces\nzz broken\nab"
+		Expect(os.WriteFile(fqn, []byte(content), cos.PermRWR)).NotTo(HaveOccurred())
+		shards, err := loadCreatedShards(fqn)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shards).To(Equal(map[string]uint64{"shard-1": 0x1f, "shard with spaces": 0xff}))
+
+		shards, err = loadCreatedShards(filepath.Join(testingConfigDir, "missing"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shards).To(BeEmpty())
+	})
+})
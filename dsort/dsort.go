@@ -20,6 +20,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/api/apc"
@@ -226,6 +227,9 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 		// Make sure that compression rate is updated before releasing
 		// next extractor goroutine.
 		m.addCompressionSizes(compressedSize, extractedSize)
+		if m.checkpoint != nil {
+			m.checkpoint.addSizes(compressedSize, extractedSize)
+		}
 
 		phaseInfo.adjuster.releaseSema(lom.MpathInfo())
 		lom.Unlock(false)
@@ -288,6 +292,23 @@ func (m *Manager) extractLocalShards() (err error) {
 	metrics.TotalCnt = m.rs.InputFormat.Template.Count()
 	metrics.mu.Unlock()
 
+	if m.checkpoint != nil {
+		restored, err := m.checkpoint.restoreRecords()
+		if err != nil {
+			return err
+		}
+		if restored {
+			// Records have been already extracted (and filtered) by the restarted job.
+			metrics.mu.Lock()
+			metrics.RestoredRecordCnt = int64(m.recManager.Records.Len())
+			metrics.mu.Unlock()
+
+			m.dsorter.postExtraction()
+			m.incrementRef(int64(m.recManager.Records.TotalObjectCount()))
+			return nil
+		}
+	}
+
 	group, ctx := errgroup.WithContext(context.Background())
 	pt := m.rs.InputFormat.Template
 	pt.InitIter()
@@ -313,6 +334,19 @@ ExtractAllShards:
 	// We will no longer reserve any memory
 	m.dsorter.postExtraction()
 
+	if err := m.filterRecords(); err != nil {
+		return err
+	}
+	if err := m.sampleRecords(); err != nil {
+		return err
+	}
+	if m.checkpoint != nil {
+		if err := m.checkpoint.saveRecords(); err != nil {
+			glog.Errorf("[dsort] %s failed to persist extracted records (restart will repeat the extraction), err: %v",
+				m.ManagerUUID, err)
+		}
+	}
+
 	m.incrementRef(int64(m.recManager.Records.TotalObjectCount()))
 	return nil
 }
@@ -331,7 +365,7 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 	// TODO: use cluster.AllocLOM, review `t.PutObject` below
 	//
 	lom := &cluster.LOM{ObjName: shardName}
-	if err = lom.InitBck(&m.rs.OutputBck); err != nil {
+	if err = lom.InitBck(m.outputBck(s)); err != nil {
 		return
 	}
 	lom.SetAtimeUnix(time.Now().UnixNano())
@@ -463,6 +497,12 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 	}
 
 exit:
+	if m.checkpoint != nil && !m.rs.DryRun {
+		if err := m.checkpoint.addCreated(s); err != nil {
+			glog.Errorf("[dsort] %s failed to persist created shard %q, err: %v", m.ManagerUUID, shardName, err)
+		}
+	}
+
 	metrics.mu.Lock()
 	metrics.CreatedCnt++
 	if si.ID() != m.ctx.node.ID() {
@@ -617,15 +657,64 @@ func (m *Manager) participateInRecordDistribution(targetOrder cluster.Nodes) (cu
 		m.recManager.MergeEnqueuedRecords()
 	}
 
-	err = sortRecords(m.recManager.Records, m.rs.Algorithm)
+	records := m.recManager.Records
+	if m.rs.Algorithm.Kind == SortKindKeyTable {
+		if records, err = m.assignTableKeys(); err != nil {
+			return true, err
+		}
+	}
+	err = sortRecords(records, m.rs.Algorithm)
 	m.dsorter.postRecordDistribution()
 	return true, err
 }
 
-func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, error) {
+// assignTableKeys replaces the keys of all records with the keys from the key
+// table. Records missing from the key table are moved (in extraction order)
+// to the end and the returned records, which are to be sorted, exclude them.
+func (m *Manager) assignTableKeys() (*extract.Records, error) {
+	body, err := m.getExternal(m.rs.Algorithm.KeyTableURL, "key table")
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(body)
+
+	sep, _ := utf8.DecodeRuneInString(m.rs.Algorithm.KeyTableSep)
+	table, err := extract.ReadKeyTable(body, sep, m.rs.Algorithm.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		records = m.recManager.Records
+		missing = make([]*extract.Record, 0)
+		n       int
+	)
+	for _, r := range records.All() {
+		name := r.NameInShard()
+		key, ok := table[name]
+		if !ok {
+			msg := fmt.Sprintf("extracted record %q which does not belong in key table", name)
+			if err := m.react(m.rs.EKMMissingKey, msg); err != nil {
+				return nil, err
+			}
+			missing = append(missing, r)
+			continue
+		}
+		r.Key = key
+		records.All()[n] = r
+		n++
+	}
+	copy(records.All()[n:], missing)
+	return records.Slice(0, n), nil
+}
+
+// generateShardsWithTemplate generates shards out of the records with the
+// names generated by the template. `totalSize` is the (estimated) total size
+// of the records.
+func (m *Manager) generateShardsWithTemplate(records *extract.Records, pt cos.ParsedTemplate,
+	maxSize, totalSize int64) ([]*extract.Shard, error) {
 	var (
-		n               = m.recManager.Records.Len()
-		pt              = m.rs.OutputFormat.Template
+		n               = records.Len()
 		shardCount      = pt.Count()
 		start           int
 		curShardSize    int64
@@ -636,10 +725,10 @@ func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, e
 
 	if maxSize <= 0 {
 		// Heuristic: to count desired size of shard in case when maxSize is not specified.
-		maxSize = int64(math.Ceil(float64(m.totalUncompressedSize()) / float64(shardCount)))
+		maxSize = int64(math.Ceil(float64(totalSize) / float64(shardCount)))
 	}
 
-	for i, r := range m.recManager.Records.All() {
+	for i, r := range records.All() {
 		numLocalRecords[r.DaemonID]++
 		curShardSize += r.TotalSize()
 		if curShardSize < maxSize && i < n-1 {
@@ -652,11 +741,11 @@ func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, e
 			return nil, errors.Errorf("number of shards to be created exceeds expected number of shards (%d)", shardCount)
 		}
 		shard := &extract.Shard{
-			Name: name + m.rs.Extension,
+			Name: name + m.rs.OutputExtension,
 		}
 
 		shard.Size = curShardSize
-		shard.Records = m.recManager.Records.Slice(start, i+1)
+		shard.Records = records.Slice(start, i+1)
 		shards = append(shards, shard)
 
 		start = i + 1
@@ -680,32 +769,17 @@ func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*extract.Shar
 		return nil, errors.New("invalid max size of shard was specified when using external key map")
 	}
 
-	req, err := http.NewRequest(http.MethodGet, m.rs.OrderFileURL, http.NoBody)
+	body, err := m.getExternal(m.rs.OrderFileURL, "order file")
 	if err != nil {
 		return nil, err
 	}
-	// is intra-call
-	tsi := m.ctx.t.Snode()
-	req.Header.Set(apc.HdrCallerID, tsi.ID())
-	req.Header.Set(apc.HdrCallerName, tsi.String())
-
-	resp, err := m.client.Do(req) // nolint:bodyclose // closed inside cos.Close
-	if err != nil {
-		return nil, err
-	}
-	defer cos.Close(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(
-			"unexpected status code (%d) when requesting order file from %q",
-			resp.StatusCode, m.rs.OrderFileURL,
-		)
-	}
+	defer cos.Close(body)
 
 	// TODO: handle very large files > GB - in case the file is very big we
 	//  need to save file to the disk and operate on the file directly rather
 	//  than keeping everything in memory.
 
-	lineReader := bufio.NewReader(resp.Body)
+	lineReader := bufio.NewReader(body)
 
 	for idx := 0; ; idx++ {
 		l, _, err := lineReader.ReadLine()
@@ -769,6 +843,31 @@ func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*extract.Shar
 	return shards, nil
 }
 
+// getExternal requests the file (eg. order file or key table) from the given URL.
+func (m *Manager) getExternal(u, what string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	// is intra-call
+	tsi := m.ctx.t.Snode()
+	req.Header.Set(apc.HdrCallerID, tsi.ID())
+	req.Header.Set(apc.HdrCallerName, tsi.String())
+
+	resp, err := m.client.Do(req) // nolint:bodyclose // closed by the caller
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		cos.Close(resp.Body)
+		return nil, fmt.Errorf(
+			"unexpected status code (%d) when requesting %s from %q",
+			resp.StatusCode, what, u,
+		)
+	}
+	return resp.Body, nil
+}
+
 // distributeShardRecords creates Shard structs in the order of
 // dsortManager.Records corresponding to a maximum size maxSize. Each Shard is
 // sent in an HTTP request to the appropriate target to create the actual file
@@ -804,16 +903,27 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 		}
 	}
 
-	if m.rs.OrderFileURL != "" {
+	switch {
+	case m.rs.OrderFileURL != "":
 		shards, err = m.generateShardsWithOrderingFile(maxSize)
-	} else {
-		shards, err = m.generateShardsWithTemplate(maxSize)
+	case m.rs.Split.hasOutputs():
+		shards, err = m.generateShardsWithSplits(maxSize)
+	default:
+		shards, err = m.generateShardsWithTemplate(m.recManager.Records, m.rs.OutputFormat.Template,
+			maxSize, m.totalUncompressedSize())
 	}
 
 	if err != nil {
 		return err
 	}
 
+	var skipped map[string]int64 // daemonID => number of record objects
+	if m.rs.RestartOf != "" {
+		if shards, skipped, err = m.skipCreatedShards(shards); err != nil {
+			return err
+		}
+	}
+
 	// TODO: The following heuristic doesn't seem to be working correctly in
 	// all cases. When there are ver few shards on each disk (e.g. <= 5)
 	// a target may end up having more shards than other
@@ -831,12 +941,16 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 	// 	// target.
 	// }
 
-	bck := cluster.CloneBck(&m.rs.OutputBck)
-	if err := bck.Init(m.ctx.bmdOwner); err != nil {
-		return err
-	}
-
+	bcks := make(map[string]*cluster.Bck, 1) // split => initialized output bucket
 	for _, s := range shards {
+		bck, ok := bcks[s.Split]
+		if !ok {
+			bck = cluster.CloneBck(m.outputBck(s))
+			if err := bck.Init(m.ctx.bmdOwner); err != nil {
+				return err
+			}
+			bcks[s.Split] = bck
+		}
 		si, err := cluster.HrwTarget(bck.MakeUname(s.Name), m.smap)
 		if err != nil {
 			return err
@@ -851,6 +965,7 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 					shard = &extract.Shard{
 						Name:    s.Name,
 						Records: extract.NewRecords(100),
+						Split:   s.Split,
 					}
 					singleSendOrder[record.DaemonID] = shard
 				}
@@ -868,7 +983,7 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 	wg := cos.NewLimitedWaitGroup(cluster.MaxBcastParallel(), len(shardsToTarget))
 	for si, s := range shardsToTarget {
 		wg.Add(1)
-		go func(si *cluster.Snode, s []*extract.Shard, order map[string]*extract.Shard) {
+		go func(si *cluster.Snode, s []*extract.Shard, order map[string]*extract.Shard, skippedObjCnt int64) {
 			defer wg.Done()
 
 			var (
@@ -880,8 +995,9 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 					buf, slab = mm.AllocSize(serializationBufSize)
 					msgpw     = msgp.NewWriterBuf(w, buf)
 					md        = &CreationPhaseMetadata{
-						Shards:    s,
-						SendOrder: order,
+						Shards:        s,
+						SendOrder:     order,
+						SkippedObjCnt: skippedObjCnt,
 					}
 				)
 				defer slab.Free(buf)
@@ -912,7 +1028,7 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 				errCh <- err
 				return
 			}
-		}(si, s, sendOrder[si.ID()])
+		}(si, s, sendOrder[si.ID()], skipped[si.ID()])
 	}
 
 	wg.Wait()
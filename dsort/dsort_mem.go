@@ -17,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/dsort/extract"
+	"github.com/NVIDIA/aistore/dsort/filetype"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/sys"
@@ -46,7 +47,13 @@ type (
 		wgr *cos.TimeoutGroup
 		// In case the reader is first to connect, the data is copied into SGL
 		// so that the reader will not block on the connection.
-		sgl *memsys.SGL
+		// The SGL spills to a work file when memory pressure is high.
+		// Both sgl and err are set (under the connector's lock) upon reader's
+		// completion; the SGL is then owned by the writer - unless the connection
+		// is no longer registered (writer gave up, or cleanup), in which case
+		// the reader frees it.
+		sgl *memsys.SpillSGL
+		err error
 
 		w   io.Writer
 		wgw *sync.WaitGroup
@@ -107,10 +114,12 @@ func newRWConnector(m *Manager) *rwConnector {
 
 func (c *rwConnector) free() {
 	c.mu.Lock()
-	for _, v := range c.connections {
+	for key, v := range c.connections {
 		if v.sgl != nil {
 			v.sgl.Free()
+			v.sgl = nil
 		}
+		delete(c.connections, key)
 	}
 	c.mu.Unlock()
 }
@@ -139,8 +148,24 @@ func (c *rwConnector) connectReader(key string, r io.Reader, size int64) (err er
 	c.mu.Unlock()
 
 	if !all {
-		rw.sgl = mm.NewSGL(size)
-		_, err = io.Copy(rw.sgl, r)
+		var (
+			sgl *memsys.SpillSGL
+			fqn string
+		)
+		if fqn, _, err = cluster.HrwFQN(&c.m.rs.OutputBck, filetype.DSortWorkfileType, key); err == nil {
+			sgl = mm.NewSpillSGL(size, 0 /*spill under pressure*/, fqn)
+			if _, err = io.Copy(sgl, r); err != nil {
+				sgl.Free()
+				sgl = nil
+			}
+		}
+		c.mu.Lock()
+		if sgl != nil && c.connections[key] != rw {
+			sgl.Free() // nobody's going to read it
+			sgl = nil
+		}
+		rw.sgl, rw.err = sgl, err
+		c.mu.Unlock()
 		rw.wgr.Done()
 		return
 	}
@@ -157,16 +182,35 @@ func (c *rwConnector) connectWriter(key string, w io.Writer) (int64, error) {
 	defer rw.wgw.Done() // inform the reader that the copying has finished
 
 	timed, stopped := rw.wgr.WaitTimeoutWithStop(c.m.callTimeout, c.m.listenAborted()) // wait for reader
-	if timed {
-		return 0, errors.Errorf("wait for remote content has timed out (%q was waiting)", c.m.ctx.node.ID())
-	} else if stopped {
+	if timed || stopped {
+		c.mu.Lock()
+		if c.connections[key] == rw {
+			delete(c.connections, key)
+		}
+		if rw.sgl != nil { // (reader completed in the meantime)
+			rw.sgl.Free()
+			rw.sgl = nil
+		}
+		c.mu.Unlock()
+		if timed {
+			return 0, errors.Errorf("wait for remote content has timed out (%q was waiting)", c.m.ctx.node.ID())
+		}
 		return 0, errors.Errorf("wait for remote content was aborted")
 	}
 
 	if all { // reader connected and left SGL with the content
-		n, err := io.Copy(rw.w, rw.sgl)
-		rw.sgl.Free()
+		c.mu.Lock()
+		sgl, err := rw.sgl, rw.err
 		rw.sgl = nil
+		c.mu.Unlock()
+		if err != nil {
+			return 0, err
+		}
+		if sgl == nil { // freed by cleanup
+			return 0, errors.Errorf("remote content for %q is no longer available", key)
+		}
+		n, err := io.Copy(rw.w, sgl)
+		sgl.Free()
 		return n, err
 	}
 
@@ -373,7 +417,8 @@ func (ds *dsorterMem) createShardsLocally() error {
 					return func() error {
 						defer ds.creationPhase.adjuster.read.releaseGoroutineSema()
 
-						bck := cluster.NewBck(ds.m.rs.OutputBck.Name, ds.m.rs.OutputBck.Provider, cmn.NsGlobal)
+						outputBck := ds.m.outputBck(shard)
+						bck := cluster.NewBck(outputBck.Name, outputBck.Provider, cmn.NsGlobal)
 						if err := bck.Init(ds.m.ctx.bmdOwner); err != nil {
 							return err
 						}
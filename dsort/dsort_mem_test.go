@@ -0,0 +1,142 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rwConnector", func() {
+	const (
+		key     = "record-1"
+		content = "record content"
+	)
+	var (
+		m *Manager
+		c *rwConnector
+	)
+
+	// returns once the reader is connected (and is copying from `r`)
+	startReader := func(r io.Reader) chan error {
+		errCh := make(chan error, 1)
+		go func() { errCh <- c.connectReader(key, r, int64(len(content))) }()
+		Eventually(func() bool {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			_, ok := c.connections[key]
+			return ok
+		}).Should(BeTrue())
+		return errCh
+	}
+	sglOf := func(key string) *memsys.SpillSGL {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if rw, ok := c.connections[key]; ok {
+			return rw.sgl
+		}
+		return nil
+	}
+
+	BeforeEach(func() {
+		Expect(cos.CreateDir(testingConfigDir)).NotTo(HaveOccurred())
+		mm = memsys.PageMM()
+		fs.TestNew(nil)
+		_, err := fs.Add(testingConfigDir, "daeID")
+		Expect(err).NotTo(HaveOccurred())
+
+		m = &Manager{}
+		m.ctx.node = newTestSmap("target").Tmap["target"]
+		m.rs = &ParsedRequestSpec{OutputBck: cmn.Bck{Name: "out", Provider: apc.ProviderAIS}}
+		m.callTimeout = 100 * time.Millisecond
+		m.state.doneCh = make(chan struct{})
+		c = newRWConnector(m)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(testingConfigDir)).NotTo(HaveOccurred())
+	})
+
+	It("should hand over reader's content to the writer", func() {
+		errCh := startReader(strings.NewReader(content))
+		Expect(<-errCh).NotTo(HaveOccurred())
+		Expect(sglOf(key)).NotTo(BeNil())
+
+		w := &bytes.Buffer{}
+		n, err := c.connectWriter(key, w)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(BeEquivalentTo(len(content)))
+		Expect(w.String()).To(Equal(content))
+		Expect(sglOf(key)).To(BeNil())
+	})
+
+	It("should free SGL when the writer times out", func() {
+		pr, pw := io.Pipe()
+		errCh := startReader(pr)
+
+		_, err := c.connectWriter(key, &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("timed out"))
+
+		// the reader completes after the writer has given up
+		_, err = pw.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+		pw.Close()
+		Expect(<-errCh).NotTo(HaveOccurred())
+		Expect(sglOf(key)).To(BeNil())
+	})
+
+	It("should free SGL when the job is aborted", func() {
+		pr, pw := io.Pipe()
+		errCh := startReader(pr)
+
+		close(m.state.doneCh)
+		_, err := c.connectWriter(key, &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("aborted"))
+
+		pw.Write([]byte(content))
+		pw.Close()
+		Expect(<-errCh).NotTo(HaveOccurred())
+		Expect(sglOf(key)).To(BeNil())
+	})
+
+	It("should free SGL upon cleanup", func() {
+		pr, pw := io.Pipe()
+		errCh := startReader(pr)
+		c.free()
+
+		pw.Write([]byte(content))
+		pw.Close()
+		Expect(<-errCh).NotTo(HaveOccurred())
+		Expect(sglOf(key)).To(BeNil())
+
+		_, err := c.connectWriter(key, &bytes.Buffer{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail the writer when the reader fails", func() {
+		pr, pw := io.Pipe()
+		errCh := startReader(pr)
+		pw.CloseWithError(errors.New("stream broken"))
+		Expect(<-errCh).To(HaveOccurred())
+		Expect(sglOf(key)).To(BeNil())
+
+		_, err := c.connectWriter(key, &bytes.Buffer{})
+		Expect(err).To(MatchError("stream broken"))
+	})
+})
@@ -0,0 +1,151 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// interface guard
+var _ Creator = (*convertExtractCreator)(nil)
+
+type (
+	// metadataGenerator is implemented by creators that can create shards
+	// out of records that were extracted by a creator of a different format.
+	metadataGenerator interface {
+		// genMetadata returns (creator-specific) metadata of the file with the
+		// given name, in the format which `CreateShard` expects.
+		genMetadata(name string) []byte
+	}
+
+	// convertExtractCreator extracts shards with `src` and creates them with `dst`.
+	convertExtractCreator struct {
+		src Creator
+		dst Creator
+		gen metadataGenerator
+	}
+
+	convertedObj struct {
+		src      *RecordObj
+		metadata []byte
+	}
+
+	// skipWriter discards first `skip` bytes and writes the rest to `w`.
+	skipWriter struct {
+		w    io.Writer
+		skip int64
+	}
+)
+
+func NewConvertExtractCreator(src, dst Creator) (Creator, error) {
+	gen, ok := dst.(metadataGenerator)
+	if !ok {
+		return nil, fmt.Errorf("conversion to %T is not supported", dst)
+	}
+	return &convertExtractCreator{src: src, dst: dst, gen: gen}, nil
+}
+
+func (c *convertExtractCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt, extractor RecordExtractor,
+	toDisk bool) (int64, int, error) {
+	return c.src.ExtractShard(lom, r, extractor, toDisk)
+}
+
+// CreateShard replaces metadata of all the (source-format) objects with
+// metadata of the destination format and creates the shard with `dst`.
+func (c *convertExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (int64, error) {
+	var (
+		records   = NewRecords(s.Records.Len())
+		converted = make(map[*RecordObj]convertedObj, s.Records.Len())
+	)
+	for _, rec := range s.Records.All() {
+		cvt := &Record{
+			Key:      rec.Key,
+			Name:     rec.Name,
+			DaemonID: rec.DaemonID,
+			Objects:  make([]*RecordObj, 0, len(rec.Objects)),
+		}
+		for _, obj := range rec.Objects {
+			metadata := c.gen.genMetadata(recordObjName(rec, obj))
+			cvtObj := &RecordObj{
+				ContentPath:    obj.ContentPath,
+				ObjectFileType: obj.ObjectFileType,
+				StoreType:      SGLStoreType, // metadata is not a part of the content anymore
+				MetadataSize:   int64(len(metadata)),
+				Size:           obj.Size,
+				Extension:      obj.Extension,
+			}
+			cvt.Objects = append(cvt.Objects, cvtObj)
+			converted[cvtObj] = convertedObj{src: obj, metadata: metadata}
+		}
+		records.arr = append(records.arr, cvt)
+	}
+
+	shard := &Shard{Name: s.Name, Size: s.Size, Records: records}
+	return c.dst.CreateShard(shard, w, func(w io.Writer, rec *Record, obj *RecordObj) (int64, error) {
+		cvt, ok := converted[obj]
+		cos.Assert(ok)
+		if _, err := w.Write(cvt.metadata); err != nil {
+			return 0, err
+		}
+		sw := &skipWriter{w: w, skip: cvt.src.MetadataSize}
+		n, err := loadContent(sw, rec, cvt.src)
+		if n -= cvt.src.MetadataSize; n < 0 {
+			n = 0
+		}
+		return int64(len(cvt.metadata)) + n, err
+	})
+}
+
+func (c *convertExtractCreator) UsingCompression() bool { return c.src.UsingCompression() }
+func (c *convertExtractCreator) SupportsOffset() bool   { return c.src.SupportsOffset() }
+func (c *convertExtractCreator) MetadataSize() int64    { return c.src.MetadataSize() }
+
+// recordObjName returns the name of the object inside the shard, eg. for
+// record "shard-1|dir/sample" and object with extension ".jpg" it is "dir/sample.jpg".
+func recordObjName(rec *Record, obj *RecordObj) string {
+	return rec.NameInShard() + obj.Extension
+}
+
+////////////////
+// skipWriter //
+////////////////
+
+func (sw *skipWriter) Write(p []byte) (int, error) {
+	if sw.skip <= 0 {
+		return sw.w.Write(p)
+	}
+	if int64(len(p)) <= sw.skip {
+		sw.skip -= int64(len(p))
+		return len(p), nil
+	}
+	skipped := int(sw.skip)
+	sw.skip = 0
+	n, err := sw.w.Write(p[skipped:])
+	return skipped + n, err
+}
+
+/////////////////////////
+// metadata generators //
+/////////////////////////
+
+func newRegTarFileHeader(name string) []byte {
+	return cos.MustMarshal(tarFileHeader{Name: name, Typeflag: tar.TypeReg, Mode: 0o644})
+}
+
+func (*tarExtractCreator) genMetadata(name string) []byte   { return newRegTarFileHeader(name) }
+func (*targzExtractCreator) genMetadata(name string) []byte { return newRegTarFileHeader(name) }
+
+func (*zipExtractCreator) genMetadata(name string) []byte {
+	return cos.MustMarshal(zipFileHeader{Name: name})
+}
+
+func (*msgpackExtractCreator) genMetadata(name string) []byte {
+	return cos.MustMarshal(msgpackFileHeader{Name: name})
+}
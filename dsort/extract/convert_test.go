@@ -0,0 +1,205 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmihailenco/msgpack"
+)
+
+var _ = Describe("Convert", func() {
+	// WebDataset-style samples: files sharing basename (up to the first dot)
+	// belong to the same record.
+	files := map[string][]byte{
+		"dir/sample1.jpg":     []byte("jpeg image of sample1"),
+		"dir/sample1.cls":     []byte("1"),
+		"dir/sample1.seg.png": []byte("segmentation of sample1"),
+		"dir/sample2.jpg":     []byte("jpeg image of sample2"),
+		"dir/sample2.cls":     []byte("2"),
+		"dir/sample3.cls":     []byte(""),
+	}
+
+	var (
+		t   = mock.NewTarget(nil)
+		bck = cmn.Bck{Name: "test", Provider: "ais"}
+	)
+
+	newRecordManager := func(ext string, creator Creator) *RecordManager {
+		keyExtractor, err := NewNameKeyExtractor()
+		Expect(err).NotTo(HaveOccurred())
+		return NewRecordManager(t, bck, ext, creator, keyExtractor, func(string) error { return nil })
+	}
+
+	extract := func(rm *RecordManager, creator Creator, shardName string, shard []byte) {
+		lom := &cluster.LOM{ObjName: shardName}
+		lom.SetSize(int64(len(shard)))
+		_, cnt, err := creator.ExtractShard(lom, bytes.NewReader(shard), rm, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cnt).To(Equal(len(files)))
+	}
+
+	create := func(rm *RecordManager, creator Creator, shardName string) []byte {
+		buf := &bytes.Buffer{}
+		loadContent := func(w io.Writer, _ *Record, obj *RecordObj) (int64, error) {
+			fullContentPath := rm.FullContentPath(obj)
+			v, ok := rm.RecordContents().Load(fullContentPath)
+			Expect(ok).To(BeTrue())
+			rm.RecordContents().Delete(fullContentPath)
+			sgl := v.(*memsys.SGL)
+			defer sgl.Free()
+			return io.Copy(w, sgl)
+		}
+		_, err := creator.CreateShard(&Shard{Name: shardName, Records: rm.Records}, buf, loadContent)
+		Expect(err).NotTo(HaveOccurred())
+		return buf.Bytes()
+	}
+
+	newTar := func() []byte {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for name, content := range files {
+			err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Size: int64(len(content)), Mode: 0o644})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tw.Write(content)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(tw.Close()).NotTo(HaveOccurred())
+		return buf.Bytes()
+	}
+
+	readMsgpack := func(b []byte) map[string][]byte {
+		var shard cmn.GenShard
+		Expect(msgpack.NewDecoder(bytes.NewReader(b)).Decode(&shard)).NotTo(HaveOccurred())
+		return shard
+	}
+
+	readZip := func(b []byte) map[string][]byte {
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		Expect(err).NotTo(HaveOccurred())
+		shard := make(map[string][]byte, len(zr.File))
+		for _, f := range zr.File {
+			r, err := f.Open()
+			Expect(err).NotTo(HaveOccurred())
+			shard[f.Name], err = io.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			cos.Close(r)
+		}
+		return shard
+	}
+
+	readTar := func(b []byte) map[string][]byte {
+		tr := tar.NewReader(bytes.NewReader(b))
+		shard := make(map[string][]byte)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).NotTo(HaveOccurred())
+			shard[header.Name], err = io.ReadAll(tr)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		return shard
+	}
+
+	expectFiles := func(shard map[string][]byte) {
+		Expect(shard).To(HaveLen(len(files)))
+		for name, content := range files {
+			Expect(shard).To(HaveKey(name))
+			Expect(shard[name]).To(Equal(content))
+		}
+	}
+
+	It("should group objects with the same basename into a single record", func() {
+		creator := NewTarExtractCreator(t)
+		rm := newRecordManager(cos.ExtTar, creator)
+		defer rm.Cleanup()
+		extract(rm, creator, "shard"+cos.ExtTar, newTar())
+
+		Expect(rm.Records.Len()).To(Equal(3))
+		Expect(rm.Records.TotalObjectCount()).To(Equal(len(files)))
+		rec, ok := rm.Records.Find("shard|dir/sample1")
+		Expect(ok).To(BeTrue())
+		exts := make([]string, 0, len(rec.Objects))
+		for _, obj := range rec.Objects {
+			exts = append(exts, obj.Extension)
+		}
+		Expect(exts).To(ConsistOf(".jpg", ".cls", ".seg.png"))
+	})
+
+	It("should clone records with their contents", func() {
+		creator := NewTarExtractCreator(t)
+		rm := newRecordManager(cos.ExtTar, creator)
+		defer rm.Cleanup()
+		extract(rm, creator, "shard"+cos.ExtTar, newTar())
+
+		rec, ok := rm.Records.Find("shard|dir/sample1")
+		Expect(ok).To(BeTrue())
+		clone, err := rm.CloneRecord(rec, "shard|dir/sample1~dup1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clone.NameInShard()).To(Equal("dir/sample1~dup1"))
+		Expect(rm.Records.Len()).To(Equal(4))
+		Expect(rm.Records.TotalObjectCount()).To(Equal(len(files) + len(rec.Objects)))
+
+		// Contents of the original and the clone are loaded (and freed) independently.
+		for _, r := range []*Record{rec, clone} {
+			for _, obj := range r.Objects {
+				v, ok := rm.RecordContents().LoadAndDelete(rm.FullContentPath(obj))
+				Expect(ok).To(BeTrue())
+				sgl := v.(*memsys.SGL)
+				b, err := io.ReadAll(sgl)
+				Expect(err).NotTo(HaveOccurred())
+				sgl.Free()
+				Expect(b[obj.MetadataSize:]).To(Equal(files["dir/sample1"+obj.Extension]))
+			}
+		}
+	})
+
+	It("should convert tar shard to msgpack shard", func() {
+		src, dst := NewTarExtractCreator(t), NewMsgpackExtractCreator(t)
+		creator, err := NewConvertExtractCreator(src, dst)
+		Expect(err).NotTo(HaveOccurred())
+		rm := newRecordManager(cos.ExtTar, creator)
+		defer rm.Cleanup()
+
+		extract(rm, creator, "shard"+cos.ExtTar, newTar())
+		expectFiles(readMsgpack(create(rm, creator, "shard"+cos.ExtMsgpack)))
+	})
+
+	It("should extract msgpack shard and convert it to zip, tar, and msgpack", func() {
+		var (
+			msgpackCreator = NewMsgpackExtractCreator(t)
+			shard          = &bytes.Buffer{}
+		)
+		Expect(msgpack.NewEncoder(shard).Encode(cmn.GenShard(files))).NotTo(HaveOccurred())
+
+		for _, test := range []struct {
+			dst  Creator
+			read func([]byte) map[string][]byte
+		}{
+			{dst: NewZipExtractCreator(t), read: readZip},
+			{dst: NewTarExtractCreator(t), read: readTar},
+			{dst: msgpackCreator, read: readMsgpack},
+		} {
+			creator, err := NewConvertExtractCreator(msgpackCreator, test.dst)
+			Expect(err).NotTo(HaveOccurred())
+			rm := newRecordManager(cos.ExtMsgpack, creator)
+			extract(rm, creator, "shard"+cos.ExtMsgpack, shard.Bytes())
+			expectFiles(test.read(create(rm, creator, "shard")))
+			rm.Cleanup()
+		}
+	})
+})
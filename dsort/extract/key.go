@@ -10,20 +10,19 @@ import (
 	"fmt"
 	"hash"
 	"io"
-	"strconv"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/pkg/errors"
 )
 
 const (
 	FormatTypeInt    = "int"
 	FormatTypeFloat  = "float"
 	FormatTypeString = "string"
+	FormatTypeTime   = "time" // RFC3339, eg. "2022-03-01T10:00:00Z"
 )
 
 var (
-	supportedFormatTypes = []string{FormatTypeInt, FormatTypeFloat, FormatTypeString}
+	supportedFormatTypes = []string{FormatTypeInt, FormatTypeFloat, FormatTypeString, FormatTypeTime}
 
 	errInvalidAlgorithmFormatTypes = fmt.Errorf("invalid algorithm format type provided, shoule be one of: %+v", supportedFormatTypes)
 )
@@ -47,8 +46,9 @@ type (
 
 	nameKeyExtractor    struct{}
 	contentKeyExtractor struct {
-		ty  string // type of key extracted, supported: supportedFormatTypes
-		ext string // extension of object record whose content will be read
+		ty     string     // type of key extracted, supported: supportedFormatTypes
+		ext    string     // extension of object record whose content will be read
+		fields []KeyField // if set, (composite) key is extracted from JSON or CSV content
 	}
 )
 
@@ -78,12 +78,26 @@ func (*nameKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{}, error
 	return ske.name, nil
 }
 
-func NewContentKeyExtractor(ty, ext string) (KeyExtractor, error) {
-	if err := ValidateAlgorithmFormatType(ty); err != nil {
-		return nil, err
+// NewContentKeyExtractor creates key extractor which reads the key from the
+// content of the object with given extension. When `fields` are provided the
+// content is parsed as JSON or CSV document and the key is composed of the
+// selected fields, otherwise the whole content is interpreted as `ty`.
+func NewContentKeyExtractor(ty, ext string, fields []KeyField) (KeyExtractor, error) {
+	if len(fields) == 0 {
+		if err := ValidateAlgorithmFormatType(ty); err != nil {
+			return nil, err
+		}
+		return &contentKeyExtractor{ty: ty, ext: ext}, nil
 	}
 
-	return &contentKeyExtractor{ty: ty, ext: ext}, nil
+	ke := &contentKeyExtractor{ext: ext, fields: make([]KeyField, len(fields))}
+	copy(ke.fields, fields)
+	for i := range ke.fields {
+		if err := ke.fields[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return ke, nil
 }
 
 func (ke *contentKeyExtractor) PrepareExtractor(name string, r cos.ReadSizer, ext string) (cos.ReadSizer, *SingleKeyExtractor, bool) {
@@ -107,17 +121,14 @@ func (ke *contentKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{},
 		return nil, err
 	}
 
-	key := string(b)
-	switch ke.ty {
-	case FormatTypeInt:
-		return strconv.ParseInt(key, 10, 64)
-	case FormatTypeFloat:
-		return strconv.ParseFloat(key, 64)
-	case FormatTypeString:
-		return key, nil
-	default:
-		return nil, errors.Errorf("not implemented extractor type: %s", ke.ty)
+	if len(ke.fields) > 0 {
+		// NOTE: all fields are of the same kind - validated with the request spec.
+		if ke.fields[0].JSONPath != "" {
+			return extractJSONKey(b, ke.fields)
+		}
+		return extractCSVKey(b, ke.fields)
 	}
+	return parseKey(ke.ty, string(b))
 }
 
 func ValidateAlgorithmFormatType(ty string) error {
@@ -0,0 +1,358 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+type (
+	// KeyField describes single field of the (composite) sorting key. Exactly
+	// one of `JSONPath` and `CSVColumn` must be set.
+	KeyField struct {
+		// Path to the value in JSON document, eg. "$.label" or "$.annotations[0].ts".
+		JSONPath string `json:"json_path,omitempty"`
+		// Name (from the header) or 0-based index of the column in CSV document.
+		CSVColumn string `json:"csv_column,omitempty"`
+		// Determines how the value is interpreted (and compared): int, float, string or time.
+		FormatType string `json:"format_type"`
+
+		jsonPath []interface{} // parsed `JSONPath`
+	}
+
+	// KeyTable maps record names (without shard name and extension) to their keys.
+	KeyTable map[string][]interface{}
+
+	// KeyPredicate is satisfied when the key of the record (or the first field
+	// of the composite key) compared with `Value` satisfies `Op`.
+	KeyPredicate struct {
+		Op    string `json:"op"`    // one of: "eq", "ne", "lt", "le", "gt", "ge"
+		Value string `json:"value"` // parsed according to the format type of the key
+
+		value interface{} // parsed `Value`
+	}
+)
+
+const (
+	KeyOpEq = "eq"
+	KeyOpNe = "ne"
+	KeyOpLt = "lt"
+	KeyOpLe = "le"
+	KeyOpGt = "gt"
+	KeyOpGe = "ge"
+)
+
+var supportedKeyOps = []string{KeyOpEq, KeyOpNe, KeyOpLt, KeyOpLe, KeyOpGt, KeyOpGe}
+
+//////////////
+// KeyField //
+//////////////
+
+func (f *KeyField) Validate() (err error) {
+	switch {
+	case f.JSONPath != "" && f.CSVColumn != "":
+		return errors.New("key field cannot have both 'json_path' and 'csv_column' set")
+	case f.JSONPath != "":
+		if f.jsonPath, err = parseJSONPath(f.JSONPath); err != nil {
+			return err
+		}
+	case f.CSVColumn != "":
+	default:
+		return errors.New("key field must have either 'json_path' or 'csv_column' set")
+	}
+	return ValidateAlgorithmFormatType(f.FormatType)
+}
+
+func (f *KeyField) String() string {
+	if f.JSONPath != "" {
+		return "json_path " + f.JSONPath
+	}
+	return "csv_column " + f.CSVColumn
+}
+
+// parseJSONPath parses the (simplified) JSONPath: sequence of object keys
+// separated with dots and array indices in square brackets, eg. "$.a.b[1].c".
+func parseJSONPath(path string) ([]interface{}, error) {
+	var (
+		parsed []interface{}
+		p      = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	)
+	for p != "" {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			end := strings.IndexAny(p, ".[")
+			if end < 0 {
+				end = len(p)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: empty key", path)
+			}
+			parsed = append(parsed, p[:end])
+			p = p[end:]
+		case '[':
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: missing ']'", path)
+			}
+			idx, err := strconv.Atoi(p[1:end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: invalid array index %q", path, p[1:end])
+			}
+			parsed = append(parsed, idx)
+			p = p[end+1:]
+		default:
+			if len(parsed) > 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: unexpected %q", path, p)
+			}
+			p = "." + p // allow "a.b" as a shortcut for "$.a.b"
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("invalid JSONPath %q: empty path", path)
+	}
+	return parsed, nil
+}
+
+// parseKey interprets the value according to the format type.
+func parseKey(ty, value string) (interface{}, error) {
+	switch ty {
+	case FormatTypeInt:
+		return strconv.ParseInt(value, 10, 64)
+	case FormatTypeFloat:
+		return strconv.ParseFloat(value, 64)
+	case FormatTypeString:
+		return value, nil
+	case FormatTypeTime:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, err
+		}
+		return t.UnixNano(), nil // compared as int
+	default:
+		return nil, errors.Errorf("not implemented extractor type: %s", ty)
+	}
+}
+
+// extractJSONKey extracts composite key from the JSON document.
+func extractJSONKey(b []byte, fields []KeyField) ([]interface{}, error) {
+	key := make([]interface{}, 0, len(fields))
+	for i := range fields {
+		field := &fields[i]
+		cos.Assert(field.jsonPath != nil)
+		v := jsoniter.Get(b, field.jsonPath...)
+		if err := v.LastError(); err != nil {
+			return nil, errors.Errorf("failed to find %s: %v", field, err)
+		}
+		// NOTE: for numbers `ToString` returns their textual representation.
+		k, err := parseKey(field.FormatType, v.ToString())
+		if err != nil {
+			return nil, errors.Errorf("failed to parse %s: %v", field, err)
+		}
+		key = append(key, k)
+	}
+	return key, nil
+}
+
+// extractCSVKey extracts composite key from the first data row of CSV
+// document: the row after the header or the only row of headerless document.
+func extractCSVKey(b []byte, fields []KeyField) ([]interface{}, error) {
+	rows, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("empty CSV document")
+	}
+	header, row := rows[0], rows[0]
+	if len(rows) > 1 {
+		row = rows[1]
+	}
+	return csvRowKey(header, row, fields)
+}
+
+func csvRowKey(header, row []string, fields []KeyField) ([]interface{}, error) {
+	key := make([]interface{}, 0, len(fields))
+	for i := range fields {
+		field := &fields[i]
+		idx := csvColumnIndex(header, field.CSVColumn)
+		if idx < 0 || idx >= len(row) {
+			return nil, errors.Errorf("failed to find %s", field)
+		}
+		k, err := parseKey(field.FormatType, strings.TrimSpace(row[idx]))
+		if err != nil {
+			return nil, errors.Errorf("failed to parse %s: %v", field, err)
+		}
+		key = append(key, k)
+	}
+	return key, nil
+}
+
+func csvColumnIndex(header []string, column string) int {
+	for idx, name := range header {
+		if strings.TrimSpace(name) == column {
+			return idx
+		}
+	}
+	if idx, err := strconv.Atoi(column); err == nil {
+		return idx
+	}
+	return -1
+}
+
+//////////////
+// KeyTable //
+//////////////
+
+// ReadKeyTable reads CSV key table (with header) where the first column
+// contains record names and `fields` select the columns of the key.
+func ReadKeyTable(r io.Reader, sep rune, fields []KeyField) (KeyTable, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = sep
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, errors.Errorf("failed to read key table header: %v", err)
+	}
+	header = append([]string(nil), header...) // `ReuseRecord`
+
+	table := make(KeyTable, 1000)
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return table, nil
+		}
+		if err != nil {
+			return nil, errors.Errorf("failed to read key table: %v", err)
+		}
+		key, err := csvRowKey(header, row, fields)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, errors.Errorf("malformed line (%d) in key table: %v", line, err)
+		}
+		table[strings.TrimSpace(row[0])] = key
+	}
+}
+
+//////////////////
+// KeyPredicate //
+//////////////////
+
+// Init validates the predicate and parses its value according to `formatType`.
+func (p *KeyPredicate) Init(formatType string) (err error) {
+	if !cos.StringInSlice(p.Op, supportedKeyOps) {
+		return errors.Errorf("invalid key predicate operator %q, expecting one of: %v", p.Op, supportedKeyOps)
+	}
+	if p.value, err = parseKey(formatType, p.Value); err != nil {
+		return errors.Errorf("invalid key predicate value %q: %v", p.Value, err)
+	}
+	return nil
+}
+
+// Match returns true if the key satisfies the predicate. Keys which are
+// missing or cannot be compared with the value never satisfy the predicate.
+func (p *KeyPredicate) Match(key interface{}) bool {
+	if composite, ok := key.([]interface{}); ok {
+		if len(composite) == 0 {
+			return false
+		}
+		key = composite[0]
+	}
+	if key == nil {
+		return false
+	}
+	c, err := compareValues(key, p.value)
+	if err != nil {
+		return false
+	}
+	switch p.Op {
+	case KeyOpEq:
+		return c == 0
+	case KeyOpNe:
+		return c != 0
+	case KeyOpLt:
+		return c < 0
+	case KeyOpLe:
+		return c <= 0
+	case KeyOpGt:
+		return c > 0
+	case KeyOpGe:
+		return c >= 0
+	}
+	return false
+}
+
+/////////////////////
+// key comparisons //
+/////////////////////
+
+// lessComposite compares composite keys field by field.
+func lessComposite(lhs, rhs []interface{}) (bool, error) {
+	for k := 0; k < len(lhs) && k < len(rhs); k++ {
+		c, err := compareValues(lhs[k], rhs[k])
+		if err != nil {
+			return false, err
+		}
+		if c != 0 {
+			return c < 0, nil
+		}
+	}
+	return len(lhs) < len(rhs), nil
+}
+
+// compareValues compares single fields of composite keys. Since keys are
+// sent between targets (and possibly through JSON) their types are checked
+// dynamically: integers may be received as floats.
+func compareValues(lhs, rhs interface{}) (int, error) {
+	if l, ok := lhs.(string); ok {
+		if r, ok := rhs.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	} else if l, ok := lhs.(int64); ok {
+		if r, ok := rhs.(int64); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	l, lok := toFloat(lhs)
+	r, rok := toFloat(rhs)
+	if !lok || !rok {
+		return 0, errors.Errorf("keys %v (%T) and %v (%T) cannot be compared", lhs, lhs, rhs, rhs)
+	}
+	switch {
+	case l < r:
+		return -1, nil
+	case l > r:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
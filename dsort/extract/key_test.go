@@ -0,0 +1,166 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyExtractor", func() {
+	extractKey := func(ke KeyExtractor, ext, content string) (interface{}, error) {
+		r := cos.NewSizedReader(strings.NewReader(content), int64(len(content)))
+		r, ske, needRead := ke.PrepareExtractor("sample"+ext, r, ext)
+		Expect(needRead).To(BeTrue())
+		_, err := bytes.NewBuffer(nil).ReadFrom(r)
+		Expect(err).NotTo(HaveOccurred())
+		return ke.ExtractKey(ske)
+	}
+
+	Context("content", func() {
+		It("should extract int key", func() {
+			ke, err := NewContentKeyExtractor(FormatTypeInt, ".cls", nil)
+			Expect(err).NotTo(HaveOccurred())
+			key, err := extractKey(ke, ".cls", "42")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(int64(42)))
+		})
+
+		It("should extract time key", func() {
+			ke, err := NewContentKeyExtractor(FormatTypeTime, ".ts", nil)
+			Expect(err).NotTo(HaveOccurred())
+			key, err := extractKey(ke, ".ts", "2022-03-01T10:00:00Z")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC).UnixNano()))
+		})
+
+		It("should not read content with different extension", func() {
+			ke, err := NewContentKeyExtractor(FormatTypeInt, ".cls", nil)
+			Expect(err).NotTo(HaveOccurred())
+			_, ske, needRead := ke.PrepareExtractor("sample.jpg", cos.NewSizedReader(strings.NewReader(""), 0), ".jpg")
+			Expect(needRead).To(BeFalse())
+			key, err := ke.ExtractKey(ske)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(BeNil())
+		})
+	})
+
+	Context("json", func() {
+		const content = `{"label": "cat", "meta": {"len": 120, "ts": "2022-03-01T10:00:00Z"}, "boxes": [{"score": 0.5}]}`
+
+		It("should extract composite key", func() {
+			ke, err := NewContentKeyExtractor("", ".json", []KeyField{
+				{JSONPath: "$.label", FormatType: FormatTypeString},
+				{JSONPath: "$.meta.len", FormatType: FormatTypeInt},
+				{JSONPath: "meta.ts", FormatType: FormatTypeTime},
+				{JSONPath: "$.boxes[0].score", FormatType: FormatTypeFloat},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			key, err := extractKey(ke, ".json", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal([]interface{}{
+				"cat", int64(120), time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC).UnixNano(), 0.5,
+			}))
+		})
+
+		It("should fail when path does not exist", func() {
+			ke, err := NewContentKeyExtractor("", ".json", []KeyField{{JSONPath: "$.meta.size", FormatType: FormatTypeInt}})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = extractKey(ke, ".json", content)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail when value has invalid type", func() {
+			ke, err := NewContentKeyExtractor("", ".json", []KeyField{{JSONPath: "$.label", FormatType: FormatTypeInt}})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = extractKey(ke, ".json", content)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail on invalid path", func() {
+			for _, path := range []string{"$", "$.", "$.a..b", "$.a[x]", "$.a[1"} {
+				_, err := NewContentKeyExtractor("", ".json", []KeyField{{JSONPath: path, FormatType: FormatTypeInt}})
+				Expect(err).To(HaveOccurred(), path)
+			}
+		})
+	})
+
+	Context("csv", func() {
+		It("should extract key by column name and index", func() {
+			ke, err := NewContentKeyExtractor("", ".csv", []KeyField{
+				{CSVColumn: "label", FormatType: FormatTypeString},
+				{CSVColumn: "2", FormatType: FormatTypeFloat},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			key, err := extractKey(ke, ".csv", "len,label,score\n120,cat,0.5\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal([]interface{}{"cat", 0.5}))
+		})
+
+		It("should extract key from headerless document", func() {
+			ke, err := NewContentKeyExtractor("", ".csv", []KeyField{{CSVColumn: "0", FormatType: FormatTypeInt}})
+			Expect(err).NotTo(HaveOccurred())
+			key, err := extractKey(ke, ".csv", "120,cat\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal([]interface{}{int64(120)}))
+		})
+
+		It("should fail when column does not exist", func() {
+			ke, err := NewContentKeyExtractor("", ".csv", []KeyField{{CSVColumn: "size", FormatType: FormatTypeInt}})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = extractKey(ke, ".csv", "len,label\n120,cat\n")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("key table", func() {
+		It("should read key table", func() {
+			table, err := ReadKeyTable(strings.NewReader("name\tlabel\tlen\ndir/a\tcat\t10\ndir/b\tdog\t2\n"), '\t', []KeyField{
+				{CSVColumn: "label", FormatType: FormatTypeString},
+				{CSVColumn: "len", FormatType: FormatTypeInt},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table).To(Equal(KeyTable{
+				"dir/a": {"cat", int64(10)},
+				"dir/b": {"dog", int64(2)},
+			}))
+		})
+
+		It("should fail on malformed line", func() {
+			_, err := ReadKeyTable(strings.NewReader("name,len\ndir/a,10\ndir/b,abc\n"), ',', []KeyField{
+				{CSVColumn: "len", FormatType: FormatTypeInt},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("key predicate", func() {
+		It("should match keys", func() {
+			p := &KeyPredicate{Op: KeyOpGe, Value: "10"}
+			Expect(p.Init(FormatTypeInt)).NotTo(HaveOccurred())
+			Expect(p.Match(int64(10))).To(BeTrue())
+			Expect(p.Match(float64(11))).To(BeTrue()) // received through JSON
+			Expect(p.Match(int64(9))).To(BeFalse())
+			Expect(p.Match([]interface{}{int64(12), "x"})).To(BeTrue())
+			Expect(p.Match(nil)).To(BeFalse())
+			Expect(p.Match("abc")).To(BeFalse())
+
+			p = &KeyPredicate{Op: KeyOpNe, Value: "cat"}
+			Expect(p.Init(FormatTypeString)).NotTo(HaveOccurred())
+			Expect(p.Match("dog")).To(BeTrue())
+			Expect(p.Match("cat")).To(BeFalse())
+		})
+
+		It("should fail on invalid predicate", func() {
+			Expect((&KeyPredicate{Op: "in", Value: "10"}).Init(FormatTypeInt)).To(HaveOccurred())
+			Expect((&KeyPredicate{Op: KeyOpEq, Value: "abc"}).Init(FormatTypeInt)).To(HaveOccurred())
+		})
+	})
+})
@@ -233,6 +233,122 @@ func (rm *RecordManager) MergeEnqueuedRecords() {
 	cos.FreeMemToOS()
 }
 
+// FilterRecords removes the records for which `keep` returns false, together
+// with their extracted contents, and returns the number of removed records.
+func (rm *RecordManager) FilterRecords(keep func(*Record) bool) int {
+	removed := rm.Records.filter(keep)
+	for _, record := range removed {
+		for _, obj := range record.Objects {
+			switch obj.StoreType {
+			case OffsetStoreType:
+				// nothing to do - content is stored in the shard itself
+			case SGLStoreType:
+				if v, ok := rm.contents.LoadAndDelete(rm.FullContentPath(obj)); ok {
+					v.(*memsys.SGL).Free()
+				}
+			case DiskStoreType:
+				fullContentPath := rm.FullContentPath(obj)
+				if err := os.Remove(fullContentPath); err != nil && !os.IsNotExist(err) {
+					glog.Errorf("could not remove extracted content of the filtered record (%s), err: %v", fullContentPath, err)
+				}
+				rm.extractionPaths.Delete(fullContentPath)
+			default:
+				cos.AssertMsg(false, obj.StoreType)
+			}
+		}
+	}
+	return len(removed)
+}
+
+// CloneRecord adds a copy of the record under the given unique name (see:
+// `Record.Name`). Extracted contents of the record are copied so that the
+// copy can be loaded (and freed) independently of the original.
+func (rm *RecordManager) CloneRecord(r *Record, name string) (*Record, error) {
+	clone := &Record{
+		Key:      r.Key,
+		Name:     name,
+		DaemonID: r.DaemonID,
+		Objects:  make([]*RecordObj, 0, len(r.Objects)),
+	}
+	for _, obj := range r.Objects {
+		cloneObj := *obj
+		switch obj.StoreType {
+		case OffsetStoreType:
+			// nothing to do - content is read from the shard itself
+		case SGLStoreType:
+			v, ok := rm.contents.Load(rm.FullContentPath(obj))
+			cos.Assert(ok)
+			sgl := v.(*memsys.SGL)
+			cloneSGL := rm.t.PageMM().NewSGL(sgl.Size())
+			if _, err := io.Copy(cloneSGL, memsys.NewReader(sgl)); err != nil {
+				cloneSGL.Free()
+				return nil, err
+			}
+			cloneObj.ContentPath = clone.MakeUniqueName(obj)
+			rm.contents.Store(rm.FullContentPath(&cloneObj), cloneSGL)
+		case DiskStoreType:
+			cloneObj.ContentPath = clone.MakeUniqueName(obj)
+			fullContentPath := rm.FullContentPath(&cloneObj)
+			rm.extractionPaths.Store(fullContentPath, struct{}{})
+			if _, _, err := cos.CopyFile(rm.FullContentPath(obj), fullContentPath, nil, cos.ChecksumNone); err != nil {
+				return nil, err
+			}
+		default:
+			cos.AssertMsg(false, obj.StoreType)
+		}
+		clone.Objects = append(clone.Objects, &cloneObj)
+	}
+	rm.Records.Insert(clone)
+	return clone, nil
+}
+
+// SpillContents moves all extracted contents which are kept in memory either
+// to the disk or, if the creator supports it, back to the offsets in the shards.
+// Returns error if some of the contents could not be moved.
+func (rm *RecordManager) SpillContents(buf []byte) error {
+	storeType := DiskStoreType
+	if rm.extractCreator.SupportsOffset() {
+		storeType = OffsetStoreType
+	}
+	rm.contents.Range(func(key, value interface{}) bool {
+		rm.ChangeStoreType(key.(string), storeType, value, buf)
+		return true
+	})
+	for _, record := range rm.Records.All() {
+		for _, obj := range record.Objects {
+			if obj.StoreType == SGLStoreType {
+				return errors.Errorf("failed to spill content of the record %q", record.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreRecords adds records extracted by the previous run of the job. The
+// contents of the records must be stored on the disk or in the shards (see:
+// `SpillContents`).
+func (rm *RecordManager) RestoreRecords(records *Records) {
+	for _, record := range records.All() {
+		for _, obj := range record.Objects {
+			switch obj.StoreType {
+			case OffsetStoreType:
+				// nothing to do - content is stored in the shard itself
+			case DiskStoreType:
+				rm.extractionPaths.Store(rm.FullContentPath(obj), struct{}{})
+			default:
+				cos.AssertMsg(false, obj.StoreType)
+			}
+		}
+	}
+	rm.Records.merge(records)
+}
+
+// KeepExtractionPaths makes `Cleanup` leave the extracted contents on the disk
+// so that they can be restored by the next run of the job.
+func (rm *RecordManager) KeepExtractionPaths() {
+	rm.extractionPaths = &sync.Map{}
+}
+
 func (rm *RecordManager) genRecordUniqueName(shardName, recordName string) string {
 	shardWithoutExt := strings.TrimSuffix(shardName, rm.extension)
 	recordWithoutExt := strings.TrimSuffix(recordName, Ext(recordName))
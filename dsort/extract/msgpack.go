@@ -0,0 +1,197 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/vmihailenco/msgpack"
+)
+
+// NOTE: msgpack shard is a single map[string][]byte (see `cmn.GenShard`) where
+// keys are the names of the files and values are their contents - the same
+// format that is produced by the archive (`xs/archive.go`) xaction.
+
+// interface guard
+var _ Creator = (*msgpackExtractCreator)(nil)
+
+type (
+	msgpackExtractCreator struct {
+		t cluster.Target
+	}
+
+	msgpackFileHeader struct {
+		Name string `json:"name"`
+	}
+
+	// msgpackRecordDataReader is used for writing metadata as well as data to the buffer.
+	msgpackRecordDataReader struct {
+		slab *memsys.Slab
+
+		metadataSize int64
+		size         int64
+		written      int64
+		metadataBuf  []byte
+		enc          *msgpack.Encoder
+		w            io.Writer
+	}
+)
+
+func newMsgpackRecordDataReader(t cluster.Target) *msgpackRecordDataReader {
+	rd := &msgpackRecordDataReader{}
+	rd.metadataBuf, rd.slab = t.ByteMM().Alloc()
+	return rd
+}
+
+func (rd *msgpackRecordDataReader) reinit(enc *msgpack.Encoder, w io.Writer, size, metadataSize int64) {
+	rd.enc = enc
+	rd.w = w
+	rd.written = 0
+	rd.size = size
+	rd.metadataSize = metadataSize
+}
+
+func (rd *msgpackRecordDataReader) free() {
+	rd.slab.Free(rd.metadataBuf)
+}
+
+func (rd *msgpackRecordDataReader) Write(p []byte) (int, error) {
+	// Read header and write map key together with the length of the value
+	remainingMetadataSize := rd.metadataSize - rd.written
+	if remainingMetadataSize > 0 {
+		writeN := int64(len(p))
+		if writeN < remainingMetadataSize {
+			debug.Assert(int64(len(rd.metadataBuf))-rd.written >= writeN)
+			copy(rd.metadataBuf[rd.written:], p)
+			rd.written += writeN
+			return len(p), nil
+		}
+		debug.Assert(int64(len(rd.metadataBuf))-rd.written >= remainingMetadataSize)
+
+		copy(rd.metadataBuf[rd.written:], p[:remainingMetadataSize])
+		rd.written += remainingMetadataSize
+		p = p[remainingMetadataSize:]
+		var metadata msgpackFileHeader
+		if err := jsoniter.Unmarshal(rd.metadataBuf[:rd.metadataSize], &metadata); err != nil {
+			return int(remainingMetadataSize), err
+		}
+		if err := rd.enc.EncodeString(metadata.Name); err != nil {
+			return int(remainingMetadataSize), err
+		}
+		if err := rd.enc.EncodeBytesLen(int(rd.size)); err != nil {
+			return int(remainingMetadataSize), err
+		}
+	} else {
+		remainingMetadataSize = 0
+	}
+
+	n, err := rd.w.Write(p)
+	rd.written += int64(n)
+	return n + int(remainingMetadataSize), err
+}
+
+// ExtractShard reads the msgpack shard and extracts its metadata.
+func (m *msgpackExtractCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt, extractor RecordExtractor,
+	toDisk bool) (extractedSize int64, extractedCount int, err error) {
+	var (
+		n, l int
+		size int64
+		br   = bufio.NewReader(r) // NOTE: decoder reads directly from `io.ByteScanner`
+		dec  = msgpack.NewDecoder(br)
+	)
+
+	if n, err = dec.DecodeMapLen(); err != nil {
+		return extractedSize, extractedCount, err
+	}
+
+	buf, slab := m.t.PageMM().AllocSize(lom.SizeBytes())
+	defer slab.Free(buf)
+
+	extractMethod := ExtractToMem
+	if toDisk {
+		extractMethod = ExtractToDisk
+	}
+	for i := 0; i < n; i++ {
+		var name string
+		if name, err = dec.DecodeString(); err != nil {
+			return extractedSize, extractedCount, err
+		}
+		if l, err = dec.DecodeBytesLen(); err != nil {
+			return extractedSize, extractedCount, err
+		}
+		if l < 0 { // nil
+			l = 0
+		}
+
+		bmeta := cos.MustMarshal(msgpackFileHeader{Name: name})
+		data := io.LimitReader(br, int64(l))
+		args := extractRecordArgs{
+			shardName:     lom.ObjName,
+			fileType:      fs.ObjectType,
+			recordName:    name,
+			r:             cos.NewSizedReader(data, int64(l)),
+			metadata:      bmeta,
+			extractMethod: extractMethod,
+			buf:           buf,
+		}
+		if size, err = extractor.ExtractRecordWithBuffer(args); err != nil {
+			return extractedSize, extractedCount, err
+		}
+		// Skip whatever was not read (eg. duplicated record) to get to the next key.
+		if _, err = io.CopyBuffer(io.Discard, data, buf); err != nil {
+			return extractedSize, extractedCount, err
+		}
+
+		extractedSize += size
+		extractedCount++
+	}
+
+	return extractedSize, extractedCount, nil
+}
+
+func NewMsgpackExtractCreator(t cluster.Target) Creator {
+	return &msgpackExtractCreator{t: t}
+}
+
+// CreateShard creates a new shard locally based on the Shard.
+func (m *msgpackExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	var (
+		n    int64
+		cnt  int
+		recs = s.Records.All()
+		enc  = msgpack.NewEncoder(w)
+	)
+	for _, rec := range recs {
+		cnt += len(rec.Objects)
+	}
+	if err = enc.EncodeMapLen(cnt); err != nil {
+		return written, err
+	}
+
+	rdReader := newMsgpackRecordDataReader(m.t)
+	defer rdReader.free()
+	for _, rec := range recs {
+		for _, obj := range rec.Objects {
+			rdReader.reinit(enc, w, obj.Size, obj.MetadataSize)
+			if n, err = loadContent(rdReader, rec, obj); err != nil {
+				return written + n, err
+			}
+
+			written += n
+		}
+	}
+	return written, nil
+}
+
+func (*msgpackExtractCreator) UsingCompression() bool { return false }
+func (*msgpackExtractCreator) SupportsOffset() bool   { return false }
+func (*msgpackExtractCreator) MetadataSize() int64    { return 0 } // msgpack does not have header size
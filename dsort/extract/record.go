@@ -7,6 +7,7 @@ package extract
 import (
 	"encoding/json"
 	"os"
+	"strings"
 	"sync"
 	"unsafe"
 
@@ -127,6 +128,12 @@ func (r *Record) MakeUniqueName(obj *RecordObj) string {
 	return r.Name + obj.Extension
 }
 
+// NameInShard returns the name of the record inside the shard - without the
+// shard name, eg. "dir/sample" for record "shard-1|dir/sample".
+func (r *Record) NameInShard() string {
+	return r.Name[strings.IndexByte(r.Name, '|')+1:]
+}
+
 // NewRecords creates new instance of Records struct and allocates n places for
 // the actual Record's
 func NewRecords(n int) *Records {
@@ -193,6 +200,29 @@ func (r *Records) Exists(name, ext string) (exists bool) {
 	return
 }
 
+// filter removes (preserving the order of the remaining ones) all the records
+// for which `keep` returns false and returns the removed records.
+func (r *Records) filter(keep func(*Record) bool) (removed []*Record) {
+	r.Lock()
+	n := 0
+	for _, record := range r.arr {
+		if keep(record) {
+			r.arr[n] = record
+			n++
+			continue
+		}
+		removed = append(removed, record)
+		delete(r.m, record.Name)
+		r.totalObjectCount -= len(record.Objects)
+	}
+	for i := n; i < len(r.arr); i++ {
+		r.arr[i] = nil
+	}
+	r.arr = r.arr[:n]
+	r.Unlock()
+	return
+}
+
 func (r *Records) merge(records *Records) {
 	r.Insert(records.arr...)
 }
@@ -221,8 +251,17 @@ func (r *Records) Less(i, j int, formatType string) (bool, error) {
 		return false, errors.Errorf("key is missing for %q", r.arr[j].Name)
 	}
 
+	// Composite keys (see `KeyField`) are compared field by field.
+	if clhs, ok := lhs.([]interface{}); ok {
+		crhs, ok := rhs.([]interface{})
+		if !ok {
+			return false, errors.Errorf("keys of %q and %q differ in type", r.arr[i].Name, r.arr[j].Name)
+		}
+		return lessComposite(clhs, crhs)
+	}
+
 	switch formatType {
-	case FormatTypeInt:
+	case FormatTypeInt, FormatTypeTime:
 		ilhs, lok := lhs.(int64)
 		irhs, rok := rhs.(int64)
 		if lok && rok {
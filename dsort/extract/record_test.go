@@ -148,4 +148,32 @@ var _ = Describe("Records", func() {
 			Expect(records.All()[0].TotalSize()).To(BeEquivalentTo(objectSize))
 		})
 	})
+
+	Context("filter", func() {
+		It("should filter records", func() {
+			records := NewRecords(0)
+			for _, name := range []string{"shard|a", "shard|b", "shard|c"} {
+				records.Insert(&Record{
+					Key:  name,
+					Name: name,
+					Objects: []*RecordObj{
+						{Size: objectSize, Extension: ".cls"},
+						{Size: objectSize, Extension: ".jpg"},
+					},
+				})
+			}
+			Expect(records.TotalObjectCount()).To(Equal(6))
+
+			removed := records.filter(func(r *Record) bool { return r.NameInShard() != "b" })
+			Expect(removed).To(HaveLen(1))
+			Expect(removed[0].Name).To(Equal("shard|b"))
+
+			Expect(records.Len()).To(Equal(2))
+			Expect(records.TotalObjectCount()).To(Equal(4))
+			_, exists := records.Find("shard|b")
+			Expect(exists).To(BeFalse())
+			_, exists = records.Find("shard|c")
+			Expect(exists).To(BeTrue())
+		})
+	})
 })
@@ -25,6 +25,9 @@ type (
 		Records *Records `msg:"r"`
 		// Name determines the output name of the shard.
 		Name string `msg:"n"`
+		// Split is the name of the output split (eg. "train") the shard belongs
+		// to, empty when the records are not split.
+		Split string `msg:"sp,omitempty"`
 	}
 )
 
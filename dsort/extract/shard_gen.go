@@ -56,6 +56,12 @@ func (z *Shard) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Name")
 				return
 			}
+		case "sp":
+			z.Split, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Split")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -69,9 +75,23 @@ func (z *Shard) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *Shard) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
+	// omitempty: check for empty values
+	zb0001Len := uint32(4)
+	var zb0001Mask uint8 /* 4 bits */
+	if z.Split == "" {
+		zb0001Len--
+		zb0001Mask |= 0x8
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
+	if err != nil {
+		return
+	}
+	if zb0001Len == 0 {
+		return
+	}
 	// write "s"
-	err = en.Append(0x83, 0xa1, 0x73)
+	err = en.Append(0xa1, 0x73)
 	if err != nil {
 		return
 	}
@@ -107,6 +127,18 @@ func (z *Shard) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Name")
 		return
 	}
+	if (zb0001Mask & 0x8) == 0 { // if not empty
+		// write "sp"
+		err = en.Append(0xa2, 0x73, 0x70)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Split)
+		if err != nil {
+			err = msgp.WrapError(err, "Split")
+			return
+		}
+	}
 	return
 }
 
@@ -118,6 +150,6 @@ func (z *Shard) Msgsize() (s int) {
 	} else {
 		s += z.Records.Msgsize()
 	}
-	s += 2 + msgp.StringPrefixSize + len(z.Name)
+	s += 2 + msgp.StringPrefixSize + len(z.Name) + 3 + msgp.StringPrefixSize + len(z.Split)
 	return
 }
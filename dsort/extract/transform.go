@@ -0,0 +1,154 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// interface guard
+var (
+	_ Creator           = (*transformExtractCreator)(nil)
+	_ metadataGenerator = (*transformExtractCreator)(nil)
+)
+
+type (
+	// TransformFunc transforms the content of a single record object.
+	TransformFunc func(r io.Reader, size int64) (cos.ReadCloseSizer, error)
+
+	// transformExtractCreator transforms (selected) record objects before the
+	// shard is created with `internal` creator.
+	transformExtractCreator struct {
+		t          cluster.Target
+		internal   Creator
+		gen        metadataGenerator
+		transform  TransformFunc
+		extensions []string // if empty all objects are transformed
+	}
+)
+
+func NewTransformExtractCreator(t cluster.Target, internal Creator, transform TransformFunc, extensions []string) (Creator, error) {
+	gen, ok := internal.(metadataGenerator)
+	if !ok {
+		return nil, fmt.Errorf("transformation of records for %T is not supported", internal)
+	}
+	return &transformExtractCreator{
+		t:          t,
+		internal:   internal,
+		gen:        gen,
+		transform:  transform,
+		extensions: extensions,
+	}, nil
+}
+
+func (tc *transformExtractCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt, extractor RecordExtractor,
+	toDisk bool) (int64, int, error) {
+	return tc.internal.ExtractShard(lom, r, extractor, toDisk)
+}
+
+// CreateShard transforms selected objects of the shard and creates the shard
+// with `internal` creator. Since the size of the object must be known before
+// it is written, all transformed objects of the shard are kept in memory.
+func (tc *transformExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (int64, error) {
+	var (
+		records     = NewRecords(s.Records.Len())
+		transformed = make(map[*RecordObj]*memsys.SGL)
+	)
+	defer func() {
+		for _, sgl := range transformed {
+			sgl.Free()
+		}
+	}()
+
+	for _, rec := range s.Records.All() {
+		trec := &Record{
+			Key:      rec.Key,
+			Name:     rec.Name,
+			DaemonID: rec.DaemonID,
+			Objects:  make([]*RecordObj, 0, len(rec.Objects)),
+		}
+		for _, obj := range rec.Objects {
+			if len(tc.extensions) > 0 && !cos.StringInSlice(obj.Extension, tc.extensions) {
+				trec.Objects = append(trec.Objects, obj)
+				continue
+			}
+			tobj, sgl, err := tc.transformObj(rec, obj, loadContent)
+			if err != nil {
+				return 0, err
+			}
+			transformed[tobj] = sgl
+			trec.Objects = append(trec.Objects, tobj)
+		}
+		records.arr = append(records.arr, trec)
+	}
+
+	shard := &Shard{Name: s.Name, Size: s.Size, Records: records}
+	return tc.internal.CreateShard(shard, w, func(w io.Writer, rec *Record, obj *RecordObj) (int64, error) {
+		if sgl, ok := transformed[obj]; ok {
+			return io.Copy(w, sgl)
+		}
+		return loadContent(w, rec, obj)
+	})
+}
+
+// transformObj loads the object, transforms its content and returns the new
+// object together with its metadata and transformed content.
+func (tc *transformExtractCreator) transformObj(rec *Record, obj *RecordObj, loadContent LoadContentFunc) (*RecordObj, *memsys.SGL, error) {
+	src := tc.t.PageMM().NewSGL(obj.MetadataSize + obj.Size)
+	defer src.Free()
+	if _, err := loadContent(src, rec, obj); err != nil {
+		return nil, nil, err
+	}
+
+	metadata := make([]byte, obj.MetadataSize)
+	if _, err := io.ReadFull(src, metadata); err != nil {
+		return nil, nil, err
+	}
+	if obj.StoreType == OffsetStoreType {
+		// Metadata is a part of the shard (eg. tar header) and describes the
+		// original content - it must be regenerated.
+		metadata = tc.gen.genMetadata(recordObjName(rec, obj))
+	}
+
+	r, err := tc.transform(src, obj.Size)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cos.Close(r)
+
+	sizeHint := r.Size()
+	if sizeHint < 0 { // unknown (eg. chunked response)
+		sizeHint = obj.Size
+	}
+	dst := tc.t.PageMM().NewSGL(sizeHint + int64(len(metadata)))
+	if _, err := dst.Write(metadata); err != nil {
+		dst.Free()
+		return nil, nil, err
+	}
+	size, err := io.Copy(dst, r)
+	if err != nil {
+		dst.Free()
+		return nil, nil, err
+	}
+	return &RecordObj{
+		ContentPath:    obj.ContentPath,
+		ObjectFileType: obj.ObjectFileType,
+		StoreType:      SGLStoreType,
+		MetadataSize:   int64(len(metadata)),
+		Size:           size,
+		Extension:      obj.Extension,
+	}, dst, nil
+}
+
+func (tc *transformExtractCreator) UsingCompression() bool { return tc.internal.UsingCompression() }
+func (tc *transformExtractCreator) SupportsOffset() bool   { return tc.internal.SupportsOffset() }
+func (tc *transformExtractCreator) MetadataSize() int64    { return tc.internal.MetadataSize() }
+
+func (tc *transformExtractCreator) genMetadata(name string) []byte { return tc.gen.genMetadata(name) }
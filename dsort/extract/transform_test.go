@@ -0,0 +1,137 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmihailenco/msgpack"
+)
+
+var _ = Describe("Transform", func() {
+	files := map[string][]byte{
+		"dir/sample1.jpg": []byte("jpeg image of sample1"),
+		"dir/sample1.cls": []byte("1"),
+		"dir/sample2.jpg": []byte("jpeg image of sample2"),
+		"dir/sample2.cls": []byte("2"),
+		"dir/sample3.txt": []byte("some text which will get longer"),
+	}
+
+	var (
+		t   = mock.NewTarget(nil)
+		bck = cmn.Bck{Name: "test", Provider: "ais"}
+	)
+
+	// repeat transforms the content so that its size changes.
+	repeat := func(r io.Reader, _ int64) (cos.ReadCloseSizer, error) {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		b = bytes.Repeat(b, 2)
+		return cos.NewSizedRC(io.NopCloser(bytes.NewReader(b)), int64(len(b))), nil
+	}
+
+	newTar := func() []byte {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for name, content := range files {
+			err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Size: int64(len(content)), Mode: 0o644})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tw.Write(content)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(tw.Close()).NotTo(HaveOccurred())
+		return buf.Bytes()
+	}
+
+	readTar := func(b []byte) map[string][]byte {
+		tr := tar.NewReader(bytes.NewReader(b))
+		shard := make(map[string][]byte)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).NotTo(HaveOccurred())
+			shard[header.Name], err = io.ReadAll(tr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(header.Size).To(BeEquivalentTo(len(shard[header.Name])))
+		}
+		return shard
+	}
+
+	// process extracts the tar shard and creates a new shard out of all of its records.
+	process := func(creator Creator, toDisk bool) []byte {
+		keyExtractor, err := NewNameKeyExtractor()
+		Expect(err).NotTo(HaveOccurred())
+		rm := NewRecordManager(t, bck, cos.ExtTar, creator, keyExtractor, func(string) error { return nil })
+		defer rm.Cleanup()
+
+		shard := newTar()
+		lom := &cluster.LOM{ObjName: "shard" + cos.ExtTar}
+		lom.SetSize(int64(len(shard)))
+		_, cnt, err := creator.ExtractShard(lom, bytes.NewReader(shard), rm, toDisk)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cnt).To(Equal(len(files)))
+
+		buf := &bytes.Buffer{}
+		loadContent := func(w io.Writer, _ *Record, obj *RecordObj) (int64, error) {
+			if obj.StoreType == OffsetStoreType {
+				sr := io.NewSectionReader(bytes.NewReader(shard), obj.Offset-obj.MetadataSize, obj.MetadataSize+obj.Size)
+				return io.Copy(w, sr)
+			}
+			fullContentPath := rm.FullContentPath(obj)
+			v, ok := rm.RecordContents().Load(fullContentPath)
+			Expect(ok).To(BeTrue())
+			rm.RecordContents().Delete(fullContentPath)
+			sgl := v.(*memsys.SGL)
+			defer sgl.Free()
+			return io.Copy(w, sgl)
+		}
+		_, err = creator.CreateShard(&Shard{Name: "output", Records: rm.Records}, buf, loadContent)
+		Expect(err).NotTo(HaveOccurred())
+		return buf.Bytes()
+	}
+
+	It("should transform objects with selected extensions", func() {
+		for _, toDisk := range []bool{false, true} {
+			creator, err := NewTransformExtractCreator(t, NewTarExtractCreator(t), repeat, []string{".jpg", ".txt"})
+			Expect(err).NotTo(HaveOccurred())
+			shard := readTar(process(creator, toDisk))
+			Expect(shard).To(HaveLen(len(files)))
+			for name, content := range files {
+				if Ext(name) == ".cls" {
+					Expect(shard[name]).To(Equal(content))
+				} else {
+					Expect(shard[name]).To(Equal(bytes.Repeat(content, 2)))
+				}
+			}
+		}
+	})
+
+	It("should transform objects of converted shard", func() {
+		dst, err := NewTransformExtractCreator(t, NewMsgpackExtractCreator(t), repeat, nil)
+		Expect(err).NotTo(HaveOccurred())
+		creator, err := NewConvertExtractCreator(NewTarExtractCreator(t), dst)
+		Expect(err).NotTo(HaveOccurred())
+
+		var shard cmn.GenShard
+		Expect(msgpack.NewDecoder(bytes.NewReader(process(creator, false))).Decode(&shard)).NotTo(HaveOccurred())
+		Expect(shard).To(HaveLen(len(files)))
+		for name, content := range files {
+			Expect(shard[name]).To(Equal(bytes.Repeat(content, 2)))
+		}
+	})
+})
@@ -0,0 +1,211 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+package dsort
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dsort/extract"
+	"github.com/NVIDIA/aistore/etl"
+	"github.com/pkg/errors"
+)
+
+type (
+	// RecordFilter describes which records are dropped during resharding.
+	// Record is dropped when it does not satisfy any of the (set) conditions.
+	RecordFilter struct {
+		// Drops records whose name (without shard name and extension) matches the regex.
+		NameRegex string `json:"name_regex,omitempty" yaml:"name_regex,omitempty"`
+		// Drops records whose total size (all objects of the record) is out of the range.
+		MinSize string `json:"min_size,omitempty" yaml:"min_size,omitempty"`
+		MaxSize string `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+		// Drops records whose key does not satisfy the predicate.
+		Key *extract.KeyPredicate `json:"key,omitempty" yaml:"key,omitempty"`
+		// URL to the file with names of the records (one per line) which are dropped.
+		DenyList string `json:"deny_list,omitempty" yaml:"deny_list,omitempty"`
+
+		nameRegex *regexp.Regexp
+		minSize   int64
+		maxSize   int64
+	}
+
+	// RecordTransform describes ETL which transforms the objects of the records
+	// in the creation phase.
+	RecordTransform struct {
+		// ID of the ETL (see: `ais etl`), must use `hpush://` or `io://` communication type.
+		ID string `json:"id" yaml:"id"`
+		// Extensions of the objects which are transformed. Default: all objects.
+		Extensions []string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+		// Timeout of single object transformation. Default: no timeout.
+		RequestTimeout cos.Duration `json:"request_timeout,omitempty" yaml:"request_timeout,omitempty"`
+	}
+)
+
+//////////////////
+// RecordFilter //
+//////////////////
+
+// init validates the filter and prepares it to be used. `formatType`
+// determines how the value of the key predicate is interpreted.
+func (f *RecordFilter) init(formatType string) (err error) {
+	if f.NameRegex != "" {
+		if f.nameRegex, err = regexp.Compile(f.NameRegex); err != nil {
+			return fmt.Errorf("invalid filter name regex %q: %v", f.NameRegex, err)
+		}
+	}
+	if f.MinSize != "" {
+		if f.minSize, err = cos.S2B(f.MinSize); err != nil || f.minSize < 0 {
+			return fmt.Errorf("invalid filter min size %q", f.MinSize)
+		}
+	}
+	if f.MaxSize != "" {
+		if f.maxSize, err = cos.S2B(f.MaxSize); err != nil || f.maxSize < f.minSize {
+			return fmt.Errorf("invalid filter max size %q (min size: %q)", f.MaxSize, f.MinSize)
+		}
+	}
+	if f.Key != nil {
+		if err := f.Key.Init(formatType); err != nil {
+			return err
+		}
+	}
+	if f.DenyList != "" {
+		if _, err := url.ParseRequestURI(f.DenyList); err != nil {
+			return fmt.Errorf("could not parse filter deny list, required URL: %v", err)
+		}
+	}
+	return nil
+}
+
+// initFilter initializes the filter with the format type of the keys which
+// are extracted by the algorithm.
+func (algo *SortAlgorithm) initFilter(f *RecordFilter) error {
+	var formatType string
+	if f.Key != nil {
+		// NOTE: keys of other algorithms are either not typed or assigned only
+		// after the extraction (key table) when the records are already filtered.
+		if algo.Kind != SortKindContent {
+			return errInvalidFilterKey
+		}
+		formatType = algo.FormatType
+		if len(algo.Fields) > 0 {
+			formatType = algo.Fields[0].FormatType
+		}
+	}
+	return f.init(formatType)
+}
+
+// keep returns true if the record satisfies the filter. `denied` contains
+// names of the records from the deny list.
+func (f *RecordFilter) keep(r *extract.Record, denied cos.StringSet) bool {
+	name := r.NameInShard()
+	if f.nameRegex != nil && f.nameRegex.MatchString(name) {
+		return false
+	}
+	if f.MinSize != "" || f.MaxSize != "" {
+		size := r.TotalSize()
+		if size < f.minSize || (f.MaxSize != "" && size > f.maxSize) {
+			return false
+		}
+	}
+	if f.Key != nil && !f.Key.Match(r.Key) {
+		return false
+	}
+	return !denied.Contains(name)
+}
+
+// readDenyList reads the names of the records, one per line. Empty lines and
+// lines starting with '#' are skipped.
+func readDenyList(r io.Reader) (cos.StringSet, error) {
+	var (
+		denied  = cos.NewStringSet()
+		scanner = bufio.NewScanner(r)
+	)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		denied.Add(line)
+	}
+	return denied, scanner.Err()
+}
+
+// filterRecords drops all extracted records which do not satisfy the filter.
+// It must be called before the records are accounted for (see: `incrementRef`).
+func (m *Manager) filterRecords() error {
+	filter := m.rs.Filter
+	if filter == nil {
+		return nil
+	}
+
+	var denied cos.StringSet
+	if filter.DenyList != "" {
+		body, err := m.getExternal(filter.DenyList, "deny list")
+		if err != nil {
+			return err
+		}
+		denied, err = readDenyList(body)
+		cos.Close(body)
+		if err != nil {
+			return errors.Errorf("failed to read deny list: %v", err)
+		}
+	}
+
+	cnt := m.recManager.FilterRecords(func(r *extract.Record) bool {
+		return filter.keep(r, denied)
+	})
+
+	metrics := m.Metrics.Extraction
+	metrics.mu.Lock()
+	metrics.FilteredRecordCnt += int64(cnt)
+	metrics.mu.Unlock()
+	return nil
+}
+
+/////////////////////
+// RecordTransform //
+/////////////////////
+
+func (t *RecordTransform) validate() error {
+	if t.ID == "" {
+		return errors.New("missing transform ETL id")
+	}
+	for _, ext := range t.Extensions {
+		if !strings.HasPrefix(ext, ".") {
+			return fmt.Errorf("invalid transform extension %q, should be in the format: .ext", ext)
+		}
+	}
+	if t.RequestTimeout < 0 {
+		return fmt.Errorf("invalid transform request timeout %v", t.RequestTimeout)
+	}
+	return nil
+}
+
+// newTransformFunc returns function which transforms the objects with the ETL.
+func (m *Manager) newTransformFunc() (extract.TransformFunc, error) {
+	comm, err := etl.GetCommunicator(m.rs.Transform.ID, m.ctx.node)
+	if err != nil {
+		return nil, err
+	}
+	timeout := m.rs.Transform.RequestTimeout.D()
+	return func(r io.Reader, size int64) (cos.ReadCloseSizer, error) {
+		rc, err := comm.TransformReader(r, size, timeout)
+		if err != nil {
+			return nil, err
+		}
+		metrics := m.Metrics.Creation
+		metrics.mu.Lock()
+		metrics.TransformedCnt++
+		metrics.mu.Unlock()
+		return rc, nil
+	}, nil
+}
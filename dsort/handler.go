@@ -39,7 +39,16 @@ type response struct {
 
 // POST /v1/sort
 func ProxyStartSortHandler(w http.ResponseWriter, r *http.Request, parsedRS *ParsedRequestSpec) {
-	var err error
+	managerUUID, errCode, err := ProxyStartSort(parsedRS)
+	if err != nil {
+		cmn.WriteErr(w, r, err, errCode)
+		return
+	}
+	w.Write([]byte(managerUUID))
+}
+
+// ProxyStartSort starts dSort job cluster-wide and returns its (manager) UUID
+func ProxyStartSort(parsedRS *ParsedRequestSpec) (managerUUID string, errCode int, err error) {
 	parsedRS.TargetOrderSalt = []byte(time.Now().Format("15:04:05.000000"))
 
 	// TODO: handle case when bucket was removed during dSort job - this should
@@ -49,21 +58,19 @@ func ProxyStartSortHandler(w http.ResponseWriter, r *http.Request, parsedRS *Par
 
 	parsedRS.DSorterType, err = determineDSorterType(parsedRS)
 	if err != nil {
-		cmn.WriteErr(w, r, err)
+		errCode = http.StatusBadRequest
 		return
 	}
 
 	b, err := js.Marshal(parsedRS)
 	if err != nil {
-		s := fmt.Sprintf("unable to marshal RequestSpec: %+v, err: %v", parsedRS, err)
-		cmn.WriteErrMsg(w, r, s, http.StatusInternalServerError)
+		err = fmt.Errorf("unable to marshal RequestSpec: %+v, err: %v", parsedRS, err)
+		errCode = http.StatusInternalServerError
 		return
 	}
 
-	var (
-		managerUUID = cos.GenUUID()
-		smap        = ctx.smapOwner.Get()
-	)
+	managerUUID = cos.GenUUID()
+	smap := ctx.smapOwner.Get()
 	checkResponses := func(responses []response) error {
 		for _, resp := range responses {
 			if resp.err == nil {
@@ -75,10 +82,8 @@ func ProxyStartSortHandler(w http.ResponseWriter, r *http.Request, parsedRS *Par
 			path := apc.URLPathdSortAbort.Join(managerUUID)
 			broadcastTargets(http.MethodDelete, path, nil, nil, smap)
 
-			s := fmt.Sprintf("failed to execute start sort, err: %s, status: %d",
+			return fmt.Errorf("failed to execute start sort, err: %s, status: %d",
 				resp.err.Error(), resp.statusCode)
-			cmn.WriteErrMsg(w, r, s, http.StatusInternalServerError)
-			return resp.err
 		}
 
 		return nil
@@ -100,7 +105,8 @@ func ProxyStartSortHandler(w http.ResponseWriter, r *http.Request, parsedRS *Par
 	}
 	path := apc.URLPathdSortInit.Join(managerUUID)
 	responses := broadcastTargets(http.MethodPost, path, nil, b, smap)
-	if err := checkResponses(responses); err != nil {
+	if err = checkResponses(responses); err != nil {
+		errCode = http.StatusInternalServerError
 		return
 	}
 
@@ -109,11 +115,10 @@ func ProxyStartSortHandler(w http.ResponseWriter, r *http.Request, parsedRS *Par
 	}
 	path = apc.URLPathdSortStart.Join(managerUUID)
 	responses = broadcastTargets(http.MethodPost, path, nil, nil, smap)
-	if err := checkResponses(responses); err != nil {
-		return
+	if err = checkResponses(responses); err != nil {
+		errCode = http.StatusInternalServerError
 	}
-
-	w.Write([]byte(managerUUID))
+	return
 }
 
 // GET /v1/sort
@@ -185,15 +190,31 @@ func proxyListSortHandler(w http.ResponseWriter, r *http.Request) {
 // GET /v1/sort?id=...
 func proxyMetricsSortHandler(w http.ResponseWriter, r *http.Request) {
 	var (
-		smap        = ctx.smapOwner.Get()
 		query       = r.URL.Query()
 		managerUUID = query.Get(apc.QparamUUID)
-		path        = apc.URLPathdSortMetrics.Join(managerUUID)
-		responses   = broadcastTargets(http.MethodGet, path, nil, nil, smap)
 	)
+	allMetrics, errCode, err := ProxyMetrics(managerUUID)
+	if err != nil {
+		cmn.WriteErr(w, r, err, errCode)
+		return
+	}
+	body, err := js.Marshal(allMetrics)
+	if err != nil {
+		cmn.WriteErr(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	w.Write(body)
+}
 
-	notFound := 0
-	allMetrics := make(map[string]*Metrics, smap.CountActiveTargets())
+// ProxyMetrics returns dSort job metrics from all targets (daeID => metrics)
+func ProxyMetrics(managerUUID string) (allMetrics map[string]*Metrics, errCode int, err error) {
+	var (
+		smap      = ctx.smapOwner.Get()
+		path      = apc.URLPathdSortMetrics.Join(managerUUID)
+		responses = broadcastTargets(http.MethodGet, path, nil, nil, smap)
+		notFound  int
+	)
+	allMetrics = make(map[string]*Metrics, smap.CountActiveTargets())
 	for _, resp := range responses {
 		if resp.statusCode == http.StatusNotFound {
 			// Probably new target which does not know anything about this dsort op.
@@ -201,29 +222,19 @@ func proxyMetricsSortHandler(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 		if resp.err != nil {
-			cmn.WriteErr(w, r, resp.err, resp.statusCode)
-			return
+			return nil, resp.statusCode, resp.err
 		}
 		metrics := &Metrics{}
-		if err := js.Unmarshal(resp.res, &metrics); err != nil {
-			cmn.WriteErr(w, r, err, http.StatusInternalServerError)
-			return
+		if err = js.Unmarshal(resp.res, &metrics); err != nil {
+			return nil, http.StatusInternalServerError, err
 		}
 		allMetrics[resp.si.ID()] = metrics
 	}
 
 	if notFound == len(responses) && notFound > 0 {
-		msg := fmt.Sprintf("%s job %q not found", DSortName, managerUUID)
-		cmn.WriteErrMsg(w, r, msg, http.StatusNotFound)
-		return
+		return nil, http.StatusNotFound, cmn.NewErrNotFound("%s job %q", DSortName, managerUUID)
 	}
-
-	body, err := js.Marshal(allMetrics)
-	if err != nil {
-		cmn.WriteErr(w, r, err, http.StatusInternalServerError)
-		return
-	}
-	w.Write(body)
+	return
 }
 
 // DELETE /v1/sort/abort
@@ -236,13 +247,19 @@ func ProxyAbortSortHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	managerUUID := r.URL.Query().Get(apc.QparamUUID)
+	if errCode, err := ProxyAbortSort(managerUUID); err != nil {
+		cmn.WriteErr(w, r, err, errCode)
+	}
+}
+
+// ProxyAbortSort aborts dSort job on all targets
+func ProxyAbortSort(managerUUID string) (errCode int, err error) {
 	var (
-		query       = r.URL.Query()
-		managerUUID = query.Get(apc.QparamUUID)
 		path        = apc.URLPathdSortAbort.Join(managerUUID)
 		responses   = broadcastTargets(http.MethodDelete, path, nil, nil, ctx.smapOwner.Get())
+		allNotFound = true
 	)
-	allNotFound := true
 	for _, resp := range responses {
 		if resp.statusCode == http.StatusNotFound {
 			continue
@@ -250,15 +267,13 @@ func ProxyAbortSortHandler(w http.ResponseWriter, r *http.Request) {
 		allNotFound = false
 
 		if resp.err != nil {
-			cmn.WriteErr(w, r, resp.err, resp.statusCode)
-			return
+			return resp.statusCode, resp.err
 		}
 	}
 	if allNotFound {
-		err := cmn.NewErrNotFound("%s job %q", DSortName, managerUUID)
-		cmn.WriteErr(w, r, err, http.StatusNotFound)
-		return
+		return http.StatusNotFound, cmn.NewErrNotFound("%s job %q", DSortName, managerUUID)
 	}
+	return
 }
 
 // DELETE /v1/sort
@@ -354,6 +369,8 @@ func SortHandler(w http.ResponseWriter, r *http.Request) {
 		metricsHandler(w, r)
 	case apc.FinishedAck:
 		finishedAckHandler(w, r)
+	case apc.Created:
+		createdHandler(w, r)
 	default:
 		cmn.WriteErrMsg(w, r, "invalid path")
 	}
@@ -498,6 +515,8 @@ func shardsHandler(managers *ManagerGroup) http.HandlerFunc {
 			return
 		}
 
+		// Shards which contain the skipped objects will not be created.
+		dsortManager.decrementRef(tmpMetadata.SkippedObjCnt)
 		dsortManager.creationPhase.metadata = *tmpMetadata
 		dsortManager.startShardCreation <- struct{}{}
 	}
@@ -703,6 +722,35 @@ func finishedAckHandler(w http.ResponseWriter, r *http.Request) {
 	dsortManager.updateFinishedAck(daemonID)
 }
 
+// createdHandler is the handler called for the HTTP endpoint /v1/sort/created.
+// A valid GET to this endpoint sends response with the shards which have been
+// created by this target, as recorded in the checkpoint of the job.
+func createdHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+	apiItems, err := checkRESTItems(w, r, 1, apc.URLPathdSortCreated.L)
+	if err != nil {
+		return
+	}
+
+	managerUUID := apiItems[0]
+	dsortManager, exists := Managers.Get(managerUUID)
+	if !exists {
+		s := fmt.Sprintf("invalid request: job %q does not exist", managerUUID)
+		cmn.WriteErrMsg(w, r, s, http.StatusNotFound)
+		return
+	}
+
+	created := map[string]uint64{}
+	if dsortManager.checkpoint != nil {
+		created = dsortManager.checkpoint.createdShards()
+	}
+	if _, err := w.Write(cos.MustMarshal(created)); err != nil {
+		glog.Error(err)
+	}
+}
+
 func broadcastTargets(method, path string, urlParams url.Values, body []byte, smap *cluster.Smap, ignore ...*cluster.Snode) []response {
 	var (
 		responses = make([]response, smap.CountActiveTargets())
@@ -103,6 +103,7 @@ type (
 
 		recManager     *extract.RecordManager
 		extractCreator extract.Creator
+		checkpoint     *checkpoint // nil if the job is not restartable
 
 		startShardCreation chan struct{}
 		rs                 *ParsedRequestSpec
@@ -183,6 +184,13 @@ func (m *Manager) init(rs *ParsedRequestSpec) error {
 
 	m.ctx.smapOwner.Listeners().Reg(m)
 
+	// Request spec is received without the parsed state of the filter.
+	if rs.Filter != nil {
+		if err := rs.Algorithm.initFilter(rs.Filter); err != nil {
+			return err
+		}
+	}
+
 	if err := m.setDSorter(); err != nil {
 		return err
 	}
@@ -196,6 +204,14 @@ func (m *Manager) init(rs *ParsedRequestSpec) error {
 		return err
 	}
 
+	if rs.Restartable {
+		checkpoint, err := newCheckpoint(m)
+		if err != nil {
+			return err
+		}
+		m.checkpoint = checkpoint
+	}
+
 	// NOTE: Total size of the records metadata can sometimes be large
 	// and so this is why we need such a long timeout.
 	config := cmn.GCO.Get()
@@ -369,6 +385,19 @@ func (m *Manager) finalCleanup() {
 		glog.Error(err)
 	}
 
+	if m.checkpoint != nil {
+		if m.aborted() {
+			m.checkpoint.close()
+			if m.checkpoint.meta.Extracted {
+				// Extracted contents are referenced by the checkpoint and
+				// will be used when the job is restarted.
+				m.recManager.KeepExtractionPaths()
+			}
+		} else {
+			m.checkpoint.remove()
+		}
+	}
+
 	// The reason why this is not in regular cleanup is because we are only sure
 	// that this can be freed once we cleanup streams - streams are asynchronous
 	// and we may have race between in-flight request and cleanup.
@@ -451,7 +480,7 @@ func (m *Manager) setExtractCreator() (err error) {
 
 	switch m.rs.Algorithm.Kind {
 	case SortKindContent:
-		keyExtractor, err = extract.NewContentKeyExtractor(m.rs.Algorithm.FormatType, m.rs.Algorithm.Extension)
+		keyExtractor, err = extract.NewContentKeyExtractor(m.rs.Algorithm.FormatType, m.rs.Algorithm.Extension, m.rs.Algorithm.Fields)
 	case SortKindMD5:
 		keyExtractor, err = extract.NewMD5KeyExtractor()
 	default:
@@ -466,16 +495,22 @@ func (m *Manager) setExtractCreator() (err error) {
 		return m.react(m.rs.DuplicatedRecords, msg)
 	}
 
-	var extractCreator extract.Creator
-	switch m.rs.Extension {
-	case cos.ExtTar:
-		extractCreator = extract.NewTarExtractCreator(m.ctx.t)
-	case cos.ExtTarTgz, cos.ExtTgz:
-		extractCreator = extract.NewTargzExtractCreator(m.ctx.t)
-	case cos.ExtZip:
-		extractCreator = extract.NewZipExtractCreator(m.ctx.t)
-	default:
-		cos.Assertf(false, "unknown extension %s", m.rs.Extension)
+	extractCreator := newExtractCreator(m.ctx.t, m.rs.Extension)
+	if m.rs.OutputExtension != "" && m.rs.OutputExtension != m.rs.Extension {
+		// Shards are extracted in one format and created in another.
+		dstCreator := newExtractCreator(m.ctx.t, m.rs.OutputExtension)
+		if m.rs.Transform != nil {
+			if dstCreator, err = m.newTransformExtractCreator(dstCreator); err != nil {
+				return err
+			}
+		}
+		if extractCreator, err = extract.NewConvertExtractCreator(extractCreator, dstCreator); err != nil {
+			return errors.WithStack(err)
+		}
+	} else if m.rs.Transform != nil {
+		if extractCreator, err = m.newTransformExtractCreator(extractCreator); err != nil {
+			return err
+		}
 	}
 
 	if !m.rs.DryRun {
@@ -493,6 +528,33 @@ func (m *Manager) setExtractCreator() (err error) {
 	return nil
 }
 
+// newTransformExtractCreator wraps the creator so that the records are
+// transformed with ETL before the shard is created.
+func (m *Manager) newTransformExtractCreator(creator extract.Creator) (extract.Creator, error) {
+	transform, err := m.newTransformFunc()
+	if err != nil {
+		return nil, err
+	}
+	creator, err = extract.NewTransformExtractCreator(m.ctx.t, creator, transform, m.rs.Transform.Extensions)
+	return creator, errors.WithStack(err)
+}
+
+func newExtractCreator(t cluster.Target, ext string) (extractCreator extract.Creator) {
+	switch ext {
+	case cos.ExtTar:
+		extractCreator = extract.NewTarExtractCreator(t)
+	case cos.ExtTarTgz, cos.ExtTgz:
+		extractCreator = extract.NewTargzExtractCreator(t)
+	case cos.ExtZip:
+		extractCreator = extract.NewZipExtractCreator(t)
+	case cos.ExtMsgpack:
+		extractCreator = extract.NewMsgpackExtractCreator(t)
+	default:
+		cos.Assertf(false, "unknown extension %s", ext)
+	}
+	return
+}
+
 // updateFinishedAck marks daemonID as finished. If all daemons ack then the
 // finalCleanup is dispatched in separate goroutine.
 func (m *Manager) updateFinishedAck(daemonID string) {
@@ -760,6 +822,9 @@ func (m *Manager) ListenSmapChanged() {
 		//  not possible as rebalance deletes moved object - dSort needs
 		//  to use `GetObject` method instead of relaying on simple `os.Open`.
 		err := errors.Errorf("number of target has changed during dSort run, aborting due to possible errors")
+		if m.checkpoint != nil {
+			err = errors.Errorf("%v (the job can be restarted with %q: %q)", err, "restart_of", m.ManagerUUID)
+		}
 		go m.abort(err)
 	}
 }
@@ -141,6 +141,7 @@ func (mg *ManagerGroup) Remove(managerUUID string) error {
 
 	key := path.Join(managersKey, managerUUID)
 	_ = mg.db.Delete(dsortCollection, key) // Delete only returns err when record does not exist, which should be ignored
+	removeCheckpoint(mg.db, managerUUID)
 	return nil
 }
 
@@ -185,6 +186,8 @@ func (mg *ManagerGroup) housekeep() time.Duration {
 	mg.mtx.Lock()
 	defer mg.mtx.Unlock()
 
+	mg.housekeepCheckpoints(regularInterval)
+
 	records, err := mg.db.GetAll(dsortCollection, managersKey)
 	if err != nil {
 		if dbdriver.IsErrNotFound(err) {
@@ -209,3 +212,29 @@ func (mg *ManagerGroup) housekeep() time.Duration {
 
 	return regularInterval
 }
+
+// housekeepCheckpoints removes checkpoints of the jobs which have not been
+// restarted for a long time.
+//
+// PRECONDITION: `mg.mtx` must be locked.
+func (mg *ManagerGroup) housekeepCheckpoints(expiration time.Duration) {
+	records, err := mg.db.GetAll(dsortCollection, checkpointsKey)
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return
+	}
+	for key, r := range records {
+		var meta checkpointMeta
+		if err := jsoniter.Unmarshal([]byte(r), &meta); err != nil {
+			glog.Error(err)
+			continue
+		}
+		managerUUID := path.Base(key)
+		if _, running := mg.managers[managerUUID]; running || time.Since(meta.Updated) <= expiration {
+			continue
+		}
+		removeCheckpoint(mg.db, managerUUID)
+	}
+}
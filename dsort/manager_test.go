@@ -63,6 +63,25 @@ var _ = Describe("Init", func() {
 		Expect(m.init(sr)).NotTo(HaveOccurred())
 		Expect(m.extractCreator.UsingCompression()).To(BeTrue())
 	})
+
+	It("should init with msgpack extension", func() {
+		m := &Manager{ctx: dsortContext{t: mock.NewTarget(nil)}}
+		m.lock()
+		defer m.unlock()
+		sr := &ParsedRequestSpec{Extension: cos.ExtMsgpack, Algorithm: &SortAlgorithm{Kind: SortKindNone}, MaxMemUsage: cos.ParsedQuantity{Type: cos.QuantityPercent, Value: 0}, DSorterType: DSorterGeneralType}
+		Expect(m.init(sr)).NotTo(HaveOccurred())
+		Expect(m.extractCreator.UsingCompression()).To(BeFalse())
+	})
+
+	It("should init with different output extension", func() {
+		m := &Manager{ctx: dsortContext{t: mock.NewTarget(nil)}}
+		m.lock()
+		defer m.unlock()
+		sr := &ParsedRequestSpec{Extension: cos.ExtTgz, OutputExtension: cos.ExtMsgpack, Algorithm: &SortAlgorithm{Kind: SortKindNone}, MaxMemUsage: cos.ParsedQuantity{Type: cos.QuantityPercent, Value: 0}, DSorterType: DSorterGeneralType}
+		Expect(m.init(sr)).NotTo(HaveOccurred())
+		Expect(m.extractCreator.UsingCompression()).To(BeTrue()) // extraction decides
+		Expect(m.extractCreator.SupportsOffset()).To(BeTrue())
+	})
 })
 
 func BenchmarkRecordsMarshal(b *testing.B) {
@@ -14,6 +14,9 @@ type (
 	CreationPhaseMetadata struct {
 		Shards    []*extract.Shard          `msg:"shards"`
 		SendOrder map[string]*extract.Shard `msg:"send_order"`
+		// Number of local record objects which will not be requested since
+		// their shards had been created by the restarted job.
+		SkippedObjCnt int64 `msg:"skipped_obj_cnt"`
 	}
 
 	RemoteResponse struct {
@@ -100,6 +100,12 @@ func (z *CreationPhaseMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.SendOrder[za0002] = za0003
 			}
+		case "skipped_obj_cnt":
+			z.SkippedObjCnt, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "SkippedObjCnt")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -113,9 +119,9 @@ func (z *CreationPhaseMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *CreationPhaseMetadata) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
+	// map header, size 3
 	// write "shards"
-	err = en.Append(0x82, 0xa6, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73)
+	err = en.Append(0x83, 0xa6, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73)
 	if err != nil {
 		return
 	}
@@ -167,6 +173,16 @@ func (z *CreationPhaseMetadata) EncodeMsg(en *msgp.Writer) (err error) {
 			}
 		}
 	}
+	// write "skipped_obj_cnt"
+	err = en.Append(0xaf, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x6f, 0x62, 0x6a, 0x5f, 0x63, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.SkippedObjCnt)
+	if err != nil {
+		err = msgp.WrapError(err, "SkippedObjCnt")
+		return
+	}
 	return
 }
 
@@ -192,6 +208,7 @@ func (z *CreationPhaseMetadata) Msgsize() (s int) {
 			}
 		}
 	}
+	s += 16 + msgp.Int64Size
 	return
 }
 
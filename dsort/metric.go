@@ -144,6 +144,18 @@ type LocalExtraction struct {
 	// ExtractedToDiskSize describes uncompressed size of extracted shards to disk
 	// to given moment.
 	ExtractedToDiskSize int64 `json:"extracted_to_disk_size,string"`
+	// FilteredRecordCnt describes number of extracted records which were
+	// dropped by the filter (see: RecordFilter).
+	FilteredRecordCnt int64 `json:"filtered_record_count,string"`
+	// SamplingDroppedCnt describes number of extracted records which were not
+	// sampled (see: Sampling).
+	SamplingDroppedCnt int64 `json:"sampling_dropped_count,string"`
+	// SamplingDuplicatedCnt describes number of copies of the records which
+	// were sampled multiple times (with replacement).
+	SamplingDuplicatedCnt int64 `json:"sampling_duplicated_count,string"`
+	// RestoredRecordCnt describes number of records restored from the
+	// checkpoint of the restarted job (see: RequestSpec.RestartOf).
+	RestoredRecordCnt int64 `json:"restored_record_count,string"`
 	// ShardExtractionStats describes time statistics about single shard extraction.
 	ShardExtractionStats *DetailedStats `json:"single_shard_stats,omitempty"`
 }
@@ -170,6 +182,12 @@ type ShardCreation struct {
 	// data. Sometimes it is faster to create a shard on a specific target and send it
 	// over (rather than creating on a destination target).
 	MovedShardCnt int64 `json:"moved_shard_count,string"`
+	// TransformedCnt specifies the number of record objects that have been so
+	// far transformed with ETL (see: RecordTransform).
+	TransformedCnt int64 `json:"transformed_count,string"`
+	// SkippedCnt specifies the number of shards which have not been created
+	// since they had been already created by the restarted job.
+	SkippedCnt int64 `json:"skipped_count,string"`
 	// RequestStats describes time statistics about request to other target.
 	RequestStats *TimeStats `json:"req_stats,omitempty"`
 	// ResponseStats describes time statistics about response to other target.
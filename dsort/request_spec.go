@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -34,7 +35,7 @@ var (
 
 var (
 	errMissingBucket            = errors.New("missing field 'bucket'")
-	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', or '.tgz'")
+	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', '.tgz', '.zip', or '.msgpack'")
 	errNegOutputShardSize       = errors.New("output shard size must be >= 0")
 	errEmptyOutputShardSize     = errors.New("output shard size must be set (cannot be 0)")
 	errNegativeConcurrencyLimit = errors.New("concurrency max limit must be 0 (limits will be calculated) or > 0")
@@ -44,6 +45,10 @@ var (
 	errInvalidAlgorithm          = errors.New("invalid algorithm specified")
 	errInvalidSeed               = errors.New("invalid seed provided, should be int")
 	errInvalidAlgorithmExtension = errors.New("invalid extension provided, should be in the format: .ext")
+	errInvalidKeyFields          = errors.New("key fields must either all use 'json_path' or all use 'csv_column'")
+	errInvalidKeyTable           = errors.New("could not parse key table, required URL")
+	errInvalidKeyTableSep        = errors.New("key table separator must be a single character")
+	errInvalidFilterKey          = errors.New("filter key predicate is supported only with 'content' algorithm")
 )
 
 // supportedExtensions is a list of extensions (archives) supported by dSort
@@ -81,6 +86,8 @@ type RequestSpec struct {
 
 	// Optional
 	Description string `json:"description" yaml:"description"`
+	// Default: same as `extension` field
+	OutputExtension string `json:"output_extension" yaml:"output_extension"`
 	// Default: same as `bck` field
 	OutputBck cmn.Bck `json:"output_bck" yaml:"output_bck"`
 	// Default: alphanumeric, increasing
@@ -99,6 +106,16 @@ type RequestSpec struct {
 	StreamMultiplier int `json:"stream_multiplier" yaml:"stream_multiplier"`
 	// Default: false
 	ExtendedMetrics bool `json:"extended_metrics" yaml:"extended_metrics"`
+	// Default: no records are dropped
+	Filter *RecordFilter `json:"filter,omitempty" yaml:"filter,omitempty"`
+	// Default: records are not transformed
+	Transform *RecordTransform `json:"transform,omitempty" yaml:"transform,omitempty"`
+	// Default: records are neither split nor sampled
+	Split *SplitSpec `json:"split,omitempty" yaml:"split,omitempty"`
+	// Default: false (intermediate state of the job is not persisted)
+	Restartable bool `json:"restartable" yaml:"restartable"`
+	// Default: "" (UUID of the failed restartable job to continue)
+	RestartOf string `json:"restart_of" yaml:"restart_of"`
 
 	// debug
 	DSorterType string `json:"dsorter_type"`
@@ -112,6 +129,7 @@ type ParsedRequestSpec struct {
 	Description         string                `json:"description"`
 	OutputBck           cmn.Bck               `json:"output_bck"`
 	Extension           string                `json:"extension"`
+	OutputExtension     string                `json:"output_extension"`
 	OutputShardSize     int64                 `json:"output_shard_size,string"`
 	InputFormat         *parsedInputTemplate  `json:"input_format"`
 	OutputFormat        *parsedOutputTemplate `json:"output_format"`
@@ -124,6 +142,11 @@ type ParsedRequestSpec struct {
 	CreateConcMaxLimit  int                   `json:"create_concurrency_max_limit"`
 	StreamMultiplier    int                   `json:"stream_multiplier"` // TODO: should be removed
 	ExtendedMetrics     bool                  `json:"extended_metrics"`
+	Filter              *RecordFilter         `json:"filter,omitempty"`
+	Transform           *RecordTransform      `json:"transform,omitempty"`
+	Split               *SplitSpec            `json:"split,omitempty"`
+	Restartable         bool                  `json:"restartable"`
+	RestartOf           string                `json:"restart_of"`
 
 	// debug
 	DSorterType string `json:"dsorter_type"`
@@ -135,7 +158,7 @@ type ParsedRequestSpec struct {
 type SortAlgorithm struct {
 	Kind string `json:"kind"`
 
-	// Kind: alphanumeric, content
+	// Kind: alphanumeric, content, key_table
 	Decreasing bool `json:"decreasing"`
 
 	// Kind: shuffle
@@ -144,6 +167,15 @@ type SortAlgorithm struct {
 	// Kind: content
 	Extension  string `json:"extension"`
 	FormatType string `json:"format_type"`
+
+	// Kind: content, key_table
+	// If set, the key is composed of the given fields (compared in order) of
+	// the JSON or CSV content (kind: content) or of the key table (kind: key_table).
+	Fields []extract.KeyField `json:"fields,omitempty"`
+
+	// Kind: key_table
+	KeyTableURL string `json:"key_table"`     // URL to the CSV file with the keys of the records
+	KeyTableSep string `json:"key_table_sep"` // Default: ","
 }
 
 // Parse returns a non-nil error if a RequestSpec is invalid. When RequestSpec
@@ -187,6 +219,12 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 		return nil, errInvalidExtension
 	}
 	parsedRS.Extension = rs.Extension
+	parsedRS.OutputExtension = rs.OutputExtension
+	if parsedRS.OutputExtension == "" {
+		parsedRS.OutputExtension = parsedRS.Extension
+	} else if !validateExtension(parsedRS.OutputExtension) {
+		return nil, errInvalidExtension
+	}
 
 	parsedRS.OutputShardSize, err = cos.S2B(rs.OutputShardSize)
 	if err != nil {
@@ -203,6 +241,8 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 
 	if empty, valid := validateOrderFileURL(rs.OrderFileURL); !valid {
 		return nil, errInvalidOrderParam
+	} else if empty && rs.Split.hasOutputs() {
+		// Output format of each split is parsed together with the split.
 	} else if empty {
 		if parsedRS.OutputFormat, err = parseOutputFormat(rs.OutputFormat); err != nil {
 			return nil, err
@@ -227,6 +267,26 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 		}
 	}
 
+	if rs.Filter != nil {
+		if err := parsedRS.Algorithm.initFilter(rs.Filter); err != nil {
+			return nil, err
+		}
+		parsedRS.Filter = rs.Filter
+	}
+	if rs.Transform != nil {
+		if err := rs.Transform.validate(); err != nil {
+			return nil, err
+		}
+		parsedRS.Transform = rs.Transform
+	}
+
+	if rs.Split != nil {
+		if err := rs.Split.parse(parsedRS); err != nil {
+			return nil, err
+		}
+		parsedRS.Split = rs.Split
+	}
+
 	if rs.MaxMemUsage == "" {
 		rs.MaxMemUsage = cfg.DefaultMaxMemUsage
 	}
@@ -250,6 +310,10 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 	parsedRS.DSorterType = rs.DSorterType
 	parsedRS.DryRun = rs.DryRun
 
+	// Restarted job must be restartable itself, so it can be restarted again.
+	parsedRS.RestartOf = rs.RestartOf
+	parsedRS.Restartable = rs.Restartable || rs.RestartOf != ""
+
 	// Check for values that override the global config.
 	if err := rs.DSortConf.ValidateWithOpts(true); err != nil {
 		return nil, err
@@ -317,7 +381,8 @@ func parseAlgorithm(algo SortAlgorithm) (parsedAlgo *SortAlgorithm, err error) {
 		}
 	}
 
-	if algo.Kind == SortKindContent {
+	switch algo.Kind {
+	case SortKindContent:
 		algo.Extension = strings.TrimSpace(algo.Extension)
 		if algo.Extension == "" {
 			return nil, errInvalidAlgorithmExtension
@@ -327,16 +392,57 @@ func parseAlgorithm(algo SortAlgorithm) (parsedAlgo *SortAlgorithm, err error) {
 			return nil, errInvalidAlgorithmExtension
 		}
 
+		if len(algo.Fields) > 0 {
+			if err := validateKeyFields(algo.Fields); err != nil {
+				return nil, err
+			}
+			break
+		}
 		if err := extract.ValidateAlgorithmFormatType(algo.FormatType); err != nil {
 			return nil, err
 		}
-	} else {
+	case SortKindKeyTable:
+		if _, err := url.ParseRequestURI(algo.KeyTableURL); err != nil {
+			return nil, errInvalidKeyTable
+		}
+		if algo.KeyTableSep == "" {
+			algo.KeyTableSep = ","
+		} else if utf8.RuneCountInString(algo.KeyTableSep) != 1 {
+			return nil, errInvalidKeyTableSep
+		}
+
+		if len(algo.Fields) == 0 {
+			// By default the key is the second column (the first one contains record names).
+			if algo.FormatType == "" {
+				algo.FormatType = extract.FormatTypeString
+			}
+			algo.Fields = []extract.KeyField{{CSVColumn: "1", FormatType: algo.FormatType}}
+		}
+		if err := validateKeyFields(algo.Fields); err != nil {
+			return nil, err
+		}
+		if algo.Fields[0].CSVColumn == "" {
+			return nil, errInvalidKeyFields
+		}
+	default:
 		algo.FormatType = extract.FormatTypeString
 	}
 
 	return &algo, nil
 }
 
+func validateKeyFields(fields []extract.KeyField) error {
+	for i := range fields {
+		if err := fields[i].Validate(); err != nil {
+			return err
+		}
+		if (fields[i].JSONPath == "") != (fields[0].JSONPath == "") {
+			return errInvalidKeyFields
+		}
+	}
+	return nil
+}
+
 func validateOrderFileURL(orderURL string) (empty, valid bool) {
 	if orderURL == "" {
 		return true, true
@@ -8,11 +8,13 @@
 package dsort
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dsort/extract"
 	"github.com/NVIDIA/aistore/fs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -158,6 +160,151 @@ var _ = Describe("RequestSpec", func() {
 			Expect(parsed.Extension).To(Equal(cos.ExtZip))
 		})
 
+		It("should parse spec with .msgpack extension and default output extension", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtMsgpack,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Extension).To(Equal(cos.ExtMsgpack))
+			Expect(parsed.OutputExtension).To(Equal(cos.ExtMsgpack))
+		})
+
+		It("should parse spec with different output extension", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				OutputExtension: cos.ExtZip,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Extension).To(Equal(cos.ExtTar))
+			Expect(parsed.OutputExtension).To(Equal(cos.ExtZip))
+		})
+
+		It("should parse spec with content algorithm and composite key", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm: SortAlgorithm{
+					Kind:      SortKindContent,
+					Extension: ".json",
+					Fields: []extract.KeyField{
+						{JSONPath: "$.label", FormatType: extract.FormatTypeString},
+						{JSONPath: "$.ts", FormatType: extract.FormatTypeTime},
+					},
+				},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.Algorithm.Fields).To(HaveLen(2))
+		})
+
+		It("should parse spec with key table algorithm and set defaults", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm: SortAlgorithm{
+					Kind:        SortKindKeyTable,
+					KeyTableURL: "http://localhost:8080/v1/objects/keys/table.csv",
+					FormatType:  extract.FormatTypeInt,
+				},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.Algorithm.KeyTableSep).To(Equal(","))
+			Expect(parsed.Algorithm.Fields).To(Equal([]extract.KeyField{
+				{CSVColumn: "1", FormatType: extract.FormatTypeInt},
+			}))
+		})
+
+		It("should parse spec with filter and transform", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm: SortAlgorithm{
+					Kind:       SortKindContent,
+					Extension:  ".cls",
+					FormatType: extract.FormatTypeInt,
+				},
+				Filter: &RecordFilter{
+					NameRegex: "^tmp/",
+					MinSize:   "1KB",
+					MaxSize:   "1MB",
+					Key:       &extract.KeyPredicate{Op: extract.KeyOpLt, Value: "100"},
+					DenyList:  "http://localhost:8080/v1/objects/lists/deny.txt",
+				},
+				Transform: &RecordTransform{ID: "resize", Extensions: []string{".jpg"}},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.Filter.minSize).To(BeEquivalentTo(cos.KiB))
+			Expect(parsed.Filter.maxSize).To(BeEquivalentTo(cos.MiB))
+			Expect(parsed.Transform.ID).To(Equal("resize"))
+		})
+
+		It("should parse spec with splits and set defaults", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				OutputBck:       cmn.Bck{Name: "out"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm: SortAlgorithm{
+					Kind: SortKindShuffle,
+					Seed: "42",
+				},
+				Split: &SplitSpec{
+					Outputs: []OutputSplit{
+						{Name: "train", Ratio: 0.8, OutputFormat: "train-{0..99}"},
+						{Name: "val", Ratio: 0.2, OutputFormat: "val-{0..9}", OutputBck: cmn.Bck{Name: "val"}},
+					},
+					Sampling: &Sampling{Rate: 2, Replacement: true},
+				},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.OutputFormat).To(BeNil())
+			Expect(parsed.Split.Seed).To(Equal("42"))
+			Expect(parsed.Split.Outputs[0].OutputBck).To(Equal(parsed.OutputBck))
+			Expect(parsed.Split.Outputs[1].OutputBck.Name).To(Equal("val"))
+		})
+
+		It("should parse spec of the restarted job as restartable", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				RestartOf:       "JGHEoo89gg",
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.RestartOf).To(Equal("JGHEoo89gg"))
+			Expect(parsed.Restartable).To(BeTrue())
+		})
+
 		It("should parse spec with %06d syntax", func() {
 			rs := RequestSpec{
 				Bck:             cmn.Bck{Name: "test"},
@@ -384,6 +531,164 @@ var _ = Describe("RequestSpec", func() {
 			Expect(err).To(Equal(errInvalidExtension))
 		})
 
+		It("should fail due to invalid output extension", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				OutputExtension: ".jpg",
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidExtension))
+		})
+
+		It("should fail due to mixed key fields", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm: SortAlgorithm{
+					Kind:      SortKindContent,
+					Extension: ".json",
+					Fields: []extract.KeyField{
+						{JSONPath: "$.label", FormatType: extract.FormatTypeString},
+						{CSVColumn: "len", FormatType: extract.FormatTypeInt},
+					},
+				},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidAlgorithm))
+		})
+
+		It("should fail due to invalid key table", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindKeyTable},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidAlgorithm))
+		})
+
+		It("should fail due to invalid filter", func() {
+			for _, filter := range []*RecordFilter{
+				{NameRegex: "(abc"},
+				{MinSize: "2KB", MaxSize: "1KB"},
+				{MinSize: "abc"},
+				{DenyList: "deny.txt"},
+				{Key: &extract.KeyPredicate{Op: extract.KeyOpEq, Value: "abc"}},
+			} {
+				rs := RequestSpec{
+					Bck:             cmn.Bck{Name: "test"},
+					Extension:       cos.ExtTar,
+					InputFormat:     "prefix-{0010..0111}-suffix",
+					OutputFormat:    "prefix-{0010..0111}-suffix",
+					OutputShardSize: "10KB",
+					Algorithm: SortAlgorithm{
+						Kind:       SortKindContent,
+						Extension:  ".cls",
+						FormatType: extract.FormatTypeInt,
+					},
+					Filter: filter,
+				}
+				_, err := rs.Parse()
+				Expect(err).Should(HaveOccurred())
+			}
+		})
+
+		It("should fail due to filter key predicate with non-content algorithm", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Filter:          &RecordFilter{Key: &extract.KeyPredicate{Op: extract.KeyOpEq, Value: "abc"}},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidFilterKey))
+		})
+
+		It("should fail due to invalid transform", func() {
+			for _, transform := range []*RecordTransform{
+				{},
+				{ID: "resize", Extensions: []string{"jpg"}},
+			} {
+				rs := RequestSpec{
+					Bck:             cmn.Bck{Name: "test"},
+					Extension:       cos.ExtTar,
+					InputFormat:     "prefix-{0010..0111}-suffix",
+					OutputFormat:    "prefix-{0010..0111}-suffix",
+					OutputShardSize: "10KB",
+					Transform:       transform,
+				}
+				_, err := rs.Parse()
+				Expect(err).Should(HaveOccurred())
+			}
+		})
+
+		It("should fail due to invalid split", func() {
+			outputs := func(ratios ...float64) []OutputSplit {
+				splits := make([]OutputSplit, 0, len(ratios))
+				for i, ratio := range ratios {
+					splits = append(splits, OutputSplit{Name: fmt.Sprintf("split%d", i), Ratio: ratio, OutputFormat: "out-{0..9}"})
+				}
+				return splits
+			}
+			for _, test := range []struct {
+				split *SplitSpec
+				err   error
+			}{
+				{split: &SplitSpec{Outputs: outputs(0.5, 0.4)}, err: errInvalidSplitOutputs},
+				{split: &SplitSpec{Outputs: outputs(1.2, -0.2)}, err: errInvalidSplitOutputs},
+				{split: &SplitSpec{Outputs: []OutputSplit{{Ratio: 1, OutputFormat: "out-{0..9}"}}}, err: errInvalidSplitOutputs},
+				{split: &SplitSpec{Seed: "abc", Outputs: outputs(0.5, 0.5)}, err: errInvalidSeed},
+				{split: &SplitSpec{Stratified: true, Outputs: outputs(0.5, 0.5)}, err: errInvalidSplitStratified},
+				{split: &SplitSpec{Sampling: &Sampling{Rate: 0}}, err: errInvalidSampling},
+				{split: &SplitSpec{Sampling: &Sampling{Rate: 1, Weights: map[string]float64{"a": 2}}}, err: errInvalidSamplingWeights},
+			} {
+				rs := RequestSpec{
+					Bck:             cmn.Bck{Name: "test"},
+					Extension:       cos.ExtTar,
+					InputFormat:     "prefix-{0010..0111}-suffix",
+					OutputFormat:    "prefix-{0010..0111}-suffix",
+					OutputShardSize: "10KB",
+					Split:           test.split,
+				}
+				_, err := rs.Parse()
+				Expect(err).Should(HaveOccurred())
+				Expect(err).To(Equal(test.err))
+			}
+		})
+
+		It("should fail due to split outputs with order file", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				OrderFileURL:    "http://localhost:8080/v1/objects/keys/order.txt",
+				Split: &SplitSpec{
+					Outputs: []OutputSplit{{Name: "train", Ratio: 1, OutputFormat: "train-{0..9}"}},
+				},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidSplitOrderFile))
+		})
+
 		It("should fail due to invalid mem usage specification", func() {
 			rs := RequestSpec{
 				Bck:             cmn.Bck{Name: "test"},
@@ -19,15 +19,16 @@ const (
 	SortKindAlphanumeric = "alphanumeric" // sort the records (decreasing or increasing)
 	SortKindNone         = "none"         // none, used for resharding
 	SortKindMD5          = "md5"
-	SortKindShuffle      = "shuffle" // shuffle randomly, can be used with seed to get reproducible results
-	SortKindContent      = "content" // sort by content of given file
+	SortKindShuffle      = "shuffle"   // shuffle randomly, can be used with seed to get reproducible results
+	SortKindContent      = "content"   // sort by content of given file
+	SortKindKeyTable     = "key_table" // sort by keys from the external key table
 )
 
 const (
 	fmtInvalidAlgorithmKind = "invalid algorithm kind, expecting one of: %+v" // <--- supportedAlgorithms
 )
 
-var supportedAlgorithms = []string{sortKindEmpty, SortKindAlphanumeric, SortKindMD5, SortKindShuffle, SortKindContent, SortKindNone}
+var supportedAlgorithms = []string{sortKindEmpty, SortKindAlphanumeric, SortKindMD5, SortKindShuffle, SortKindContent, SortKindKeyTable, SortKindNone}
 
 type (
 	alphaByKey struct {
@@ -66,10 +67,7 @@ func sortRecords(r *extract.Records, algo *SortAlgorithm) (err error) {
 	} else if algo.Kind == SortKindShuffle {
 		seed := time.Now().Unix()
 		if algo.Seed != "" {
-			seed, err = strconv.ParseInt(algo.Seed, 10, 64)
-			// We assert error since we know that the seed should be validated
-			// during request spec validation.
-			cos.AssertNoErr(err)
+			seed = parseSeed(algo.Seed)
 		}
 
 		rand.Seed(seed)
@@ -88,3 +86,13 @@ func sortRecords(r *extract.Records, algo *SortAlgorithm) (err error) {
 
 	return nil
 }
+
+// parseSeed parses the seed of the random generator (shuffle) or of the hash
+// (splits and sampling).
+func parseSeed(seed string) int64 {
+	v, err := strconv.ParseInt(seed, 10, 64)
+	// We assert error since we know that the seed should be validated
+	// during request spec validation.
+	cos.AssertNoErr(err)
+	return v
+}
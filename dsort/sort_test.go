@@ -72,6 +72,36 @@ var _ = Describe("SortRecords", func() {
 		Expect(fm).To(Equal(expected))
 	})
 
+	It("should sort records by composite keys", func() {
+		expected := createRecords(
+			[]interface{}{"cat", int64(1)},
+			[]interface{}{"cat", int64(20)},
+			[]interface{}{"dog", int64(3)},
+		)
+		fm := createRecords(
+			[]interface{}{"dog", int64(3)},
+			[]interface{}{"cat", int64(20)},
+			[]interface{}{"cat", int64(1)},
+		)
+		err := sortRecords(fm, &SortAlgorithm{Kind: SortKindContent})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fm).To(Equal(expected))
+	})
+
+	It("should sort records by composite keys with ints received as floats", func() {
+		expected := createRecords([]interface{}{int64(2), "b"}, []interface{}{float64(10), "a"})
+		fm := createRecords([]interface{}{float64(10), "a"}, []interface{}{int64(2), "b"})
+		err := sortRecords(fm, &SortAlgorithm{Kind: SortKindKeyTable, Decreasing: false})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fm).To(Equal(expected))
+	})
+
+	It("should return error when composite keys cannot be compared", func() {
+		fm := createRecords([]interface{}{"abc"}, []interface{}{int64(1)})
+		err := sortRecords(fm, &SortAlgorithm{Kind: SortKindKeyTable})
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("should not sort records when none algorithm specified", func() {
 		expected := createRecords("def", "abc")
 		fm := createRecords("def", "abc")
@@ -0,0 +1,417 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+package dsort
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dsort/extract"
+	"github.com/OneOfOne/xxhash"
+	"github.com/pkg/errors"
+)
+
+const (
+	// dupRecordSep separates the name of the record duplicated by sampling
+	// with replacement and the number of the copy, eg. "dir/sample~dup1".
+	dupRecordSep = "~dup"
+
+	// maxSamplingRate limits the expected number of copies of a single record.
+	maxSamplingRate = 100
+
+	// Salts of the hash make assignment to the splits and sampling independent.
+	splitSalt  = 0
+	sampleSalt = 0x9e3779b97f4a7c15
+)
+
+type (
+	// SplitSpec describes how the records are divided into multiple outputs
+	// (eg. train, validation and test datasets) and/or sampled.
+	SplitSpec struct {
+		// Seed of the hash which deterministically assigns the records to the
+		// splits and samples them. Default: `algorithm.seed` or current time.
+		Seed string `json:"seed,omitempty" yaml:"seed,omitempty"`
+		// If set, records with the same label (key of the record or the first
+		// field of the composite key) are divided among the splits in the given ratios.
+		Stratified bool `json:"stratified,omitempty" yaml:"stratified,omitempty"`
+		// Outputs of the splits. If empty, the records are not split and are
+		// written with `output_format` (or `order_file`).
+		Outputs []OutputSplit `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+		// Sampling of the records, done (on each target) before the records are split.
+		Sampling *Sampling `json:"sampling,omitempty" yaml:"sampling,omitempty"`
+	}
+
+	// OutputSplit describes a single output of the split records.
+	OutputSplit struct {
+		Name         string  `json:"name" yaml:"name"`
+		Ratio        float64 `json:"ratio" yaml:"ratio"`
+		OutputFormat string  `json:"output_format" yaml:"output_format"`
+		// Default: same as `output_bck` of the request.
+		OutputBck cmn.Bck `json:"output_bck" yaml:"output_bck"`
+	}
+
+	// Sampling describes weighted (Poisson) sampling: each record is sampled
+	// independently and the expected number of its copies is `Rate` multiplied
+	// by the weight of its label.
+	Sampling struct {
+		Rate float64 `json:"rate" yaml:"rate"`
+		// If not set, each record is sampled at most once.
+		Replacement bool `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+		// Weights of the labels (key of the record or the first field of the
+		// composite key). Labels which are not listed have weight 1.
+		Weights map[string]float64 `json:"weights,omitempty" yaml:"weights,omitempty"`
+	}
+)
+
+var (
+	errInvalidSplitOutputs    = errors.New("split outputs must have unique, non-empty names and positive ratios which sum up to 1")
+	errInvalidSplitOrderFile  = errors.New("split outputs cannot be used together with order file")
+	errInvalidSplitStratified = errors.New("stratified split requires records with labels ('content' or 'key_table' algorithm) and split outputs")
+	errInvalidSampling        = fmt.Errorf("sampling rate must be positive and with weights cannot exceed %d", maxSamplingRate)
+	errInvalidSamplingWeights = errors.New("sampling weights are supported only with 'content' algorithm")
+)
+
+///////////////
+// SplitSpec //
+///////////////
+
+func (s *SplitSpec) hasOutputs() bool { return s != nil && len(s.Outputs) > 0 }
+
+// parse validates the split and sets the defaults.
+func (s *SplitSpec) parse(parsedRS *ParsedRequestSpec) (err error) {
+	if s.Seed == "" {
+		s.Seed = parsedRS.Algorithm.Seed
+		if s.Seed == "" {
+			// NOTE: the seed is set once so that all targets use the same one.
+			s.Seed = strconv.FormatInt(time.Now().Unix(), 10)
+		}
+	}
+	if _, err := strconv.ParseInt(s.Seed, 10, 64); err != nil {
+		return errInvalidSeed
+	}
+
+	if s.hasOutputs() {
+		if parsedRS.OrderFileURL != "" {
+			return errInvalidSplitOrderFile
+		}
+		var (
+			sum   float64
+			names = cos.NewStringSet()
+		)
+		for i := range s.Outputs {
+			output := &s.Outputs[i]
+			if output.Name == "" || names.Contains(output.Name) || output.Ratio <= 0 {
+				return errInvalidSplitOutputs
+			}
+			names.Add(output.Name)
+			sum += output.Ratio
+
+			pot, err := parseOutputFormat(output.OutputFormat)
+			if err != nil {
+				return err
+			}
+			if pot.Template.Count() > math.MaxInt32 && parsedRS.OutputShardSize == 0 {
+				return errEmptyOutputShardSize
+			}
+			if output.OutputBck.IsEmpty() {
+				output.OutputBck = parsedRS.OutputBck
+			} else if _, err := cmn.NormalizeProvider(output.OutputBck.Provider); err != nil {
+				return err
+			} else if err := output.OutputBck.Validate(); err != nil {
+				return err
+			}
+		}
+		if math.Abs(sum-1) > 1e-6 {
+			return errInvalidSplitOutputs
+		}
+	}
+
+	if s.Stratified {
+		kind := parsedRS.Algorithm.Kind
+		if !s.hasOutputs() || (kind != SortKindContent && kind != SortKindKeyTable) {
+			return errInvalidSplitStratified
+		}
+	}
+
+	if s.Sampling != nil {
+		return s.Sampling.validate(parsedRS.Algorithm)
+	}
+	return nil
+}
+
+// output returns the output of the split with the given name.
+func (s *SplitSpec) output(name string) *OutputSplit {
+	for i := range s.Outputs {
+		if s.Outputs[i].Name == name {
+			return &s.Outputs[i]
+		}
+	}
+	return nil
+}
+
+// pick returns the index of the split for the given (uniform) hash value.
+func (s *SplitSpec) pick(u float64) int {
+	var cum float64
+	for i := range s.Outputs {
+		cum += s.Outputs[i].Ratio
+		if u < cum {
+			return i
+		}
+	}
+	return len(s.Outputs) - 1
+}
+
+// splitRecords divides the records among the split outputs preserving their
+// order. Copies of the record made by sampling always end up in the same split
+// as the original.
+func (s *SplitSpec) splitRecords(records *extract.Records) []*extract.Records {
+	var (
+		seed     = parseSeed(s.Seed)
+		all      = records.All()
+		assigned = make([]int, len(all)) // index of the split of each record
+	)
+	if s.Stratified {
+		// Label => original record name => indices of the record and its copies.
+		strata := make(map[string]map[string][]int)
+		for idx, r := range all {
+			label, name := recordLabel(r.Key), origRecordName(r.Name)
+			if strata[label] == nil {
+				strata[label] = make(map[string][]int)
+			}
+			strata[label][name] = append(strata[label][name], idx)
+		}
+		for _, units := range strata {
+			names := make([]string, 0, len(units))
+			hashes := make(map[string]float64, len(units))
+			for name := range units {
+				names = append(names, name)
+				hashes[name] = hashName(name, seed, splitSalt)
+			}
+			sort.Slice(names, func(i, j int) bool {
+				if hashes[names[i]] != hashes[names[j]] {
+					return hashes[names[i]] < hashes[names[j]]
+				}
+				return names[i] < names[j]
+			})
+			var (
+				cum   float64
+				start int
+			)
+			for split := range s.Outputs {
+				cum += s.Outputs[split].Ratio
+				end := cos.Min(int(math.Round(cum*float64(len(names)))), len(names))
+				if split == len(s.Outputs)-1 {
+					end = len(names)
+				}
+				for _, name := range names[start:end] {
+					for _, idx := range units[name] {
+						assigned[idx] = split
+					}
+				}
+				start = cos.Max(start, end)
+			}
+		}
+	} else {
+		for idx, r := range all {
+			assigned[idx] = s.pick(hashName(origRecordName(r.Name), seed, splitSalt))
+		}
+	}
+
+	splits := make([]*extract.Records, len(s.Outputs))
+	for i := range splits {
+		splits[i] = extract.NewRecords(int(float64(len(all)) * s.Outputs[i].Ratio))
+	}
+	for idx, r := range all {
+		splits[assigned[idx]].Insert(r)
+	}
+	return splits
+}
+
+//////////////
+// Sampling //
+//////////////
+
+func (s *Sampling) validate(algo *SortAlgorithm) error {
+	if s.Rate <= 0 || s.Rate > maxSamplingRate {
+		return errInvalidSampling
+	}
+	if len(s.Weights) > 0 && algo.Kind != SortKindContent {
+		// Keys of other algorithms are either not typed or assigned only after
+		// the extraction (key table) when the records are already sampled.
+		return errInvalidSamplingWeights
+	}
+	for _, w := range s.Weights {
+		if w < 0 || s.Rate*w > maxSamplingRate {
+			return errInvalidSampling
+		}
+	}
+	return nil
+}
+
+func (s *Sampling) weight(key interface{}) float64 {
+	if len(s.Weights) == 0 {
+		return 1
+	}
+	if w, ok := s.Weights[recordLabel(key)]; ok {
+		return w
+	}
+	return 1
+}
+
+// copies returns the number of copies of the record in the sample, that is:
+// 0 or 1 without replacement and a value drawn from the Poisson distribution
+// with replacement.
+func (s *Sampling) copies(r *extract.Record, seed int64) int {
+	var (
+		lambda = s.Rate * s.weight(r.Key)
+		u      = hashName(r.Name, seed, sampleSalt)
+	)
+	if !s.Replacement {
+		if u < lambda {
+			return 1
+		}
+		return 0
+	}
+	// Inverse transform sampling.
+	var (
+		p   = math.Exp(-lambda)
+		cdf = p
+		k   int
+	)
+	for u >= cdf && p > 0 {
+		k++
+		p *= lambda / float64(k)
+		cdf += p
+	}
+	return k
+}
+
+// sampleRecords drops the records which are not sampled and adds copies of the
+// records sampled multiple times. It must be called before the records are
+// accounted for (see: `incrementRef`).
+func (m *Manager) sampleRecords() error {
+	if m.rs.Split == nil || m.rs.Split.Sampling == nil {
+		return nil
+	}
+
+	var (
+		sampling = m.rs.Split.Sampling
+		seed     = parseSeed(m.rs.Split.Seed)
+		toCopy   = make([]*extract.Record, 0)
+		copies   = make([]int, 0)
+	)
+	dropped := m.recManager.FilterRecords(func(r *extract.Record) bool {
+		n := sampling.copies(r, seed)
+		if n > 1 {
+			toCopy = append(toCopy, r)
+			copies = append(copies, n)
+		}
+		return n > 0
+	})
+
+	var duplicated int
+	for i, r := range toCopy {
+		for k := 1; k < copies[i]; k++ {
+			if _, err := m.recManager.CloneRecord(r, r.Name+dupRecordSep+strconv.Itoa(k)); err != nil {
+				return errors.Errorf("failed to copy sampled record %q: %v", r.Name, err)
+			}
+			duplicated++
+		}
+	}
+
+	metrics := m.Metrics.Extraction
+	metrics.mu.Lock()
+	metrics.SamplingDroppedCnt += int64(dropped)
+	metrics.SamplingDuplicatedCnt += int64(duplicated)
+	metrics.mu.Unlock()
+	return nil
+}
+
+// generateShardsWithSplits splits the (sorted) records and generates shards of
+// each split with its output template.
+func (m *Manager) generateShardsWithSplits(maxSize int64) ([]*extract.Shard, error) {
+	var (
+		shards = make([]*extract.Shard, 0)
+		names  = cos.NewStringSet()
+		splits = m.rs.Split.splitRecords(m.recManager.Records)
+	)
+	for i, records := range splits {
+		output := &m.rs.Split.Outputs[i]
+		pot, err := parseOutputFormat(output.OutputFormat)
+		if err != nil {
+			return nil, err
+		}
+		glog.Infof("[dsort] %s split %q contains %d records", m.ManagerUUID, output.Name, records.Len())
+
+		totalSize := int64(float64(m.totalUncompressedSize()) * output.Ratio)
+		splitShards, err := m.generateShardsWithTemplate(records, pot.Template, maxSize, totalSize)
+		if err != nil {
+			return nil, errors.Errorf("split %q: %v", output.Name, err)
+		}
+		for _, s := range splitShards {
+			// Shards are identified by names (see: `SendOrder`).
+			if names.Contains(s.Name) {
+				return nil, errors.Errorf("output shard %q is generated by more than one split", s.Name)
+			}
+			names.Add(s.Name)
+			s.Split = output.Name
+		}
+		shards = append(shards, splitShards...)
+	}
+	return shards, nil
+}
+
+// outputBck returns the bucket in which the shard is created.
+func (m *Manager) outputBck(s *extract.Shard) *cmn.Bck {
+	if s.Split != "" {
+		output := m.rs.Split.output(s.Split)
+		cos.Assert(output != nil)
+		return &output.OutputBck
+	}
+	return &m.rs.OutputBck
+}
+
+/////////////
+// helpers //
+/////////////
+
+// hashName returns deterministic, uniformly distributed value in [0, 1).
+func hashName(name string, seed int64, salt uint64) float64 {
+	h := xxhash.ChecksumString64S(name, uint64(seed)^salt)
+	return float64(h>>11) / (1 << 53)
+}
+
+// recordLabel returns the label of the record: its key or, for composite
+// keys, the first field of the key.
+func recordLabel(key interface{}) string {
+	if composite, ok := key.([]interface{}); ok {
+		if len(composite) == 0 {
+			return ""
+		}
+		key = composite[0]
+	}
+	return fmt.Sprint(key)
+}
+
+// origRecordName returns the name of the original record (for copies made by
+// sampling with replacement) or the name itself.
+func origRecordName(name string) string {
+	idx := strings.LastIndex(name, dupRecordSep)
+	if idx < 0 {
+		return name
+	}
+	if _, err := strconv.Atoi(name[idx+len(dupRecordSep):]); err != nil {
+		return name
+	}
+	return name[:idx]
+}
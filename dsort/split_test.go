@@ -0,0 +1,146 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+package dsort
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/dsort/extract"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Split", func() {
+	const recordCnt = 10000
+
+	// createLabeledRecords creates records where every tenth has label "rare".
+	createLabeledRecords := func() *extract.Records {
+		records := extract.NewRecords(recordCnt)
+		for i := 0; i < recordCnt; i++ {
+			label := "common"
+			if i%10 == 0 {
+				label = "rare"
+			}
+			records.Insert(&extract.Record{
+				Key:  []interface{}{label, int64(i)},
+				Name: fmt.Sprintf("shard-%d|sample-%d", i%7, i),
+			})
+		}
+		return records
+	}
+
+	newSpec := func(stratified bool) *SplitSpec {
+		return &SplitSpec{
+			Seed:       "1234",
+			Stratified: stratified,
+			Outputs: []OutputSplit{
+				{Name: "train", Ratio: 0.8},
+				{Name: "val", Ratio: 0.1},
+				{Name: "test", Ratio: 0.1},
+			},
+		}
+	}
+
+	countLabels := func(records *extract.Records) map[string]int {
+		cnt := make(map[string]int)
+		for _, r := range records.All() {
+			cnt[recordLabel(r.Key)]++
+		}
+		return cnt
+	}
+
+	It("should split records deterministically by ratio", func() {
+		splits := newSpec(false).splitRecords(createLabeledRecords())
+		Expect(splits).To(HaveLen(3))
+		Expect(splits[0].Len() + splits[1].Len() + splits[2].Len()).To(Equal(recordCnt))
+		Expect(splits[0].Len()).To(BeNumerically("~", 0.8*recordCnt, 0.03*recordCnt))
+		Expect(splits[1].Len()).To(BeNumerically("~", 0.1*recordCnt, 0.03*recordCnt))
+
+		again := newSpec(false).splitRecords(createLabeledRecords())
+		for i := range splits {
+			Expect(again[i].All()).To(Equal(splits[i].All()))
+		}
+
+		spec := newSpec(false)
+		spec.Seed = "4321"
+		other := spec.splitRecords(createLabeledRecords())
+		Expect(other[1].All()).NotTo(Equal(splits[1].All()))
+	})
+
+	It("should preserve the order of records in the splits", func() {
+		for _, records := range newSpec(false).splitRecords(createLabeledRecords()) {
+			all := records.All()
+			for i := 1; i < len(all); i++ {
+				Expect(all[i-1].Key.([]interface{})[1]).To(BeNumerically("<", all[i].Key.([]interface{})[1]))
+			}
+		}
+	})
+
+	It("should split records stratified by label", func() {
+		splits := newSpec(true).splitRecords(createLabeledRecords())
+		Expect(countLabels(splits[0])).To(Equal(map[string]int{"common": 7200, "rare": 800}))
+		Expect(countLabels(splits[1])).To(Equal(map[string]int{"common": 900, "rare": 100}))
+		Expect(countLabels(splits[2])).To(Equal(map[string]int{"common": 900, "rare": 100}))
+	})
+
+	It("should keep copies of the record in the same split", func() {
+		for _, stratified := range []bool{false, true} {
+			records := createLabeledRecords()
+			for _, r := range records.All()[:100] {
+				records.Insert(&extract.Record{Key: r.Key, Name: r.Name + dupRecordSep + "1"})
+			}
+			splitOf := make(map[string]int)
+			for i, split := range newSpec(stratified).splitRecords(records) {
+				for _, r := range split.All() {
+					splitOf[r.Name] = i
+				}
+			}
+			for _, r := range records.All()[:100] {
+				Expect(splitOf[r.Name+dupRecordSep+"1"]).To(Equal(splitOf[r.Name]))
+			}
+		}
+	})
+
+	It("should sample records without replacement", func() {
+		var (
+			records  = createLabeledRecords()
+			sampling = &Sampling{Rate: 0.5, Weights: map[string]float64{"rare": 2}}
+			cnt      = make(map[string]int)
+		)
+		for _, r := range records.All() {
+			n := sampling.copies(r, 1234)
+			Expect(n).To(BeNumerically("<=", 1))
+			cnt[recordLabel(r.Key)] += n
+		}
+		Expect(cnt["rare"]).To(Equal(recordCnt / 10))
+		Expect(cnt["common"]).To(BeNumerically("~", 0.5*0.9*recordCnt, 0.03*recordCnt))
+	})
+
+	It("should sample records with replacement", func() {
+		var (
+			records  = createLabeledRecords()
+			sampling = &Sampling{Rate: 1, Replacement: true, Weights: map[string]float64{"rare": 3, "common": 0}}
+			cnt      = make(map[string]int)
+			multiple int
+		)
+		for _, r := range records.All() {
+			n := sampling.copies(r, 1234)
+			if n > 1 {
+				multiple++
+			}
+			cnt[recordLabel(r.Key)] += n
+		}
+		Expect(cnt["common"]).To(BeZero())
+		Expect(cnt["rare"]).To(BeNumerically("~", 3*recordCnt/10, 0.1*recordCnt))
+		Expect(multiple).To(BeNumerically(">", 0))
+	})
+
+	It("should return the name of the original record", func() {
+		Expect(origRecordName("shard|dir/sample" + dupRecordSep + "12")).To(Equal("shard|dir/sample"))
+		Expect(origRecordName("shard|dir/sample")).To(Equal("shard|dir/sample"))
+		Expect(origRecordName("shard|dir/sample" + dupRecordSep + "x")).To(Equal("shard|dir/sample" + dupRecordSep + "x"))
+	})
+})
@@ -6,6 +6,7 @@ package etl
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httputil"
@@ -47,6 +48,11 @@ type (
 		// with GET requests from users (such as training models and apps)
 		// to perform on-the-fly transformation.
 		OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error)
+
+		// TransformReader transforms the data which is not stored as an object
+		// (eg. records of the dSort job). Only push communication types
+		// (see `PushCommType` and `IOCommType`) support this kind of transformation.
+		TransformReader(r io.Reader, size int64, timeout time.Duration) (cos.ReadCloseSizer, error)
 		Stop()
 
 		CommStats
@@ -203,21 +209,26 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.R
 	if err != nil {
 		return nil, err
 	}
+	return pc.push(fh, size, timeout)
+}
 
+// push sends the data to the ETL container and returns the transformed data.
+func (pc *pushComm) push(body io.ReadCloser, size int64, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	var (
 		req    *http.Request
 		resp   *http.Response
 		cancel func()
+		err    error
 	)
 	if timeout != 0 {
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
-		req, err = http.NewRequestWithContext(ctx, http.MethodPut, pc.uri, fh)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, pc.uri, body)
 	} else {
-		req, err = http.NewRequest(http.MethodPut, pc.uri, fh)
+		req, err = http.NewRequest(http.MethodPut, pc.uri, body)
 	}
 	if err != nil {
-		cos.Close(fh)
+		cos.Close(body)
 		goto finish
 	}
 	if len(pc.command) != 0 {
@@ -271,6 +282,13 @@ func (pc *pushComm) OfflineTransform(bck *cluster.Bck, objName string, timeout t
 	return pc.doRequest(bck, objName, timeout)
 }
 
+func (pc *pushComm) TransformReader(r io.Reader, size int64, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := pc.xctn.AbortErr(); err != nil {
+		return nil, cmn.NewErrAborted(pc.xctn.Name(), "push-comm-reader", err)
+	}
+	return pc.push(io.NopCloser(r), size, timeout)
+}
+
 //////////////////
 // redirectComm //
 //////////////////
@@ -302,6 +320,10 @@ func (rc *redirectComm) OfflineTransform(bck *cluster.Bck, objName string, timeo
 	return rc.getWithTimeout(etlURL, size, timeout)
 }
 
+func (rc *redirectComm) TransformReader(io.Reader, int64, time.Duration) (cos.ReadCloseSizer, error) {
+	return nil, errTransformReader(rc.name, RedirectCommType)
+}
+
 //////////////////
 // revProxyComm //
 //////////////////
@@ -331,6 +353,10 @@ func (pc *revProxyComm) OfflineTransform(bck *cluster.Bck, objName string, timeo
 	return pc.getWithTimeout(etlURL, size, timeout)
 }
 
+func (pc *revProxyComm) TransformReader(io.Reader, int64, time.Duration) (cos.ReadCloseSizer, error) {
+	return nil, errTransformReader(pc.name, RevProxyCommType)
+}
+
 //////////////
 // cbWriter //
 //////////////
@@ -345,6 +371,11 @@ func (cw *cbWriter) Write(b []byte) (n int, err error) {
 // utils //
 ///////////
 
+func errTransformReader(name, commType string) error {
+	return fmt.Errorf("ETL %q: %q communication type does not support transforming data which is not stored as an object, expecting %q or %q",
+		name, commType, PushCommType, IOCommType)
+}
+
 // prune query (received from AIS proxy) prior to reverse-proxying the request to/from container -
 // not removing apc.QparamUUID, for instance, would cause infinite loop.
 func pruneQuery(rawQuery string) string {
@@ -6,6 +6,8 @@ package fs
 
 import (
 	"fmt"
+	"os"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -37,6 +39,21 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+
+	ObjVersionType = "ov" // previous versions and delete markers (see cmn.VersionConf.History)
+	SnapshotType   = "sn" // bucket snapshots: <snapshot name>/<object name> (see cmn.BckSnap)
+	ArchIndexType  = "ai" // archive index sidecars (see cmn/archidx)
+)
+
+// ObjVersionType naming: <original name> + verSepa + "/" + { 'v' | 'd' } + <version>
+// where 'd' denotes a delete marker. In other words, each object gets its own directory
+// of previous versions, so that the history of one object is listed without reading
+// the versions of its neighbors. The separator sorts ahead of all printable characters
+// so that (sorted) walks produce versions in the same order as the respective objects.
+const (
+	verSepa      = "\x01"
+	verPrefData  = 'v'
+	verPrefDelMk = 'd'
 )
 
 type (
@@ -163,10 +180,13 @@ func (f *contentSpecMgr) PermToProcess(fqn string) (ok bool) {
 // FIXME: This should be probably placed somewhere else \/
 
 type (
-	ObjectContentResolver   struct{}
-	WorkfileContentResolver struct{}
-	ECSliceContentResolver  struct{}
-	ECMetaContentResolver   struct{}
+	ObjectContentResolver     struct{}
+	WorkfileContentResolver   struct{}
+	ECSliceContentResolver    struct{}
+	ECMetaContentResolver     struct{}
+	ObjVersionContentResolver struct{}
+	SnapshotContentResolver   struct{}
+	ArchIndexContentResolver  struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -235,3 +255,89 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+// NOTE: previous versions stay on the mountpath of the (then) current object
+// and are neither migrated by rebalance/resilver nor erasure-coded or mirrored.
+func (*ObjVersionContentResolver) PermToMove() bool    { return false }
+func (*ObjVersionContentResolver) PermToEvict() bool   { return false }
+func (*ObjVersionContentResolver) PermToProcess() bool { return false }
+
+// prefix is expected to be generated by `ObjVersionTag`
+func (*ObjVersionContentResolver) GenUniqueFQN(base, prefix string) string {
+	return base + verSepa + "/" + prefix
+}
+
+// NOTE: the original name is the (per-object) directory - see ParseObjVersion
+func (*ObjVersionContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	_, _, ok = ParseObjVersionTag(base)
+	return base, false, ok
+}
+
+func ObjVersionTag(ver string, delMarker bool) string {
+	if delMarker {
+		return string(verPrefDelMk) + ver
+	}
+	return string(verPrefData) + ver
+}
+
+// ParseObjVersionTag parses the base name of ObjVersionType content
+func ParseObjVersionTag(tag string) (ver string, delMarker, ok bool) {
+	if len(tag) < 2 {
+		return
+	}
+	switch tag[0] {
+	case verPrefData:
+	case verPrefDelMk:
+		delMarker = true
+	default:
+		return
+	}
+	ver = tag[1:]
+	if _, err := strconv.ParseUint(ver, 10, 64); err != nil {
+		return
+	}
+	return ver, delMarker, true
+}
+
+// ParseObjVersion parses the (bucket-relative) name of ObjVersionType content
+func ParseObjVersion(name string) (orig, ver string, delMarker, ok bool) {
+	dir, tag := path.Split(name)
+	if !strings.HasSuffix(dir, verSepa+"/") || len(dir) <= len(verSepa)+1 {
+		return
+	}
+	if ver, delMarker, ok = ParseObjVersionTag(tag); ok {
+		orig = dir[:len(dir)-len(verSepa)-1]
+	}
+	return
+}
+
+// RemoveObjVersionDir removes the directory of previous versions of a given object,
+// if and only if the directory is empty.
+func RemoveObjVersionDir(fqn string) {
+	dir := filepath.Dir(fqn)
+	if strings.HasSuffix(dir, verSepa) {
+		os.Remove(dir)
+	}
+}
+
+// NOTE: same as previous versions (above), snapshots are hard links that stay
+// on the mountpath of the respective objects.
+func (*SnapshotContentResolver) PermToMove() bool                   { return false }
+func (*SnapshotContentResolver) PermToEvict() bool                  { return false }
+func (*SnapshotContentResolver) PermToProcess() bool                { return false }
+func (*SnapshotContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*SnapshotContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// NOTE: archive indices are neither moved nor evicted - an index that no longer
+// matches its archive is ignored and, eventually, removed by space cleanup.
+func (*ArchIndexContentResolver) PermToMove() bool                   { return false }
+func (*ArchIndexContentResolver) PermToEvict() bool                  { return false }
+func (*ArchIndexContentResolver) PermToProcess() bool                { return false }
+func (*ArchIndexContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*ArchIndexContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
@@ -29,6 +29,12 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileEditArch     = "edit-arch"      // rewrite existing archive (to add, replace, or delete archived file)
+	WorkfileExtractArch  = "extract-arch"   // PUT archived file as object
+	WorkfileCow          = "cow"            // copy object that is shared with a snapshot (prior to in-place update)
+	WorkfileRestoreSnap  = "restore-snap"   // restore object from a bucket snapshot
+	WorkfileDownload     = "dl"             // download (resumable, see downloader/partial.go)
+	WorkfileGetBatch     = "get-batch"      // object fetched by GET batch and spilled (see ais/tgtbatch.go)
 )
 
 type ParsedFQN struct {
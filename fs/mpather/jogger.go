@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
@@ -59,6 +61,12 @@ type (
 		IncludeCopy           bool // Traverses LOMs that are copies.
 		SkipGloballyMisplaced bool // Skips content types that are globally misplaced.
 		Throttle              bool // Determines if the jogger should throttle itself.
+
+		// Resumable traversal (a single bucket only): walk in sorted order while tracking
+		// per-mountpath positions (see JoggerGroup.Positions); skip everything up to and
+		// including the `Resume` positions, if any.
+		Resumable bool
+		Resume    map[string]string // mountpath => FQN
 	}
 
 	// JoggerGroup runs jogger per mountpath which walk the entire bucket and
@@ -85,6 +93,18 @@ type (
 		syncGroup *joggerSyncGroup
 
 		num int64
+		pos *joggerPos // when resumable
+	}
+
+	// tracks the position of a (resumable) jogger: the FQN such that all
+	// FQNs up to and including it have been visited (even if in parallel)
+	joggerPos struct {
+		mu      sync.Mutex
+		fqn     string
+		skip    string           // resuming: skip up to and including
+		next    int64            // next seq. number to visit
+		done    int64            // all seq. numbers below are visited
+		pending map[int64]string // visited out of order
 	}
 
 	joggerSyncGroup struct {
@@ -137,6 +157,22 @@ func NewJoggerGroup(opts *JoggerGroupOpts, selectedMpaths ...string) *JoggerGrou
 
 func (jg *JoggerGroup) Num() int { return len(jg.joggers) }
 
+// current positions of resumable joggers (see JoggerGroupOpts.Resumable)
+func (jg *JoggerGroup) Positions() (pos map[string]string) {
+	pos = make(map[string]string, len(jg.joggers))
+	for mpath, j := range jg.joggers {
+		if j.pos == nil {
+			continue
+		}
+		j.pos.mu.Lock()
+		if j.pos.fqn != "" {
+			pos[mpath] = j.pos.fqn
+		}
+		j.pos.mu.Unlock()
+	}
+	return
+}
+
 func (jg *JoggerGroup) Run() {
 	for _, jogger := range jg.joggers {
 		jg.wg.Go(jogger.run)
@@ -179,7 +215,7 @@ func newJogger(ctx context.Context, opts *JoggerGroupOpts, mi *fs.MountpathInfo)
 		}
 	}
 
-	return &jogger{
+	j := &jogger{
 		ctx:       ctx,
 		opts:      opts,
 		mi:        mi,
@@ -187,6 +223,12 @@ func newJogger(ctx context.Context, opts *JoggerGroupOpts, mi *fs.MountpathInfo)
 		stopCh:    cos.NewStopCh(),
 		syncGroup: syncGroup,
 	}
+	if opts.Resumable {
+		debug.Assert(!opts.Bck.IsEmpty())
+		skip := opts.Resume[mi.Path]
+		j.pos = &joggerPos{fqn: skip, skip: skip, pending: make(map[int64]string)}
+	}
+	return j
 }
 
 func (j *jogger) run() error {
@@ -231,7 +273,7 @@ func (j *jogger) runBck(bck *cmn.Bck) (aborted bool, err error) {
 		Mi:       j.mi,
 		CTs:      j.opts.CTs,
 		Callback: j.jog,
-		Sorted:   false,
+		Sorted:   j.pos != nil,
 	}
 	opts.Bck.Copy(bck)
 
@@ -267,10 +309,20 @@ func (j *jogger) jog(fqn string, de fs.DirEntry) error {
 		return err
 	}
 
+	var seq int64
+	if j.pos != nil {
+		if j.pos.skip != "" && !walkedAfter(fqn, j.pos.skip) {
+			return nil // visited prior to restart
+		}
+		seq = j.pos.next
+		j.pos.next++
+	}
+
 	if j.syncGroup == nil {
 		if err := j.visitFQN(fqn, j.getBuf(0)); err != nil {
 			return err
 		}
+		j.visited(seq, fqn)
 	} else {
 		select {
 		case bufPosition = <-j.syncGroup.sema:
@@ -284,7 +336,11 @@ func (j *jogger) jog(fqn string, de fs.DirEntry) error {
 				// NOTE: There is no need to select j.ctx.Done() as put to this chanel is immediate.
 				j.syncGroup.sema <- bufPosition
 			}()
-			return j.visitFQN(fqn, j.getBuf(bufPosition))
+			if err := j.visitFQN(fqn, j.getBuf(bufPosition)); err != nil {
+				return err
+			}
+			j.visited(seq, fqn)
+			return nil
 		})
 	}
 
@@ -299,6 +355,38 @@ func (j *jogger) jog(fqn string, de fs.DirEntry) error {
 	return nil
 }
 
+// advance the position (resumable only)
+func (j *jogger) visited(seq int64, fqn string) {
+	if j.pos == nil {
+		return
+	}
+	p := j.pos
+	p.mu.Lock()
+	p.pending[seq] = fqn
+	for {
+		f, ok := p.pending[p.done]
+		if !ok {
+			break
+		}
+		delete(p.pending, p.done)
+		p.fqn = f
+		p.done++
+	}
+	p.mu.Unlock()
+}
+
+// whether sorted walk visits `fqn` after `pos`: compare path components
+// (rather than entire strings) in the order of (sorted) directory traversal
+func walkedAfter(fqn, pos string) bool {
+	a, b := strings.Split(fqn, "/"), strings.Split(pos, "/")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] > b[i]
+		}
+	}
+	return len(a) > len(b)
+}
+
 func (j *jogger) visitFQN(fqn string, buf []byte) error {
 	ct, err := cluster.NewCTFromFQN(fqn, j.opts.T.Bowner())
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -259,6 +260,70 @@ func TestJoggerGroupOneErrorStopsAll(t *testing.T) {
 	tassert.Errorf(t, err != nil && strings.Contains(err.Error(), "oops"), "expected an error")
 }
 
+// Resumable joggers: stop midway and resume from the positions - must visit all the rest
+// (exactly, when not parallel).
+func TestJoggerGroupResume(t *testing.T) {
+	for _, parallel := range []int{0, 4} {
+		t.Run(fmt.Sprintf("parallel=%d", parallel), func(t *testing.T) {
+			var (
+				totalObjCnt = 2000
+				failAt      = int32(totalObjCnt / 3)
+				desc        = tutils.ObjectsDesc{
+					CTs: []tutils.ContentTypeDesc{
+						{Type: fs.ObjectType, ContentCnt: totalObjCnt},
+					},
+					MountpathsCnt: 4,
+					ObjectSize:    cos.KiB,
+				}
+				out     = tutils.PrepareObjects(t, desc)
+				mu      sync.Mutex
+				visited = make(map[string]int, totalObjCnt)
+				counter = atomic.NewInt32(0)
+				slab, _ = memsys.PageMM().GetSlab(memsys.PageSize)
+			)
+			defer os.RemoveAll(out.Dir)
+
+			opts := &mpather.JoggerGroupOpts{
+				T:         out.T,
+				Bck:       out.Bck,
+				CTs:       []string{fs.ObjectType},
+				Slab:      slab,
+				Parallel:  parallel,
+				Resumable: true,
+				VisitObj: func(lom *cluster.LOM, buf []byte) error {
+					if counter.Inc() == failAt {
+						return fmt.Errorf("oops")
+					}
+					mu.Lock()
+					visited[lom.FQN]++
+					mu.Unlock()
+					return nil
+				},
+			}
+			jg := mpather.NewJoggerGroup(opts)
+			jg.Run()
+			<-jg.ListenFinished()
+			tassert.Errorf(t, jg.Stop() != nil, "expected an error")
+			nfirst := len(visited)
+
+			// resume
+			opts.Resume = jg.Positions()
+			jg = mpather.NewJoggerGroup(opts)
+			jg.Run()
+			<-jg.ListenFinished()
+			tassert.CheckFatal(t, jg.Stop())
+
+			tassert.Fatalf(t, len(visited) == totalObjCnt, "expected all %d objects visited, got %d (first run %d)",
+				totalObjCnt, len(visited), nfirst)
+			if parallel == 0 {
+				for fqn, n := range visited {
+					tassert.Fatalf(t, n == 1, "%q visited %d times", fqn, n)
+				}
+			}
+		})
+	}
+}
+
 func TestJoggerGroupMultiContentTypes(t *testing.T) {
 	var (
 		cts  = []string{fs.ObjectType, fs.ECSliceType, fs.ECMetaType}
@@ -30,6 +30,9 @@ var mdFilesDirs = []string{
 	fname.BmdPrevious,
 
 	fname.Vmd,
+
+	fname.XactCkptDir,
+	fname.SyncMarkersDir,
 }
 
 func MarkerExists(marker string) bool {
@@ -156,3 +159,89 @@ func CountPersisted(fname string) (cnt int) {
 	}
 	return
 }
+
+//
+// checkpoints of resumable xactions (see xact.Ckpt)
+//
+
+func PersistXactCkpt(id string, meta jsp.Opts, atMost int) error {
+	if cnt, availCnt := PersistOnMpaths(filepath.Join(fname.XactCkptDir, id), "", meta, atMost, nil, nil); cnt == 0 {
+		if availCnt == 0 {
+			return cmn.ErrNoMountpaths
+		}
+		return fmt.Errorf("failed to persist %q checkpoint (%d)", id, availCnt)
+	}
+	return nil
+}
+
+// IDs of all persisted checkpoints (union across mountpaths)
+func XactCkptIDs() (ids []string) {
+	for _, mi := range GetAvail() {
+		dentries, err := os.ReadDir(filepath.Join(mi.Path, fname.XactCkptDir))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				glog.Errorf("%s: failed to read %q: %v", mi, fname.XactCkptDir, err)
+			}
+			continue
+		}
+		for _, de := range dentries {
+			if id := de.Name(); !de.IsDir() && !cos.StringInSlice(id, ids) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return
+}
+
+// load the first valid copy
+func LoadXactCkpt(id string, meta jsp.Opts) (err error) {
+	err = os.ErrNotExist
+	for _, mi := range GetAvail() {
+		fpath := filepath.Join(mi.Path, fname.XactCkptDir, id)
+		if _, err = jsp.LoadMeta(fpath, meta); err == nil {
+			return
+		}
+		if !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to load %q checkpoint: %v", mi, id, err)
+		}
+	}
+	return
+}
+
+func RemoveXactCkpt(id string) {
+	relname := filepath.Join(fname.XactCkptDir, id)
+	for _, mi := range GetAvail() {
+		if err := cos.RemoveFile(filepath.Join(mi.Path, relname)); err != nil {
+			glog.Errorf("Failed to remove %q checkpoint from %q: %v", id, mi.Path, err)
+		}
+	}
+}
+
+//
+// markers of incremental bucket copies (see mirror.XactTCB)
+//
+
+func PersistSyncMarker(name string, meta jsp.Opts, atMost int) error {
+	if cnt, availCnt := PersistOnMpaths(filepath.Join(fname.SyncMarkersDir, name), "", meta, atMost, nil, nil); cnt == 0 {
+		if availCnt == 0 {
+			return cmn.ErrNoMountpaths
+		}
+		return fmt.Errorf("failed to persist %q sync marker (%d)", name, availCnt)
+	}
+	return nil
+}
+
+// load the first valid copy
+func LoadSyncMarker(name string, meta jsp.Opts) (err error) {
+	err = os.ErrNotExist
+	for _, mi := range GetAvail() {
+		fpath := filepath.Join(mi.Path, fname.SyncMarkersDir, name)
+		if _, err = jsp.LoadMeta(fpath, meta); err == nil {
+			return
+		}
+		if !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to load %q sync marker: %v", mi, name, err)
+		}
+	}
+	return
+}
@@ -5,9 +5,12 @@
 package fs_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/devtools/tassert"
 	"github.com/NVIDIA/aistore/devtools/tutils"
 	"github.com/NVIDIA/aistore/fs"
@@ -100,3 +103,43 @@ func TestMarkersClear(t *testing.T) {
 		markerEntry{marker: fname.ResilverMarker, exists: false},
 	)
 }
+
+type testCkpt struct {
+	ID  string `json:"id"`
+	Pos int64  `json:"pos"`
+}
+
+func (*testCkpt) JspOpts() jsp.Options { return jsp.CCSign(1) }
+
+func TestXactCkpts(t *testing.T) {
+	const mpathsCnt = 5
+	mpaths := tutils.PrepareMountPaths(t, mpathsCnt)
+	defer tutils.RemoveMpaths(t, mpaths)
+
+	tassert.Fatalf(t, len(fs.XactCkptIDs()) == 0, "expected no checkpoints")
+
+	for _, id := range []string{"x1", "x2"} {
+		tassert.CheckFatal(t, fs.PersistXactCkpt(id, &testCkpt{ID: id, Pos: 1}, 2))
+	}
+	// overwrite
+	tassert.CheckFatal(t, fs.PersistXactCkpt("x1", &testCkpt{ID: "x1", Pos: 2}, 2))
+	tassert.Fatalf(t, fs.CountPersisted(filepath.Join(fname.XactCkptDir, "x1")) == 2, "expected 2 copies")
+
+	ids := fs.XactCkptIDs()
+	tassert.Fatalf(t, len(ids) == 2, "expected 2 checkpoints, got %v", ids)
+
+	ck := &testCkpt{}
+	tassert.CheckFatal(t, fs.LoadXactCkpt("x1", ck))
+	tassert.Errorf(t, ck.ID == "x1" && ck.Pos == 2, "unexpected %+v", ck)
+
+	fs.RemoveXactCkpt("x1")
+	err := fs.LoadXactCkpt("x1", ck)
+	tassert.Errorf(t, os.IsNotExist(err), "expected not-exist, got %v", err)
+	ids = fs.XactCkptIDs()
+	tassert.Errorf(t, len(ids) == 1 && ids[0] == "x2", "expected [x2], got %v", ids)
+
+	for _, mpath := range mpaths {
+		mpath.ClearMDs()
+	}
+	tassert.Errorf(t, len(fs.XactCkptIDs()) == 0, "expected no checkpoints")
+}
@@ -7,6 +7,7 @@ package fs
 import (
 	"container/heap"
 	"context"
+	"path"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -15,6 +16,9 @@ import (
 
 // provides default low-level `jogger` to traverse a bucket - a poor-man's joggers of sorts
 // for those (very few) clients that don't have their own custom implementation
+//
+// Multiple content types (opts.CTs) are walked by separate joggers and merged
+// by the original object name (see ContentResolver.ParseUniqueFQN).
 
 type WalkBckOpts struct {
 	WalkOpts
@@ -47,24 +51,28 @@ func WalkBck(opts *WalkBckOpts) error {
 	debug.Assert(opts.Mi == nil && opts.Sorted) // TODO: support `opts.Sorted == false`
 	var (
 		availablePaths = GetAvail()
-		l              = len(availablePaths)
+		cts            = opts.CTs
+		l              = len(availablePaths) * len(cts)
 		joggers        = make([]*joggerBck, l)
 		group, ctx     = errgroup.WithContext(context.Background())
 		idx            int
 	)
 	for _, mi := range availablePaths {
-		workCh := make(chan *wbe, mpathQueueSize)
-		jg := &joggerBck{
-			workCh:   workCh,
-			mi:       mi,
-			validate: opts.ValidateCallback,
-			ctx:      ctx,
-			opts:     opts.WalkOpts,
+		for _, ct := range cts {
+			workCh := make(chan *wbe, mpathQueueSize)
+			jg := &joggerBck{
+				workCh:   workCh,
+				mi:       mi,
+				validate: opts.ValidateCallback,
+				ctx:      ctx,
+				opts:     opts.WalkOpts,
+			}
+			jg.opts.Callback = jg.cb
+			jg.opts.Mi = mi
+			jg.opts.CTs = []string{ct}
+			joggers[idx] = jg
+			idx++
 		}
-		jg.opts.Callback = jg.cb
-		jg.opts.Mi = mi
-		joggers[idx] = jg
-		idx++
 	}
 
 	for i := 0; i < l; i++ {
@@ -148,6 +156,18 @@ func (h *wbeHeap) Push(x interface{}) {
 		return
 	}
 	info.objName = parsedFQN.ObjName
+	if parsedFQN.ContentType == ObjVersionType {
+		if orig, _, _, ok := ParseObjVersion(parsedFQN.ObjName); ok {
+			info.objName = orig
+		}
+	} else if parsedFQN.ContentType != ObjectType {
+		if spec := CSM.Resolver(parsedFQN.ContentType); spec != nil {
+			dir, base := path.Split(parsedFQN.ObjName)
+			if orig, _, ok := spec.ParseUniqueFQN(base); ok {
+				info.objName = dir + orig
+			}
+		}
+	}
 	*h = append(*h, info)
 }
 
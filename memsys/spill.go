@@ -0,0 +1,192 @@
+// Package memsys provides memory management and slab/SGL allocation with io.Reader and io.Writer interfaces
+// on top of scatter-gather lists of reusable buffers.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package memsys
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+)
+
+// SpillSGL is a memory-bounded variant of SGL: it starts in memory and transparently
+// spills its content to a work file (given by the caller - typically, on a chosen mountpath)
+// once the size exceeds the configured limit or memory pressure becomes high.
+// Once spilled, it stays on disk until freed.
+//
+// Same as SGL, it is intended for write-once-read-many usage and can be
+// simultaneously read via multiple concurrent readers (see Open()).
+
+// interface guard
+var (
+	_ io.ReaderFrom      = (*SpillSGL)(nil)
+	_ io.WriterTo        = (*SpillSGL)(nil)
+	_ cos.ReadOpenCloser = (*SpillSGL)(nil)
+)
+
+// check memory pressure every so often (bytes written) while in memory
+const spillCheckIval = 4 * cos.MiB
+
+type (
+	SpillSGL struct {
+		mm    *MMSA
+		sgl   *SGL     // nil when spilled
+		file  *os.File // non-nil when spilled
+		fqn   string   // work file
+		limit int64    // max in-memory size (0: spill only under memory pressure)
+		next  int64    // next memory pressure check
+		woff  int64
+		roff  int64
+	}
+	// hides SpillSGL.ReadFrom from io.CopyBuffer
+	spillWriter struct{ z *SpillSGL }
+)
+
+// NewSpillSGL allocates SGL that spills to `fqn` upon exceeding `limit` bytes
+// (or under high memory pressure)
+func (r *MMSA) NewSpillSGL(immediateSize, limit int64, fqn string) *SpillSGL {
+	debug.Assert(fqn != "")
+	if limit > 0 && immediateSize > limit {
+		immediateSize = limit
+	}
+	return &SpillSGL{mm: r, sgl: r.NewSGL(immediateSize), fqn: fqn, limit: limit, next: spillCheckIval}
+}
+
+func (z *SpillSGL) Size() int64   { return z.woff }
+func (z *SpillSGL) Len() int64    { return z.woff - z.roff }
+func (z *SpillSGL) Spilled() bool { return z.file != nil }
+func (z *SpillSGL) FQN() string   { return z.fqn }
+
+func (z *SpillSGL) needSpill(add int64) bool {
+	if z.limit > 0 && z.woff+add > z.limit {
+		return true
+	}
+	if z.woff+add < z.next {
+		return false
+	}
+	z.next = z.woff + add + spillCheckIval
+	return z.mm.Pressure() >= PressureHigh
+}
+
+// move in-memory content to the work file and free the SGL
+func (z *SpillSGL) spill() (err error) {
+	if err = cos.CreateDir(filepath.Dir(z.fqn)); err != nil {
+		return
+	}
+	// (compare with cos.CreateFile) - reading, too
+	if z.file, err = os.OpenFile(z.fqn, os.O_RDWR|os.O_CREATE|os.O_TRUNC, cos.PermRWR); err != nil {
+		return
+	}
+	if _, err = z.sgl.WriteTo(z.file); err != nil {
+		cos.Close(z.file)
+		z.file = nil
+		_ = cos.RemoveFile(z.fqn)
+		return
+	}
+	z.sgl.Free()
+	z.sgl = nil
+	return
+}
+
+func (z *SpillSGL) Write(p []byte) (n int, err error) {
+	if z.file == nil && z.needSpill(int64(len(p))) {
+		if err = z.spill(); err != nil {
+			return
+		}
+	}
+	if z.file != nil {
+		n, err = z.file.Write(p)
+	} else {
+		n, err = z.sgl.Write(p)
+	}
+	z.woff += int64(n)
+	return
+}
+
+func (z *SpillSGL) ReadFrom(r io.Reader) (n int64, err error) {
+	buf, slab := z.mm.AllocSize(DefaultBufSize)
+	n, err = io.CopyBuffer(spillWriter{z}, r, buf)
+	slab.Free(buf)
+	return
+}
+
+// NOTE: same as SGL, not advancing roff here
+func (z *SpillSGL) WriteTo(dst io.Writer) (n int64, err error) {
+	if z.file == nil {
+		return z.sgl.WriteTo(dst)
+	}
+	buf, slab := z.mm.AllocSize(DefaultBufSize)
+	n, err = io.CopyBuffer(dst, io.NewSectionReader(z.file, 0, z.woff), buf)
+	slab.Free(buf)
+	return
+}
+
+func (z *SpillSGL) Read(b []byte) (n int, err error) {
+	if z.file == nil {
+		n, z.roff, err = z.sgl.readAtOffset(b, z.roff)
+		return
+	}
+	if z.roff >= z.woff {
+		return 0, io.EOF
+	}
+	if rem := z.woff - z.roff; int64(len(b)) > rem {
+		b = b[:rem]
+	}
+	n, err = z.file.ReadAt(b, z.roff)
+	z.roff += int64(n)
+	if err == nil && z.roff >= z.woff {
+		err = io.EOF
+	}
+	return
+}
+
+// returns a new (independent) reader of the current content
+func (z *SpillSGL) Open() (cos.ReadOpenCloser, error) {
+	if z.file == nil {
+		return NewReader(z.sgl), nil
+	}
+	return cos.NewFileSectionHandle(z.fqn, 0, z.woff)
+}
+
+func (*SpillSGL) Close() error { return nil } // NOTE: no-op - see Free()
+
+// reuse (and keep spilled, if spilled)
+func (z *SpillSGL) Reset() {
+	if z.file != nil {
+		if err := z.file.Truncate(0); err == nil {
+			_, err = z.file.Seek(0, io.SeekStart)
+			debug.AssertNoErr(err)
+		}
+	} else {
+		z.sgl.Reset()
+	}
+	z.woff, z.roff, z.next = 0, 0, spillCheckIval
+}
+
+// frees memory or closes and removes the work file; idempotent
+func (z *SpillSGL) Free() {
+	if z.sgl == nil && z.file == nil {
+		return // already freed
+	}
+	if z.file == nil {
+		z.sgl.Free()
+		z.sgl = nil
+		return
+	}
+	cos.Close(z.file)
+	z.file = nil
+	if err := cos.RemoveFile(z.fqn); err != nil {
+		debug.AssertNoErr(err)
+	}
+}
+
+/////////////////
+// spillWriter //
+/////////////////
+
+func (w spillWriter) Write(p []byte) (int, error) { return w.z.Write(p) }
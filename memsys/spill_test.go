@@ -0,0 +1,113 @@
+// Package memsys provides memory management and Slab allocation
+// with io.Reader and io.Writer interfaces on top of a scatter-gather lists
+// (of reusable buffers)
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package memsys
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpillSGL", func() {
+	var (
+		mm     = PageMM()
+		tmpDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "spill")
+		Expect(err).ToNot(HaveOccurred())
+	})
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	randBytes := func(size int64) []byte {
+		buf := make([]byte, size)
+		rand.Read(buf)
+		return buf
+	}
+	readAll := func(r io.Reader) []byte {
+		b, err := io.ReadAll(r)
+		Expect(err).ToNot(HaveOccurred())
+		return b
+	}
+
+	It("should stay in memory below the limit", func() {
+		buf := randBytes(cos.MiB)
+		z := mm.NewSpillSGL(0, 2*cos.MiB, filepath.Join(tmpDir, "work"))
+		n, err := z.ReadFrom(bytes.NewReader(buf))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(BeEquivalentTo(cos.MiB))
+		Expect(z.Spilled()).To(BeFalse())
+		Expect(z.FQN()).ToNot(BeAnExistingFile())
+
+		Expect(readAll(z)).To(Equal(buf))
+		z.Free()
+		z.Free() // (idempotent)
+	})
+
+	It("should spill to disk upon exceeding the limit", func() {
+		size := int64(3*cos.MiB + 123)
+		buf := randBytes(size)
+		z := mm.NewSpillSGL(0, cos.MiB, filepath.Join(tmpDir, "work"))
+
+		// in chunks, to cross the limit in the middle
+		for off := int64(0); off < size; off += 100 * cos.KiB {
+			_, err := z.Write(buf[off:cos.MinI64(off+100*cos.KiB, size)])
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(z.Spilled()).To(BeTrue())
+		Expect(z.Size()).To(Equal(size))
+		Expect(z.FQN()).To(BeAnExistingFile())
+
+		// concurrent readers
+		r1, err := z.Open()
+		Expect(err).ToNot(HaveOccurred())
+		r2, err := z.Open()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readAll(r1)).To(Equal(buf))
+		Expect(readAll(r2)).To(Equal(buf))
+		r1.Close()
+		r2.Close()
+
+		// WriteTo (does not advance) and Read
+		var out bytes.Buffer
+		n, err := z.WriteTo(&out)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(size))
+		Expect(out.Bytes()).To(Equal(buf))
+		Expect(readAll(z)).To(Equal(buf))
+		Expect(z.Len()).To(BeZero())
+
+		z.Free()
+		Expect(z.FQN()).ToNot(BeAnExistingFile())
+		z.Free() // (idempotent)
+	})
+
+	It("should reset and reuse spilled SGL", func() {
+		z := mm.NewSpillSGL(0, cos.KiB, filepath.Join(tmpDir, "work"))
+		_, err := z.Write(randBytes(4 * cos.KiB))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(z.Spilled()).To(BeTrue())
+
+		z.Reset()
+		buf := randBytes(2 * cos.KiB)
+		_, err = z.Write(buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(z.Size()).To(BeEquivalentTo(2 * cos.KiB))
+		Expect(readAll(z)).To(Equal(buf))
+		z.Free()
+	})
+})
@@ -93,7 +93,11 @@ func newXactMNC(bck *cluster.Bck, p *mncFactory, slab *memsys.Slab) (r *xactMNC)
 		Throttle: true,
 	}
 	mpopts.Bck.Copy(bck.Bucket())
-	r.BckJog.Init(p.UUID(), apc.ActMakeNCopies, bck, mpopts)
+	ckpt := p.Ckpt
+	if ckpt == nil {
+		ckpt = xact.NewCkpt(p.UUID(), apc.ActMakeNCopies, bck.Bucket(), &p.args)
+	}
+	r.BckJog.InitResumable(bck, mpopts, ckpt)
 	return
 }
 
@@ -101,6 +105,7 @@ func (r *xactMNC) Run(wg *sync.WaitGroup) {
 	wg.Done()
 	tname := r.Target().String()
 	if err := fs.ValidateNCopies(tname, r.copies); err != nil {
+		r.RemoveCkpt()
 		r.Finish(err)
 		return
 	}
@@ -5,8 +5,13 @@
 package mirror
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +22,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/mpather"
 	"github.com/NVIDIA/aistore/memsys"
@@ -24,6 +30,7 @@ import (
 	"github.com/NVIDIA/aistore/transport/bundle"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/OneOfOne/xxhash"
 )
 
 type (
@@ -44,6 +51,18 @@ type (
 		// finishing
 		refc atomic.Int32
 		err  cos.ErrValue
+		// sync (incremental copy)
+		since   int64 // copy only objects modified after
+		resumed bool
+		pruned  atomic.Int64
+		smapVer int64       // at the start (see pruneObj)
+		unsafe  atomic.Bool // cluster map changed while pruning
+	}
+	// the time of the last successful sync (apc.CopyBckMsg.Sync) of two given buckets
+	syncMarker struct {
+		From cmn.Bck `json:"from"`
+		To   cmn.Bck `json:"to"`
+		Time int64   `json:"time,string"`
 	}
 )
 
@@ -51,10 +70,18 @@ const OpcTxnDone = 27182
 
 const etlBucketParallelCnt = 2
 
+const (
+	syncMarkerCopies  = 2
+	syncMarkerMetaver = 1
+)
+
+var syncJspOpts = jsp.CCSign(syncMarkerMetaver)
+
 // interface guard
 var (
 	_ cluster.Xact   = (*XactTCB)(nil)
 	_ xreg.Renewable = (*tcbFactory)(nil)
+	_ jsp.Opts       = (*syncMarker)(nil)
 )
 
 ////////////////
@@ -154,7 +181,7 @@ func (r *XactTCB) TxnAbort() {
 //
 func newXactTCB(e *tcbFactory, slab *memsys.Slab) (r *XactTCB) {
 	var parallel int
-	r = &XactTCB{t: e.T, args: *e.args}
+	r = &XactTCB{t: e.T, args: *e.args, smapVer: e.T.Sowner().Get().Version}
 	if e.kind == apc.ActETLBck {
 		parallel = etlBucketParallelCnt // TODO: optimize with respect to disk bw and transforming computation
 	}
@@ -168,7 +195,20 @@ func newXactTCB(e *tcbFactory, slab *memsys.Slab) (r *XactTCB) {
 		Throttle: true,
 	}
 	mpopts.Bck.Copy(e.args.BckFrom.Bucket())
-	r.BckJog.Init(e.UUID(), e.kind, e.args.BckTo, mpopts)
+	if e.args.Msg.Sync {
+		r.initSync()
+	}
+	if e.args.Msg.DryRun {
+		r.BckJog.Init(e.UUID(), e.kind, e.args.BckTo, mpopts)
+		return
+	}
+	ckpt := e.Ckpt
+	r.resumed = ckpt != nil
+	if ckpt == nil {
+		ckpt = xact.NewCkpt(e.UUID(), e.kind, e.args.BckFrom.Bucket(), e.args.Msg)
+		ckpt.BckTo.Copy(e.args.BckTo.Bucket())
+	}
+	r.BckJog.InitResumable(e.args.BckTo, mpopts, ckpt)
 	return
 }
 
@@ -206,6 +246,12 @@ func (r *XactTCB) Run(wg *sync.WaitGroup) {
 			err = fmt.Errorf("%s: %v", r, cmn.ErrQuiesceTimeout)
 		}
 	}
+	if err == nil && r.args.Msg.Prune {
+		err = r.prune()
+	}
+	if err == nil && r.args.Msg.Sync && !r.args.Msg.DryRun {
+		r.markSynced()
+	}
 
 	// close
 	r.dm.Close(err)
@@ -215,6 +261,13 @@ func (r *XactTCB) Run(wg *sync.WaitGroup) {
 }
 
 func (r *XactTCB) copyObject(lom *cluster.LOM, buf []byte) (err error) {
+	// NOTE: modification time here is the time the object was written _in the cluster_
+	// (when the source is remote, that's when it was cached, e.g. via cold GET or prefetch)
+	if r.since != 0 {
+		if finfo, err := os.Stat(lom.FQN); err == nil && finfo.ModTime().UnixNano() <= r.since {
+			return nil
+		}
+	}
 	objNameTo := r.args.Msg.ToName(lom.ObjName)
 	params := cluster.AllocCpObjParams()
 	{
@@ -224,6 +277,7 @@ func (r *XactTCB) copyObject(lom *cluster.LOM, buf []byte) (err error) {
 		params.DM = r.dm
 		params.DP = r.args.DP
 		params.Xact = r
+		params.Sync = r.args.Msg.Sync
 	}
 	_, err = r.Target().CopyObject(lom, params, r.args.Msg.DryRun)
 	if err != nil && cos.IsErrOOS(err) {
@@ -233,6 +287,154 @@ func (r *XactTCB) copyObject(lom *cluster.LOM, buf []byte) (err error) {
 	return
 }
 
+//
+// sync (incremental copy)
+//
+
+func (r *XactTCB) initSync() {
+	msg := &r.args.Msg.CopyBckMsg
+	if msg.Since != apc.SyncSinceLast {
+		r.since, _ = msg.SinceTime() // validated by the proxy
+		return
+	}
+	marker := r.newSyncMarker()
+	if err := fs.LoadSyncMarker(marker.name(), marker); err != nil {
+		glog.Infof("%s: no previous sync - copying all", r.Name())
+		return
+	}
+	r.since = marker.Time
+}
+
+func (r *XactTCB) newSyncMarker() *syncMarker {
+	marker := &syncMarker{}
+	marker.From.Copy(r.args.BckFrom.Bucket())
+	marker.To.Copy(r.args.BckTo.Bucket())
+	return marker
+}
+
+// NOTE: a resumed (see xact.Ckpt) sync does not update the marker
+func (r *XactTCB) markSynced() {
+	if r.resumed {
+		return
+	}
+	marker := r.newSyncMarker()
+	marker.Time = r.StartTime().UnixNano()
+	if err := fs.PersistSyncMarker(marker.name(), marker, syncMarkerCopies); err != nil {
+		glog.Errorf("%s: %v", r.Name(), err)
+	}
+}
+
+// walk local objects of the destination and remove those that have no source;
+// only when the cluster is stable, though: during rebalance or resilver, or upon any
+// Smap change since the start of the job, not finding an object at its (HRW) location
+// does not mean it's gone
+func (r *XactTCB) prune() (err error) {
+	if skip := r.pruneSkip(); skip != "" {
+		glog.Warningf("%s: not pruning - %s", r.Name(), skip)
+		return nil
+	}
+	mpopts := &mpather.JoggerGroupOpts{
+		T:        r.t,
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.pruneObj,
+		DoLoad:   mpather.Load,
+		Throttle: true,
+	}
+	mpopts.Bck.Copy(r.args.BckTo.Bucket())
+	jg := mpather.NewJoggerGroup(mpopts)
+	jg.Run()
+	select {
+	case errCause := <-r.ChanAbort():
+		jg.Stop()
+		err = cmn.NewErrAborted(r.Name(), "prune", errCause)
+	case <-jg.ListenFinished():
+		err = jg.Stop()
+	}
+	if r.unsafe.Load() {
+		glog.Warningf("%s: cluster map changed - stopped pruning", r.Name())
+	}
+	if n := r.pruned.Load(); n > 0 {
+		glog.Infof("%s: pruned %d object%s", r.Name(), n, cos.Plural(int(n)))
+	}
+	return
+}
+
+func (r *XactTCB) pruneSkip() string {
+	if v := r.t.Sowner().Get().Version; v != r.smapVer {
+		return fmt.Sprintf("cluster map changed (v%d => v%d)", r.smapVer, v)
+	}
+	if marked := xreg.GetRebMarked(); marked.Xact != nil || marked.Interrupted {
+		return "rebalance is running or was interrupted"
+	}
+	if marked := xreg.GetResilverMarked(); marked.Xact != nil || marked.Interrupted {
+		return "resilver is running or was interrupted"
+	}
+	return ""
+}
+
+func (r *XactTCB) pruneObj(lom *cluster.LOM, _ []byte) error {
+	prefix := r.args.Msg.Prefix
+	if !strings.HasPrefix(lom.ObjName, prefix) {
+		return nil // not ours
+	}
+	src := cluster.AllocLOM(strings.TrimPrefix(lom.ObjName, prefix))
+	defer cluster.FreeLOM(src)
+	if err := src.InitBck(r.args.BckFrom.Bucket()); err != nil {
+		return err
+	}
+	smap := r.t.Sowner().Get()
+	if r.unsafe.Load() || smap.Version != r.smapVer {
+		r.unsafe.Store(true)
+		return nil
+	}
+	tsi, local, err := src.HrwTarget(smap)
+	if err != nil {
+		return err
+	}
+	if local {
+		err = src.Load(false /*cache it*/, false /*locked*/)
+		if err == nil || !cmn.IsObjNotExist(err) {
+			return nil
+		}
+	} else if ok, errCode := r.t.HeadObjT2T(src, tsi); ok {
+		return nil
+	} else if errCode != http.StatusNotFound {
+		// (timeout, restarting peer, etc.) - cannot tell
+		glog.Warningf("%s: not pruning %s: failed to HEAD %s at %s (%d)", r.Name(), lom, src, tsi, errCode)
+		return nil
+	}
+	// remote source: not being in the cluster does not mean not existing
+	if src.Bck().IsRemote() {
+		_, errCode, err := r.t.Backend(src.Bck()).HeadObj(context.Background(), src)
+		if err == nil {
+			return nil
+		}
+		if errCode != http.StatusNotFound && !cmn.IsObjNotExist(err) {
+			glog.Warningf("%s: not pruning %s: %v(%d)", r.Name(), lom, err, errCode)
+			return nil
+		}
+	}
+	if !r.args.Msg.DryRun {
+		if errCode, err := r.t.DeleteObject(lom, false /*evict*/); err != nil && !cmn.IsObjNotExist(err) {
+			glog.Warningf("%s: failed to prune %s: %v(%d)", r.Name(), lom, err, errCode)
+			return nil
+		}
+	}
+	r.pruned.Inc()
+	return nil
+}
+
+////////////////
+// syncMarker //
+////////////////
+
+func (*syncMarker) JspOpts() jsp.Options { return syncJspOpts }
+
+func (m *syncMarker) name() string {
+	h := xxhash.ChecksumString64S(m.From.MakeUname("")+m.To.MakeUname(""), cos.MLCG32)
+	return strconv.FormatUint(h, 16)
+}
+
 // NOTE: strict(est) error handling: abort on any of the errors below
 func (r *XactTCB) recv(hdr transport.ObjHdr, objReader io.Reader, err error) error {
 	defer transport.DrainAndFreeReader(objReader)
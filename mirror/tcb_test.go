@@ -0,0 +1,215 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+type (
+	// source objects are owned by another target (see HeadObjT2T)
+	pruneTarget struct {
+		*mock.TargetMock
+		smap    *cluster.Smap
+		inClu   map[string]int // in-cluster objects of the source bucket: HTTP status (none - not found)
+		remote  map[string]int
+		deleted []string
+	}
+	pruneSowner  struct{ smap *cluster.Smap }
+	pruneBackend struct {
+		cluster.BackendProvider
+		t *pruneTarget
+	}
+)
+
+func (t *pruneTarget) Sowner() cluster.Sowner { return &pruneSowner{t.smap} }
+func (t *pruneTarget) HeadObjT2T(lom *cluster.LOM, _ *cluster.Snode) (bool, int) {
+	code, ok := t.inClu[lom.ObjName]
+	if !ok {
+		return false, http.StatusNotFound
+	}
+	return code == http.StatusOK, code
+}
+func (t *pruneTarget) Backend(*cluster.Bck) cluster.BackendProvider { return &pruneBackend{t: t} }
+
+func (t *pruneTarget) DeleteObject(lom *cluster.LOM, _ bool) (int, error) {
+	t.deleted = append(t.deleted, lom.ObjName)
+	return 0, nil
+}
+
+func (o *pruneSowner) Get() *cluster.Smap             { return o.smap }
+func (*pruneSowner) Listeners() cluster.SmapListeners { return nil }
+
+// remote objects: HTTP status (OK - exists)
+func (b *pruneBackend) HeadObj(_ context.Context, lom *cluster.LOM) (*cmn.ObjAttrs, int, error) {
+	switch code := b.t.remote[lom.ObjName]; code {
+	case http.StatusOK:
+		return &cmn.ObjAttrs{}, 0, nil
+	case 0:
+		return nil, http.StatusNotFound, cmn.NewErrNotFound("%s", lom)
+	default:
+		return nil, code, errors.New("backend failure")
+	}
+}
+
+func initTCBTest(t *testing.T, bcks ...*cluster.Bck) {
+	fs.TestNew(nil)
+	fs.TestDisableValidation()
+	_, err := fs.Add(t.TempDir(), "daeID")
+	tassert.CheckFatal(t, err)
+	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
+	mock.NewTarget(mock.NewBaseBownerMock(bcks...))
+}
+
+func newTCBTestBck(name, provider string, bid uint64) *cluster.Bck {
+	props := &cmn.BucketProps{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}, BID: bid}
+	return &cluster.Bck{Name: name, Provider: provider, Ns: cmn.NsGlobal, Props: props}
+}
+
+func TestSyncMarker(t *testing.T) {
+	var (
+		bckFrom = newTCBTestBck("src", apc.ProviderAIS, 1)
+		bckTo   = newTCBTestBck("dst", apc.ProviderAIS, 2)
+		other   = newTCBTestBck("other", apc.ProviderAIS, 3)
+	)
+	initTCBTest(t, bckFrom, bckTo, other)
+	newSync := func(to *cluster.Bck) *XactTCB {
+		msg := &apc.TCBMsg{CopyBckMsg: apc.CopyBckMsg{Sync: true, Since: apc.SyncSinceLast}}
+		r := &XactTCB{args: xreg.TCBArgs{BckFrom: bckFrom, BckTo: to, Msg: msg}}
+		r.InitBase(cos.GenUUID(), apc.ActCopyBck, to)
+		r.initSync()
+		return r
+	}
+
+	// first sync copies all
+	r := newSync(bckTo)
+	tassert.Fatalf(t, r.since == 0, "expected no previous sync, got %d", r.since)
+	r.markSynced()
+
+	// next one - since the start of the previous
+	r2 := newSync(bckTo)
+	tassert.Errorf(t, r2.since == r.StartTime().UnixNano(), "expected since %d, got %d", r.StartTime().UnixNano(), r2.since)
+
+	// different pair of buckets
+	r3 := newSync(other)
+	tassert.Errorf(t, r3.since == 0, "expected no previous sync, got %d", r3.since)
+
+	// resumed sync does not update the marker
+	r2.resumed = true
+	r2.markSynced()
+	r4 := newSync(bckTo)
+	tassert.Errorf(t, r4.since == r.StartTime().UnixNano(), "expected since %d, got %d", r.StartTime().UnixNano(), r4.since)
+
+	// explicit time
+	msg := &apc.TCBMsg{CopyBckMsg: apc.CopyBckMsg{Sync: true, Since: "2022-06-01T10:00:00Z"}}
+	r5 := &XactTCB{args: xreg.TCBArgs{BckFrom: bckFrom, BckTo: bckTo, Msg: msg}}
+	r5.initSync()
+	expected, _ := msg.SinceTime()
+	tassert.Errorf(t, r5.since == expected, "expected since %d, got %d", expected, r5.since)
+}
+
+func TestPrune(t *testing.T) {
+	var (
+		aisFrom    = newTCBTestBck("src", apc.ProviderAIS, 1)
+		remoteFrom = newTCBTestBck("cloud", apc.ProviderAmazon, 2)
+		bckTo      = newTCBTestBck("dst", apc.ProviderAIS, 3)
+		tsi        = cluster.NewSnode("other", apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+		smap       = &cluster.Smap{Tmap: cluster.NodeMap{tsi.ID(): tsi}, Version: 2}
+	)
+	initTCBTest(t, aisFrom, remoteFrom, bckTo)
+
+	tests := []struct {
+		name    string
+		from    *cluster.Bck
+		prefix  string
+		dryRun  bool
+		inClu   map[string]int
+		remote  map[string]int
+		smapVer int64 // at the start of the job
+		deleted []string
+	}{
+		{
+			name:    "ais",
+			from:    aisFrom,
+			inClu:   map[string]int{"a": http.StatusOK},
+			deleted: []string{"b", "c", "d"},
+		},
+		{
+			// HEAD failed (timed out, peer restarting, etc.) is not the same as not found
+			name:    "ais-unreachable",
+			from:    aisFrom,
+			inClu:   map[string]int{"a": http.StatusOK, "b": 0, "c": http.StatusServiceUnavailable},
+			deleted: []string{"d"},
+		},
+		{
+			name:    "prefix",
+			from:    aisFrom,
+			prefix:  "pre-",
+			inClu:   map[string]int{"a": http.StatusOK},
+			deleted: []string{"pre-b", "pre-c", "pre-d"},
+		},
+		{
+			name:   "dry-run",
+			from:   aisFrom,
+			dryRun: true,
+			inClu:  map[string]int{"a": http.StatusOK},
+		},
+		{
+			// not cached is not the same as not existing
+			name:    "remote",
+			from:    remoteFrom,
+			inClu:   map[string]int{"a": http.StatusOK},
+			remote:  map[string]int{"a": http.StatusOK, "b": http.StatusOK, "c": http.StatusInternalServerError},
+			deleted: []string{"d"},
+		},
+		{
+			// cluster map changed since the start
+			name:    "smap-changed",
+			from:    aisFrom,
+			smapVer: smap.Version - 1,
+		},
+	}
+	for _, test := range tests {
+		tt := &pruneTarget{TargetMock: &mock.TargetMock{}, smap: smap, inClu: test.inClu, remote: test.remote}
+		msg := &apc.TCBMsg{CopyBckMsg: apc.CopyBckMsg{Sync: true, Prune: true, Prefix: test.prefix, DryRun: test.dryRun}}
+		r := &XactTCB{t: tt, args: xreg.TCBArgs{BckFrom: test.from, BckTo: bckTo, Msg: msg}, smapVer: smap.Version}
+		if test.smapVer != 0 {
+			r.smapVer = test.smapVer
+		}
+		for _, name := range []string{"a", "b", "c", "d"} {
+			lom := &cluster.LOM{ObjName: test.prefix + name}
+			tassert.CheckFatal(t, r.pruneObj(lom, nil))
+		}
+		// (not ours)
+		if test.prefix != "" {
+			tassert.CheckFatal(t, r.pruneObj(&cluster.LOM{ObjName: "b"}, nil))
+		}
+		sort.Strings(tt.deleted)
+		expected := len(test.deleted)
+		if test.dryRun {
+			expected = 3 // counted but not deleted
+		}
+		if test.smapVer != 0 {
+			tassert.Errorf(t, r.unsafe.Load() && r.pruneSkip() != "", "%s: expected unsafe to prune", test.name)
+		}
+		tassert.Errorf(t, cos.StrSlicesEqual(tt.deleted, test.deleted), "%s: expected deleted %v, got %v",
+			test.name, test.deleted, tt.deleted)
+		tassert.Errorf(t, r.pruned.Load() == int64(expected), "%s: expected %d pruned, got %d",
+			test.name, expected, r.pruned.Load())
+	}
+}
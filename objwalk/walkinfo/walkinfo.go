@@ -7,6 +7,7 @@ package walkinfo
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -108,15 +109,24 @@ func (wi *WalkInfo) ProcessDir(fqn string) error {
 	if err != nil {
 		return nil
 	}
+	dirName := ct.ObjectName()
+	if snap := wi.msg.Snapshot; snap != "" {
+		if dirName == snap {
+			return nil
+		}
+		if dirName = strings.TrimPrefix(dirName, snap+"/"); len(dirName) == len(ct.ObjectName()) {
+			return filepath.SkipDir // other snapshot
+		}
+	}
 
-	if !cmn.DirNameContainsPrefix(ct.ObjectName(), wi.prefix) {
+	if !cmn.DirNameContainsPrefix(dirName, wi.prefix) {
 		return filepath.SkipDir
 	}
 
 	// When markerDir = "b/c/d/" we should skip directories: "a/", "b/a/",
 	// "b/b/" etc. but should not skip entire "b/" or "b/c/" since it is our
 	// parent which we want to traverse (see that: "b/" < "b/c/d/").
-	if wi.markerDir != "" && ct.ObjectName() < wi.markerDir && !strings.HasPrefix(wi.markerDir, ct.ObjectName()) {
+	if wi.markerDir != "" && dirName < wi.markerDir && !strings.HasPrefix(wi.markerDir, dirName) {
 		return filepath.SkipDir
 	}
 
@@ -235,3 +245,114 @@ func (wi *WalkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.BucketEntry, error) {
 	}
 	return wi.lsObject(lom, objStatus), nil
 }
+
+// CallbackVersion lists a single previous version or delete marker
+// (fs.ObjVersionType content - see apc.LsVersions).
+// NOTE: version ID is always included to distinguish same-name entries.
+func (wi *WalkInfo) CallbackVersion(parsedFQN *fs.ParsedFQN, fqn string) (*cmn.BucketEntry, error) {
+	objName, ver, delMarker, ok := fs.ParseObjVersion(parsedFQN.ObjName)
+	if !ok {
+		return nil, nil
+	}
+	if !cmn.ObjNameContainsPrefix(objName, wi.prefix) {
+		return nil, nil
+	}
+	if wi.Marker != "" && cmn.TokenIncludesObject(wi.Marker, objName) {
+		return nil, nil
+	}
+	entry := &cmn.BucketEntry{Name: objName, Version: ver, Flags: apc.EntryIsCached | apc.EntryIsPrevVer}
+	if delMarker {
+		entry.Flags |= apc.EntryIsDelMarker
+	}
+	if wi.msg.IsFlagSet(apc.LsNameOnly) {
+		return entry, nil
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil // trimmed in the meantime
+		}
+		return nil, err
+	}
+	ov := &cluster.ObjVersion{FQN: fqn, Ver: ver, Mtime: finfo.ModTime().UnixNano(), DelMarker: delMarker}
+	if wi.needAtime() {
+		entry.Atime = cos.FormatUnixNano(ov.Mtime, wi.timeFormat)
+	}
+	if wi.needTargetURL() {
+		entry.TargetURL = wi.t.Snode().URL(cmn.NetPublic)
+	}
+	if delMarker {
+		return entry, nil
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(&parsedFQN.Bck); err != nil {
+		return nil, err
+	}
+	hlom, err := lom.LoadVersionMD(ov)
+	if err != nil {
+		if cmn.IsErrObjNought(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if wi.needCksum() && hlom.Checksum() != nil {
+		entry.Checksum = hlom.Checksum().Value()
+	}
+	if wi.needSize() {
+		entry.Size = hlom.SizeBytes()
+	}
+	cluster.FreeLOM(hlom)
+	return entry, nil
+}
+
+// CallbackSnap lists a single object in a given bucket snapshot
+// (fs.SnapshotType content - see apc.ListObjsMsg.Snapshot).
+func (wi *WalkInfo) CallbackSnap(parsedFQN *fs.ParsedFQN, fqn string) (*cmn.BucketEntry, error) {
+	objName := strings.TrimPrefix(parsedFQN.ObjName, wi.msg.Snapshot+"/")
+	if len(objName) == len(parsedFQN.ObjName) {
+		return nil, nil // other snapshot
+	}
+	if !cmn.ObjNameContainsPrefix(objName, wi.prefix) {
+		return nil, nil
+	}
+	if wi.Marker != "" && cmn.TokenIncludesObject(wi.Marker, objName) {
+		return nil, nil
+	}
+	entry := &cmn.BucketEntry{Name: objName, Flags: apc.ObjStatusOK | apc.EntryIsCached}
+	if wi.msg.IsFlagSet(apc.LsNameOnly) {
+		return entry, nil
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(&parsedFQN.Bck); err != nil {
+		return nil, err
+	}
+	slom, err := lom.LoadSnapMD(fqn, parsedFQN.MpathInfo)
+	if err != nil {
+		if os.IsNotExist(err) || cmn.IsErrObjNought(err) {
+			return nil, nil // deleted in the meantime
+		}
+		return nil, err
+	}
+	if wi.needAtime() {
+		entry.Atime = cos.FormatUnixNano(slom.AtimeUnix(), wi.timeFormat)
+	}
+	if wi.needCksum() && slom.Checksum() != nil {
+		entry.Checksum = slom.Checksum().Value()
+	}
+	if wi.needVersion() {
+		entry.Version = slom.Version()
+	}
+	if wi.needCopies() {
+		entry.Copies = 1
+	}
+	if wi.needTargetURL() {
+		entry.TargetURL = wi.t.Snode().URL(cmn.NetPublic)
+	}
+	if wi.needSize() {
+		entry.Size = slom.SizeBytes()
+	}
+	cluster.FreeLOM(slom)
+	return entry, nil
+}
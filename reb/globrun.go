@@ -625,7 +625,7 @@ func (reb *Reb) retransmit(rargs *rebArgs) (cnt int) {
 				continue
 			}
 			tsi, _ := cluster.HrwTarget(lom.Uname(), rargs.smap)
-			if reb.t.HeadObjT2T(lom, tsi) {
+			if ok, _ := reb.t.HeadObjT2T(lom, tsi); ok {
 				if glog.FastV(4, glog.SmoduleReb) {
 					glog.Infof("%s: HEAD ok %s at %s", loghdr, lom, tsi.StringEx())
 				}
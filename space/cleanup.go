@@ -309,7 +309,7 @@ func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
 		Mi:       j.mi,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.ObjVersionType, fs.ArchIndexType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -372,6 +372,36 @@ func (j *clnJ) visitCT(parsedFQN fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.ObjVersionType:
+		// previous versions and delete markers:
+		// - history disabled: remove all
+		// - otherwise, remove those that are older than the configured retention
+		ct, err := cluster.NewCTFromFQN(fqn, j.p.ini.T.Bowner())
+		if err != nil {
+			return
+		}
+		conf := ct.Bck().VersionConf()
+		if !ct.Bck().IsAIS() || !conf.HistoryEnabled() {
+			j.oldWork = append(j.oldWork, fqn)
+			return
+		}
+		if conf.Retention == 0 {
+			return
+		}
+		finfo, err := os.Stat(fqn)
+		if err == nil && cluster.VersionExpired(&conf, finfo.ModTime().UnixNano(), j.now) {
+			j.oldWork = append(j.oldWork, fqn)
+		}
+	case fs.ArchIndexType:
+		// archive indices: remove those that no longer match their archives
+		// (skipping recently modified, to not race with indexing in progress)
+		finfo, err := os.Stat(fqn)
+		if err != nil || finfo.ModTime().UnixNano()+int64(j.config.LRU.DontEvictTime) > j.now {
+			return
+		}
+		if cluster.ArchIndexObsolete(fqn, &parsedFQN) {
+			j.oldWork = append(j.oldWork, fqn)
+		}
 	default:
 		debug.Assertf(false, "Unsupported content type: %s", parsedFQN.ContentType)
 	}
@@ -490,6 +520,7 @@ func (j *clnJ) rmLeftovers() (size int64, err error) {
 			if err := cos.RemoveFile(workfqn); err != nil {
 				glog.Errorf("%s: failed to rm old work %q: %v", j, workfqn, err)
 			} else {
+				fs.RemoveObjVersionDir(workfqn)
 				size += finfo.Size()
 				fevicted++
 				bevicted += finfo.Size()
@@ -118,6 +118,7 @@ type (
 		promDesc  promDesc
 		statsdC   *statsd.Client
 		statsTime time.Duration
+		promHist  promDesc // KindLatency histograms
 		sgl       *memsys.SGL
 		cmu       sync.RWMutex // ctracker vs Prometheus Collect()
 	}
@@ -194,16 +195,19 @@ type (
 	}
 	// Stats are tracked via a map of stats names (key) to statsValue (values).
 	// There are two main types of stats: counter and latency declared
-	// using the the kind field. Only latency stats have numSamples used to compute latency.
+	// using the the kind field. Only latency stats have numSamples used to compute latency
+	// and the histogram used to compute percentiles (see histogram.go).
 	statsValue struct {
 		sync.RWMutex
 		Value int64 `json:"v,string"`
 		kind  string
 		label struct {
-			comm string // common part of the metric label (as in: <prefix> . comm . <suffix>)
-			stsd string // StatsD label
-			prom string // Prometheus label
+			comm string         // common part of the metric label (as in: <prefix> . comm . <suffix>)
+			stsd string         // StatsD label
+			prom string         // Prometheus label
+			pct  [numPct]string // StatsD percentile labels (KindLatency only)
 		}
+		hist       *histogram
 		numSamples int64
 		cumulative int64
 		isCommon   bool // optional, common to the proxy and target
@@ -247,6 +251,7 @@ func match(s string, prefs []string) bool {
 func (s *CoreStats) init(node *cluster.Snode, size int) {
 	s.Tracker = make(statsTracker, size)
 	s.promDesc = make(promDesc, size)
+	s.promHist = make(promDesc, 16)
 
 	// debug.NewExpvar & debug.SetExpvar could be placed here and elsewhere to visualize:
 	//     * all counters including errors
@@ -367,6 +372,9 @@ func (s *CoreStats) initProm(node *cluster.Snode) {
 
 		fullqn := prometheus.BuildFQName("ais", node.Type(), id+"_"+v.label.prom)
 		s.promDesc[name] = prometheus.NewDesc(fullqn, help, nil /*variableLabels*/, nil /*constLabels*/)
+		if v.kind == KindLatency {
+			s.promHist[name] = prometheus.NewDesc(fullqn+"_hist", "latency histogram (milliseconds)", nil, nil)
+		}
 	}
 }
 
@@ -398,6 +406,7 @@ func (s *CoreStats) doAdd(name, nameSuffix string, val int64) {
 		v.numSamples++
 		v.cumulative += val
 		v.Value += val
+		v.hist.observe(val)
 		v.Unlock()
 	case KindThroughput:
 		v.Lock()
@@ -428,7 +437,10 @@ func (s *CoreStats) copyT(ctracker copyTracker, idlePrefs []string) (idle bool)
 	for name, v := range s.Tracker {
 		switch v.kind {
 		case KindLatency:
-			var lat int64
+			var (
+				lat int64
+				pct [numPct]int64
+			)
 			v.Lock()
 			if v.numSamples > 0 {
 				lat = v.Value / v.numSamples
@@ -437,6 +449,13 @@ func (s *CoreStats) copyT(ctracker copyTracker, idlePrefs []string) (idle bool)
 					idle = false
 				}
 			}
+			// (an idle period resets the percentiles - see histogram.percentiles)
+			if v.hist.percentiles() {
+				pct = v.hist.pct
+				for j := range pct {
+					ctracker[name+pctNames[j]] = copyValue{pct[j]}
+				}
+			}
 			v.Value = 0
 			v.numSamples = 0
 			v.Unlock()
@@ -444,6 +463,10 @@ func (s *CoreStats) copyT(ctracker copyTracker, idlePrefs []string) (idle bool)
 			millis := cos.DivRound(lat, int64(time.Millisecond))
 			if !s.isPrometheus() && millis > 0 && strings.HasSuffix(name, ".ns") {
 				s.statsdC.AppMetric(metric{Type: statsd.Timer, Name: v.label.stsd, Value: float64(millis)}, s.sgl)
+				for j := range pct {
+					millis = cos.DivRound(pct[j], int64(time.Millisecond))
+					s.statsdC.AppMetric(metric{Type: statsd.Timer, Name: v.label.pct[j], Value: float64(millis)}, s.sgl)
+				}
 			}
 		case KindThroughput, KindComputedThroughput:
 			var throughput int64
@@ -511,6 +534,11 @@ func (s *CoreStats) copyCumulative(ctracker copyTracker) {
 		v.RLock()
 		if v.kind == KindLatency || v.kind == KindThroughput {
 			ctracker[name] = copyValue{v.cumulative}
+			if v.kind == KindLatency && v.hist.live {
+				for j := range v.hist.pct {
+					ctracker[name+pctNames[j]] = copyValue{v.hist.pct[j]} // most recent period
+				}
+			}
 		} else if v.kind == KindCounter {
 			if v.Value != 0 {
 				ctracker[name] = copyValue{v.Value}
@@ -583,6 +611,10 @@ func (tracker statsTracker) register(node *cluster.Snode, name, kind string, isC
 		v.label.comm = strings.ReplaceAll(v.label.comm, ".ns.", ".")
 		v.label.comm = strings.ReplaceAll(v.label.comm, ":", "_")
 		v.label.stsd = fmt.Sprintf("%s.%s.%s.%s", "ais"+node.Type(), node.ID(), v.label.comm, "ms")
+		for j, pn := range pctNames {
+			v.label.pct[j] = fmt.Sprintf("%s.%s.%s%s.%s", "ais"+node.Type(), node.ID(), v.label.comm, pn, "ms")
+		}
+		v.hist = newHistogram(latencyBounds(cmn.GCO.Get()))
 	case KindThroughput, KindComputedThroughput:
 		debug.AssertMsg(strings.HasSuffix(name, ".bps"), name)
 		v.label.comm = strings.TrimSuffix(name, ".bps")
@@ -646,6 +678,9 @@ func (r *statsRunner) Describe(ch chan<- *prometheus.Desc) {
 	for _, desc := range r.Core.promDesc {
 		ch <- desc
 	}
+	for _, desc := range r.Core.promHist {
+		ch <- desc
+	}
 }
 
 func (r *statsRunner) Collect(ch chan<- prometheus.Metric) {
@@ -658,6 +693,9 @@ func (r *statsRunner) Collect(ch chan<- prometheus.Metric) {
 			val int64
 			fv  float64
 		)
+		if v.kind == KindLatency {
+			r.collectHist(ch, name, v)
+		}
 		copyV, okc := r.ctracker[name]
 		if !okc {
 			continue
@@ -696,6 +734,25 @@ func (r *statsRunner) Collect(ch chan<- prometheus.Metric) {
 	r.Core.promRUnlock()
 }
 
+func (r *statsRunner) collectHist(ch chan<- prometheus.Metric, name string, v *statsValue) {
+	v.RLock()
+	if v.hist.cnt == 0 {
+		v.RUnlock()
+		return
+	}
+	var (
+		cnt     = v.hist.cnt
+		sum     = float64(v.hist.sum) / float64(time.Millisecond)
+		buckets = v.hist.promBuckets()
+	)
+	v.RUnlock()
+	desc, ok := r.Core.promHist[name]
+	debug.AssertMsg(ok, name)
+	m, err := prometheus.NewConstHistogram(desc, cnt, sum, buckets)
+	debug.AssertNoErr(err)
+	ch <- m
+}
+
 func (r *statsRunner) Name() string { return r.name }
 
 func (r *statsRunner) CoreStats() *CoreStats       { return r.Core }
@@ -0,0 +1,169 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Latency histogram: fixed (configurable) buckets, two sets of counters:
+// - cumulative (never reset) - to export Prometheus histograms;
+// - periodic (reset every config.Periodic.StatsTime) - to compute percentiles.
+
+// percentiles reported for each KindLatency metric, e.g. "get.ns.p99"
+var (
+	pctNames = [numPct]string{".p50", ".p90", ".p99", ".p999"}
+	pctVals  = [numPct]float64{0.5, 0.9, 0.99, 0.999}
+)
+
+const numPct = 4
+
+// IsPercentile returns true if a given stats name is a latency percentile (e.g. "get.ns.p99")
+// rather than a value that can be summed up or averaged.
+func IsPercentile(name string) bool {
+	for _, pn := range pctNames {
+		if strings.HasSuffix(name, pn) {
+			return strings.HasSuffix(name[:len(name)-len(pn)], ".ns")
+		}
+	}
+	return false
+}
+
+// default upper bounds of the latency buckets (see also config.Periodic.LatencyBuckets)
+var dfltLatencyBuckets = []time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+type histogram struct {
+	bounds []int64  // bucket upper bounds (nanoseconds), in increasing order
+	counts []uint64 // cumulative per-bucket counts; the last one is +Inf
+	period []uint64 // ditto, reset upon each percentiles() call
+	sum    int64    // cumulative sum of all observed values
+	cnt    uint64   // cumulative number of observations
+	pct    [numPct]int64
+	live   bool // pct computed over the most recent period (false when the latter had no samples)
+}
+
+func latencyBounds(config *cmn.Config) (bounds []int64) {
+	if l := len(config.Periodic.LatencyBuckets); l > 0 {
+		bounds = make([]int64, l)
+		for i, b := range config.Periodic.LatencyBuckets {
+			bounds[i] = int64(b)
+		}
+		return
+	}
+	bounds = make([]int64, len(dfltLatencyBuckets))
+	for i, b := range dfltLatencyBuckets {
+		bounds[i] = int64(b)
+	}
+	return
+}
+
+func newHistogram(bounds []int64) *histogram {
+	return &histogram{
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)+1),
+		period: make([]uint64, len(bounds)+1),
+	}
+}
+
+func (h *histogram) observe(val int64) {
+	i := h.bucket(val)
+	h.counts[i]++
+	h.period[i]++
+	h.sum += val
+	h.cnt++
+}
+
+// binary search for the first bucket that can hold the value
+func (h *histogram) bucket(val int64) int {
+	lo, hi := 0, len(h.bounds)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if val <= h.bounds[mid] {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// compute percentiles over the current period (linearly interpolating within
+// the bucket, same as Prometheus `histogram_quantile`) and start a new period;
+// an empty period resets the values - no traffic, no percentiles (rather than stale ones)
+func (h *histogram) percentiles() (updated bool) {
+	var total uint64
+	for _, c := range h.period {
+		total += c
+	}
+	if total == 0 {
+		h.pct, h.live = [numPct]int64{}, false
+		return
+	}
+	h.live = true
+	for j, q := range pctVals {
+		h.pct[j] = h.quantile(q, total)
+	}
+	for i := range h.period {
+		h.period[i] = 0
+	}
+	return true
+}
+
+func (h *histogram) quantile(q float64, total uint64) int64 {
+	var (
+		rank = q * float64(total)
+		cum  uint64
+	)
+	for i, c := range h.period {
+		if c == 0 || float64(cum+c) < rank {
+			cum += c
+			continue
+		}
+		if i == len(h.bounds) {
+			// +Inf bucket: the best we can do is the highest finite bound
+			return h.bounds[i-1]
+		}
+		var lower int64
+		if i > 0 {
+			lower = h.bounds[i-1]
+		}
+		frac := (rank - float64(cum)) / float64(c)
+		return lower + int64(frac*float64(h.bounds[i]-lower))
+	}
+	return h.bounds[len(h.bounds)-1]
+}
+
+// Prometheus: cumulative bucket counts keyed by upper bounds (milliseconds)
+func (h *histogram) promBuckets() (buckets map[float64]uint64) {
+	var cum uint64
+	buckets = make(map[float64]uint64, len(h.bounds))
+	for i, b := range h.bounds {
+		cum += h.counts[i]
+		buckets[float64(b)/float64(time.Millisecond)] = cum
+	}
+	return
+}
@@ -0,0 +1,136 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+func TestHistogramPercentiles(t *testing.T) {
+	bounds := []int64{int64(time.Millisecond), int64(10 * time.Millisecond), int64(100 * time.Millisecond)}
+	h := newHistogram(bounds)
+
+	// 90 fast, 9 medium, 1 slow
+	for i := 0; i < 90; i++ {
+		h.observe(int64(500 * time.Microsecond))
+	}
+	for i := 0; i < 9; i++ {
+		h.observe(int64(5 * time.Millisecond))
+	}
+	h.observe(int64(50 * time.Millisecond))
+
+	if !h.percentiles() {
+		t.Fatal("expected percentiles to be computed")
+	}
+	p50, p90, p99, p999 := h.pct[0], h.pct[1], h.pct[2], h.pct[3]
+	if p50 > bounds[0] {
+		t.Errorf("p50 %v: expected within the first bucket", time.Duration(p50))
+	}
+	if p90 > bounds[0] {
+		t.Errorf("p90 %v: expected within the first bucket", time.Duration(p90))
+	}
+	if p99 <= bounds[0] || p99 > bounds[1] {
+		t.Errorf("p99 %v: expected within the second bucket", time.Duration(p99))
+	}
+	if p999 <= bounds[1] || p999 > bounds[2] {
+		t.Errorf("p999 %v: expected within the third bucket", time.Duration(p999))
+	}
+
+	// new (empty) period resets the values
+	if h.percentiles() {
+		t.Error("expected no update on empty period")
+	}
+	if h.live || h.pct[2] != 0 {
+		t.Errorf("expected p99 reset, got %d (live %t)", h.pct[2], h.live)
+	}
+
+	// cumulative counts and Prometheus buckets
+	if h.cnt != 100 {
+		t.Errorf("count %d, expected 100", h.cnt)
+	}
+	buckets := h.promBuckets()
+	if buckets[1] != 90 || buckets[10] != 99 || buckets[100] != 100 {
+		t.Errorf("unexpected cumulative buckets: %v", buckets)
+	}
+
+	// the next non-empty one computes them anew
+	h.observe(int64(5 * time.Millisecond))
+	if !h.percentiles() || !h.live || h.pct[2] <= bounds[0] || h.pct[2] > bounds[1] {
+		t.Errorf("p99 %v: expected within the second bucket", time.Duration(h.pct[2]))
+	}
+}
+
+func TestHistogramOverflow(t *testing.T) {
+	bounds := []int64{10, 20}
+	h := newHistogram(bounds)
+	h.observe(1000)
+	h.percentiles()
+	for j, p := range h.pct {
+		if p != bounds[1] {
+			t.Errorf("%s: %d, expected highest bound %d", pctNames[j], p, bounds[1])
+		}
+	}
+}
+
+func TestIsPercentile(t *testing.T) {
+	tests := map[string]bool{
+		"get.ns.p50":   true,
+		"put.ns.p999":  true,
+		"get.ns":       false,
+		"get.n":        false,
+		"get.size.p99": false,
+		"p99":          false,
+		"err.ns.p":     false,
+	}
+	for name, expected := range tests {
+		if IsPercentile(name) != expected {
+			t.Errorf("%q: expected %t", name, expected)
+		}
+	}
+}
+
+// samples, then an idle interval: no (stale) percentiles in either copy
+func TestLatencyPercentilesIdle(t *testing.T) {
+	const name = "get.ns"
+	var (
+		node = cluster.NewSnode("test", apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+		s    = &CoreStats{Tracker: make(statsTracker, 4)}
+	)
+	s.sgl = memsys.PageMM().NewSGL(memsys.PageSize)
+	defer s.sgl.Free()
+	s.Tracker.register(node, name, KindLatency)
+
+	s.doAdd(name, "", int64(5*time.Millisecond))
+	ctracker := make(copyTracker, 8)
+	s.copyT(ctracker, nil)
+	if _, ok := ctracker[name+pctNames[2]]; !ok {
+		t.Fatalf("expected %s%s, got %v", name, pctNames[2], ctracker)
+	}
+	cumulative := make(copyTracker, 8)
+	s.copyCumulative(cumulative)
+	if _, ok := cumulative[name+pctNames[2]]; !ok {
+		t.Fatalf("expected cumulative %s%s, got %v", name, pctNames[2], cumulative)
+	}
+
+	// idle
+	ctracker = make(copyTracker, 8)
+	s.copyT(ctracker, nil)
+	cumulative = make(copyTracker, 8)
+	s.copyCumulative(cumulative)
+	for j := range pctNames {
+		if _, ok := ctracker[name+pctNames[j]]; ok {
+			t.Errorf("idle: unexpected %s%s in %v", name, pctNames[j], ctracker)
+		}
+		if _, ok := cumulative[name+pctNames[j]]; ok {
+			t.Errorf("idle: unexpected cumulative %s%s in %v", name, pctNames[j], cumulative)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/admit"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -54,6 +55,10 @@ const (
 	// special
 	RestartCount = "restart.n"
 
+	// admission control (see admit.Check)
+	AdmitRejectPutCount  = "admit.reject.put.n"  // rejected PUTs
+	AdmitRejectXactCount = "admit.reject.xact.n" // rejected (not started) xactions
+
 	// KindLatency
 	PutLatency      = "put.ns"
 	AppendLatency   = "append.ns"
@@ -189,6 +194,9 @@ func (r *Trunner) RegMetrics(node *cluster.Snode) {
 	// special
 	r.reg(RestartCount, KindCounter)
 
+	r.reg(AdmitRejectPutCount, KindCounter)
+	r.reg(AdmitRejectXactCount, KindCounter)
+
 	// download
 	r.reg(DownloadSize, KindCounter)
 	r.reg(DownloadLatency, KindLatency)
@@ -228,6 +236,17 @@ func (r *Trunner) log(now int64, uptime time.Duration, config *cmn.Config) {
 		v.Value = stats.Util
 	}
 
+	// admission control counters are maintained by the admit package
+	nput, nxact := admit.Stats()
+	v := s.Tracker[AdmitRejectPutCount]
+	v.Lock()
+	v.Value = nput
+	v.Unlock()
+	v = s.Tracker[AdmitRejectXactCount]
+	v.Lock()
+	v.Value = nxact
+	v.Unlock()
+
 	// 2 copy stats, reset latencies, send via StatsD if configured
 	r.Core.updateUptime(uptime)
 	r.Core.promLock()
@@ -5,34 +5,144 @@
 package xact
 
 import (
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/ios"
 )
 
 type BckJog struct {
 	Base
 	t       cluster.Target
 	joggers *mpather.JoggerGroup
+	walkBck cmn.Bck // (the bucket that is being walked - may differ from r.Bck(), e.g. copy)
+	ckpt    *Ckpt   // resumable only
 }
 
 func (r *BckJog) Init(id, kind string, bck *cluster.Bck, opts *mpather.JoggerGroupOpts) {
 	r.t = opts.T
 	r.InitBase(id, kind, bck)
+	r.walkBck = opts.Bck
+	// track progress in terms of visited objects (see Base.ToProgress)
+	if visit := opts.VisitObj; visit != nil {
+		opts.VisitObj = func(lom *cluster.LOM, buf []byte) error {
+			err := visit(lom, buf)
+			r.VisitedAdd(1, lom.SizeBytes(true))
+			return err
+		}
+	}
 	r.joggers = mpather.NewJoggerGroup(opts)
 }
 
-func (r *BckJog) Run()                   { r.joggers.Run() }
+// InitResumable is Init that, in addition, checkpoints progress; `ckpt` is either
+// new (see NewCkpt) or loaded upon restart, in which case the xaction resumes
+// from the checkpointed positions
+func (r *BckJog) InitResumable(bck *cluster.Bck, opts *mpather.JoggerGroupOpts, ckpt *Ckpt) {
+	opts.Resumable, opts.Resume = true, ckpt.Mpaths
+	r.Init(ckpt.ID, ckpt.Kind, bck, opts)
+	if ckpt.Objs > 0 || ckpt.Bytes > 0 {
+		r.ObjsAdd(int(ckpt.Objs), ckpt.Bytes)
+		r.VisitedAdd(int(ckpt.Objs), ckpt.Bytes) // (approximately)
+		glog.Infof("%s: resuming from checkpoint (objs %d)", r.Name(), ckpt.Objs)
+	}
+	r.ckpt = ckpt
+}
+
+func (r *BckJog) Run() {
+	if r.ckpt != nil {
+		r.checkpoint() // to resume from scratch if need be
+	}
+	go r.estimateTotals()
+	r.joggers.Run()
+}
+
+// estimate the work using the same fast (`du`-based) method as bucket summary
+func (r *BckJog) estimateTotals() {
+	bck := cluster.CloneBck(&r.walkBck)
+	if err := bck.Init(r.t.Bowner()); err != nil {
+		return
+	}
+	var objs, size int64
+	for _, mpathInfo := range fs.GetAvail() {
+		fileCount, dirSize, err := FastCount(mpathInfo, bck)
+		if err != nil {
+			continue
+		}
+		objs += int64(fileCount)
+		size += int64(dirSize)
+		if r.IsAborted() || r.Finished() {
+			return
+		}
+	}
+	r.SetTotals(objs, size, true)
+}
+
+// FastCount returns the number of objects in a given bucket on a given mountpath and their
+// total size, both approximate (`du`-based and adjusted for n-way mirroring)
+func FastCount(mpathInfo *fs.MountpathInfo, bck *cluster.Bck) (fileCount int, dirSize uint64, err error) {
+	path := mpathInfo.MakePathCT(bck.Bucket(), fs.ObjectType)
+	if dirSize, err = ios.GetDirSize(path); err != nil {
+		return
+	}
+	if fileCount, err = ios.GetFileCount(path); err != nil {
+		return
+	}
+	if bck.Props.Mirror.Enabled {
+		copies := int(bck.Props.Mirror.Copies)
+		dirSize /= uint64(copies)
+		fileCount = fileCount/copies + fileCount%copies
+	}
+	return
+}
+
 func (r *BckJog) Target() cluster.Target { return r.t }
 
+// when finishing without running (resumable only)
+func (r *BckJog) RemoveCkpt() {
+	if r.ckpt != nil {
+		r.ckpt.Fin(nil)
+	}
+}
+
 func (r *BckJog) Wait() error {
+	var tick <-chan time.Time
+	if r.ckpt != nil {
+		ticker := time.NewTicker(ckptIval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
 	for {
 		select {
 		case errCause := <-r.ChanAbort():
 			r.joggers.Stop()
+			if r.ckpt != nil {
+				r.ckptUpd()
+				r.ckpt.Fin(errCause)
+			}
 			return cmn.NewErrAborted(r.Name(), "x-bck-jog", errCause)
 		case <-r.joggers.ListenFinished():
-			return r.joggers.Stop()
+			err := r.joggers.Stop()
+			if r.ckpt != nil {
+				r.ckpt.Fin(nil)
+			}
+			return err
+		case <-tick:
+			r.checkpoint()
 		}
 	}
 }
+
+func (r *BckJog) ckptUpd() {
+	r.ckpt.Mpaths, r.ckpt.Objs, r.ckpt.Bytes = r.joggers.Positions(), r.Objs(), r.Bytes()
+}
+
+func (r *BckJog) checkpoint() {
+	r.ckptUpd()
+	if err := r.ckpt.Persist(); err != nil {
+		glog.Errorf("%s: %v", r.Name(), err)
+	}
+}
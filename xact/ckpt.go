@@ -0,0 +1,96 @@
+// Package xact provides core functionality for the AIStore eXtended Actions (xactions).
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package xact
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Long-running (resumable) bucket xactions periodically checkpoint their progress
+// to the target's persistent metadata (see fs.PersistXactCkpt) and, upon target
+// restart or cluster reboot, resume with the same xaction ID (see Table: `Resumable`).
+//
+// A checkpoint is removed when the xaction completes (with or without errors)
+// or gets aborted by the user; it is retained when the xaction is aborted for
+// any other reason - e.g., when the node is shutting down.
+
+const (
+	ckptCopies  = 2
+	ckptMetaver = 1
+	ckptIval    = 30 * time.Second
+)
+
+var ckptJspOpts = jsp.CCSign(ckptMetaver)
+
+type Ckpt struct {
+	ID    string      `json:"id"`
+	Kind  string      `json:"kind"`
+	Bck   cmn.Bck     `json:"bck"`
+	BckTo cmn.Bck     `json:"bck_to"`
+	Msg   interface{} `json:"msg,omitempty"` // action-specific arguments to resume with
+	// progress: per-mountpath walk positions (mpather) or list/range position (multi-object)
+	Mpaths map[string]string `json:"mpaths,omitempty"`
+	Pos    int64             `json:"pos,omitempty"`
+	Objs   int64             `json:"objs,string"`
+	Bytes  int64             `json:"bytes,string"`
+	Time   int64             `json:"time,string"`
+	last   int64             // mono time of the last persist
+}
+
+// interface guard
+var _ jsp.Opts = (*Ckpt)(nil)
+
+func NewCkpt(id, kind string, bck *cmn.Bck, msg interface{}) *Ckpt {
+	ck := &Ckpt{ID: id, Kind: kind, Msg: msg}
+	ck.Bck.Copy(bck)
+	return ck
+}
+
+func (*Ckpt) JspOpts() jsp.Options { return ckptJspOpts }
+
+// (periodic checkpointing)
+func (ck *Ckpt) Due() bool { return mono.Since(ck.last) >= ckptIval }
+
+func (ck *Ckpt) Persist() error {
+	ck.Time, ck.last = time.Now().UnixNano(), mono.NanoTime()
+	return fs.PersistXactCkpt(ck.ID, ck, ckptCopies)
+}
+
+// upon completion or abort (see above)
+func (ck *Ckpt) Fin(abortErr error) {
+	if abortErr == nil || errors.Is(abortErr, cmn.ErrXactUserAbort) {
+		fs.RemoveXactCkpt(ck.ID)
+		return
+	}
+	if err := ck.Persist(); err != nil {
+		glog.Errorf("%s[%s]: %v", ck.Kind, ck.ID, err)
+		return
+	}
+	glog.Infof("%s[%s]: retaining checkpoint (objs %d) to resume upon restart", ck.Kind, ck.ID, ck.Objs)
+}
+
+// all persisted checkpoints
+func LoadCkpts() (ckpts []*Ckpt) {
+	for _, id := range fs.XactCkptIDs() {
+		ck := &Ckpt{}
+		if err := fs.LoadXactCkpt(id, ck); err != nil {
+			continue
+		}
+		if ck.ID != id || !IsValidKind(ck.Kind) || !Table[ck.Kind].Resumable {
+			glog.Errorf("invalid checkpoint %q (%s[%s]) - removing", id, ck.Kind, ck.ID)
+			fs.RemoveXactCkpt(id)
+			continue
+		}
+		ckpts = append(ckpts, ck)
+	}
+	return
+}
@@ -123,6 +123,7 @@ func (r *DemandBase) ExtSnap() *SnapExt {
 		snap.Bck = *r.Bck().Bucket()
 	}
 	snap.Ext = &BaseDemandStatsExt{IsIdle: r.likelyIdle()}
+	r.ToProgress(&snap.Progress)
 	return snap
 }
 
@@ -10,6 +10,8 @@ import (
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/nl"
 )
 
 type (
@@ -34,7 +36,28 @@ type (
 
 	SnapExt struct {
 		Snap
-		Ext interface{} `json:"ext"`
+		Ext      interface{} `json:"ext"`
+		Progress Progress    `json:"progress"`
+	}
+
+	// Progress is reported by each target (see Base.ToProgress) and gets aggregated
+	// cluster-wide by the proxy (see AggrProgress). The totals are either precomputed
+	// (e.g., list of objects to prefetch) or estimated (e.g., bucket walk).
+	Progress struct {
+		TotalObjs  int64   `json:"total-objs,string"`  // number of objects to process (0: unknown)
+		TotalBytes int64   `json:"total-bytes,string"` // their total size (0: unknown)
+		Objs       int64   `json:"objs,string"`        // processed (or visited) so far
+		Bytes      int64   `json:"bytes,string"`       // ditto
+		Estimated  bool    `json:"estimated"`          // totals are estimated rather than precomputed
+		Pct        float64 `json:"pct"`                // percent complete (0 when totals are unknown)
+		Throughput int64   `json:"throughput,string"`  // current throughput, bytes/s
+		ObjsPerSec float64 `json:"objs-per-sec"`       // ditto, objects/s
+		ETA        int64   `json:"eta,string"`         // estimated time remaining, ns (0: unknown)
+	}
+	// xaction status (see `nl.NotifStatus`), including cluster-wide progress
+	StatusExt struct {
+		nl.NotifStatus
+		Progress *Progress `json:"progress,omitempty"`
 	}
 	BaseDemandStatsExt struct {
 		IsIdle bool `json:"is_idle"`
@@ -85,6 +108,77 @@ func (b *SnapExt) Idle() bool {
 	return !b.Running()
 }
 
+//////////////
+// Progress //
+//////////////
+
+func (p *Progress) Known() bool { return p.TotalObjs > 0 || p.TotalBytes > 0 }
+
+// compute percentage and ETA given totals, counters, and throughput
+func (p *Progress) finalize() {
+	p.Pct, p.ETA = 0, 0
+	switch {
+	case p.TotalBytes > 0:
+		p.Pct = float64(p.Bytes) * 100 / float64(p.TotalBytes)
+		if rem := p.TotalBytes - p.Bytes; rem > 0 && p.Throughput > 0 {
+			p.ETA = int64(float64(rem) / float64(p.Throughput) * float64(time.Second))
+		}
+	case p.TotalObjs > 0:
+		p.Pct = float64(p.Objs) * 100 / float64(p.TotalObjs)
+		if rem := p.TotalObjs - p.Objs; rem > 0 && p.ObjsPerSec > 0 {
+			p.ETA = int64(float64(rem) / p.ObjsPerSec * float64(time.Second))
+		}
+	}
+	// (estimates may be off)
+	if p.Pct > 100 {
+		p.Pct = 100
+	}
+}
+
+func (p *Progress) String() string {
+	if !p.Known() {
+		return fmt.Sprintf("objs %d, %s/s", p.Objs, cos.B2S(p.Throughput, 1))
+	}
+	s := fmt.Sprintf("%.1f%%", p.Pct)
+	if p.Estimated {
+		s = "~" + s
+	}
+	s += fmt.Sprintf(" (objs %d/%d), %s/s", p.Objs, p.TotalObjs, cos.B2S(p.Throughput, 1))
+	if p.ETA > 0 {
+		s += ", ETA " + time.Duration(p.ETA).Round(time.Second).String()
+	}
+	return s
+}
+
+// AggrProgress sums up per-target progress; finished targets contribute
+// their counters and totals but not throughput
+func AggrProgress(snaps []*SnapExt) (aggr *Progress) {
+	var unknown bool
+	aggr = &Progress{}
+	for _, snap := range snaps {
+		p := &snap.Progress
+		aggr.Objs += p.Objs
+		aggr.Bytes += p.Bytes
+		aggr.Estimated = aggr.Estimated || p.Estimated
+		if snap.Finished() && !snap.IsAborted() {
+			// done (ie., whatever's processed is the total)
+			aggr.TotalObjs += cos.MaxI64(p.TotalObjs, p.Objs)
+			aggr.TotalBytes += cos.MaxI64(p.TotalBytes, p.Bytes)
+			continue
+		}
+		unknown = unknown || !p.Known()
+		aggr.TotalObjs += p.TotalObjs
+		aggr.TotalBytes += p.TotalBytes
+		aggr.Throughput += p.Throughput
+		aggr.ObjsPerSec += p.ObjsPerSec
+	}
+	if unknown {
+		aggr.TotalObjs, aggr.TotalBytes = 0, 0
+	}
+	aggr.finalize()
+	return
+}
+
 //////////////
 // QueryMsg //
 //////////////
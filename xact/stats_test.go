@@ -0,0 +1,93 @@
+// Package xact provides core functionality for the AIStore eXtended Actions (xactions).
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package xact
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func approx(a, b float64) bool { return math.Abs(a-b) <= 0.05*math.Max(math.Abs(a), math.Abs(b)) }
+
+func TestProgressFinalize(t *testing.T) {
+	tests := []struct {
+		p   Progress
+		pct float64
+		eta time.Duration
+	}{
+		// unknown totals
+		{Progress{Objs: 10, Bytes: 1000, Throughput: 100}, 0, 0},
+		// bytes take precedence
+		{Progress{TotalObjs: 10, TotalBytes: 1000, Objs: 8, Bytes: 250, Throughput: 50, ObjsPerSec: 1}, 25, 15 * time.Second},
+		// objects only
+		{Progress{TotalObjs: 10, Objs: 4, ObjsPerSec: 2}, 40, 3 * time.Second},
+		// no throughput - no ETA
+		{Progress{TotalObjs: 10, Objs: 4}, 40, 0},
+		// estimated totals exceeded
+		{Progress{TotalObjs: 10, Objs: 12, ObjsPerSec: 2, Estimated: true}, 100, 0},
+	}
+	for _, test := range tests {
+		p := test.p
+		p.ETA = int64(time.Hour) // must be recomputed
+		p.finalize()
+		tassert.Errorf(t, p.Pct == test.pct, "%+v: expected %.1f%%, got %.1f%%", test.p, test.pct, p.Pct)
+		tassert.Errorf(t, time.Duration(p.ETA) == test.eta, "%+v: expected ETA %v, got %v", test.p, test.eta, time.Duration(p.ETA))
+	}
+}
+
+func TestAggrProgress(t *testing.T) {
+	var (
+		running = &SnapExt{Progress: Progress{TotalObjs: 100, TotalBytes: 1000, Objs: 10, Bytes: 100,
+			Throughput: 10, ObjsPerSec: 1}}
+		finished = &SnapExt{Progress: Progress{TotalObjs: 50, TotalBytes: 500, Objs: 60, Bytes: 600,
+			Throughput: 1000, ObjsPerSec: 100}}
+		aborted = &SnapExt{Progress: Progress{TotalObjs: 100, TotalBytes: 1000, Objs: 20, Bytes: 200,
+			Throughput: 10, ObjsPerSec: 1}}
+	)
+	finished.EndTime = time.Now()
+	aborted.EndTime, aborted.AbortedX = time.Now(), true
+
+	aggr := AggrProgress([]*SnapExt{running, finished})
+	tassert.Errorf(t, aggr.Objs == 70 && aggr.Bytes == 700, "unexpected counters %+v", aggr)
+	// finished target: whatever's processed is its total, and it contributes no throughput
+	tassert.Errorf(t, aggr.TotalObjs == 160 && aggr.TotalBytes == 1600, "unexpected totals %+v", aggr)
+	tassert.Errorf(t, aggr.Throughput == 10 && aggr.ObjsPerSec == 1, "unexpected throughput %+v", aggr)
+	tassert.Errorf(t, approx(aggr.Pct, 43.75), "unexpected pct %+v", aggr)
+	tassert.Errorf(t, time.Duration(aggr.ETA) == 90*time.Second, "unexpected ETA %v", time.Duration(aggr.ETA))
+
+	// aborted target keeps its totals
+	aggr = AggrProgress([]*SnapExt{running, aborted})
+	tassert.Errorf(t, aggr.TotalObjs == 200 && aggr.TotalBytes == 2000 && aggr.Bytes == 300, "unexpected %+v", aggr)
+
+	// any running target with unknown totals makes it all unknown
+	unknown := &SnapExt{Progress: Progress{Objs: 5, Bytes: 50, Throughput: 5, Estimated: true}}
+	aggr = AggrProgress([]*SnapExt{running, unknown})
+	tassert.Errorf(t, !aggr.Known() && aggr.Pct == 0 && aggr.ETA == 0, "expected unknown totals, got %+v", aggr)
+	tassert.Errorf(t, aggr.Estimated && aggr.Throughput == 15 && aggr.Objs == 15, "unexpected %+v", aggr)
+
+	aggr = AggrProgress(nil)
+	tassert.Errorf(t, !aggr.Known() && aggr.Objs == 0, "unexpected %+v", aggr)
+}
+
+func TestThroughput(t *testing.T) {
+	xctn := &Base{}
+	xctn.setStartTime(time.Now().Add(-10 * time.Second))
+
+	// first sample: since start
+	rate, orate := xctn.throughput(100, 1000)
+	tassert.Errorf(t, approx(float64(rate), 100) && approx(orate, 10), "unexpected throughput %d, %.1f", rate, orate)
+
+	// within the interval: same
+	r, o := xctn.throughput(1000, 100000)
+	tassert.Errorf(t, r == rate && o == orate, "expected unchanged throughput, got %d, %.1f", r, o)
+
+	// next interval: computed over the delta
+	xctn.thrput.last -= int64(thrputIval)
+	rate, orate = xctn.throughput(300, 11000)
+	tassert.Errorf(t, approx(float64(rate), 1000) && approx(orate, 20), "unexpected throughput %d, %.1f", rate, orate)
+}
@@ -28,6 +28,10 @@ type (
 		Rebalance  bool // moves data between nodes
 		Resilver   bool // moves data between mountpaths
 		MassiveBck bool // massive data copying (transforming, encoding) operation on a bucket
+		// admission control: not started when the target is overloaded (see also MassiveBck)
+		Background bool
+		// checkpoints progress and resumes upon restart (see ckpt.go)
+		Resumable bool
 	}
 )
 
@@ -37,7 +41,7 @@ type (
 var Table = map[string]Descriptor{
 	// bucket-less xactions that will typically have a 'cluster' scope (with resilver being a notable exception)
 	apc.ActLRU:          {Scope: ScopeG, Startable: true, Mountpath: true},
-	apc.ActStoreCleanup: {Scope: ScopeG, Startable: true, Mountpath: true},
+	apc.ActStoreCleanup: {Scope: ScopeG, Startable: true, Mountpath: true, Background: true},
 	apc.ActElection:     {Scope: ScopeG, Startable: false},
 	apc.ActResilver:     {Scope: ScopeT, Startable: true, Mountpath: true, Resilver: true},
 	apc.ActRebalance:    {Scope: ScopeG, Startable: true, Metasync: true, Owned: false, Mountpath: true, Rebalance: true},
@@ -48,19 +52,22 @@ var Table = map[string]Descriptor{
 	apc.ActECGet:           {Scope: ScopeBck, Startable: false},
 	apc.ActECPut:           {Scope: ScopeBck, Startable: false, Mountpath: true, RefreshCap: true},
 	apc.ActECRespond:       {Scope: ScopeBck, Startable: false},
-	apc.ActMakeNCopies:     {Scope: ScopeBck, Access: apc.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
+	apc.ActMakeNCopies:     {Scope: ScopeBck, Access: apc.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, Resumable: true},
 	apc.ActPutCopies:       {Scope: ScopeBck, Startable: false, Mountpath: true, RefreshCap: true},
 	apc.ActArchive:         {Scope: ScopeBck, Startable: false, RefreshCap: true},
 	apc.ActCopyObjects:     {Scope: ScopeBck, Startable: false, RefreshCap: true},
 	apc.ActETLObjects:      {Scope: ScopeBck, Startable: false, RefreshCap: true},
 	apc.ActMoveBck:         {Scope: ScopeBck, Access: apc.AceMoveBucket, Startable: false, Metasync: true, Owned: false, Mountpath: true, Rebalance: true, MassiveBck: true},
-	apc.ActCopyBck:         {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true},
-	apc.ActETLBck:          {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true},
+	apc.ActCopyBck:         {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true, Resumable: true},
+	apc.ActETLBck:          {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true, Resumable: true},
 	apc.ActECEncode:        {Scope: ScopeBck, Access: apc.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true},
+	apc.ActSnapshotBck:     {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: true, Owned: false, Mountpath: true, MassiveBck: true},
+	apc.ActRestoreSnap:     {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: false, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true},
 	apc.ActEvictObjects:    {Scope: ScopeBck, Access: apc.AceObjDELETE, Startable: false, RefreshCap: true, Mountpath: true},
 	apc.ActDeleteObjects:   {Scope: ScopeBck, Access: apc.AceObjDELETE, Startable: false, RefreshCap: true, Mountpath: true},
-	apc.ActLoadLomCache:    {Scope: ScopeBck, Startable: true, Mountpath: true},
-	apc.ActPrefetchObjects: {Scope: ScopeBck, Access: apc.AccessRW, RefreshCap: true, Startable: true},
+	apc.ActLoadLomCache:    {Scope: ScopeBck, Startable: true, Mountpath: true, Background: true},
+	apc.ActIndexArch:       {Scope: ScopeBck, Access: apc.AccessRW, Startable: true, Mountpath: true},
+	apc.ActPrefetchObjects: {Scope: ScopeBck, Access: apc.AccessRW, RefreshCap: true, Startable: true, Resumable: true},
 	apc.ActPromote:         {Scope: ScopeBck, Access: apc.AcePromote, Startable: false, RefreshCap: true},
 	apc.ActList:            {Scope: ScopeBck, Access: apc.AceObjLIST, Startable: false, Metasync: false, Owned: true},
 	apc.ActInvalListCache:  {Scope: ScopeBck, Access: apc.AceObjLIST, Startable: false},
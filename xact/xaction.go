@@ -18,11 +18,15 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/nl"
 )
 
-const abortErrWait = time.Second
+const (
+	abortErrWait = time.Second
+	thrputIval   = 10 * time.Second // to (re)compute current throughput
+)
 
 type (
 	Base struct {
@@ -39,6 +43,27 @@ type (
 			inobjs   atomic.Int64 // receive
 			inbytes  atomic.Int64
 		}
+		// progress: precomputed or estimated totals and current throughput (see Progress)
+		totals struct {
+			objs      atomic.Int64
+			bytes     atomic.Int64
+			estimated atomic.Bool
+			// xactions that skip some of the objects (e.g., make-n-copies) track
+			// their progress separately from Objs/Bytes - see VisitedAdd
+			visited struct {
+				objs  atomic.Int64
+				bytes atomic.Int64
+			}
+			tracked atomic.Bool
+		}
+		thrput struct {
+			mu    sync.Mutex
+			last  int64 // mono time of the last sample
+			objs  int64 // counters at the time
+			bytes int64
+			rate  float64 // bytes/s
+			orate float64 // objs/s
+		}
 		notif *NotifXact
 		abort struct {
 			mu   sync.Mutex
@@ -303,11 +328,63 @@ func (xctn *Base) InObjsAdd(cnt int, size int64) {
 }
 
 func (xctn *Base) Snap() cluster.XactSnap {
-	snap := &Snap{}
-	xctn.ToSnap(snap)
+	snap := &SnapExt{}
+	xctn.ToSnap(&snap.Snap)
+	xctn.ToProgress(&snap.Progress)
 	return snap
 }
 
+// progress: totals (to be set by xactions that can precompute or estimate the work)
+func (xctn *Base) TotalObjs() int64  { return xctn.totals.objs.Load() }
+func (xctn *Base) TotalBytes() int64 { return xctn.totals.bytes.Load() }
+
+func (xctn *Base) SetTotals(objs, bytes int64, estimated bool) {
+	xctn.totals.objs.Store(objs)
+	xctn.totals.bytes.Store(bytes)
+	xctn.totals.estimated.Store(estimated)
+}
+
+func (xctn *Base) VisitedAdd(cnt int, size int64) {
+	xctn.totals.tracked.Store(true)
+	xctn.totals.visited.objs.Add(int64(cnt))
+	xctn.totals.visited.bytes.Add(size)
+}
+
+func (xctn *Base) ToProgress(p *Progress) {
+	p.TotalObjs, p.TotalBytes = xctn.TotalObjs(), xctn.TotalBytes()
+	p.Estimated = xctn.totals.estimated.Load()
+	if xctn.totals.tracked.Load() {
+		p.Objs, p.Bytes = xctn.totals.visited.objs.Load(), xctn.totals.visited.bytes.Load()
+	} else {
+		p.Objs, p.Bytes = xctn.Objs(), xctn.Bytes()
+	}
+	if !xctn.Finished() {
+		p.Throughput, p.ObjsPerSec = xctn.throughput(p.Objs, p.Bytes)
+	}
+	p.finalize()
+}
+
+// current throughput: computed over the last `thrputIval` (or since start)
+func (xctn *Base) throughput(objs, bytes int64) (int64, float64) {
+	t := &xctn.thrput
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := mono.NanoTime()
+	switch {
+	case t.last == 0:
+		if elapsed := time.Since(xctn.StartTime()).Seconds(); elapsed > 0 {
+			t.rate, t.orate = float64(bytes)/elapsed, float64(objs)/elapsed
+		}
+	case time.Duration(now-t.last) >= thrputIval:
+		elapsed := time.Duration(now - t.last).Seconds()
+		t.rate, t.orate = float64(bytes-t.bytes)/elapsed, float64(objs-t.objs)/elapsed
+	default:
+		return int64(t.rate), t.orate
+	}
+	t.last, t.objs, t.bytes = now, objs, bytes
+	return int64(t.rate), t.orate
+}
+
 func (xctn *Base) ToSnap(snap *Snap) {
 	snap.ID = xctn.ID()
 	snap.Kind = xctn.Kind()
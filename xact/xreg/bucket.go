@@ -42,6 +42,11 @@ type (
 		Tag    string
 		Copies int
 	}
+
+	// bucket snapshot: take or restore (see cmn.BckSnap)
+	SnapArgs struct {
+		Snap string
+	}
 )
 
 //////////////
@@ -66,8 +71,14 @@ func RenewBucketXact(kind string, bck *cluster.Bck, args Args) (res RenewRes) {
 	return dreg.renew(e, bck)
 }
 
+// RenewResumed (re)creates a resumable bucket xaction from its persisted checkpoint
+// (same kind and ID); `custom` is the same as the one the xaction was originally started with
+func RenewResumed(t cluster.Target, ckpt *xact.Ckpt, bck *cluster.Bck, custom interface{}) RenewRes {
+	return RenewBucketXact(ckpt.Kind, bck, Args{T: t, UUID: ckpt.ID, Custom: custom, Ckpt: ckpt})
+}
+
 func RenewECEncode(t cluster.Target, bck *cluster.Bck, uuid, phase string) RenewRes {
-	return RenewBucketXact(apc.ActECEncode, bck, Args{t, uuid, &ECEncodeArgs{Phase: phase}})
+	return RenewBucketXact(apc.ActECEncode, bck, Args{T: t, UUID: uuid, Custom: &ECEncodeArgs{Phase: phase}})
 }
 
 func RenewMakeNCopies(t cluster.Target, uuid, tag string) {
@@ -100,28 +111,40 @@ func RenewMakeNCopies(t cluster.Target, uuid, tag string) {
 }
 
 func RenewBckMakeNCopies(t cluster.Target, bck *cluster.Bck, uuid, tag string, copies int) (res RenewRes) {
-	e := dreg.bckXacts[apc.ActMakeNCopies].New(Args{t, uuid, &MNCArgs{tag, copies}}, bck)
+	e := dreg.bckXacts[apc.ActMakeNCopies].New(Args{T: t, UUID: uuid, Custom: &MNCArgs{tag, copies}}, bck)
 	return dreg.renew(e, bck)
 }
 
 func RenewPromote(t cluster.Target, uuid string, bck *cluster.Bck, args *cluster.PromoteArgs) RenewRes {
-	return RenewBucketXact(apc.ActPromote, bck, Args{t, uuid, args})
+	return RenewBucketXact(apc.ActPromote, bck, Args{T: t, UUID: uuid, Custom: args})
+}
+
+func RenewSnapshot(t cluster.Target, bck *cluster.Bck, uuid, snap string) RenewRes {
+	return RenewBucketXact(apc.ActSnapshotBck, bck, Args{T: t, UUID: uuid, Custom: &SnapArgs{Snap: snap}})
+}
+
+func RenewRestoreSnap(t cluster.Target, bck *cluster.Bck, uuid, snap string) RenewRes {
+	return RenewBucketXact(apc.ActRestoreSnap, bck, Args{T: t, UUID: uuid, Custom: &SnapArgs{Snap: snap}})
 }
 
 func RenewBckLoadLomCache(t cluster.Target, uuid string, bck *cluster.Bck) RenewRes {
 	return RenewBucketXact(apc.ActLoadLomCache, bck, Args{T: t, UUID: uuid})
 }
 
+func RenewIndexArch(t cluster.Target, uuid string, bck *cluster.Bck) RenewRes {
+	return RenewBucketXact(apc.ActIndexArch, bck, Args{T: t, UUID: uuid})
+}
+
 func RenewPutMirror(t cluster.Target, lom *cluster.LOM) RenewRes {
 	return RenewBucketXact(apc.ActPutCopies, lom.Bck(), Args{T: t, Custom: lom})
 }
 
 func RenewTCB(t cluster.Target, uuid, kind string, custom *TCBArgs) RenewRes {
-	return RenewBucketXact(kind, custom.BckTo /*NOTE: to not from*/, Args{t, uuid, custom})
+	return RenewBucketXact(kind, custom.BckTo /*NOTE: to not from*/, Args{T: t, UUID: uuid, Custom: custom})
 }
 
 func RenewTCObjs(t cluster.Target, uuid, kind string, custom *TCObjsArgs) RenewRes {
-	return RenewBucketXact(kind, custom.BckFrom, Args{t, uuid, custom})
+	return RenewBucketXact(kind, custom.BckFrom, Args{T: t, UUID: uuid, Custom: custom})
 }
 
 func RenewBckRename(t cluster.Target, bckFrom, bckTo *cluster.Bck, uuid string, rmdVersion int64, phase string) RenewRes {
@@ -131,7 +154,7 @@ func RenewBckRename(t cluster.Target, bckFrom, bckTo *cluster.Bck, uuid string,
 		BckFrom: bckFrom,
 		BckTo:   bckTo,
 	}
-	return RenewBucketXact(apc.ActMoveBck, bckTo, Args{t, uuid, custom})
+	return RenewBucketXact(apc.ActMoveBck, bckTo, Args{T: t, UUID: uuid, Custom: custom})
 }
 
 func RenewObjList(t cluster.Target, bck *cluster.Bck, uuid string, msg *apc.ListObjsMsg) RenewRes {
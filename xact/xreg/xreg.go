@@ -11,6 +11,7 @@ import (
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/admit"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -56,6 +57,7 @@ type (
 		T      cluster.Target
 		UUID   string
 		Custom interface{} // Additional arguments that are specific for a given xact.
+		Ckpt   *xact.Ckpt  // resuming from checkpoint (see xact.Table: Resumable)
 	}
 	RenewBase struct {
 		Args
@@ -473,6 +475,13 @@ func (r *registry) renewLocked(entry Renewable, flt XactFilter, bck *cluster.Bck
 			time.Sleep(waitPrevAborted)
 		}
 	}
+	// shed background work when overloaded (massive operations, in turn, are rejected
+	// in the "begin" phase of the respective transactions)
+	if xact.Table[entry.Kind()].Background {
+		if err = admit.Check(entry.Kind()); err != nil {
+			return RenewRes{Entry: nil, Err: err, UUID: ""}
+		}
+	}
 	if err = entry.Start(); err != nil {
 		return RenewRes{Entry: nil, Err: err, UUID: ""}
 	}
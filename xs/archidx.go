@@ -0,0 +1,106 @@
+// Package xs contains eXtended actions (xactions) except storage services
+// (mirror, ec) and extensions (downloader, lru).
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/archidx"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// (re)build missing and obsolete indices of all tar, tar.gz, and zip archives
+// in a given bucket (see cmn/archidx)
+
+type (
+	aidxFactory struct {
+		xreg.RenewBase
+		xctn *xactIndexArch
+	}
+	xactIndexArch struct {
+		xact.BckJog
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*xactIndexArch)(nil)
+	_ xreg.Renewable = (*aidxFactory)(nil)
+)
+
+/////////////////
+// aidxFactory //
+/////////////////
+
+func (*aidxFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	p := &aidxFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *aidxFactory) Start() error {
+	xctn := newXactIndexArch(p.T, p.UUID(), p.Bck)
+	p.xctn = xctn
+	go xctn.Run(nil)
+	return nil
+}
+
+func (*aidxFactory) Kind() string        { return apc.ActIndexArch }
+func (p *aidxFactory) Get() cluster.Xact { return p.xctn }
+
+func (*aidxFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+///////////////////
+// xactIndexArch //
+///////////////////
+
+func newXactIndexArch(t cluster.Target, uuid string, bck *cluster.Bck) (r *xactIndexArch) {
+	r = &xactIndexArch{}
+	mpopts := &mpather.JoggerGroupOpts{
+		T:        t,
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		DoLoad:   mpather.LoadRLock,
+		Throttle: true,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActIndexArch, bck, mpopts)
+	return
+}
+
+func (r *xactIndexArch) Run(*sync.WaitGroup) {
+	r.BckJog.Run()
+	glog.Infoln(r.Name())
+	err := r.BckJog.Wait()
+	r.Finish(err)
+}
+
+func (r *xactIndexArch) visitObj(lom *cluster.LOM, _ []byte) error {
+	if mime, err := cos.Mime("", lom.ObjName); err != nil || !archidx.Indexable(mime) {
+		return nil
+	}
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return nil // (removed in the meantime)
+	}
+	if lom.LoadArchIndex(finfo) != nil {
+		return nil // up to date
+	}
+	// NOTE: not failing the entire xaction because of a (single) corrupted archive
+	if _, err := lom.BuildArchIndex(); err != nil {
+		glog.Errorf("%s: failed to index %s: %v", r, lom, err)
+		return nil
+	}
+	r.ObjsAdd(1, lom.SizeBytes())
+	return nil
+}
@@ -41,7 +41,7 @@ type (
 		fini()
 	}
 	baseW struct {
-		wmul   *cos.WriterMulti
+		dst    io.Writer
 		archwi *archwi
 		buf    []byte
 		slab   *memsys.Slab
@@ -51,8 +51,9 @@ type (
 		tw *tar.Writer
 	}
 	tgzWriter struct {
-		tw  tarWriter
-		gzw *gzip.Writer
+		tw    tarWriter
+		gzw   *gzip.Writer
+		usize int64 // uncompressed size of the current gzip member
 	}
 	zipWriter struct {
 		baseW
@@ -92,6 +93,12 @@ type (
 		}
 		config *cmn.Config
 	}
+	// reports archiving requests (transactions) that are still in progress -
+	// to tell when a given request is done (see ais/prxpipe)
+	ArchStatsExt struct {
+		xact.BaseDemandStatsExt
+		Pending []string `json:"pending,omitempty"` // txn UUIDs
+	}
 )
 
 // interface guard
@@ -173,21 +180,8 @@ func (r *XactCreateArchMultiObj) Begin(msg *cmn.ArchiveMsg) (err error) {
 			return
 		}
 		// construct format-specific writer
-		switch msg.Mime {
-		case cos.ExtTar:
-			tw := &tarWriter{}
-			tw.init(wi)
-		case cos.ExtTgz, cos.ExtTarTgz:
-			tzw := &tgzWriter{}
-			tzw.init(wi)
-		case cos.ExtZip:
-			zw := &zipWriter{}
-			zw.init(wi)
-		case cos.ExtMsgpack:
-			mpw := &msgpackWriter{}
-			mpw.init(wi)
-		default:
-			debug.AssertMsg(false, msg.Mime)
+		if err = wi.initWriter(msg.Mime, cos.NewWriterMulti(wi.fh, &wi.cksum)); err != nil {
+			debug.AssertNoErr(err)
 			return
 		}
 	}
@@ -198,6 +192,18 @@ func (r *XactCreateArchMultiObj) Begin(msg *cmn.ArchiveMsg) (err error) {
 	return
 }
 
+func (r *XactCreateArchMultiObj) Snap() cluster.XactSnap {
+	snap := r.DemandBase.ExtSnap()
+	ext := &ArchStatsExt{BaseDemandStatsExt: *snap.Ext.(*xact.BaseDemandStatsExt)}
+	r.pending.RLock()
+	for uuid := range r.pending.m {
+		ext.Pending = append(ext.Pending, uuid)
+	}
+	r.pending.RUnlock()
+	snap.Ext = ext
+	return snap
+}
+
 func (r *XactCreateArchMultiObj) Do(msg *cmn.ArchiveMsg) {
 	r.IncPending()
 	r.workCh <- msg
@@ -429,7 +435,30 @@ func (wi *archwi) nameInArch(objName string) string {
 	return cos.UnsafeS(buf)
 }
 
+func (wi *archwi) initWriter(mime string, dst io.Writer) error {
+	switch mime {
+	case cos.ExtTar:
+		tw := &tarWriter{}
+		tw.init(wi, dst)
+	case cos.ExtTgz, cos.ExtTarTgz:
+		tzw := &tgzWriter{}
+		tzw.init(wi, dst)
+	case cos.ExtZip:
+		zw := &zipWriter{}
+		zw.init(wi, dst)
+	case cos.ExtMsgpack:
+		mpw := &msgpackWriter{}
+		mpw.init(wi, dst)
+	default:
+		return cos.NewUnknownMimeError(mime)
+	}
+	return nil
+}
+
 func (wi *archwi) openTarForAppend() (err error) {
+	if err := wi.lom.CowInPlace(nil); err != nil { // (shared with a snapshot?)
+		return err
+	}
 	if err := os.Rename(wi.lom.FQN, wi.fqn); err != nil {
 		return err
 	}
@@ -473,11 +502,11 @@ func (wi *archwi) finalize() (size int64, err error) {
 ///////////////
 // tarWriter //
 ///////////////
-func (tw *tarWriter) init(wi *archwi) {
+func (tw *tarWriter) init(wi *archwi, dst io.Writer) {
 	tw.archwi = wi
 	tw.buf, tw.slab = memsys.PageMM().Alloc()
-	tw.wmul = cos.NewWriterMulti(wi.fh, &wi.cksum)
-	tw.tw = tar.NewWriter(tw.wmul)
+	tw.dst = dst
+	tw.tw = tar.NewWriter(tw.dst)
 	wi.writer = tw
 }
 
@@ -510,11 +539,14 @@ func (tw *tarWriter) write(fullname string, oah cmn.ObjAttrsHolder, reader io.Re
 ///////////////
 // tgzWriter //
 ///////////////
-func (tzw *tgzWriter) init(wi *archwi) {
+
+const tgzMemberSize = 4 * cos.MiB
+
+func (tzw *tgzWriter) init(wi *archwi, dst io.Writer) {
 	tzw.tw.archwi = wi
 	tzw.tw.buf, tzw.tw.slab = memsys.PageMM().Alloc()
-	tzw.tw.wmul = cos.NewWriterMulti(wi.fh, &wi.cksum)
-	tzw.gzw = gzip.NewWriter(tzw.tw.wmul)
+	tzw.tw.dst = dst
+	tzw.gzw = gzip.NewWriter(tzw.tw.dst)
 	tzw.tw.tw = tar.NewWriter(tzw.gzw)
 	wi.writer = tzw
 }
@@ -524,8 +556,27 @@ func (tzw *tgzWriter) fini() {
 	tzw.gzw.Close()
 }
 
-func (tzw *tgzWriter) write(fullname string, oah cmn.ObjAttrsHolder, reader io.Reader) error {
-	return tzw.tw.write(fullname, oah, reader)
+// NOTE: tar.gz gets written as a sequence of gzip members (of roughly `tgzMemberSize`
+// uncompressed bytes each) - to provide for random access via archive index (cmn/archidx)
+func (tzw *tgzWriter) write(fullname string, oah cmn.ObjAttrsHolder, reader io.Reader) (err error) {
+	if err = tzw.tw.write(fullname, oah, reader); err != nil {
+		return
+	}
+	tzw.tw.archwi.wmu.Lock()
+	if tzw.usize += oah.SizeBytes(); tzw.usize >= tgzMemberSize {
+		tzw.usize = 0
+		if err = tzw.tw.tw.Flush(); err == nil {
+			if err = tzw.gzw.Close(); err == nil {
+				tzw.gzw.Reset(tzw.tw.dst)
+			}
+		}
+		if err != nil {
+			tzw.tw.archwi.err = err
+			tzw.tw.archwi.errCnt.Inc()
+		}
+	}
+	tzw.tw.archwi.wmu.Unlock()
+	return
 }
 
 ///////////////
@@ -533,11 +584,11 @@ func (tzw *tgzWriter) write(fullname string, oah cmn.ObjAttrsHolder, reader io.R
 ///////////////
 
 // wi.writer = &zipWriter{archwi: wi, w: zip.NewWriter(wi.fh)}
-func (zw *zipWriter) init(wi *archwi) {
+func (zw *zipWriter) init(wi *archwi, dst io.Writer) {
 	zw.archwi = wi
 	zw.buf, zw.slab = memsys.PageMM().Alloc()
-	zw.wmul = cos.NewWriterMulti(wi.fh, &wi.cksum)
-	zw.zw = zip.NewWriter(zw.wmul)
+	zw.dst = dst
+	zw.zw = zip.NewWriter(zw.dst)
 	wi.writer = zw
 }
 
@@ -573,10 +624,10 @@ func (zw *zipWriter) write(fullname string, oah cmn.ObjAttrsHolder, reader io.Re
 
 const dfltNumPerShard = 32
 
-func (mpw *msgpackWriter) init(wi *archwi) {
+func (mpw *msgpackWriter) init(wi *archwi, dst io.Writer) {
 	mpw.archwi = wi
 	mpw.shard = make(sglShard, dfltNumPerShard)
-	mpw.wmul = cos.NewWriterMulti(wi.fh, &wi.cksum)
+	mpw.dst = dst
 	wi.writer = mpw
 }
 
@@ -585,7 +636,7 @@ func (mpw *msgpackWriter) fini() {
 	for fullname, sgl := range mpw.shard {
 		genShard[fullname] = sgl.Bytes() // NOTE potential heap alloc
 	}
-	enc := msgpack.NewEncoder(mpw.wmul)
+	enc := msgpack.NewEncoder(mpw.dst)
 	err := enc.Encode(genShard)
 	debug.AssertNoErr(err)
 
@@ -628,3 +679,30 @@ func (mpw *msgpackWriter) write(fullname string, oah cmn.ObjAttrsHolder, reader
 	mpw.archwi.wmu.Unlock()
 	return err
 }
+
+////////////////
+// ArchStream //
+////////////////
+
+// ArchStream formats (archives) a sequence of objects and writes the result into
+// a given writer, e.g. http.ResponseWriter - using the same format-specific writers
+// that create archives (above). Not safe for concurrent use.
+// NOTE: msgpack-formatted output gets buffered in memory until Fini.
+type ArchStream struct {
+	wi archwi
+}
+
+func NewArchStream(mime string, dst io.Writer) (*ArchStream, error) {
+	s := &ArchStream{}
+	if err := s.wi.initWriter(mime, dst); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *ArchStream) Write(fullname string, oah cmn.ObjAttrsHolder, reader io.Reader) error {
+	return s.wi.writer.write(fullname, oah, reader)
+}
+
+// Fini completes the archive (e.g., writes TAR trailer) and releases resources.
+func (s *ArchStream) Fini() { s.wi.writer.fini() }
@@ -19,7 +19,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
-	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/objwalk"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -205,22 +204,10 @@ func (r *bsummXact) fast(bck *cluster.Bck) (objCount, size uint64, err error) {
 	for _, mpathInfo := range availablePaths {
 		group.Go(func(mpathInfo *fs.MountpathInfo) func() error {
 			return func() error {
-				path := mpathInfo.MakePathCT(bck.Bucket(), fs.ObjectType)
-				dirSize, err := ios.GetDirSize(path)
+				fileCount, dirSize, err := xact.FastCount(mpathInfo, bck)
 				if err != nil {
 					return err
 				}
-				fileCount, err := ios.GetFileCount(path)
-				if err != nil {
-					return err
-				}
-
-				if bck.Props.Mirror.Enabled {
-					copies := int(bck.Props.Mirror.Copies)
-					dirSize /= uint64(copies)
-					fileCount = fileCount/copies + fileCount%copies
-				}
-
 				gatomic.AddUint64(&objCount, uint64(fileCount))
 				gatomic.AddUint64(&size, dirSize)
 				r.ObjsAdd(fileCount, int64(dirSize))
@@ -32,6 +32,9 @@ func Init() {
 
 	xreg.RegBckXact(&proFactory{})
 	xreg.RegBckXact(&llcFactory{})
+	xreg.RegBckXact(&aidxFactory{})
+	xreg.RegBckXact(&snapFactory{kind: apc.ActSnapshotBck})
+	xreg.RegBckXact(&snapFactory{kind: apc.ActRestoreSnap})
 
 	xreg.RegBckXact(&tcoFactory{streamingF: streamingF{kind: apc.ActETLObjects}})
 	xreg.RegBckXact(&tcoFactory{streamingF: streamingF{kind: apc.ActCopyObjects}})
@@ -38,11 +38,15 @@ type (
 	lrwi interface {
 		do(*cluster.LOM, *lriterator)
 	}
-	// two selected methods that lriterator needs for itself (a strict subset of cluster.Xact)
+	// selected methods that lriterator needs for itself (a strict subset of cluster.Xact)
 	lrxact interface {
 		Bck() *cluster.Bck
 		IsAborted() bool
 		Finished() bool
+		Objs() int64
+		Bytes() int64
+		SetTotals(objs, bytes int64, estimated bool)
+		VisitedAdd(cnt int, size int64)
 	}
 	// common mult-obj operation context
 	// common iterateList()/iterateRange() logic
@@ -52,6 +56,12 @@ type (
 		ctx     context.Context
 		msg     *cmn.SelectObjsMsg
 		freeLOM bool // free LOM upon return from lriterator.do()
+		// resumable only (see xact.Ckpt)
+		ckpt *xact.Ckpt
+		pos  int64 // number of iterated names
+		skip int64 // resuming: skip that many
+		// progress (see initTotals)
+		visit bool
 	}
 )
 
@@ -104,6 +114,22 @@ func (r *lriterator) init(xctn lrxact, t cluster.Target, msg *cmn.SelectObjsMsg,
 	r.freeLOM = freeLOM
 }
 
+// checkpoint progress in terms of the number of iterated names (that is, the
+// iteration must be deterministic - same list, template, or prefix)
+func (r *lriterator) initCkpt(ckpt *xact.Ckpt) {
+	r.ckpt, r.skip = ckpt, ckpt.Pos
+}
+
+func (r *lriterator) checkpoint(force bool) {
+	r.ckpt.Pos, r.ckpt.Objs, r.ckpt.Bytes = r.pos, r.xctn.Objs(), r.xctn.Bytes()
+	if !force && !r.ckpt.Due() {
+		return
+	}
+	if err := r.ckpt.Persist(); err != nil {
+		glog.Errorf("%s[%s]: %v", r.ckpt.Kind, r.ckpt.ID, err)
+	}
+}
+
 func (r *lriterator) iterateRange(wi lrwi, smap *cluster.Smap) error {
 	pt, err := cos.NewParsedTemplate(r.msg.Template)
 	if err != nil {
@@ -211,6 +237,13 @@ func (r *lriterator) iterateList(wi lrwi, smap *cluster.Smap) error {
 }
 
 func (r *lriterator) do(lom *cluster.LOM, wi lrwi, smap *cluster.Smap) error {
+	var skip bool
+	if r.ckpt != nil {
+		r.pos++
+		if skip = r.pos <= r.skip; !skip {
+			defer r.checkpoint(false)
+		}
+	}
 	if err := lom.InitBck(r.xctn.Bck().Bucket()); err != nil {
 		return err
 	}
@@ -223,10 +256,42 @@ func (r *lriterator) do(lom *cluster.LOM, wi lrwi, smap *cluster.Smap) error {
 			return nil
 		}
 	}
-	wi.do(lom, r)
+	if !skip { // otherwise, done prior to restart
+		wi.do(lom, r)
+	}
+	if r.visit {
+		r.xctn.VisitedAdd(1, 0)
+	}
 	return nil
 }
 
+// precompute (list) or estimate (range) the number of objects this target is
+// going to visit; prefix-based iteration has no totals
+func (r *lriterator) initTotals(smap *cluster.Smap) {
+	r.visit = true
+	if r.msg.IsList() {
+		var (
+			cnt int64
+			bck = r.xctn.Bck()
+		)
+		for _, objName := range r.msg.ObjNames {
+			tsi, err := cluster.HrwTarget(bck.MakeUname(objName), smap)
+			if err == nil && tsi.ID() == r.t.SID() {
+				cnt++
+			}
+		}
+		r.xctn.SetTotals(cnt, 0, false)
+		return
+	}
+	pt, err := cos.NewParsedTemplate(r.msg.Template)
+	if err != nil || len(pt.Ranges) == 0 {
+		return
+	}
+	if n := int64(smap.CountActiveTargets()); n > 0 {
+		r.xctn.SetTotals(cos.DivRound(pt.Count(), n), 0, true)
+	}
+}
+
 //////////////////
 // evict/delete //
 //////////////////
@@ -262,6 +327,7 @@ func (r *evictDelete) Run(*sync.WaitGroup) {
 		err  error
 		smap = r.t.Sowner().Get()
 	)
+	r.initTotals(smap)
 	if r.msg.IsList() {
 		err = r.iterateList(r, smap)
 	} else {
@@ -323,6 +389,15 @@ func newPrefetch(xargs *xreg.Args, kind string, bck *cluster.Bck, msg *cmn.Selec
 	prf.lriterator.init(prf, xargs.T, msg, true /*freeLOM*/)
 	prf.InitBase(xargs.UUID, kind, bck)
 	prf.lriterator.xctn = prf
+
+	ckpt := xargs.Ckpt
+	if ckpt == nil {
+		ckpt = xact.NewCkpt(xargs.UUID, kind, bck.Bucket(), msg)
+	} else {
+		prf.ObjsAdd(int(ckpt.Objs), ckpt.Bytes)
+		glog.Infof("%s: resuming from checkpoint (pos %d, objs %d)", prf.Name(), ckpt.Pos, ckpt.Objs)
+	}
+	prf.lriterator.initCkpt(ckpt)
 	return
 }
 
@@ -331,11 +406,19 @@ func (r *prefetch) Run(*sync.WaitGroup) {
 		err  error
 		smap = r.t.Sowner().Get()
 	)
+	r.checkpoint(true /*force*/)
+	r.initTotals(smap)
 	if r.msg.IsList() {
 		err = r.iterateList(r, smap)
 	} else {
 		err = r.iterateRange(r, smap)
 	}
+	r.checkpoint(false)
+	if r.IsAborted() {
+		r.ckpt.Fin(r.AbortErr())
+	} else {
+		r.ckpt.Fin(nil)
+	}
 	r.Finish(err)
 }
 
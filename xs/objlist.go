@@ -262,6 +262,20 @@ func (r *ObjListXact) nextPageAIS(cnt uint) error {
 		read++
 		r.lastPage = append(r.lastPage, obj)
 	}
+	// (apc.LsVersions) read the remaining versions of the last object plus one more
+	// entry, to be able to tell when the page ends (see also isPageCached)
+	for r.msg.IsFlagSet(apc.LsVersions) && !r.walkDone && len(r.lastPage) > 0 {
+		last := r.lastPage[len(r.lastPage)-1]
+		obj, ok := <-r.objCache
+		if !ok {
+			r.walkDone = true
+			break
+		}
+		r.lastPage = append(r.lastPage, obj)
+		if obj.Name != last.Name {
+			break
+		}
+	}
 	return nil
 }
 
@@ -280,6 +294,9 @@ func (r *ObjListXact) isPageCached(marker string, cnt uint) bool {
 		return true
 	}
 	idx := r.findMarker(marker)
+	if r.msg.IsFlagSet(apc.LsVersions) {
+		cnt = uint(cmn.GroupVersions(r.lastPage[idx:], int(cnt)))
+	}
 	return idx+cnt < uint(len(r.lastPage))
 }
 
@@ -316,6 +333,9 @@ func (r *ObjListXact) getPage(marker string, cnt uint) *cmn.BucketList {
 
 	debug.Assert(uint(len(list)) >= cnt || r.walkDone)
 
+	if r.msg.IsFlagSet(apc.LsVersions) {
+		cnt = uint(cmn.GroupVersions(list, int(cnt)))
+	}
 	if uint(len(list)) >= cnt {
 		entries := list[:cnt]
 		return &cmn.BucketList{
@@ -382,21 +402,72 @@ func (r *ObjListXact) discardObsolete(token string) {
 }
 
 func (r *ObjListXact) traverseBucket(msg *apc.ListObjsMsg) {
-	wi := walkinfo.NewWalkInfo(r.walkCtx(), r.t, msg)
+	var (
+		wi       = walkinfo.NewWalkInfo(r.walkCtx(), r.t, msg)
+		versions = msg.IsFlagSet(apc.LsVersions)
+		group    []*cmn.BucketEntry // (apc.LsVersions) current and previous versions of the same object
+	)
 	defer r.walkWg.Done()
+	send := func(entry *cmn.BucketEntry) error {
+		select {
+		case r.objCache <- entry:
+			return nil
+		case <-r.walkStopCh.Listen():
+			return errStopped
+		}
+	}
+	flush := func() error {
+		cmn.SortBckEntries(group)
+		for _, e := range group {
+			if err := send(e); err != nil {
+				return err
+			}
+		}
+		group = group[:0]
+		return nil
+	}
 	cb := func(fqn string, de fs.DirEntry) error {
-		entry, err := wi.Callback(fqn, de)
+		var (
+			entry *cmn.BucketEntry
+			err   error
+		)
+		switch {
+		case msg.Snapshot != "":
+			parsedFQN, errP := fs.ParseFQN(fqn)
+			if errP != nil {
+				return nil
+			}
+			entry, err = wi.CallbackSnap(&parsedFQN, fqn)
+		case versions:
+			parsedFQN, errP := fs.ParseFQN(fqn)
+			if errP != nil {
+				return nil
+			}
+			if parsedFQN.ContentType == fs.ObjVersionType {
+				entry, err = wi.CallbackVersion(&parsedFQN, fqn)
+			} else {
+				entry, err = wi.Callback(fqn, de)
+			}
+		default:
+			entry, err = wi.Callback(fqn, de)
+		}
 		if err != nil || entry == nil {
 			return err
 		}
 		if entry.Name <= msg.StartAfter {
 			return nil
 		}
-		select {
-		case r.objCache <- entry:
-			/* do nothing */
-		case <-r.walkStopCh.Listen():
-			return errStopped
+		if versions {
+			if len(group) > 0 && group[0].Name != entry.Name {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			group = append(group, entry)
+			return nil
+		}
+		if err := send(entry); err != nil {
+			return err
 		}
 		if !msg.IsFlagSet(apc.LsArchDir) {
 			return nil
@@ -411,11 +482,8 @@ func (r *ObjListXact) traverseBucket(msg *apc.ListObjsMsg) {
 				Flags: entry.Flags | apc.EntryInArch,
 				Size:  int64(archEntry.size),
 			}
-			select {
-			case r.objCache <- e:
-				/* do nothing */
-			case <-r.walkStopCh.Listen():
-				return errStopped
+			if err := send(e); err != nil {
+				return err
 			}
 		}
 		return nil
@@ -423,6 +491,12 @@ func (r *ObjListXact) traverseBucket(msg *apc.ListObjsMsg) {
 	opts := &fs.WalkBckOpts{
 		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: cb, Sorted: true},
 	}
+	switch {
+	case msg.Snapshot != "":
+		opts.WalkOpts.CTs = []string{fs.SnapshotType} // (read-only snapshot of the bucket)
+	case versions:
+		opts.WalkOpts.CTs = append(opts.WalkOpts.CTs, fs.ObjVersionType)
+	}
 	opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
 	opts.ValidateCallback = func(fqn string, de fs.DirEntry) error {
 		if de.IsDir() {
@@ -431,7 +505,11 @@ func (r *ObjListXact) traverseBucket(msg *apc.ListObjsMsg) {
 		return nil
 	}
 
-	if err := fs.WalkBck(opts); err != nil {
+	err := fs.WalkBck(opts)
+	if err == nil && len(group) > 0 {
+		err = flush()
+	}
+	if err != nil {
 		if err != filepath.SkipDir && err != errStopped {
 			glog.Errorf("%s walk failed, err %v", r, err)
 		}
@@ -457,16 +535,18 @@ func listArchive(fqn string) ([]*archEntry, error) {
 	)
 	f, err := os.Open(fqn)
 	if err == nil {
+		if finfo, err = f.Stat(); err == nil {
+			archList = listArchIndex(fqn, arch, finfo)
+		}
+	}
+	if err == nil && archList == nil {
 		switch arch {
 		case cos.ExtTar:
 			archList, err = listTar(f)
 		case cos.ExtTgz, cos.ExtTarTgz:
 			archList, err = listTgz(f)
 		case cos.ExtZip:
-			finfo, err = os.Stat(fqn)
-			if err == nil {
-				archList, err = listZip(f, finfo.Size())
-			}
+			archList, err = listZip(f, finfo.Size())
 		case cos.ExtMsgpack:
 			archList, err = listMsgpack(f)
 		default:
@@ -482,6 +562,25 @@ func listArchive(fqn string) ([]*archEntry, error) {
 	return archList, nil
 }
 
+// list the archive content using its index, if available and up to date (see cmn/archidx)
+func listArchIndex(fqn, arch string, finfo os.FileInfo) (archList []*archEntry) {
+	lom := cluster.AllocLOM("")
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		return
+	}
+	idx := lom.LoadArchIndex(finfo)
+	if idx == nil || !idx.Matches(arch) {
+		return
+	}
+	archList = make([]*archEntry, 0, len(idx.Entries))
+	for i := range idx.Entries {
+		e := &idx.Entries[i]
+		archList = append(archList, &archEntry{name: e.Name, size: uint64(e.Size)})
+	}
+	return
+}
+
 //
 // list: tar, tgz, zip, msgpack
 //
@@ -136,6 +136,6 @@ func (xres *Resilver) String() string {
 
 // TODO: check "resilver-marked" and unify with rebalance
 func (xres *Resilver) Snap() cluster.XactSnap {
-	baseStats := xres.Base.Snap().(*xact.Snap)
+	baseStats := xres.Base.Snap().(*xact.SnapExt)
 	return baseStats
 }
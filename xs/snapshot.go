@@ -0,0 +1,171 @@
+// Package xs contains eXtended actions (xactions) except storage services
+// (mirror, ec) and extensions (downloader, lru).
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// take (apc.ActSnapshotBck) and restore (apc.ActRestoreSnap) bucket snapshots
+// (see cluster/lom_snap.go for the on-disk structure and copy-on-write)
+
+type (
+	snapFactory struct {
+		xreg.RenewBase
+		xctn *XactSnap
+		kind string
+	}
+	XactSnap struct {
+		xact.BckJog
+		snap    string
+		started int64 // (ActSnapshotBck only)
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactSnap)(nil)
+	_ xreg.Renewable = (*snapFactory)(nil)
+)
+
+/////////////////
+// snapFactory //
+/////////////////
+
+func (p *snapFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	return &snapFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, kind: p.kind}
+}
+
+func (p *snapFactory) Start() error {
+	slab, err := p.T.PageMM().GetSlab(memsys.MaxPageSlabSize)
+	cos.AssertNoErr(err)
+	p.xctn = newXactSnap(p, slab)
+	return nil
+}
+
+func (p *snapFactory) Kind() string      { return p.kind }
+func (p *snapFactory) Get() cluster.Xact { return p.xctn }
+
+func (p *snapFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	err = fmt.Errorf("%s is currently running, cannot start a new %q", prevEntry.Get(), p.Str(p.Kind()))
+	return
+}
+
+//////////////
+// XactSnap //
+//////////////
+
+func newXactSnap(p *snapFactory, slab *memsys.Slab) (r *XactSnap) {
+	r = &XactSnap{snap: p.Args.Custom.(*xreg.SnapArgs).Snap}
+	mpopts := &mpather.JoggerGroupOpts{
+		T:        p.T,
+		CTs:      []string{fs.ObjectType},
+		Slab:     slab,
+		Throttle: true,
+	}
+	if p.kind == apc.ActSnapshotBck {
+		mpopts.VisitObj = r.link
+		mpopts.DoLoad = mpather.LoadRLock
+	} else {
+		// remove objects that are not in the snapshot, restore those that are
+		mpopts.CTs = append(mpopts.CTs, fs.SnapshotType)
+		mpopts.VisitObj = r.prune
+		mpopts.VisitCT = r.restore
+		mpopts.DoLoad = mpather.LoadLock
+	}
+	mpopts.Bck.Copy(p.Bck.Bucket())
+	r.BckJog.Init(p.UUID(), p.kind, p.Bck, mpopts)
+	return
+}
+
+func (r *XactSnap) Run(wg *sync.WaitGroup) {
+	if r.Kind() == apc.ActSnapshotBck {
+		// the snapshot's point in time (must be set prior to returning to the caller)
+		r.started = cluster.SnapBegin(r.Bck(), r.snap)
+	}
+	wg.Done()
+	r.BckJog.Run()
+	glog.Infoln(r.Name())
+	err := r.BckJog.Wait()
+	if r.Kind() == apc.ActSnapshotBck {
+		cluster.SnapEnd(r.Bck())
+	}
+	r.Finish(err)
+}
+
+func (r *XactSnap) String() string { return fmt.Sprintf("%s snapshot=%s", r.Base.String(), r.snap) }
+func (r *XactSnap) Name() string   { return fmt.Sprintf("%s snapshot=%s", r.Base.Name(), r.snap) }
+
+// (rlocked)
+func (r *XactSnap) link(lom *cluster.LOM, _ []byte) error {
+	linked, err := lom.LinkSnap(r.snap, r.started)
+	if err != nil {
+		return r.visitErr(err)
+	}
+	if linked {
+		r.ObjsAdd(1, lom.SizeBytes())
+	}
+	return nil
+}
+
+// (locked)
+func (r *XactSnap) prune(lom *cluster.LOM, _ []byte) error {
+	if _, _, err := lom.FindSnap(r.snap); err == nil || !cmn.IsErrNotFound(err) {
+		return nil
+	}
+	if err := lom.Remove(); err != nil {
+		return r.visitErr(err)
+	}
+	r.ObjsAdd(1, lom.SizeBytes())
+	return nil
+}
+
+func (r *XactSnap) restore(ct *cluster.CT, buf []byte) error {
+	objName := strings.TrimPrefix(ct.ObjectName(), r.snap+"/")
+	if len(objName) == len(ct.ObjectName()) {
+		return nil // other snapshot
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(ct.Bck().Bucket()); err != nil {
+		return err
+	}
+	lom.Lock(true)
+	restored, err := lom.RestoreSnap(ct.FQN(), ct.MpathInfo(), buf)
+	lom.Unlock(true)
+	if err != nil {
+		return r.visitErr(err)
+	}
+	if restored {
+		r.ObjsAdd(1, lom.SizeBytes(true))
+	}
+	return nil
+}
+
+func (r *XactSnap) visitErr(err error) error {
+	if os.IsNotExist(err) {
+		return nil // removed in the meantime
+	}
+	if cos.IsErrOOS(err) {
+		return cmn.NewErrAborted(r.Name(), "visit", err)
+	}
+	glog.Errorf("%s: %v", r, err)
+	return nil
+}
@@ -106,6 +106,37 @@ func TestXactionRenewPrefetch(t *testing.T) {
 	tassert.Errorf(t, len(res) > 0, "expected some evictDelete xactions to be created, got %d", len(res))
 }
 
+func TestXactionRenewResumed(t *testing.T) {
+	var (
+		bmd   = mock.NewBaseBownerMock()
+		bck   = cluster.NewBck("test", apc.ProviderGoogle, cmn.NsGlobal)
+		tMock = mock.NewTarget(bmd)
+		msg   = &cmn.SelectObjsMsg{Template: "obj-{0000..9999}"}
+	)
+	xreg.TestReset()
+	bmd.Add(bck)
+
+	xreg.RegBckXact(&xs.TestXFactory{})
+	defer xreg.AbortAll(nil)
+
+	// as if loaded upon restart
+	ckpt := xact.NewCkpt("resumed-uuid", apc.ActPrefetchObjects, bck.Bucket(), msg)
+	ckpt.Pos, ckpt.Objs, ckpt.Bytes = 100, 90, 9000
+
+	rns := xreg.RenewResumed(tMock, ckpt, bck, msg)
+	tassert.CheckFatal(t, rns.Err)
+	tassert.Fatalf(t, !rns.IsRunning(), "expected new xaction")
+	xctn := rns.Entry.Get()
+	tassert.Errorf(t, xctn.ID() == ckpt.ID, "expected the same ID %q, got %q", ckpt.ID, xctn.ID())
+	tassert.Errorf(t, xctn.Kind() == apc.ActPrefetchObjects, "unexpected kind %q", xctn.Kind())
+	tassert.Errorf(t, xctn.Objs() == ckpt.Objs, "expected resumed count %d, got %d", ckpt.Objs, xctn.Objs())
+
+	// resuming twice (e.g., duplicate checkpoint) does not start another one
+	rns = xreg.RenewResumed(tMock, ckpt, bck, msg)
+	tassert.CheckFatal(t, rns.Err)
+	tassert.Errorf(t, rns.IsRunning() && rns.Entry.Get() == xctn, "expected the same running xaction")
+}
+
 func TestXactionAbortAll(t *testing.T) {
 	var (
 		bmd     = mock.NewBaseBownerMock()